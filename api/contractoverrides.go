@@ -0,0 +1,94 @@
+package api
+
+import (
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/golang/glog"
+	"github.com/juju/errors"
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/db"
+)
+
+// ContractOverride is a correction of the metadata of a contract set by the operator,
+// only the specified fields replace the metadata returned by the backend
+type ContractOverride struct {
+	Name     *string `json:"name,omitempty"`
+	Symbol   *string `json:"symbol,omitempty"`
+	Decimals *int    `json:"decimals,omitempty"`
+	// Scam flags the contract as a scam token
+	Scam bool `json:"scam,omitempty"`
+}
+
+// contractOverrides are indexed by the address descriptor of the contract
+var contractOverrides map[string]*ContractOverride
+
+// InitContractOverrides loads the operator overrides of the contract metadata from a json file,
+// which contains an object with contract addresses as keys and ContractOverride as values
+func InitContractOverrides(path string, parser bchain.BlockChainParser) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return errors.Annotatef(err, "Cannot read contract overrides file %v", path)
+	}
+	var o map[string]*ContractOverride
+	if err = json.Unmarshal(data, &o); err != nil {
+		return errors.Annotatef(err, "Invalid contract overrides file %v", path)
+	}
+	co := make(map[string]*ContractOverride, len(o))
+	for address, v := range o {
+		if v == nil {
+			continue
+		}
+		if v.Decimals != nil && (*v.Decimals < 0 || *v.Decimals > 255) {
+			return errors.Errorf("Invalid decimals %v of contract %v", *v.Decimals, address)
+		}
+		addrDesc, err := parser.GetAddrDescFromAddress(address)
+		if err != nil {
+			return errors.Annotatef(err, "Invalid contract %v", address)
+		}
+		co[string(addrDesc)] = v
+	}
+	contractOverrides = co
+	glog.Info("loaded overrides of ", len(co), " contracts from ", path)
+	return nil
+}
+
+// applyContractOverride corrects the metadata of the contract by the operator override,
+// it returns true if the contract is flagged as scam
+func applyContractOverride(contract bchain.AddressDescriptor, name, symbol *string, decimals *int) bool {
+	o := contractOverrides[string(contract)]
+	if o == nil {
+		return false
+	}
+	if o.Name != nil {
+		*name = *o.Name
+	}
+	if o.Symbol != nil {
+		*symbol = *o.Symbol
+	}
+	if o.Decimals != nil {
+		*decimals = *o.Decimals
+	}
+	return o.Scam
+}
+
+// getContractInfo returns the metadata of the contract from the index corrected by the operator override
+// it returns nil if neither the backend nor the override provide the token metadata of the contract
+func (w *Worker) getContractInfo(contract bchain.AddressDescriptor, standard TokenType) (*db.ContractInfo, bool, error) {
+	ci, err := w.db.GetContractInfo(contract, string(standard))
+	if err != nil {
+		return nil, false, err
+	}
+	if ci == nil {
+		ci = &db.ContractInfo{Standard: string(standard)}
+		addresses, _, _ := w.chainParser.GetAddressesFromAddrDesc(contract)
+		if len(addresses) > 0 {
+			ci.Contract = addresses[0]
+		}
+	}
+	scam := applyContractOverride(contract, &ci.Name, &ci.Symbol, &ci.Decimals)
+	if !ci.HasTokenMetadata() {
+		return nil, scam, nil
+	}
+	return ci, scam, nil
+}
@@ -0,0 +1,61 @@
+// +build unittest
+
+package api
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/trezor/blockbook/bchain/coins/eth"
+)
+
+func Test_InitContractOverrides(t *testing.T) {
+	defer func() { contractOverrides = nil }()
+	parser := eth.NewEthereumParser(1)
+	dir, err := ioutil.TempDir("", "contractoverrides")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "overrides.json")
+	writeFile := func(data string) {
+		if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeFile(`{
+		"0x4af4114F73d1c1C903aC9E0361b379D1291808A2": {"decimals": 6},
+		"0x0d0f936ee4c93e25944694d6c121de94d9760f11": {"name": "Fake MTT", "symbol": "", "scam": true}
+	}`)
+	if err := InitContractOverrides(path, parser); err != nil {
+		t.Fatal(err)
+	}
+	contract4a, _ := parser.GetAddrDescFromAddress("0x4af4114F73d1c1C903aC9E0361b379D1291808A2")
+	contract0d, _ := parser.GetAddrDescFromAddress("0x0d0F936Ee4c93e25944694D6C121de94D9760F11")
+	contract47, _ := parser.GetAddrDescFromAddress("0x479CC461fEcd078F766eCc58533D6F69580CF3AC")
+
+	name, symbol, decimals := "Verity", "VTY", 18
+	if scam := applyContractOverride(contract4a, &name, &symbol, &decimals); scam || name != "Verity" || symbol != "VTY" || decimals != 6 {
+		t.Errorf("applyContractOverride(4a) = %v, %q, %q, %d", scam, name, symbol, decimals)
+	}
+	name, symbol, decimals = "MTT", "MTT", 18
+	if scam := applyContractOverride(contract0d, &name, &symbol, &decimals); !scam || name != "Fake MTT" || symbol != "" || decimals != 18 {
+		t.Errorf("applyContractOverride(0d) = %v, %q, %q, %d", scam, name, symbol, decimals)
+	}
+	name, symbol, decimals = "", "", 0
+	if scam := applyContractOverride(contract47, &name, &symbol, &decimals); scam || name != "" || symbol != "" || decimals != 0 {
+		t.Errorf("applyContractOverride(47) = %v, %q, %q, %d", scam, name, symbol, decimals)
+	}
+
+	writeFile(`{"0x4af4114F73d1c1C903aC9E0361b379D1291808A2": {"decimals": 300}}`)
+	if err := InitContractOverrides(path, parser); err == nil {
+		t.Error("InitContractOverrides with invalid decimals: expected error")
+	}
+	writeFile(`{"invalid": {"scam": true}}`)
+	if err := InitContractOverrides(path, parser); err == nil {
+		t.Error("InitContractOverrides with invalid address: expected error")
+	}
+}
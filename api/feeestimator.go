@@ -0,0 +1,106 @@
+package api
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"sort"
+
+	"github.com/golang/glog"
+	"github.com/juju/errors"
+	"github.com/trezor/blockbook/db"
+)
+
+// internalFeeEstimatorParams configures the internal fee estimator
+type internalFeeEstimatorParams struct {
+	// HistoryBlocks is the number of the last blocks, from which the fee statistics are used
+	HistoryBlocks int `json:"historyBlocks"`
+	// HistoryDecile is the decile of the fee rates in a block used for the conservative estimate (0-10, 5 is the median)
+	HistoryDecile int `json:"historyDecile"`
+	// MinFeePerKb is the lowest fee rate returned by the estimator in satoshi per kB
+	MinFeePerKb int64 `json:"minFeePerKb"`
+}
+
+var internalFeeEstimator *internalFeeEstimatorParams
+
+// InitInternalFeeEstimator enables the internal fee estimator, which estimates fees
+// from the projected mempool blocks and the fee statistics of the last confirmed blocks
+func InitInternalFeeEstimator(params string) error {
+	p := internalFeeEstimatorParams{
+		HistoryBlocks: 6,
+		HistoryDecile: 5,
+		MinFeePerKb:   1000,
+	}
+	if params != "" {
+		if err := json.Unmarshal([]byte(params), &p); err != nil {
+			return errors.Annotatef(err, "Invalid internal fee estimator params")
+		}
+	}
+	if p.HistoryBlocks < 0 || p.HistoryDecile < 0 || p.HistoryDecile > 10 || p.MinFeePerKb < 0 {
+		return errors.Errorf("Invalid internal fee estimator params %+v", p)
+	}
+	internalFeeEstimator = &p
+	glog.Infof("internal fee estimator enabled with params %+v", p)
+	return nil
+}
+
+// IsInternalFeeEstimatorEnabled returns true if the fees are estimated by the internal fee estimator
+func IsInternalFeeEstimatorEnabled() bool {
+	return internalFeeEstimator != nil
+}
+
+// estimateFeeFromStats returns the fee rate in satoshi per kB needed to confirm a transaction in given number of blocks
+// the economical estimate is the minimal fee rate of the projected mempool block, which is the last to confirm in time,
+// the conservative estimate is at least the median of the selected decile of the fee rates in the last blocks
+func estimateFeeFromStats(p *internalFeeEstimatorParams, blocks int, conservative bool, mempool *MempoolStats, history []*db.BlockFeeStats) int64 {
+	fee := p.MinFeePerKb
+	if blocks < 1 {
+		blocks = 1
+	}
+	// if the mempool does not fit into the requested number of blocks, the transaction must outbid the last of them
+	if mempool != nil && len(mempool.Blocks) > blocks {
+		if f := int64(math.Ceil(mempool.Blocks[blocks-1].MinFeeRate * 1000)); f > fee {
+			fee = f
+		}
+	}
+	if conservative {
+		fees := make([]int64, 0, len(history))
+		for _, s := range history {
+			if s != nil && s.TxCount > 0 {
+				fees = append(fees, s.DecilesFeePerKb[p.HistoryDecile])
+			}
+		}
+		if len(fees) > 0 {
+			sort.Slice(fees, func(i, j int) bool { return fees[i] < fees[j] })
+			if f := fees[len(fees)/2]; f > fee {
+				fee = f
+			}
+		}
+	}
+	return fee
+}
+
+func (w *Worker) internalEstimateFee(blocks int, conservative bool) (big.Int, error) {
+	var r big.Int
+	p := internalFeeEstimator
+	mempool, err := w.GetMempoolStats(blocks+1, false)
+	if err != nil {
+		return r, err
+	}
+	var history []*db.BlockFeeStats
+	if conservative {
+		bestHeight, _, err := w.db.GetBestBlock()
+		if err != nil {
+			return r, errors.Annotatef(err, "GetBestBlock")
+		}
+		for i := 0; i < p.HistoryBlocks && uint32(i) <= bestHeight; i++ {
+			s, err := w.db.GetBlockFeeStats(bestHeight - uint32(i))
+			if err != nil {
+				return r, errors.Annotatef(err, "GetBlockFeeStats %v", bestHeight-uint32(i))
+			}
+			history = append(history, s)
+		}
+	}
+	r.SetInt64(estimateFeeFromStats(p, blocks, conservative, mempool, history))
+	return r, nil
+}
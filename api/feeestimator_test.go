@@ -0,0 +1,71 @@
+// +build unittest
+
+package api
+
+import (
+	"testing"
+
+	"github.com/trezor/blockbook/db"
+)
+
+func Test_estimateFeeFromStats(t *testing.T) {
+	p := &internalFeeEstimatorParams{HistoryBlocks: 3, HistoryDecile: 5, MinFeePerKb: 1000}
+	mempool := &MempoolStats{
+		Blocks: []MempoolBlock{
+			{MinFeeRate: 20.5},
+			{MinFeeRate: 8},
+			{MinFeeRate: 0.5},
+		},
+	}
+	deciles := func(median int64) [11]int64 {
+		var d [11]int64
+		d[5] = median
+		return d
+	}
+	history := []*db.BlockFeeStats{
+		{TxCount: 10, DecilesFeePerKb: deciles(12000)},
+		nil,
+		{TxCount: 20, DecilesFeePerKb: deciles(3000)},
+		{TxCount: 5, DecilesFeePerKb: deciles(30000)},
+	}
+	tests := []struct {
+		name         string
+		blocks       int
+		conservative bool
+		mempool      *MempoolStats
+		history      []*db.BlockFeeStats
+		want         int64
+	}{
+		{name: "next block", blocks: 1, mempool: mempool, want: 20500},
+		{name: "zero blocks", blocks: 0, mempool: mempool, want: 20500},
+		{name: "second block", blocks: 2, mempool: mempool, want: 8000},
+		{name: "mempool fits", blocks: 3, mempool: mempool, want: 1000},
+		{name: "empty mempool", blocks: 1, mempool: &MempoolStats{}, want: 1000},
+		{name: "conservative history", blocks: 2, conservative: true, mempool: mempool, history: history, want: 12000},
+		{name: "conservative mempool", blocks: 1, conservative: true, mempool: mempool, history: history, want: 20500},
+		{name: "conservative no history", blocks: 3, conservative: true, mempool: mempool, history: []*db.BlockFeeStats{nil}, want: 1000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := estimateFeeFromStats(p, tt.blocks, tt.conservative, tt.mempool, tt.history); got != tt.want {
+				t.Errorf("estimateFeeFromStats() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInitInternalFeeEstimator(t *testing.T) {
+	defer func() { internalFeeEstimator = nil }()
+	if err := InitInternalFeeEstimator(`{"historyDecile":11}`); err == nil {
+		t.Error("InitInternalFeeEstimator() with invalid decile, want error")
+	}
+	if IsInternalFeeEstimatorEnabled() {
+		t.Error("IsInternalFeeEstimatorEnabled() after error = true, want false")
+	}
+	if err := InitInternalFeeEstimator(`{"historyBlocks":10}`); err != nil {
+		t.Fatal(err)
+	}
+	if want := (internalFeeEstimatorParams{HistoryBlocks: 10, HistoryDecile: 5, MinFeePerKb: 1000}); *internalFeeEstimator != want {
+		t.Errorf("InitInternalFeeEstimator() params = %+v, want %+v", *internalFeeEstimator, want)
+	}
+}
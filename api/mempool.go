@@ -0,0 +1,164 @@
+package api
+
+import (
+	"math"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/trezor/blockbook/bchain"
+)
+
+const (
+	// mempoolBlockVSize is the maximum virtual size of a projected block (4M weight units)
+	mempoolBlockVSize = 1000000
+	// DefaultMempoolStatsBlocks is the default number of the projected blocks returned in the mempool stats
+	DefaultMempoolStatsBlocks = 8
+	// MaxMempoolStatsBlocks is the maximum number of the projected blocks returned in the mempool stats
+	MaxMempoolStatsBlocks   = 50
+	mempoolStatsCachePeriod = 10 * time.Second
+	// blockingPendingTxSeconds is the time after which a pending tx with the confirmed nonce blocking later txs is reported
+	blockingPendingTxSeconds = 10 * 60
+)
+
+// mempoolFeeRateBuckets are the lower bounds of the fee rate histogram buckets in sat/vB
+var mempoolFeeRateBuckets = []float64{0, 1, 2, 3, 4, 5, 6, 8, 10, 12, 15, 20, 30, 40, 50, 60, 70, 80, 90, 100, 125, 150, 175, 200, 250, 300, 350, 400, 500, 600, 700, 800, 900, 1000, 1200, 1400, 1600, 1800, 2000}
+
+type mempoolStatsCache struct {
+	timestamp time.Time
+	stats     *MempoolStats
+	lock      sync.Mutex
+}
+
+var cachedMempoolStats mempoolStatsCache
+
+type mempoolFeeRate struct {
+	fee     uint64
+	vsize   uint32
+	feeRate float64
+}
+
+func roundFeeRate(feeRate float64) float64 {
+	return math.Round(feeRate*1000) / 1000
+}
+
+// computeMempoolStats computes the fee rate histogram and projects all mempool transactions to blocks ordered by the fee rate
+func computeMempoolStats(entries []bchain.MempoolFeeEntry, blockVSize uint64) *MempoolStats {
+	txs := make([]mempoolFeeRate, len(entries))
+	for i := range entries {
+		e := &entries[i]
+		txs[i] = mempoolFeeRate{
+			fee:     e.Fee,
+			vsize:   e.VSize,
+			feeRate: float64(e.Fee) / float64(e.VSize),
+		}
+	}
+	sort.Slice(txs, func(i, j int) bool { return txs[i].feeRate > txs[j].feeRate })
+	histogram := make([]MempoolFeeRateBucket, len(mempoolFeeRateBuckets))
+	for i, f := range mempoolFeeRateBuckets {
+		histogram[i].FeeRate = f
+	}
+	var totalFees big.Int
+	var vsize uint64
+	blocks := make([]MempoolBlock, 0)
+	var block *MempoolBlock
+	var blockFees uint64
+	var blockStart int
+	finishBlock := func(end int) {
+		if block == nil {
+			return
+		}
+		block.TotalFees = (*Amount)(new(big.Int).SetUint64(blockFees))
+		// the median is weighted by the vsize, i.e. it is the fee rate of the tx in the middle of the block
+		var v uint64
+		for i := blockStart; i < end; i++ {
+			v += uint64(txs[i].vsize)
+			if v*2 >= block.VSize {
+				block.MedianFeeRate = roundFeeRate(txs[i].feeRate)
+				break
+			}
+		}
+		blocks = append(blocks, *block)
+		block = nil
+	}
+	for i := range txs {
+		tx := &txs[i]
+		b := sort.Search(len(mempoolFeeRateBuckets), func(j int) bool { return mempoolFeeRateBuckets[j] > tx.feeRate }) - 1
+		if b < 0 {
+			b = 0
+		}
+		histogram[b].Count++
+		histogram[b].VSize += uint64(tx.vsize)
+		totalFees.Add(&totalFees, new(big.Int).SetUint64(tx.fee))
+		vsize += uint64(tx.vsize)
+		if block != nil && block.VSize+uint64(tx.vsize) > blockVSize {
+			finishBlock(i)
+		}
+		if block == nil {
+			block = &MempoolBlock{MaxFeeRate: roundFeeRate(tx.feeRate)}
+			blockFees = 0
+			blockStart = i
+		}
+		block.Count++
+		block.VSize += uint64(tx.vsize)
+		block.MinFeeRate = roundFeeRate(tx.feeRate)
+		blockFees += tx.fee
+	}
+	finishBlock(len(txs))
+	return &MempoolStats{
+		Time:      time.Now().Unix(),
+		Count:     len(txs),
+		VSize:     vsize,
+		TotalFees: (*Amount)(&totalFees),
+		Histogram: histogram,
+		Blocks:    blocks,
+	}
+}
+
+// GetMempoolStats returns the fee rate histogram and the first projected blocks of the mempool
+// the stats are cached for a short period, refresh forces their recomputation
+func (w *Worker) GetMempoolStats(blocks int, refresh bool) (*MempoolStats, error) {
+	if w.chainType != bchain.ChainBitcoinType {
+		return nil, NewAPIError("Mempool stats are not supported", true)
+	}
+	if blocks <= 0 {
+		blocks = DefaultMempoolStatsBlocks
+	} else if blocks > MaxMempoolStatsBlocks {
+		blocks = MaxMempoolStatsBlocks
+	}
+	cachedMempoolStats.lock.Lock()
+	defer cachedMempoolStats.lock.Unlock()
+	if refresh || cachedMempoolStats.stats == nil || time.Since(cachedMempoolStats.timestamp) > mempoolStatsCachePeriod {
+		cachedMempoolStats.stats = computeMempoolStats(w.mempool.GetAllFeeEntries(), mempoolBlockVSize)
+		cachedMempoolStats.timestamp = time.Now()
+	}
+	// return a copy with the requested number of blocks, the cached stats are shared
+	stats := *cachedMempoolStats.stats
+	if len(stats.Blocks) > blocks {
+		stats.Blocks = stats.Blocks[:blocks]
+	}
+	return &stats, nil
+}
+
+// computePendingNonceIssues finds the pending txs of an address, which can never be confirmed because their nonce is below the confirmed nonce,
+// the pending txs waiting for a missing nonce and the pending txs with the confirmed nonce blocking the later txs for a long time
+// pending must be ordered by nonce, now is the current unix time
+func computePendingNonceIssues(pending []bchain.MempoolNonceEntry, confirmedNonce uint64, now uint32) []PendingNonceIssue {
+	var issues []PendingNonceIssue
+	expected := confirmedNonce
+	for i := range pending {
+		p := &pending[i]
+		switch {
+		case p.Nonce < confirmedNonce:
+			issues = append(issues, PendingNonceIssue{Type: NonceTooLow, Txid: p.Txid, Nonce: p.Nonce, ExpectedNonce: confirmedNonce})
+			continue
+		case p.Nonce > expected:
+			issues = append(issues, PendingNonceIssue{Type: NonceGap, Txid: p.Txid, Nonce: p.Nonce, ExpectedNonce: expected})
+		case p.Nonce == confirmedNonce && i < len(pending)-1 && now >= p.Time+blockingPendingTxSeconds:
+			issues = append(issues, PendingNonceIssue{Type: NonceBlocking, Txid: p.Txid, Nonce: p.Nonce, ExpectedNonce: confirmedNonce, BlockedTxs: len(pending) - 1 - i})
+		}
+		expected = p.Nonce + 1
+	}
+	return issues
+}
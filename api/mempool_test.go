@@ -0,0 +1,100 @@
+// +build unittest
+
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/trezor/blockbook/bchain"
+)
+
+func Test_computeMempoolStats(t *testing.T) {
+	entries := []bchain.MempoolFeeEntry{
+		{Txid: "a", Fee: 1000, VSize: 100},  // 10 sat/vB
+		{Txid: "b", Fee: 15000, VSize: 500}, // 30 sat/vB
+		{Txid: "c", Fee: 50, VSize: 100},    // 0.5 sat/vB
+		{Txid: "d", Fee: 2250, VSize: 150},  // 15 sat/vB
+		{Txid: "e", Fee: 3333, VSize: 300},  // 11.11 sat/vB
+	}
+	stats := computeMempoolStats(entries, 600)
+	if stats.Count != 5 || stats.VSize != 1150 || (*Amount)(stats.TotalFees).String() != "21633" {
+		t.Errorf("totals = %v, %v, %v", stats.Count, stats.VSize, stats.TotalFees)
+	}
+	wantBuckets := map[float64][2]uint64{0: {1, 100}, 10: {2, 400}, 15: {1, 150}, 30: {1, 500}}
+	for _, b := range stats.Histogram {
+		w := wantBuckets[b.FeeRate]
+		if uint64(b.Count) != w[0] || b.VSize != w[1] {
+			t.Errorf("bucket %v = %v, %v, want %v", b.FeeRate, b.Count, b.VSize, w)
+		}
+	}
+	got, err := json.Marshal(stats.Blocks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"count":1,"vsize":500,"totalFees":"15000","minFeeRate":30,"medianFeeRate":30,"maxFeeRate":30},` +
+		`{"count":3,"vsize":550,"totalFees":"6583","minFeeRate":10,"medianFeeRate":11.11,"maxFeeRate":15},` +
+		`{"count":1,"vsize":100,"totalFees":"50","minFeeRate":0.5,"medianFeeRate":0.5,"maxFeeRate":0.5}]`
+	if string(got) != want {
+		t.Errorf("blocks = %v, want %v", string(got), want)
+	}
+}
+
+func Test_computePendingNonceIssues(t *testing.T) {
+	const now = 10000
+	tests := []struct {
+		name    string
+		pending []bchain.MempoolNonceEntry
+		nonce   uint64
+		want    []PendingNonceIssue
+	}{
+		{
+			name:    "no pending txs",
+			pending: nil,
+			nonce:   5,
+		},
+		{
+			name:    "consecutive recent txs",
+			pending: []bchain.MempoolNonceEntry{{Txid: "a", Nonce: 5, Time: now - 60}, {Txid: "b", Nonce: 6, Time: now - 30}},
+			nonce:   5,
+		},
+		{
+			name:    "single old tx does not block",
+			pending: []bchain.MempoolNonceEntry{{Txid: "a", Nonce: 5, Time: now - 3600}},
+			nonce:   5,
+		},
+		{
+			name: "old tx blocks later txs",
+			pending: []bchain.MempoolNonceEntry{
+				{Txid: "a", Nonce: 5, Time: now - blockingPendingTxSeconds},
+				{Txid: "b", Nonce: 6, Time: now - 30},
+				{Txid: "c", Nonce: 7, Time: now - 20},
+			},
+			nonce: 5,
+			want:  []PendingNonceIssue{{Type: NonceBlocking, Txid: "a", Nonce: 5, ExpectedNonce: 5, BlockedTxs: 2}},
+		},
+		{
+			name: "nonce too low and gaps",
+			pending: []bchain.MempoolNonceEntry{
+				{Txid: "a", Nonce: 3, Time: now - 3600},
+				{Txid: "b", Nonce: 7, Time: now - 30},
+				{Txid: "c", Nonce: 8, Time: now - 20},
+				{Txid: "d", Nonce: 10, Time: now - 10},
+			},
+			nonce: 5,
+			want: []PendingNonceIssue{
+				{Type: NonceTooLow, Txid: "a", Nonce: 3, ExpectedNonce: 5},
+				{Type: NonceGap, Txid: "b", Nonce: 7, ExpectedNonce: 5},
+				{Type: NonceGap, Txid: "d", Nonce: 10, ExpectedNonce: 9},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := computePendingNonceIssues(tt.pending, tt.nonce, now); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("computePendingNonceIssues() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
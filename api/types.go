@@ -141,22 +141,37 @@ const ERC20TokenType TokenType = "ERC20"
 
 const TRC20TokenType TokenType = "TRC20"
 
+// ERC721TokenType is Ethereum ERC721 non fungible token
+const ERC721TokenType TokenType = "ERC721"
+
+// ERC1155TokenType is Ethereum ERC1155 multi token
+const ERC1155TokenType TokenType = "ERC1155"
+
 // XPUBAddressTokenType is address derived from xpub
 const XPUBAddressTokenType TokenType = "XPUBAddress"
 
 // Token contains info about tokens held by an address
 type Token struct {
-	Type             TokenType `json:"type"`
-	Name             string    `json:"name"`
-	Path             string    `json:"path,omitempty"`
-	Contract         string    `json:"contract,omitempty"`
-	Transfers        int       `json:"transfers"`
-	Symbol           string    `json:"symbol,omitempty"`
-	Decimals         int       `json:"decimals,omitempty"`
-	BalanceSat       *Amount   `json:"balance"`
-	TotalReceivedSat *Amount   `json:"totalReceived,omitempty"`
-	TotalSentSat     *Amount   `json:"totalSent,omitempty"`
-	ContractIndex    string    `json:"-"`
+	Type             TokenType         `json:"type"`
+	Name             string            `json:"name"`
+	Path             string            `json:"path,omitempty"`
+	Contract         string            `json:"contract,omitempty"`
+	Transfers        int               `json:"transfers"`
+	Symbol           string            `json:"symbol,omitempty"`
+	Decimals         int               `json:"decimals,omitempty"`
+	BalanceSat       *Amount           `json:"balance"`
+	TotalReceivedSat *Amount           `json:"totalReceived,omitempty"`
+	TotalSentSat     *Amount           `json:"totalSent,omitempty"`
+	Ids              []Amount          `json:"ids,omitempty"`              // ERC721 tokens held by the address
+	MultiTokenValues []MultiTokenValue `json:"multiTokenValues,omitempty"` // ERC1155 tokens held by the address
+	Scam             bool              `json:"scam,omitempty"`             // the contract is flagged as scam by the operator
+	ContractIndex    string            `json:"-"`
+}
+
+// MultiTokenValue is the id and the amount of an ERC1155 token
+type MultiTokenValue struct {
+	Id    *Amount `json:"id"`
+	Value *Amount `json:"value"`
 }
 
 // TokenTransfer contains info about a token transfer done in a transaction
@@ -168,39 +183,82 @@ type TokenTransfer struct {
 	Name     string    `json:"name"`
 	Symbol   string    `json:"symbol"`
 	Decimals int       `json:"decimals"`
-	Value    *Amount   `json:"value"`
+	// Value is the amount of ERC20 transfer or the id of ERC721 token
+	Value            *Amount           `json:"value"`
+	MultiTokenValues []MultiTokenValue `json:"multiTokenValues,omitempty"`
+	// Scam is set if the contract is flagged as scam by the operator
+	Scam bool `json:"scam,omitempty"`
+}
+
+// InternalTransfer is a transfer of value done by a contract call inside of an Ethereum transaction
+type InternalTransfer struct {
+	Type  string  `json:"type"`
+	From  string  `json:"from"`
+	To    string  `json:"to"`
+	Value *Amount `json:"value"`
+}
+
+var internalTransferTypes = map[bchain.EthereumInternalTransactionType]string{
+	bchain.CALL:         "call",
+	bchain.CREATE:       "create",
+	bchain.SELFDESTRUCT: "selfdestruct",
 }
 
 // EthereumSpecific contains ethereum specific transaction data
 type EthereumSpecific struct {
-	Status   eth.TxStatus `json:"status"` // 1 OK, 0 Fail, -1 pending
-	Nonce    uint64       `json:"nonce"`
-	GasLimit *big.Int     `json:"gasLimit"`
-	GasUsed  *big.Int     `json:"gasUsed"`
-	GasPrice *Amount      `json:"gasPrice"`
-	Data     string       `json:"data,omitempty"`
+	Type                 int                  `json:"type,omitempty"`
+	Status               eth.TxStatus         `json:"status"` // 1 OK, 0 Fail, -1 pending
+	Nonce                uint64               `json:"nonce"`
+	GasLimit             *big.Int             `json:"gasLimit"`
+	GasUsed              *big.Int             `json:"gasUsed"`
+	GasPrice             *Amount              `json:"gasPrice"`
+	MaxFeePerGas         *Amount              `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *Amount              `json:"maxPriorityFeePerGas,omitempty"`
+	BaseFeePerGas        *Amount              `json:"baseFeePerGas,omitempty"`
+	EffectiveGasPrice    *Amount              `json:"effectiveGasPrice,omitempty"`
+	AccessList           []eth.AccessListItem `json:"accessList,omitempty"`
+	Data                 string               `json:"data,omitempty"`
+}
+
+// TxReplacement is a link between a mempool transaction and a conflicting transaction, which replaced it or which was replaced by it
+type TxReplacement struct {
+	Txid string `json:"txid"`
+	Time int64  `json:"time"`
+}
+
+// MempoolTxRelative is an unconfirmed ancestor or descendant of a mempool transaction
+type MempoolTxRelative struct {
+	Txid  string  `json:"txid"`
+	Fees  *Amount `json:"fees,omitempty"`
+	VSize uint32  `json:"vsize,omitempty"`
 }
 
 // Tx holds information about a transaction
 type Tx struct {
-	Txid             string            `json:"txid"`
-	Version          int32             `json:"version,omitempty"`
-	Locktime         uint32            `json:"lockTime,omitempty"`
-	Vin              []Vin             `json:"vin"`
-	Vout             []Vout            `json:"vout"`
-	Blockhash        string            `json:"blockHash,omitempty"`
-	Blockheight      int               `json:"blockHeight"`
-	Confirmations    uint32            `json:"confirmations"`
-	Blocktime        int64             `json:"blockTime"`
-	Size             int               `json:"size,omitempty"`
-	ValueOutSat      *Amount           `json:"value"`
-	ValueInSat       *Amount           `json:"valueIn,omitempty"`
-	FeesSat          *Amount           `json:"fees,omitempty"`
-	Hex              string            `json:"hex,omitempty"`
-	Rbf              bool              `json:"rbf,omitempty"`
-	CoinSpecificData json.RawMessage   `json:"coinSpecificData,omitempty"`
-	TokenTransfers   []TokenTransfer   `json:"tokenTransfers,omitempty"`
-	EthereumSpecific *EthereumSpecific `json:"ethereumSpecific,omitempty"`
+	Txid              string              `json:"txid"`
+	Version           int32               `json:"version,omitempty"`
+	Locktime          uint32              `json:"lockTime,omitempty"`
+	Vin               []Vin               `json:"vin"`
+	Vout              []Vout              `json:"vout"`
+	Blockhash         string              `json:"blockHash,omitempty"`
+	Blockheight       int                 `json:"blockHeight"`
+	Confirmations     uint32              `json:"confirmations"`
+	Blocktime         int64               `json:"blockTime"`
+	Size              int                 `json:"size,omitempty"`
+	ValueOutSat       *Amount             `json:"value"`
+	ValueInSat        *Amount             `json:"valueIn,omitempty"`
+	FeesSat           *Amount             `json:"fees,omitempty"`
+	Hex               string              `json:"hex,omitempty"`
+	Rbf               bool                `json:"rbf,omitempty"`
+	ReplacedBy        *TxReplacement      `json:"replacedBy,omitempty"`
+	Replaces          []TxReplacement     `json:"replaces,omitempty"`
+	Ancestors         []MempoolTxRelative `json:"ancestors,omitempty"`
+	Descendants       []MempoolTxRelative `json:"descendants,omitempty"`
+	EffectiveFeeRate  float64             `json:"effectiveFeeRate,omitempty"`
+	CoinSpecificData  json.RawMessage     `json:"coinSpecificData,omitempty"`
+	TokenTransfers    []TokenTransfer     `json:"tokenTransfers,omitempty"`
+	InternalTransfers []InternalTransfer  `json:"internalTransfers,omitempty"`
+	EthereumSpecific  *EthereumSpecific   `json:"ethereumSpecific,omitempty"`
 }
 
 // FeeStats contains detailed block fee statistics
@@ -250,6 +308,27 @@ type AddressFilter struct {
 	OnlyConfirmed bool
 }
 
+// types of PendingNonceIssue
+const (
+	// NonceTooLow is a pending tx with nonce below the confirmed nonce of the address, it can never be confirmed
+	NonceTooLow = "nonceTooLow"
+	// NonceGap is a pending tx waiting for the txs with the missing nonces from ExpectedNonce, the later pending txs wait as well
+	NonceGap = "nonceGap"
+	// NonceBlocking is a pending tx with the confirmed nonce of the address, which is not confirmed for a long time and blocks the later pending txs
+	NonceBlocking = "blocking"
+)
+
+// PendingNonceIssue is a pending tx of an Ethereum type address, which cannot be confirmed or which blocks the later pending txs of the address
+type PendingNonceIssue struct {
+	Type  string `json:"type"`
+	Txid  string `json:"txid"`
+	Nonce uint64 `json:"nonce"`
+	// ExpectedNonce is the first missing nonce for NonceGap, otherwise the confirmed nonce of the address
+	ExpectedNonce uint64 `json:"expectedNonce"`
+	// BlockedTxs is the number of the later pending txs blocked by the tx
+	BlockedTxs int `json:"blockedTxs,omitempty"`
+}
+
 // Address holds information about address and its transactions
 type Address struct {
 	Paging
@@ -268,6 +347,7 @@ type Address struct {
 	Tokens                []Token               `json:"tokens,omitempty"`
 	Erc20Contract         *bchain.Erc20Contract `json:"erc20Contract,omitempty"`
 	Trc20Contract         *bchain.Trc20Contract `json:"trc20Contract,omitempty"`
+	PendingNonceIssues    []PendingNonceIssue   `json:"pendingNonceIssues,omitempty"`
 	// helpers for explorer
 	Filter        string              `json:"-"`
 	XPubAddresses map[string]struct{} `json:"-"`
@@ -284,6 +364,10 @@ type Utxo struct {
 	Path          string  `json:"path,omitempty"`
 	Locktime      uint32  `json:"lockTime,omitempty"`
 	Coinbase      bool    `json:"coinbase,omitempty"`
+	// unconfirmed relatives of the mempool transaction of the utxo
+	Ancestors        []MempoolTxRelative `json:"ancestors,omitempty"`
+	Descendants      []MempoolTxRelative `json:"descendants,omitempty"`
+	EffectiveFeeRate float64             `json:"effectiveFeeRate,omitempty"`
 }
 
 // Utxos is array of Utxo
@@ -371,6 +455,98 @@ func (a BalanceHistories) SortAndAggregate(groupByTime uint32) BalanceHistories
 	return bhs
 }
 
+// ContractTransfer is a token transfer of a contract together with the transaction in which it was done
+type ContractTransfer struct {
+	Txid          string `json:"txid"`
+	BlockHeight   int    `json:"blockHeight"`
+	Confirmations uint32 `json:"confirmations"`
+	BlockTime     int64  `json:"blockTime"`
+	TokenTransfer
+}
+
+// ContractTransfers is list of token transfers of a contract with paging information
+type ContractTransfers struct {
+	Paging
+	Contract  string             `json:"contract"`
+	Transfers []ContractTransfer `json:"transfers"`
+}
+
+// Contract contains the creation info, token metadata and statistics of a contract
+type Contract struct {
+	Contract       string    `json:"contract"`
+	Type           TokenType `json:"type,omitempty"`
+	Name           string    `json:"name,omitempty"`
+	Symbol         string    `json:"symbol,omitempty"`
+	Decimals       int       `json:"decimals,omitempty"`
+	Scam           bool      `json:"scam,omitempty"`           // the contract is flagged as scam by the operator
+	CreatedInBlock uint32    `json:"createdInBlock,omitempty"` // the height of the block with the creation transaction
+	CreationTxid   string    `json:"creationTxid,omitempty"`   // the transaction deploying the contract
+	Creator        string    `json:"creator,omitempty"`        // the sender of the creation transaction or the deploying contract
+	Holders        uint      `json:"holders"`                  // the number of addresses holding the token of the contract
+	Transfers      uint      `json:"transfers"`                // the number of token transfers of the contract
+}
+
+// ContractHolder is an address holding the ERC20/TRC20 token of a contract
+type ContractHolder struct {
+	Address    string  `json:"address"`
+	BalanceSat *Amount `json:"balance"`
+}
+
+// ContractHolders is list of holders of a contract sorted by their balance with paging information
+type ContractHolders struct {
+	Paging
+	Contract     string           `json:"contract"`
+	Height       uint32           `json:"height,omitempty"` // the holders after the block at height, current holders if not set
+	Name         string           `json:"name,omitempty"`
+	Symbol       string           `json:"symbol,omitempty"`
+	Decimals     int              `json:"decimals,omitempty"`
+	TotalHolders int              `json:"totalHolders"`
+	Holders      []ContractHolder `json:"holders"`
+}
+
+// TokenApproval is an allowance of the ERC20/TRC20 token of a contract set by an Approval event,
+// the spender can transfer up to value tokens of the owner
+type TokenApproval struct {
+	Type        TokenType `json:"type"`
+	Contract    string    `json:"contract"`
+	Name        string    `json:"name,omitempty"`
+	Symbol      string    `json:"symbol,omitempty"`
+	Decimals    int       `json:"decimals"`
+	Owner       string    `json:"owner"`
+	Spender     string    `json:"spender"`
+	ValueSat    *Amount   `json:"value"`
+	Txid        string    `json:"txid"`
+	BlockHeight int       `json:"blockHeight"`
+}
+
+// AddressAllowances contains the current non-zero allowances, in which the address is the owner or the spender,
+// and the history of the approvals of the address from the newest with paging information
+type AddressAllowances struct {
+	Paging
+	Address    string          `json:"address"`
+	Allowances []TokenApproval `json:"allowances"`
+	History    []TokenApproval `json:"history"`
+}
+
+// TransactionSimulation is the expected effect of an unsigned transaction executed against the latest block
+type TransactionSimulation struct {
+	Success      bool   `json:"success"`
+	RevertReason string `json:"revertReason,omitempty"`
+	// GasUsed is the gas (Ethereum) or the energy (Tron) consumed by the execution
+	GasUsed        uint64             `json:"gasUsed"`
+	ValueTransfers []InternalTransfer `json:"valueTransfers,omitempty"`
+	TokenTransfers []TokenTransfer    `json:"tokenTransfers,omitempty"`
+	// Traced is set if the transfers were taken from the trace of the execution,
+	// otherwise only the transfers decoded from the call data are returned
+	Traced bool `json:"traced"`
+}
+
+// AddressNfts is list of ERC721 and ERC1155 tokens held by an address
+type AddressNfts struct {
+	Address string  `json:"address"`
+	Tokens  []Token `json:"tokens"`
+}
+
 // Blocks is list of blocks with paging information
 type Blocks struct {
 	Paging
@@ -442,3 +618,30 @@ type MempoolTxids struct {
 	Mempool     []MempoolTxid `json:"mempool"`
 	MempoolSize int           `json:"mempoolSize"`
 }
+
+// MempoolFeeRateBucket is a bucket of the mempool fee rate histogram, FeeRate is the lower bound of the bucket in sat/vB
+type MempoolFeeRateBucket struct {
+	FeeRate float64 `json:"feeRate"`
+	Count   int     `json:"count"`
+	VSize   uint64  `json:"vsize"`
+}
+
+// MempoolBlock is a block projected from the mempool transactions with the highest fee rates, the fee rates are in sat/vB
+type MempoolBlock struct {
+	Count         int     `json:"count"`
+	VSize         uint64  `json:"vsize"`
+	TotalFees     *Amount `json:"totalFees"`
+	MinFeeRate    float64 `json:"minFeeRate"`
+	MedianFeeRate float64 `json:"medianFeeRate"`
+	MaxFeeRate    float64 `json:"maxFeeRate"`
+}
+
+// MempoolStats contains the fee rate histogram and the projected blocks of the mempool transactions with known fee
+type MempoolStats struct {
+	Time      int64                  `json:"time"`
+	Count     int                    `json:"count"`
+	VSize     uint64                 `json:"vsize"`
+	TotalFees *Amount                `json:"totalFees"`
+	Histogram []MempoolFeeRateBucket `json:"histogram"`
+	Blocks    []MempoolBlock         `json:"blocks"`
+}
@@ -120,6 +120,10 @@ func (w *Worker) GetSpendingTxid(txid string, n int) (string, error) {
 func (w *Worker) GetTransaction(txid string, spendingTxs bool, specificJSON bool) (*Tx, error) {
 	bchainTx, height, err := w.txCache.GetTransaction(txid)
 	if err != nil {
+		// the replaced transactions are removed from the backend mempool
+		if replacedBy, _ := w.getTxReplacements(txid); replacedBy != nil {
+			return nil, NewAPIError(fmt.Sprintf("Transaction '%v' not found, it was replaced by '%v'", txid, replacedBy.Txid), true)
+		}
 		if err == bchain.ErrTxNotFound {
 			return nil, NewAPIError(fmt.Sprintf("Transaction '%v' not found", txid), true)
 		}
@@ -133,6 +137,7 @@ func (w *Worker) GetTransactionFromBchainTx(bchainTx *bchain.Tx, height int, spe
 	var err error
 	var ta *db.TxAddresses
 	var tokens []TokenTransfer
+	var internalTransfers []InternalTransfer
 	var ethSpecific *EthereumSpecific
 	var blockhash string
 	if bchainTx.Confirmations > 0 {
@@ -270,22 +275,24 @@ func (w *Worker) GetTransactionFromBchainTx(bchainTx *bchain.Tx, height int, spe
 			glog.Errorf("GetErc20FromTx error %v, %v", err, bchainTx)
 		}
 		tokens = w.getTokensFromErc20(ets)
+		its, err := w.chainParser.EthereumTypeGetInternalTransfersFromTx(bchainTx)
+		if err != nil {
+			glog.Errorf("GetInternalTransfersFromTx error %v, %v", err, bchainTx)
+		}
+		internalTransfers = getInternalTransfers(its)
 		ethTxData := eth.GetEthereumTxData(bchainTx)
 		// mempool txs do not have fees yet
 		if ethTxData.GasUsed != nil {
-			feesSat.Mul(ethTxData.GasPrice, ethTxData.GasUsed)
+			if ethTxData.EffectiveGasPrice != nil {
+				feesSat.Mul(ethTxData.EffectiveGasPrice, ethTxData.GasUsed)
+			} else {
+				feesSat.Mul(ethTxData.GasPrice, ethTxData.GasUsed)
+			}
 		}
 		if len(bchainTx.Vout) > 0 {
 			valOutSat = bchainTx.Vout[0].ValueSat
 		}
-		ethSpecific = &EthereumSpecific{
-			GasLimit: ethTxData.GasLimit,
-			GasPrice: (*Amount)(ethTxData.GasPrice),
-			GasUsed:  ethTxData.GasUsed,
-			Nonce:    ethTxData.Nonce,
-			Status:   ethTxData.Status,
-			Data:     ethTxData.Data,
-		}
+		ethSpecific = getEthereumSpecific(ethTxData)
 	} else if w.chainType == bchain.ChainTronType {
 		ets, err := w.chainParser.TronTypeGetTrc20FromTx(bchainTx)
 		if err != nil {
@@ -308,27 +315,78 @@ func (w *Worker) GetTransactionFromBchainTx(bchainTx *bchain.Tx, height int, spe
 		bchainTx.Blocktime = int64(w.mempool.GetTransactionTime(bchainTx.Txid))
 	}
 	r := &Tx{
-		Blockhash:        blockhash,
-		Blockheight:      height,
-		Blocktime:        bchainTx.Blocktime,
-		Confirmations:    bchainTx.Confirmations,
-		FeesSat:          (*Amount)(&feesSat),
-		Locktime:         bchainTx.LockTime,
-		Txid:             bchainTx.Txid,
-		ValueInSat:       (*Amount)(pValInSat),
-		ValueOutSat:      (*Amount)(&valOutSat),
-		Version:          bchainTx.Version,
-		Hex:              bchainTx.Hex,
-		Rbf:              rbf,
-		Vin:              vins,
-		Vout:             vouts,
-		CoinSpecificData: sj,
-		TokenTransfers:   tokens,
-		EthereumSpecific: ethSpecific,
+		Blockhash:         blockhash,
+		Blockheight:       height,
+		Blocktime:         bchainTx.Blocktime,
+		Confirmations:     bchainTx.Confirmations,
+		FeesSat:           (*Amount)(&feesSat),
+		Locktime:          bchainTx.LockTime,
+		Txid:              bchainTx.Txid,
+		ValueInSat:        (*Amount)(pValInSat),
+		ValueOutSat:       (*Amount)(&valOutSat),
+		Version:           bchainTx.Version,
+		Hex:               bchainTx.Hex,
+		Rbf:               rbf,
+		Vin:               vins,
+		Vout:              vouts,
+		CoinSpecificData:  sj,
+		TokenTransfers:    tokens,
+		InternalTransfers: internalTransfers,
+		EthereumSpecific:  ethSpecific,
+	}
+	r.ReplacedBy, r.Replaces = w.getTxReplacements(r.Txid)
+	if bchainTx.Confirmations == 0 {
+		r.Ancestors, r.Descendants, r.EffectiveFeeRate = w.getMempoolTxPackage(r.Txid)
 	}
 	return r, nil
 }
 
+func mempoolTxRelatives(entries []bchain.MempoolFeeEntry) []MempoolTxRelative {
+	if len(entries) == 0 {
+		return nil
+	}
+	relatives := make([]MempoolTxRelative, len(entries))
+	for i := range entries {
+		e := &entries[i]
+		relatives[i] = MempoolTxRelative{Txid: e.Txid}
+		// the fee is known only if the virtual size is known
+		if e.VSize > 0 {
+			relatives[i].Fees = (*Amount)(new(big.Int).SetUint64(e.Fee))
+			relatives[i].VSize = e.VSize
+		}
+	}
+	return relatives
+}
+
+// getMempoolTxPackage returns the unconfirmed ancestors and descendants of a mempool transaction and its effective fee rate in sat/vB
+func (w *Worker) getMempoolTxPackage(txid string) ([]MempoolTxRelative, []MempoolTxRelative, float64) {
+	if w.chainType != bchain.ChainBitcoinType {
+		return nil, nil, 0
+	}
+	p := w.mempool.GetTxPackage(txid)
+	if p == nil {
+		return nil, nil, 0
+	}
+	return mempoolTxRelatives(p.Ancestors), mempoolTxRelatives(p.Descendants), roundFeeRate(p.EffectiveFeeRate)
+}
+
+// getTxReplacements returns the tx which replaced the transaction and the txs replaced by the transaction
+func (w *Worker) getTxReplacements(txid string) (*TxReplacement, []TxReplacement) {
+	if w.chainType != bchain.ChainBitcoinType && w.chainType != bchain.ChainEthereumType {
+		return nil, nil
+	}
+	var replacedBy *TxReplacement
+	var replaces []TxReplacement
+	for _, r := range w.mempool.GetTxReplacements(txid) {
+		if r.Txid == txid {
+			replacedBy = &TxReplacement{Txid: r.ReplacedBy, Time: int64(r.Time)}
+		} else {
+			replaces = append(replaces, TxReplacement{Txid: r.Txid, Time: int64(r.Time)})
+		}
+	}
+	return replacedBy, replaces
+}
+
 // GetTransactionFromMempoolTx converts bchain.MempoolTx to Tx, with limited amount of data
 // it is not doing any request to backend or to db
 func (w *Worker) GetTransactionFromMempoolTx(mempoolTx *bchain.MempoolTx) (*Tx, error) {
@@ -399,14 +457,7 @@ func (w *Worker) GetTransactionFromMempoolTx(mempoolTx *bchain.MempoolTx) (*Tx,
 		}
 		tokens = w.getTokensFromErc20(mempoolTx.Erc20)
 		ethTxData := eth.GetEthereumTxDataFromSpecificData(mempoolTx.CoinSpecificData)
-		ethSpecific = &EthereumSpecific{
-			GasLimit: ethTxData.GasLimit,
-			GasPrice: (*Amount)(ethTxData.GasPrice),
-			GasUsed:  ethTxData.GasUsed,
-			Nonce:    ethTxData.Nonce,
-			Status:   ethTxData.Status,
-			Data:     ethTxData.Data,
-		}
+		ethSpecific = getEthereumSpecific(ethTxData)
 	}
 	r := &Tx{
 		Blocktime:        mempoolTx.Blocktime,
@@ -424,6 +475,10 @@ func (w *Worker) GetTransactionFromMempoolTx(mempoolTx *bchain.MempoolTx) (*Tx,
 		TokenTransfers:   tokens,
 		EthereumSpecific: ethSpecific,
 	}
+	r.ReplacedBy, r.Replaces = w.getTxReplacements(r.Txid)
+	if mempoolTx.Blockheight == 0 {
+		r.Ancestors, r.Descendants, r.EffectiveFeeRate = w.getMempoolTxPackage(r.Txid)
+	}
 	return r, nil
 }
 
@@ -436,27 +491,85 @@ func (w *Worker) getTokensFromErc20(erc20 []bchain.Erc20Transfer) []TokenTransfe
 			glog.Errorf("GetAddrDescFromAddress error %v, contract %v", err, e.Contract)
 			continue
 		}
-		erc20c, err := w.chain.EthereumTypeGetErc20ContractInfo(cd)
+		tokenType := tokenTypeFromBchain(e.Type)
+		ci, scam, err := w.getContractInfo(cd, tokenType)
 		if err != nil {
-			glog.Errorf("GetErc20ContractInfo error %v, contract %v", err, e.Contract)
+			glog.Errorf("getContractInfo error %v, contract %v", err, e.Contract)
 		}
-		if erc20c == nil {
-			erc20c = &bchain.Erc20Contract{Name: e.Contract}
+		if ci == nil {
+			ci = &db.ContractInfo{Name: e.Contract}
 		}
 		tokens[i] = TokenTransfer{
-			Type:     ERC20TokenType,
+			Type:     tokenType,
 			Token:    e.Contract,
 			From:     e.From,
 			To:       e.To,
-			Decimals: erc20c.Decimals,
-			Value:    (*Amount)(&e.Tokens),
-			Name:     erc20c.Name,
-			Symbol:   erc20c.Symbol,
+			Decimals: ci.Decimals,
+			Name:     ci.Name,
+			Symbol:   ci.Symbol,
+			Scam:     scam,
+		}
+		if e.Type == bchain.MultiToken {
+			tokens[i].MultiTokenValues = getMultiTokenValues(e.MultiTokenValues)
+		} else {
+			tokens[i].Value = (*Amount)(&e.Tokens)
 		}
 	}
 	return tokens
 }
 
+func tokenTypeFromBchain(t bchain.TokenType) TokenType {
+	switch t {
+	case bchain.NonFungibleToken:
+		return ERC721TokenType
+	case bchain.MultiToken:
+		return ERC1155TokenType
+	}
+	return ERC20TokenType
+}
+
+func getMultiTokenValues(mtvs []bchain.MultiTokenValue) []MultiTokenValue {
+	r := make([]MultiTokenValue, len(mtvs))
+	for i := range mtvs {
+		r[i] = MultiTokenValue{Id: (*Amount)(&mtvs[i].Id), Value: (*Amount)(&mtvs[i].Value)}
+	}
+	return r
+}
+
+func getEthereumSpecific(ethTxData *eth.EthereumTxData) *EthereumSpecific {
+	return &EthereumSpecific{
+		Type:                 ethTxData.Type,
+		GasLimit:             ethTxData.GasLimit,
+		GasPrice:             (*Amount)(ethTxData.GasPrice),
+		GasUsed:              ethTxData.GasUsed,
+		MaxFeePerGas:         (*Amount)(ethTxData.MaxFeePerGas),
+		MaxPriorityFeePerGas: (*Amount)(ethTxData.MaxPriorityFeePerGas),
+		BaseFeePerGas:        (*Amount)(ethTxData.BaseFeePerGas),
+		EffectiveGasPrice:    (*Amount)(ethTxData.EffectiveGasPrice),
+		AccessList:           ethTxData.AccessList,
+		Nonce:                ethTxData.Nonce,
+		Status:               ethTxData.Status,
+		Data:                 ethTxData.Data,
+	}
+}
+
+func getInternalTransfers(its []bchain.EthereumInternalTransfer) []InternalTransfer {
+	if len(its) == 0 {
+		return nil
+	}
+	r := make([]InternalTransfer, len(its))
+	for i := range its {
+		t := &its[i]
+		r[i] = InternalTransfer{
+			Type:  internalTransferTypes[t.Type],
+			From:  t.From,
+			To:    t.To,
+			Value: (*Amount)(&t.Value),
+		}
+	}
+	return r
+}
+
 func (w *Worker) getTokensFromTrc20(trc20 []bchain.Trc20Transfer) []TokenTransfer {
 	var tokens []TokenTransfer
 	for i := range trc20 {
@@ -469,23 +582,24 @@ func (w *Worker) getTokensFromTrc20(trc20 []bchain.Trc20Transfer) []TokenTransfe
 			glog.Errorf("GetAddrDescFromAddress error %v, contract %v", err, e.Contract)
 			continue
 		}
-		trc20c, err := w.chain.TronTypeGetTrc20ContractInfo(cd)
+		ci, scam, err := w.getContractInfo(cd, TRC20TokenType)
 		if err != nil {
-			glog.Errorf("GetErc20ContractInfo error %v, contract %v", err, e.Contract)
+			glog.Errorf("getContractInfo error %v, contract %v", err, e.Contract)
 			continue
 		}
-		if trc20c == nil {
-			trc20c = &bchain.Trc20Contract{}
+		if ci == nil {
+			ci = &db.ContractInfo{}
 		}
 		tokens = append(tokens, TokenTransfer{
 			Type:     TRC20TokenType,
 			Token:    e.Contract,
 			From:     e.From,
 			To:       e.To,
-			Decimals: trc20c.Decimals,
+			Decimals: ci.Decimals,
 			Value:    (*Amount)(&e.Amount),
-			Name:     trc20c.Name,
-			Symbol:   trc20c.Symbol,
+			Name:     ci.Name,
+			Symbol:   ci.Symbol,
+			Scam:     scam,
 		})
 	}
 	return tokens
@@ -669,15 +783,21 @@ func computePaging(count, page, itemsOnPage int) (Paging, int, int, int) {
 	}, from, to, page
 }
 
-func (w *Worker) getEthereumToken(index int, addrDesc, contract bchain.AddressDescriptor, details AccountDetails, txs int) (*Token, error) {
+// getEthereumToken returns the token of the contract held by the address
+// c is the contract record from the index, it is nil if the address has no transfers of the contract
+func (w *Worker) getEthereumToken(index int, addrDesc, contract bchain.AddressDescriptor, details AccountDetails, txs int, c *db.AddrContract) (*Token, error) {
 	var b *big.Int
+	tokenType := bchain.FungibleToken
+	if c != nil {
+		tokenType = c.Type
+	}
 	validContract := true
-	ci, err := w.chain.EthereumTypeGetErc20ContractInfo(contract)
+	ci, scam, err := w.getContractInfo(contract, tokenTypeFromBchain(tokenType))
 	if err != nil {
-		return nil, errors.Annotatef(err, "EthereumTypeGetErc20ContractInfo %v", contract)
+		return nil, errors.Annotatef(err, "getContractInfo %v", contract)
 	}
 	if ci == nil {
-		ci = &bchain.Erc20Contract{}
+		ci = &db.ContractInfo{}
 		addresses, _, _ := w.chainParser.GetAddressesFromAddrDesc(contract)
 		if len(addresses) > 0 {
 			ci.Contract = addresses[0]
@@ -685,33 +805,53 @@ func (w *Worker) getEthereumToken(index int, addrDesc, contract bchain.AddressDe
 		}
 		validContract = false
 	}
-	// do not read contract balances etc in case of Basic option
-	if details >= AccountDetailsTokenBalances && validContract {
-		b, err = w.chain.EthereumTypeGetErc20ContractBalance(addrDesc, contract)
-		if err != nil {
-			// return nil, nil, nil, errors.Annotatef(err, "EthereumTypeGetErc20ContractBalance %v %v", addrDesc, c.Contract)
-			glog.Warningf("EthereumTypeGetErc20ContractBalance addr %v, contract %v, %v", addrDesc, contract, err)
-		}
-	} else {
-		b = nil
-	}
-	return &Token{
-		Type:          ERC20TokenType,
-		BalanceSat:    (*Amount)(b),
+	t := Token{
+		Type:          tokenTypeFromBchain(tokenType),
 		Contract:      ci.Contract,
 		Name:          ci.Name,
 		Symbol:        ci.Symbol,
 		Transfers:     txs,
 		Decimals:      ci.Decimals,
+		Scam:          scam,
 		ContractIndex: strconv.Itoa(index),
-	}, nil
+	}
+	// do not read contract balances etc in case of Basic option
+	if details >= AccountDetailsTokenBalances {
+		switch tokenType {
+		case bchain.NonFungibleToken:
+			// the holdings of NFTs are taken from the index, the balance is the number of held tokens
+			if err = w.db.GetAddrDescTokenHoldings(addrDesc, c); err != nil {
+				return nil, errors.Annotatef(err, "GetAddrDescTokenHoldings %v", contract)
+			}
+			t.BalanceSat = (*Amount)(big.NewInt(int64(len(c.Ids))))
+			t.Ids = make([]Amount, len(c.Ids))
+			for i := range c.Ids {
+				t.Ids[i] = Amount(c.Ids[i])
+			}
+		case bchain.MultiToken:
+			if err = w.db.GetAddrDescTokenHoldings(addrDesc, c); err != nil {
+				return nil, errors.Annotatef(err, "GetAddrDescTokenHoldings %v", contract)
+			}
+			t.MultiTokenValues = getMultiTokenValues(c.MultiTokenValues)
+		default:
+			if validContract {
+				b, err = w.chain.EthereumTypeGetErc20ContractBalance(addrDesc, contract)
+				if err != nil {
+					// return nil, nil, nil, errors.Annotatef(err, "EthereumTypeGetErc20ContractBalance %v %v", addrDesc, c.Contract)
+					glog.Warningf("EthereumTypeGetErc20ContractBalance addr %v, contract %v, %v", addrDesc, contract, err)
+				}
+				t.BalanceSat = (*Amount)(b)
+			}
+		}
+	}
+	return &t, nil
 }
 
 func (w *Worker) getTronToken(index int, addrDesc, contract bchain.AddressDescriptor, details AccountDetails, txs int, c *db.AddrContract) (*Token, error) {
 	if c != nil {
 		n := new(big.Int)
 		if n, ok := n.SetString(c.Amount, 10); ok {
-			return &Token{
+			t := &Token{
 				Type:          TRC20TokenType,
 				BalanceSat:    (*Amount)(n),
 				Contract:      common2.EncodeCheck(contract),
@@ -720,17 +860,19 @@ func (w *Worker) getTronToken(index int, addrDesc, contract bchain.AddressDescri
 				Transfers:     txs,
 				Decimals:      c.Decimals,
 				ContractIndex: strconv.Itoa(index),
-			}, nil
+			}
+			t.Scam = applyContractOverride(contract, &t.Name, &t.Symbol, &t.Decimals)
+			return t, nil
 		}
 	}
 	var b *big.Int
 	validContract := true
-	ci, err := w.chain.TronTypeGetTrc20ContractInfo(contract)
+	ci, scam, err := w.getContractInfo(contract, TRC20TokenType)
 	if err != nil {
-		return nil, errors.Annotatef(err, "EthereumTypeGetErc20ContractInfo %v", contract)
+		return nil, errors.Annotatef(err, "getContractInfo %v", contract)
 	}
 	if ci == nil {
-		ci = &bchain.Trc20Contract{}
+		ci = &db.ContractInfo{}
 		addresses, _, _ := w.chainParser.GetAddressesFromAddrDesc(contract)
 		if len(addresses) > 0 {
 			ci.Contract = addresses[0]
@@ -756,6 +898,7 @@ func (w *Worker) getTronToken(index int, addrDesc, contract bchain.AddressDescri
 		Symbol:        ci.Symbol,
 		Transfers:     txs,
 		Decimals:      ci.Decimals,
+		Scam:          scam,
 		ContractIndex: strconv.Itoa(index),
 	}, nil
 }
@@ -807,7 +950,7 @@ func (w *Worker) getEthereumTypeAddressBalances(addrDesc bchain.AddressDescripto
 					// filter only transactions of this contract
 					filter.Vout = i + 1
 				}
-				t, err := w.getEthereumToken(i+1, addrDesc, c.Contract, details, int(c.Txs))
+				t, err := w.getEthereumToken(i+1, addrDesc, c.Contract, details, int(c.Txs), &ca.Contracts[i])
 				if err != nil {
 					return nil, nil, nil, 0, 0, 0, err
 				}
@@ -817,7 +960,7 @@ func (w *Worker) getEthereumTypeAddressBalances(addrDesc bchain.AddressDescripto
 			// special handling if filter has contract
 			// if the address has no transactions with given contract, check the balance, the address may have some balance even without transactions
 			if len(filterDesc) > 0 && j == 0 && details >= AccountDetailsTokens {
-				t, err := w.getEthereumToken(0, addrDesc, filterDesc, details, 0)
+				t, err := w.getEthereumToken(0, addrDesc, filterDesc, details, 0, nil)
 				if err != nil {
 					return nil, nil, nil, 0, 0, 0, err
 				}
@@ -828,9 +971,10 @@ func (w *Worker) getEthereumTypeAddressBalances(addrDesc bchain.AddressDescripto
 				tokens = tokens[:j]
 			}
 		}
-		ci, err = w.chain.EthereumTypeGetErc20ContractInfo(addrDesc)
-		if err != nil {
+		if aci, _, err := w.getContractInfo(addrDesc, ""); err != nil {
 			return nil, nil, nil, 0, 0, 0, err
+		} else if aci != nil {
+			ci = &bchain.Erc20Contract{Contract: aci.Contract, Name: aci.Name, Symbol: aci.Symbol, Decimals: aci.Decimals}
 		}
 		if filter.FromHeight == 0 && filter.ToHeight == 0 {
 			// compute total results for paging
@@ -854,7 +998,7 @@ func (w *Worker) getEthereumTypeAddressBalances(addrDesc bchain.AddressDescripto
 		}
 		// special handling if filtering for a contract, check the ballance of it
 		if len(filterDesc) > 0 && details >= AccountDetailsTokens {
-			t, err := w.getEthereumToken(0, addrDesc, filterDesc, details, 0)
+			t, err := w.getEthereumToken(0, addrDesc, filterDesc, details, 0, nil)
 			if err != nil {
 				return nil, nil, nil, 0, 0, 0, err
 			}
@@ -930,10 +1074,11 @@ func (w *Worker) getTronTypeAddressBalances(addrDesc bchain.AddressDescriptor, d
 				tokens = tokens[:j]
 			}
 		}
-		ci, err = w.chain.TronTypeGetTrc20ContractInfo(addrDesc)
-		if err != nil {
+		if aci, _, err := w.getContractInfo(addrDesc, ""); err != nil {
 			glog.Error(err)
 			return nil, nil, nil, 0, 0, 0, err
+		} else if aci != nil {
+			ci = &bchain.Trc20Contract{Contract: aci.Contract, Name: aci.Name, Symbol: aci.Symbol, Decimals: aci.Decimals}
 		}
 		if filter.FromHeight == 0 && filter.ToHeight == 0 {
 			// compute total results for paging
@@ -1048,6 +1193,7 @@ func (w *Worker) GetAddress(address string, page int, txsOnPage int, option Acco
 		uBalSat                  big.Int
 		totalReceived, totalSent *big.Int
 		nonce                    string
+		pendingNonceIssues       []PendingNonceIssue
 		unconfirmedTxs           int
 		nonTokenTxs              int
 		totalResults             int
@@ -1063,6 +1209,9 @@ func (w *Worker) GetAddress(address string, page int, txsOnPage int, option Acco
 			return nil, err
 		}
 		nonce = strconv.Itoa(int(n))
+		if filter.ToHeight == 0 && !filter.OnlyConfirmed {
+			pendingNonceIssues = computePendingNonceIssues(w.mempool.GetAddrDescNonces(addrDesc), n, uint32(time.Now().Unix()))
+		}
 	} else if w.chainType == bchain.ChainTronType {
 		ba, tokens, trc20c, _, nonTokenTxs, totalResults, err = w.getTronTypeAddressBalances(addrDesc, option, filter)
 		if err != nil {
@@ -1173,11 +1322,385 @@ func (w *Worker) GetAddress(address string, page int, txsOnPage int, option Acco
 		Erc20Contract:         erc20c,
 		Trc20Contract:         trc20c,
 		Nonce:                 nonce,
+		PendingNonceIssues:    pendingNonceIssues,
 	}
 	//glog.Info("GetAddress ", address, ", ", time.Since(start))
 	return r, nil
 }
 
+// getTokenTransfer returns the token transfer with given index in the list of token transfers of the transaction
+func (w *Worker) getTokenTransfer(bchainTx *bchain.Tx, index int32) (*TokenTransfer, error) {
+	var tokens []TokenTransfer
+	if w.chainType == bchain.ChainEthereumType {
+		erc20, err := w.chainParser.EthereumTypeGetErc20FromTx(bchainTx)
+		if err != nil {
+			return nil, err
+		}
+		if index < 0 || int(index) >= len(erc20) {
+			return nil, nil
+		}
+		tokens = w.getTokensFromErc20(erc20[index : index+1])
+	} else {
+		trc20, err := w.chainParser.TronTypeGetTrc20FromTx(bchainTx)
+		if err != nil {
+			return nil, err
+		}
+		if index < 0 || int(index) >= len(trc20) {
+			return nil, nil
+		}
+		tokens = w.getTokensFromTrc20(trc20[index : index+1])
+	}
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+	return &tokens[0], nil
+}
+
+// GetAddressNfts returns the ERC721 and ERC1155 tokens held by the address, the holdings are derived from the index
+func (w *Worker) GetAddressNfts(address string) (*AddressNfts, error) {
+	if w.chainType != bchain.ChainEthereumType {
+		return nil, NewAPIError("NFTs are not supported", true)
+	}
+	addrDesc, address, err := w.getAddrDescAndNormalizeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	ca, err := w.db.GetAddrDescContracts(addrDesc)
+	if err != nil {
+		return nil, NewAPIError(fmt.Sprintf("Address not found, %v", err), true)
+	}
+	r := &AddressNfts{
+		Address: address,
+		Tokens:  []Token{},
+	}
+	if ca == nil {
+		return r, nil
+	}
+	for i := range ca.Contracts {
+		c := &ca.Contracts[i]
+		if c.HeldIds == 0 {
+			continue
+		}
+		t, err := w.getEthereumToken(i+1, addrDesc, c.Contract, AccountDetailsTokenBalances, int(c.Txs), c)
+		if err != nil {
+			return nil, err
+		}
+		r.Tokens = append(r.Tokens, *t)
+	}
+	return r, nil
+}
+
+// GetAddressAllowances returns the current non-zero allowances of the ERC20/TRC20 tokens, in which the address is the owner or the spender,
+// and the paged history of its approvals
+// the current allowance is the value of the last Approval event, the allowances decreased by transfers without the event are not reflected
+func (w *Worker) GetAddressAllowances(address string, page int, approvalsOnPage int) (*AddressAllowances, error) {
+	if w.chainType != bchain.ChainEthereumType && w.chainType != bchain.ChainTronType {
+		return nil, NewAPIError("Allowances are not supported", true)
+	}
+	page--
+	if page < 0 {
+		page = 0
+	}
+	addrDesc, address, err := w.getAddrDescAndNormalizeAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	approvals, err := w.db.GetTokenApprovals(addrDesc)
+	if err != nil {
+		return nil, errors.Annotatef(err, "GetTokenApprovals %v", address)
+	}
+	standard := ERC20TokenType
+	if w.chainType == bchain.ChainTronType {
+		standard = TRC20TokenType
+	}
+	addressOf := func(addrDesc bchain.AddressDescriptor) string {
+		if addresses, _, _ := w.chainParser.GetAddressesFromAddrDesc(addrDesc); len(addresses) > 0 {
+			return addresses[0]
+		}
+		return ""
+	}
+	contracts := make(map[string]*db.ContractInfo)
+	toTokenApproval := func(a *db.TokenApproval) (TokenApproval, error) {
+		ci, found := contracts[string(a.Contract)]
+		if !found {
+			var err error
+			if ci, _, err = w.getContractInfo(a.Contract, standard); err != nil {
+				return TokenApproval{}, errors.Annotatef(err, "getContractInfo %v", a.Contract)
+			}
+			contracts[string(a.Contract)] = ci
+		}
+		ta := TokenApproval{
+			Type:        standard,
+			Contract:    addressOf(a.Contract),
+			Owner:       addressOf(a.Owner),
+			Spender:     addressOf(a.Spender),
+			ValueSat:    (*Amount)(&a.Value),
+			Txid:        a.Txid,
+			BlockHeight: int(a.Height),
+		}
+		if ci != nil {
+			ta.Name = ci.Name
+			ta.Symbol = ci.Symbol
+			ta.Decimals = ci.Decimals
+		}
+		return ta, nil
+	}
+	pg, from, to, _ := computePaging(len(approvals), page, approvalsOnPage)
+	r := &AddressAllowances{
+		Paging:     pg,
+		Address:    address,
+		Allowances: []TokenApproval{},
+		History:    make([]TokenApproval, 0, to-from),
+	}
+	// the approvals are ordered from the newest, the first approval of the owner and the spender is the current allowance
+	seen := make(map[string]struct{})
+	for i := range approvals {
+		a := &approvals[i]
+		key := string(a.Contract) + string(a.Owner) + string(a.Spender)
+		if _, found := seen[key]; found {
+			continue
+		}
+		seen[key] = struct{}{}
+		if a.Value.Sign() > 0 {
+			ta, err := toTokenApproval(a)
+			if err != nil {
+				return nil, err
+			}
+			r.Allowances = append(r.Allowances, ta)
+		}
+	}
+	for i := from; i < to; i++ {
+		ta, err := toTokenApproval(&approvals[i])
+		if err != nil {
+			return nil, err
+		}
+		r.History = append(r.History, ta)
+	}
+	return r, nil
+}
+
+// SimulateTransaction executes the unsigned transaction given by the parameters against the latest block
+// and returns the expected transfers of the coin and of the tokens, the revert reason and the consumed gas
+func (w *Worker) SimulateTransaction(params map[string]interface{}) (*TransactionSimulation, error) {
+	var sim *bchain.TransactionSimulation
+	var err error
+	switch w.chainType {
+	case bchain.ChainEthereumType:
+		sim, err = w.chain.EthereumTypeSimulateTransaction(params)
+	case bchain.ChainTronType:
+		sim, err = w.chain.TronTypeSimulateTransaction(params)
+	default:
+		return nil, NewAPIError("Transaction simulation is not supported", true)
+	}
+	if err != nil {
+		// the other errors of the backend may contain internal details like the url of the backend
+		if e, ok := errors.Cause(err).(*bchain.InvalidParamsError); ok {
+			return nil, NewAPIError(e.Message, true)
+		}
+		glog.Error("SimulateTransaction error ", err)
+		return nil, NewAPIError("Transaction simulation failed", true)
+	}
+	r := &TransactionSimulation{
+		Success:        sim.Success,
+		RevertReason:   sim.RevertReason,
+		GasUsed:        sim.GasUsed,
+		ValueTransfers: getInternalTransfers(sim.ValueTransfers),
+		Traced:         sim.Traced,
+	}
+	if w.chainType == bchain.ChainTronType {
+		trc20 := make([]bchain.Trc20Transfer, len(sim.TokenTransfers))
+		for i := range sim.TokenTransfers {
+			t := &sim.TokenTransfers[i]
+			trc20[i] = bchain.Trc20Transfer{Contract: t.Contract, From: t.From, To: t.To, Amount: t.Tokens}
+		}
+		r.TokenTransfers = w.getTokensFromTrc20(trc20)
+	} else if len(sim.TokenTransfers) > 0 {
+		r.TokenTransfers = w.getTokensFromErc20(sim.TokenTransfers)
+	}
+	return r, nil
+}
+
+// GetContract returns the creation info, token metadata and the holder and transfer counts of a contract
+func (w *Worker) GetContract(contract string) (*Contract, error) {
+	if w.chainType != bchain.ChainEthereumType && w.chainType != bchain.ChainTronType {
+		return nil, NewAPIError("Contracts are not supported", true)
+	}
+	contractDesc, contract, err := w.getAddrDescAndNormalizeAddress(contract)
+	if err != nil {
+		return nil, err
+	}
+	ci, err := w.db.GetContractInfo(contractDesc, "")
+	if err != nil {
+		return nil, errors.Annotatef(err, "GetContractInfo %v", contract)
+	}
+	if ci == nil {
+		ci = &db.ContractInfo{}
+	}
+	r := &Contract{
+		Contract:       contract,
+		Type:           TokenType(ci.Standard),
+		Name:           ci.Name,
+		Symbol:         ci.Symbol,
+		Decimals:       ci.Decimals,
+		CreatedInBlock: ci.CreatedInBlock,
+		CreationTxid:   ci.CreationTxid,
+		Creator:        ci.Creator,
+		Holders:        ci.Holders,
+		Transfers:      ci.Transfers,
+	}
+	r.Scam = applyContractOverride(contractDesc, &r.Name, &r.Symbol, &r.Decimals)
+	return r, nil
+}
+
+// GetContractTransfers returns confirmed token transfers of the contract, from the newest to the oldest
+func (w *Worker) GetContractTransfers(contract string, page int, transfersOnPage int, fromHeight, toHeight uint32) (*ContractTransfers, error) {
+	if w.chainType != bchain.ChainEthereumType && w.chainType != bchain.ChainTronType {
+		return nil, NewAPIError("Contract transfers are not supported", true)
+	}
+	page--
+	if page < 0 {
+		page = 0
+	}
+	contractDesc, contract, err := w.getAddrDescAndNormalizeAddress(contract)
+	if err != nil {
+		return nil, err
+	}
+	if toHeight == 0 {
+		toHeight = maxUint32
+	}
+	type transferIndex struct {
+		txid  string
+		index int32
+	}
+	maxResults := (page + 1) * transfersOnPage
+	transfers := make([]transferIndex, 0, 16)
+	err = w.db.GetContractTransfers(contractDesc, fromHeight, toHeight, func(txid string, height uint32, indexes []int32) error {
+		for _, index := range indexes {
+			transfers = append(transfers, transferIndex{txid, index})
+			if len(transfers) >= maxResults {
+				return &db.StopIteration{}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Annotatef(err, "GetContractTransfers %v", contract)
+	}
+	bestheight, _, err := w.db.GetBestBlock()
+	if err != nil {
+		return nil, errors.Annotatef(err, "GetBestBlock")
+	}
+	pg, from, to, _ := computePaging(len(transfers), page, transfersOnPage)
+	// the total number of transfers is not known
+	if len(transfers) >= maxResults {
+		pg.TotalPages = -1
+	}
+	r := &ContractTransfers{
+		Paging:    pg,
+		Contract:  contract,
+		Transfers: make([]ContractTransfer, 0, to-from),
+	}
+	var bchainTx *bchain.Tx
+	var height int
+	var lastTxid string
+	for i := from; i < to; i++ {
+		t := &transfers[i]
+		// the transfers of one transaction are adjacent
+		if t.txid != lastTxid {
+			bchainTx, height, err = w.txCache.GetTransaction(t.txid)
+			if err != nil {
+				return nil, errors.Annotatef(err, "GetTransaction %v", t.txid)
+			}
+			lastTxid = t.txid
+		}
+		tt, err := w.getTokenTransfer(bchainTx, t.index)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getTokenTransfer %v", t.txid)
+		}
+		if tt == nil {
+			glog.Warning("DB inconsistency: tx ", t.txid, ": token transfer ", t.index, " not found")
+			continue
+		}
+		r.Transfers = append(r.Transfers, ContractTransfer{
+			Txid:          bchainTx.Txid,
+			BlockHeight:   height,
+			Confirmations: bestheight - uint32(height) + 1,
+			BlockTime:     bchainTx.Blocktime,
+			TokenTransfer: *tt,
+		})
+	}
+	return r, nil
+}
+
+// GetContractHolders returns the holders of the ERC20/TRC20 token of the contract sorted by the balance from the highest,
+// the current holders if height is 0, otherwise the holders after the block at height
+func (w *Worker) GetContractHolders(contract string, page int, holdersOnPage int, height uint32) (*ContractHolders, error) {
+	if w.chainType != bchain.ChainEthereumType && w.chainType != bchain.ChainTronType {
+		return nil, NewAPIError("Contract holders are not supported", true)
+	}
+	page--
+	if page < 0 {
+		page = 0
+	}
+	contractDesc, contract, err := w.getAddrDescAndNormalizeAddress(contract)
+	if err != nil {
+		return nil, err
+	}
+	bestheight, _, err := w.db.GetBestBlock()
+	if err != nil {
+		return nil, errors.Annotatef(err, "GetBestBlock")
+	}
+	if height > bestheight {
+		return nil, NewAPIError(fmt.Sprintf("Height %d is above the best block %d", height, bestheight), true)
+	}
+	from := page * holdersOnPage
+	holders, total, err := w.db.GetContractHolders(contractDesc, height, from, holdersOnPage)
+	if err == nil && from > 0 && from >= total {
+		// the page is beyond the last holder, return the last page
+		_, from, _, _ = computePaging(total, page, holdersOnPage)
+		holders, total, err = w.db.GetContractHolders(contractDesc, height, from, holdersOnPage)
+	}
+	if err != nil {
+		if err == db.ErrTooManyContractHolderBalances {
+			return nil, NewAPIError("The contract has too many holders to return them after a block in the past", true)
+		}
+		return nil, errors.Annotatef(err, "GetContractHolders %v", contract)
+	}
+	standard := ERC20TokenType
+	if w.chainType == bchain.ChainTronType {
+		standard = TRC20TokenType
+	}
+	ci, _, err := w.getContractInfo(contractDesc, standard)
+	if err != nil {
+		return nil, errors.Annotatef(err, "getContractInfo %v", contract)
+	}
+	pg, _, _, _ := computePaging(total, page, holdersOnPage)
+	r := &ContractHolders{
+		Paging:       pg,
+		Contract:     contract,
+		Height:       height,
+		TotalHolders: total,
+		Holders:      make([]ContractHolder, 0, len(holders)),
+	}
+	if ci != nil {
+		r.Name = ci.Name
+		r.Symbol = ci.Symbol
+		r.Decimals = ci.Decimals
+	}
+	for i := range holders {
+		h := &holders[i]
+		var address string
+		if addresses, _, _ := w.chainParser.GetAddressesFromAddrDesc(h.AddrDesc); len(addresses) > 0 {
+			address = addresses[0]
+		}
+		r.Holders = append(r.Holders, ContractHolder{
+			Address:    address,
+			BalanceSat: (*Amount)(&h.Balance),
+		})
+	}
+	return r, nil
+}
+
 func (w *Worker) balanceHistoryHeightsFromTo(fromTimestamp, toTimestamp int64) (uint32, uint32, uint32, uint32) {
 	fromUnix := uint32(0)
 	toUnix := maxUint32
@@ -1507,13 +2030,15 @@ func (w *Worker) getAddrDescUtxo(addrDesc bchain.AddressDescriptor, ba *db.AddrB
 								if len(bchainTx.Vin) == 1 && len(bchainTx.Vin[0].Coinbase) > 0 {
 									coinbase = true
 								}
-								utxos = append(utxos, Utxo{
+								u := Utxo{
 									Txid:      bchainTx.Txid,
 									Vout:      int32(i),
 									AmountSat: (*Amount)(&vout.ValueSat),
 									Locktime:  bchainTx.LockTime,
 									Coinbase:  coinbase,
-								})
+								}
+								u.Ancestors, u.Descendants, u.EffectiveFeeRate = w.getMempoolTxPackage(bchainTx.Txid)
+								utxos = append(utxos, u)
 								inMempool[bchainTx.Txid] = struct{}{}
 							}
 						}
@@ -2135,7 +2660,7 @@ func (w *Worker) cachedBitcoinTypeEstimateFee(blocks int, conservative bool, s *
 	if s.timestamp >= threshold {
 		return s.fee, nil
 	}
-	fee, err := w.chain.EstimateSmartFee(blocks, conservative)
+	fee, err := w.estimateSmartFee(blocks, conservative)
 	if err == nil {
 		s.timestamp = time.Now().Unix()
 		s.fee = fee
@@ -2143,11 +2668,18 @@ func (w *Worker) cachedBitcoinTypeEstimateFee(blocks int, conservative bool, s *
 	return fee, err
 }
 
+func (w *Worker) estimateSmartFee(blocks int, conservative bool) (big.Int, error) {
+	if IsInternalFeeEstimatorEnabled() {
+		return w.internalEstimateFee(blocks, conservative)
+	}
+	return w.chain.EstimateSmartFee(blocks, conservative)
+}
+
 // BitcoinTypeEstimateFee returns a fee estimation for given number of blocks
-// it uses 10 second cache to reduce calls to the backend
+// it uses 10 second cache to reduce calls to the backend or the internal fee estimator
 func (w *Worker) BitcoinTypeEstimateFee(blocks int, conservative bool) (big.Int, error) {
 	if blocks >= bitcoinTypeEstimatedFeeCacheSize {
-		return w.chain.EstimateSmartFee(blocks, conservative)
+		return w.estimateSmartFee(blocks, conservative)
 	}
 	if conservative {
 		return w.cachedBitcoinTypeEstimateFee(blocks, conservative, &bitcoinTypeEstimatedFeeConservativeCache[blocks])
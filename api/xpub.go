@@ -47,6 +47,7 @@ func (a xpubTxids) Less(i, j int) bool {
 type xpubAddress struct {
 	addrDesc  bchain.AddressDescriptor
 	balance   *db.AddrBalance
+	contracts *db.AddrContracts // contracts of the address, only for Ethereum and Tron type coins
 	txs       uint32
 	maxHeight uint32
 	complete  bool
@@ -187,6 +188,9 @@ func (w *Worker) xpubCheckAndLoadTxids(ad *xpubAddress, filter *AddressFilter, m
 
 func (w *Worker) xpubDerivedAddressBalance(data *xpubData, ad *xpubAddress) (bool, error) {
 	var err error
+	if w.chainType != bchain.ChainBitcoinType {
+		return w.xpubDerivedAddressContracts(data, ad)
+	}
 	if ad.balance, err = w.db.GetAddrDescBalance(ad.addrDesc, db.AddressBalanceDetailUTXO); err != nil {
 		return false, err
 	}
@@ -199,6 +203,40 @@ func (w *Worker) xpubDerivedAddressBalance(data *xpubData, ad *xpubAddress) (boo
 	return false, nil
 }
 
+// xpubDerivedAddressContracts loads the contracts and the balance of an address derived from Ethereum or Tron type xpub
+// the address is used if it has some transactions in the index, only the balance of the used addresses is read from the backend
+func (w *Worker) xpubDerivedAddressContracts(data *xpubData, ad *xpubAddress) (bool, error) {
+	var err error
+	var b *big.Int
+	if w.chainType == bchain.ChainTronType {
+		ad.contracts, err = w.db.GetTronAddrDescContracts(ad.addrDesc)
+	} else {
+		ad.contracts, err = w.db.GetAddrDescContracts(ad.addrDesc)
+	}
+	if err != nil {
+		return false, err
+	}
+	if ad.contracts == nil {
+		ad.balance = nil
+		return false, nil
+	}
+	if w.chainType == bchain.ChainTronType {
+		b, err = w.chain.TronTypeGetBalance(ad.addrDesc)
+	} else {
+		b, err = w.chain.EthereumTypeGetBalance(ad.addrDesc)
+	}
+	if err != nil {
+		return false, errors.Annotatef(err, "GetBalance %v", ad.addrDesc)
+	}
+	ad.balance = &db.AddrBalance{Txs: uint32(ad.contracts.TotalTxs)}
+	if b != nil {
+		ad.balance.BalanceSat = *b
+	}
+	data.txCountEstimate += ad.balance.Txs
+	data.balanceSat.Add(&data.balanceSat, &ad.balance.BalanceSat)
+	return true, nil
+}
+
 func (w *Worker) xpubScanAddresses(xpub string, data *xpubData, addresses []xpubAddress, gap int, change int, minDerivedIndex int, fork bool) (int, []xpubAddress, error) {
 	// rescan known addresses
 	lastUsed := 0
@@ -259,8 +297,11 @@ func (w *Worker) tokenFromXpubAddress(data *xpubData, ad *xpubAddress, changeInd
 		transfers = int(ad.balance.Txs)
 		if option >= AccountDetailsTokenBalances {
 			balance = &ad.balance.BalanceSat
-			totalSent = &ad.balance.SentSat
-			totalReceived = ad.balance.ReceivedSat()
+			// the sent and received amounts are not indexed for Ethereum and Tron type coins
+			if w.chainType == bchain.ChainBitcoinType {
+				totalSent = &ad.balance.SentSat
+				totalReceived = ad.balance.ReceivedSat()
+			}
 		}
 	}
 	return Token{
@@ -275,8 +316,78 @@ func (w *Worker) tokenFromXpubAddress(data *xpubData, ad *xpubAddress, changeInd
 	}
 }
 
+// xpubContractTokens returns the tokens of all derived addresses of Ethereum or Tron type xpub aggregated by the contract
+func (w *Worker) xpubContractTokens(data *xpubData, option AccountDetails) ([]Token, error) {
+	var tokens []Token
+	contractToken := make(map[string]int)
+	for i := range data.addresses {
+		ad := &data.addresses[i]
+		if ad.contracts == nil {
+			continue
+		}
+		for j := range ad.contracts.Contracts {
+			c := &ad.contracts.Contracts[j]
+			var t *Token
+			var err error
+			if w.chainType == bchain.ChainTronType {
+				t, err = w.getTronToken(0, ad.addrDesc, c.Contract, option, int(c.Txs), c)
+			} else {
+				t, err = w.getEthereumToken(0, ad.addrDesc, c.Contract, option, int(c.Txs), c)
+			}
+			if err != nil {
+				return nil, err
+			}
+			t.ContractIndex = ""
+			if k, found := contractToken[string(c.Contract)]; found {
+				aggregateToken(&tokens[k], t)
+			} else {
+				contractToken[string(c.Contract)] = len(tokens)
+				tokens = append(tokens, *t)
+			}
+		}
+	}
+	return tokens, nil
+}
+
+// aggregateToken adds the transfers and the holdings of the token o to the token t
+// the amounts are summed to new big.Ints, the token o can reference cached data
+func aggregateToken(t *Token, o *Token) {
+	t.Transfers += o.Transfers
+	if o.BalanceSat != nil {
+		var b big.Int
+		if t.BalanceSat != nil {
+			b.Add((*big.Int)(t.BalanceSat), (*big.Int)(o.BalanceSat))
+		} else {
+			b.Set((*big.Int)(o.BalanceSat))
+		}
+		t.BalanceSat = (*Amount)(&b)
+	}
+	if len(o.Ids) > 0 {
+		t.Ids = append(append([]Amount{}, t.Ids...), o.Ids...)
+	}
+	if len(o.MultiTokenValues) > 0 {
+		mtvs := append([]MultiTokenValue{}, t.MultiTokenValues...)
+		for _, ov := range o.MultiTokenValues {
+			found := false
+			for k := range mtvs {
+				if (*big.Int)(mtvs[k].Id).Cmp((*big.Int)(ov.Id)) == 0 {
+					var v big.Int
+					v.Add((*big.Int)(mtvs[k].Value), (*big.Int)(ov.Value))
+					mtvs[k].Value = (*Amount)(&v)
+					found = true
+					break
+				}
+			}
+			if !found {
+				mtvs = append(mtvs, ov)
+			}
+		}
+		t.MultiTokenValues = mtvs
+	}
+}
+
 func (w *Worker) getXpubData(xpub string, page int, txsOnPage int, option AccountDetails, filter *AddressFilter, gap int) (*xpubData, uint32, bool, error) {
-	if w.chainType != bchain.ChainBitcoinType {
+	if w.chainType != bchain.ChainBitcoinType && w.chainType != bchain.ChainEthereumType && w.chainType != bchain.ChainTronType {
 		return nil, 0, false, ErrUnsupportedXpub
 	}
 	var (
@@ -334,9 +445,12 @@ func (w *Worker) getXpubData(xpub string, page int, txsOnPage int, option Accoun
 			if err != nil {
 				return nil, 0, inCache, err
 			}
-			_, data.changeAddresses, err = w.xpubScanAddresses(xpub, &data, data.changeAddresses, gap, 1, lastUsedIndex, fork)
-			if err != nil {
-				return nil, 0, inCache, err
+			// Ethereum and Tron type accounts do not use the change addresses
+			if w.chainType == bchain.ChainBitcoinType {
+				_, data.changeAddresses, err = w.xpubScanAddresses(xpub, &data, data.changeAddresses, gap, 1, lastUsedIndex, fork)
+				if err != nil {
+					return nil, 0, inCache, err
+				}
 			}
 		}
 		if option >= AccountDetailsTxidHistory {
@@ -433,7 +547,12 @@ func (w *Worker) GetXpubAddress(xpub string, page int, txsOnPage int, option Acc
 							unconfirmedTxs++
 						}
 						uBalSat.Add(&uBalSat, tx.getAddrVoutValue(ad.addrDesc))
-						uBalSat.Sub(&uBalSat, tx.getAddrVinValue(ad.addrDesc))
+						// ethereum has a different logic - value not in input and add maximum possible fees
+						if w.chainType == bchain.ChainEthereumType {
+							uBalSat.Sub(&uBalSat, tx.getAddrEthereumTypeMempoolInputValue(ad.addrDesc))
+						} else {
+							uBalSat.Sub(&uBalSat, tx.getAddrVinValue(ad.addrDesc))
+						}
 						// mempool txs are returned only on the first page, uniquely and filtered
 						if page == 0 && !foundTx && (txidFilter == nil || txidFilter(&txid, ad)) {
 							mempoolEntries = append(mempoolEntries, bchain.MempoolTxidEntry{Txid: txid.txid, Time: uint32(tx.Blocktime)})
@@ -530,14 +649,24 @@ func (w *Worker) GetXpubAddress(xpub string, page int, txsOnPage int, option Acc
 			}
 		}
 	}
-	var totalReceived big.Int
-	totalReceived.Add(&data.balanceSat, &data.sentSat)
+	var totalReceived, totalSent *big.Int
+	if w.chainType == bchain.ChainBitcoinType {
+		totalReceived = new(big.Int).Add(&data.balanceSat, &data.sentSat)
+		totalSent = &data.sentSat
+	} else if option > AccountDetailsBasic {
+		// the tokens of the derived addresses are followed by the tokens of the contracts aggregated over all derived addresses
+		contractTokens, err := w.xpubContractTokens(data, option)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, contractTokens...)
+	}
 	addr := Address{
 		Paging:                pg,
 		AddrStr:               xpub,
 		BalanceSat:            (*Amount)(&data.balanceSat),
-		TotalReceivedSat:      (*Amount)(&totalReceived),
-		TotalSentSat:          (*Amount)(&data.sentSat),
+		TotalReceivedSat:      (*Amount)(totalReceived),
+		TotalSentSat:          (*Amount)(totalSent),
 		Txs:                   txCount,
 		UnconfirmedBalanceSat: (*Amount)(&uBalSat),
 		UnconfirmedTxs:        unconfirmedTxs,
@@ -553,6 +682,9 @@ func (w *Worker) GetXpubAddress(xpub string, page int, txsOnPage int, option Acc
 
 // GetXpubUtxo returns unspent outputs for given xpub
 func (w *Worker) GetXpubUtxo(xpub string, onlyConfirmed bool, gap int) (Utxos, error) {
+	if w.chainType != bchain.ChainBitcoinType {
+		return nil, ErrUnsupportedXpub
+	}
 	start := time.Now()
 	data, _, inCache, err := w.getXpubData(xpub, 0, 1, AccountDetailsBasic, &AddressFilter{
 		Vout:          AddressFilterVoutOff,
@@ -592,6 +724,37 @@ func (w *Worker) GetXpubUtxo(xpub string, onlyConfirmed bool, gap int) (Utxos, e
 	return r, nil
 }
 
+// XpubDerivedAddresses are the addresses derived from xpub, Addresses[0] is the receive chain and Addresses[1] the change chain
+// all addresses up to the last used address plus the gap are derived
+type XpubDerivedAddresses struct {
+	BasePath  string
+	Gap       int
+	Addresses [2][]bchain.AddressDescriptor
+}
+
+// GetXpubDerivedAddresses returns the derived addresses of xpub which should be watched for new transactions
+// the returned Gap is the number of addresses which must be derived after the last used address
+func (w *Worker) GetXpubDerivedAddresses(xpub string, gap int) (*XpubDerivedAddresses, error) {
+	data, _, _, err := w.getXpubData(xpub, 0, 1, AccountDetailsBasic, &AddressFilter{
+		Vout:          AddressFilterVoutOff,
+		OnlyConfirmed: true,
+	}, gap)
+	if err != nil {
+		return nil, err
+	}
+	r := &XpubDerivedAddresses{
+		BasePath: data.basePath,
+		Gap:      data.gap,
+	}
+	for ci, da := range [][]xpubAddress{data.addresses, data.changeAddresses} {
+		r.Addresses[ci] = make([]bchain.AddressDescriptor, len(da))
+		for i := range da {
+			r.Addresses[ci][i] = da[i].addrDesc
+		}
+	}
+	return r, nil
+}
+
 // GetXpubBalanceHistory returns history of balance for given xpub
 func (w *Worker) GetXpubBalanceHistory(xpub string, fromTimestamp, toTimestamp int64, currencies []string, gap int, groupBy uint32) (BalanceHistories, error) {
 	bhs := make(BalanceHistories, 0)
@@ -0,0 +1,47 @@
+// +build unittest
+
+package api
+
+import (
+	"math/big"
+	"testing"
+)
+
+func Test_aggregateToken(t *testing.T) {
+	amount := func(i int64) *Amount { return (*Amount)(big.NewInt(i)) }
+	balance := amount(5)
+	mtvValue := amount(3)
+	o := Token{
+		Transfers:        2,
+		BalanceSat:       balance,
+		Ids:              []Amount{*amount(7)},
+		MultiTokenValues: []MultiTokenValue{{Id: amount(1), Value: mtvValue}, {Id: amount(2), Value: amount(4)}},
+	}
+	tk := Token{
+		Transfers:        1,
+		BalanceSat:       amount(10),
+		Ids:              []Amount{*amount(6)},
+		MultiTokenValues: []MultiTokenValue{{Id: amount(1), Value: amount(1)}},
+	}
+	aggregateToken(&tk, &o)
+	if tk.Transfers != 3 || tk.BalanceSat.String() != "15" {
+		t.Errorf("aggregateToken() transfers %d, balance %v", tk.Transfers, tk.BalanceSat)
+	}
+	if len(tk.Ids) != 2 || tk.Ids[0].String() != "6" || tk.Ids[1].String() != "7" {
+		t.Errorf("aggregateToken() ids %v", tk.Ids)
+	}
+	if len(tk.MultiTokenValues) != 2 || tk.MultiTokenValues[0].Value.String() != "4" || tk.MultiTokenValues[1].Id.String() != "2" || tk.MultiTokenValues[1].Value.String() != "4" {
+		t.Errorf("aggregateToken() multiTokenValues %+v", tk.MultiTokenValues)
+	}
+	// the aggregated token must not modify the amounts of the added token
+	if balance.String() != "5" || mtvValue.String() != "3" {
+		t.Errorf("aggregateToken() modified the added token, balance %v, value %v", balance, mtvValue)
+	}
+
+	// token without balance takes a copy of the balance of the added token
+	tk = Token{}
+	aggregateToken(&tk, &o)
+	if tk.BalanceSat == balance || tk.BalanceSat.String() != "5" {
+		t.Errorf("aggregateToken() balance %v", tk.BalanceSat)
+	}
+}
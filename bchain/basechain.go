@@ -59,6 +59,16 @@ func (b *BaseChain) EthereumTypeGetErc20ContractBalance(addrDesc, contractDesc A
 	return nil, errors.New("Not supported")
 }
 
+// EthereumTypeGetEip1559Fees is not supported
+func (b *BaseChain) EthereumTypeGetEip1559Fees() (*Eip1559Fees, error) {
+	return nil, errors.New("Not supported")
+}
+
+// EthereumTypeSimulateTransaction is not supported
+func (b *BaseChain) EthereumTypeSimulateTransaction(params map[string]interface{}) (*TransactionSimulation, error) {
+	return nil, errors.New("Not supported")
+}
+
 func (b *BaseChain) TronTypeGetBalance(addrDesc AddressDescriptor) (*big.Int, error) {
 	return nil, errors.New("Not supported")
 }
@@ -75,6 +85,11 @@ func (b *BaseChain) TronTypeGetTransactionNotify(tx *Tx) bool {
 	return false
 }
 
+// TronTypeSimulateTransaction is not supported
+func (b *BaseChain) TronTypeSimulateTransaction(params map[string]interface{}) (*TransactionSimulation, error) {
+	return nil, errors.New("Not supported")
+}
+
 func (b *BaseChain) Reconnect(url string) error {
 	return errors.New("Not supported")
 }
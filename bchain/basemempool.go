@@ -14,11 +14,20 @@ type addrIndex struct {
 type txEntry struct {
 	addrIndexes []addrIndex
 	time        uint32
+	fee         uint64     // fee in satoshi, set only for BitcoinType
+	vsize       uint32     // virtual size, 0 if the fee is not known
+	inputs      []Outpoint // outpoints spent by the transaction, set only for BitcoinType
+	sender      string     // address descriptor of the sender, set only for EthereumType
+	nonce       uint64     // nonce of the sender, set only for EthereumType
+	missing     bool       // the backend did not return the transaction, set only for EthereumType
 }
 
 type txidio struct {
-	txid string
-	io   []addrIndex
+	txid   string
+	io     []addrIndex
+	fee    uint64
+	vsize  uint32
+	inputs []Outpoint
 }
 
 // BaseMempool is mempool base handle
@@ -27,8 +36,12 @@ type BaseMempool struct {
 	mux          sync.Mutex
 	txEntries    map[string]txEntry
 	addrDescToTx map[string][]Outpoint
+	spentBy      map[string]map[int32]string     // txid -> vout -> txid of the mempool transaction spending the outpoint
+	replacements map[string][]MempoolReplacement // txid -> replacements in which the transaction takes part
+	senderNonces map[string]map[uint64]string    // sender -> nonce -> txid of the mempool transaction, set only for EthereumType
 	OnNewTxAddr  OnNewTxAddrFunc
 	OnNewTx      OnNewTxFunc
+	OnReplacedTx OnReplacedTxFunc
 }
 
 // GetTransactions returns slice of mempool transactions for given address
@@ -70,6 +83,20 @@ func (a MempoolTxidEntries) Less(i, j int) bool {
 // removeEntryFromMempool removes entry from mempool structs. The caller is responsible for locking!
 func (m *BaseMempool) removeEntryFromMempool(txid string, entry txEntry) {
 	delete(m.txEntries, txid)
+	for _, o := range entry.inputs {
+		if spent, found := m.spentBy[o.Txid]; found && spent[o.Vout] == txid {
+			delete(spent, o.Vout)
+			if len(spent) == 0 {
+				delete(m.spentBy, o.Txid)
+			}
+		}
+	}
+	if nonces, found := m.senderNonces[entry.sender]; found && nonces[entry.nonce] == txid {
+		delete(nonces, entry.nonce)
+		if len(nonces) == 0 {
+			delete(m.senderNonces, entry.sender)
+		}
+	}
 	for _, si := range entry.addrIndexes {
 		outpoints, found := m.addrDescToTx[si.addrDesc]
 		if found {
@@ -105,6 +132,23 @@ func (m *BaseMempool) GetAllEntries() MempoolTxidEntries {
 	return entries
 }
 
+// GetAllFeeEntries returns the fee and the virtual size of all mempool entries with known fee
+func (m *BaseMempool) GetAllFeeEntries() []MempoolFeeEntry {
+	m.mux.Lock()
+	entries := make([]MempoolFeeEntry, 0, len(m.txEntries))
+	for txid, entry := range m.txEntries {
+		if entry.vsize > 0 {
+			entries = append(entries, MempoolFeeEntry{
+				Txid:  txid,
+				Fee:   entry.fee,
+				VSize: entry.vsize,
+			})
+		}
+	}
+	m.mux.Unlock()
+	return entries
+}
+
 // GetTransactionTime returns first seen time of a transaction
 func (m *BaseMempool) GetTransactionTime(txid string) uint32 {
 	m.mux.Lock()
@@ -116,6 +160,182 @@ func (m *BaseMempool) GetTransactionTime(txid string) uint32 {
 	return e.time
 }
 
+// GetTxReplacements returns the replacements of conflicting mempool transactions in which the transaction takes part
+func (m *BaseMempool) GetTxReplacements(txid string) []MempoolReplacement {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	r := m.replacements[txid]
+	if len(r) == 0 {
+		return nil
+	}
+	rv := make([]MempoolReplacement, len(r))
+	copy(rv, r)
+	return rv
+}
+
+// GetAddrDescNonces returns the mempool transactions sent by the address ordered by their nonces, the nonces are known only for EthereumType
+func (m *BaseMempool) GetAddrDescNonces(addrDesc AddressDescriptor) []MempoolNonceEntry {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	nonces := m.senderNonces[string(addrDesc)]
+	if len(nonces) == 0 {
+		return nil
+	}
+	rv := make([]MempoolNonceEntry, 0, len(nonces))
+	for nonce, txid := range nonces {
+		rv = append(rv, MempoolNonceEntry{Txid: txid, Nonce: nonce, Time: m.txEntries[txid].time})
+	}
+	sort.Slice(rv, func(i, j int) bool { return rv[i].Nonce < rv[j].Nonce })
+	return rv
+}
+
+// entryAddrDescs returns the unique address descriptors of the mempool entry
+func entryAddrDescs(entry txEntry) []AddressDescriptor {
+	var addrDescs []AddressDescriptor
+	unique := make(map[string]struct{}, len(entry.addrIndexes))
+	for _, ai := range entry.addrIndexes {
+		if _, ok := unique[ai.addrDesc]; !ok {
+			unique[ai.addrDesc] = struct{}{}
+			addrDescs = append(addrDescs, AddressDescriptor(ai.addrDesc))
+		}
+	}
+	return addrDescs
+}
+
+// addReplacement records the replacement of txid by replacedBy, returns false if it is already known. The caller is responsible for locking!
+func (m *BaseMempool) addReplacement(txid, replacedBy string, time uint32) (*MempoolReplacement, bool) {
+	for i := range m.replacements[txid] {
+		if m.replacements[txid][i].ReplacedBy == replacedBy {
+			return nil, false
+		}
+	}
+	r := MempoolReplacement{Txid: txid, ReplacedBy: replacedBy, Time: time}
+	m.replacements[txid] = append(m.replacements[txid], r)
+	m.replacements[replacedBy] = append(m.replacements[replacedBy], r)
+	return &r, true
+}
+
+// pruneReplacements removes the replacements detected before the threshold. The caller is responsible for locking!
+func (m *BaseMempool) pruneReplacements(threshold uint32) {
+	for txid, rs := range m.replacements {
+		kept := rs[:0]
+		for _, r := range rs {
+			if r.Time >= threshold {
+				kept = append(kept, r)
+			}
+		}
+		if len(kept) > 0 {
+			m.replacements[txid] = kept
+		} else {
+			delete(m.replacements, txid)
+		}
+	}
+}
+
+// maxMempoolTxRelatives limits the number of the ancestors and descendants of a transaction returned by GetTxPackage
+const maxMempoolTxRelatives = 100
+
+// mempoolParents returns the mempool transactions, outputs of which are spent by the entry. The caller is responsible for locking!
+func (m *BaseMempool) mempoolParents(txid string) []string {
+	entry := m.txEntries[txid]
+	var parents []string
+	for _, o := range entry.inputs {
+		if _, found := m.txEntries[o.Txid]; found {
+			parents = append(parents, o.Txid)
+		}
+	}
+	return parents
+}
+
+// mempoolChildren returns the mempool transactions spending the outputs of the entry. The caller is responsible for locking!
+func (m *BaseMempool) mempoolChildren(txid string) []string {
+	spent := m.spentBy[txid]
+	vouts := make([]int32, 0, len(spent))
+	for n := range spent {
+		vouts = append(vouts, n)
+	}
+	// keep the order of the outputs
+	sort.Slice(vouts, func(i, j int) bool { return vouts[i] < vouts[j] })
+	var children []string
+	for _, n := range vouts {
+		children = append(children, spent[n])
+	}
+	return children
+}
+
+// mempoolRelatives returns the transitive closure of the relation next of the transaction, without the transaction itself. The caller is responsible for locking!
+func (m *BaseMempool) mempoolRelatives(txid string, next func(string) []string) []string {
+	visited := map[string]struct{}{txid: {}}
+	var relatives []string
+	queue := []string{txid}
+	for len(queue) > 0 && len(relatives) < maxMempoolTxRelatives {
+		for _, r := range next(queue[0]) {
+			if _, found := visited[r]; !found && len(relatives) < maxMempoolTxRelatives {
+				visited[r] = struct{}{}
+				relatives = append(relatives, r)
+				queue = append(queue, r)
+			}
+		}
+		queue = queue[1:]
+	}
+	return relatives
+}
+
+// ancestorsFeeRate returns the fee rate of the transaction together with its ancestors, 0 if the fee of the transaction is not known. The caller is responsible for locking!
+func (m *BaseMempool) ancestorsFeeRate(txid string, ancestors []string) float64 {
+	entry := m.txEntries[txid]
+	if entry.vsize == 0 {
+		return 0
+	}
+	fee, vsize := entry.fee, uint64(entry.vsize)
+	for _, a := range ancestors {
+		if e := m.txEntries[a]; e.vsize > 0 {
+			fee += e.fee
+			vsize += uint64(e.vsize)
+		}
+	}
+	return float64(fee) / float64(vsize)
+}
+
+func (m *BaseMempool) feeEntries(txids []string) []MempoolFeeEntry {
+	if len(txids) == 0 {
+		return nil
+	}
+	entries := make([]MempoolFeeEntry, len(txids))
+	for i, txid := range txids {
+		e := m.txEntries[txid]
+		entries[i] = MempoolFeeEntry{Txid: txid, Fee: e.fee, VSize: e.vsize}
+	}
+	return entries
+}
+
+// GetTxPackage returns the unconfirmed ancestors and descendants of a mempool transaction
+// and its effective fee rate, taking into account the fees paid by the descendants (CPFP)
+// it returns nil if the transaction is not in the mempool
+func (m *BaseMempool) GetTxPackage(txid string) *MempoolTxPackage {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if _, found := m.txEntries[txid]; !found {
+		return nil
+	}
+	ancestors := m.mempoolRelatives(txid, m.mempoolParents)
+	descendants := m.mempoolRelatives(txid, m.mempoolChildren)
+	// the transaction is mined with the best package of a descendant and its ancestors, which contains the transaction
+	feeRate := m.ancestorsFeeRate(txid, ancestors)
+	if feeRate > 0 {
+		for _, d := range descendants {
+			if f := m.ancestorsFeeRate(d, m.mempoolRelatives(d, m.mempoolParents)); f > feeRate {
+				feeRate = f
+			}
+		}
+	}
+	return &MempoolTxPackage{
+		Ancestors:        m.feeEntries(ancestors),
+		Descendants:      m.feeEntries(descendants),
+		EffectiveFeeRate: feeRate,
+	}
+}
+
 func (m *BaseMempool) txToMempoolTx(tx *Tx) *MempoolTx {
 	mtx := MempoolTx{
 		Hex:              tx.Hex,
@@ -300,6 +300,46 @@ func (p *BaseParser) EthereumTypeGetErc20FromTx(tx *Tx) ([]Erc20Transfer, error)
 	return nil, errors.New("Not supported")
 }
 
+// EthereumTypeGetInternalTransfersFromTx is unsupported
+func (p *BaseParser) EthereumTypeGetInternalTransfersFromTx(tx *Tx) ([]EthereumInternalTransfer, error) {
+	return nil, errors.New("Not supported")
+}
+
+// EthereumTypeGetContractCreationsFromTx is unsupported
+func (p *BaseParser) EthereumTypeGetContractCreationsFromTx(tx *Tx) ([]ContractCreation, error) {
+	return nil, errors.New("Not supported")
+}
+
+// EthereumTypeGetTokenApprovalsFromTx is unsupported
+func (p *BaseParser) EthereumTypeGetTokenApprovalsFromTx(tx *Tx) ([]TokenApproval, error) {
+	return nil, errors.New("Not supported")
+}
+
+// EthereumTypeGetNonceFromTx is unsupported
+func (p *BaseParser) EthereumTypeGetNonceFromTx(tx *Tx) (uint64, error) {
+	return 0, errors.New("Not supported")
+}
+
 func (p *BaseParser) TronTypeGetTrc20FromTx(tx *Tx) ([]Trc20Transfer, error) {
 	return nil, errors.New("Not supported")
 }
+
+// TronTypeGetContractType is unsupported
+func (p *BaseParser) TronTypeGetContractType(tx *MempoolTx) (string, error) {
+	return "", errors.New("Not supported")
+}
+
+// TronTypeGetContractTypes is unsupported
+func (p *BaseParser) TronTypeGetContractTypes() []string {
+	return nil
+}
+
+// TronTypeGetContractCreationsFromTx is unsupported
+func (p *BaseParser) TronTypeGetContractCreationsFromTx(tx *Tx) ([]ContractCreation, error) {
+	return nil, errors.New("Not supported")
+}
+
+// TronTypeGetTokenApprovalsFromTx is unsupported
+func (p *BaseParser) TronTypeGetTokenApprovalsFromTx(tx *Tx) ([]TokenApproval, error) {
+	return nil, errors.New("Not supported")
+}
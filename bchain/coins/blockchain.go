@@ -193,8 +193,8 @@ func (c *blockChainWithMetrics) CreateMempool(chain bchain.BlockChain) (bchain.M
 	return c.b.CreateMempool(chain)
 }
 
-func (c *blockChainWithMetrics) InitializeMempool(addrDescForOutpoint bchain.AddrDescForOutpointFunc, onNewTxAddr bchain.OnNewTxAddrFunc, onNewTx bchain.OnNewTxFunc) error {
-	return c.b.InitializeMempool(addrDescForOutpoint, onNewTxAddr, onNewTx)
+func (c *blockChainWithMetrics) InitializeMempool(addrDescForOutpoint bchain.AddrDescForOutpointFunc, onNewTxAddr bchain.OnNewTxAddrFunc, onNewTx bchain.OnNewTxFunc, onReplacedTx bchain.OnReplacedTxFunc) error {
+	return c.b.InitializeMempool(addrDescForOutpoint, onNewTxAddr, onNewTx, onReplacedTx)
 }
 
 func (c *blockChainWithMetrics) Shutdown(ctx context.Context) error {
@@ -321,6 +321,16 @@ func (c *blockChainWithMetrics) EthereumTypeGetErc20ContractBalance(addrDesc, co
 	return c.b.EthereumTypeGetErc20ContractBalance(addrDesc, contractDesc)
 }
 
+func (c *blockChainWithMetrics) EthereumTypeGetEip1559Fees() (v *bchain.Eip1559Fees, err error) {
+	defer func(s time.Time) { c.observeRPCLatency("EthereumTypeGetEip1559Fees", s, err) }(time.Now())
+	return c.b.EthereumTypeGetEip1559Fees()
+}
+
+func (c *blockChainWithMetrics) EthereumTypeSimulateTransaction(params map[string]interface{}) (v *bchain.TransactionSimulation, err error) {
+	defer func(s time.Time) { c.observeRPCLatency("EthereumTypeSimulateTransaction", s, err) }(time.Now())
+	return c.b.EthereumTypeSimulateTransaction(params)
+}
+
 func (c *blockChainWithMetrics) TronTypeGetBalance(addrDesc bchain.AddressDescriptor) (v *big.Int, err error) {
 	defer func(s time.Time) { c.observeRPCLatency("TronTypeGetBalance", s, err) }(time.Now())
 	return c.b.TronTypeGetBalance(addrDesc)
@@ -341,6 +351,11 @@ func (c *blockChainWithMetrics) TronTypeGetTransactionNotify(tx *bchain.Tx) bool
 	return c.b.TronTypeGetTransactionNotify(tx)
 }
 
+func (c *blockChainWithMetrics) TronTypeSimulateTransaction(params map[string]interface{}) (v *bchain.TransactionSimulation, err error) {
+	defer func(s time.Time) { c.observeRPCLatency("TronTypeSimulateTransaction", s, err) }(time.Now())
+	return c.b.TronTypeSimulateTransaction(params)
+}
+
 func (c *blockChainWithMetrics) Reconnect(url string) error {
 	defer func(s time.Time) { c.observeRPCLatency("Reconnect", s, nil) }(time.Now())
 	return c.b.Reconnect(url)
@@ -383,6 +398,23 @@ func (c *mempoolWithMetrics) GetAllEntries() (v bchain.MempoolTxidEntries) {
 	return c.mempool.GetAllEntries()
 }
 
+func (c *mempoolWithMetrics) GetAllFeeEntries() (v []bchain.MempoolFeeEntry) {
+	defer func(s time.Time) { c.observeRPCLatency("GetAllFeeEntries", s, nil) }(time.Now())
+	return c.mempool.GetAllFeeEntries()
+}
+
 func (c *mempoolWithMetrics) GetTransactionTime(txid string) uint32 {
 	return c.mempool.GetTransactionTime(txid)
 }
+
+func (c *mempoolWithMetrics) GetTxReplacements(txid string) []bchain.MempoolReplacement {
+	return c.mempool.GetTxReplacements(txid)
+}
+
+func (c *mempoolWithMetrics) GetTxPackage(txid string) *bchain.MempoolTxPackage {
+	return c.mempool.GetTxPackage(txid)
+}
+
+func (c *mempoolWithMetrics) GetAddrDescNonces(addrDesc bchain.AddressDescriptor) []bchain.MempoolNonceEntry {
+	return c.mempool.GetAddrDescNonces(addrDesc)
+}
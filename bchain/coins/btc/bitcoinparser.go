@@ -311,6 +311,8 @@ func (p *BitcoinParser) ParseBlock(b []byte) (*bchain.Block, error) {
 	txs := make([]bchain.Tx, len(w.Transactions))
 	for ti, t := range w.Transactions {
 		txs[ti] = p.TxFromMsgTx(t, false)
+		// virtual size is used for the fee statistics of the block
+		txs[ti].VSize = int64((t.SerializeSizeStripped()*3 + t.SerializeSize() + 3) / 4)
 	}
 
 	return &bchain.Block{
@@ -6,7 +6,6 @@ import (
 	"encoding/hex"
 	"math/big"
 	"strings"
-	"sync"
 	"unicode/utf8"
 
 	ethcommon "github.com/ethereum/go-ethereum/common"
@@ -33,13 +32,13 @@ var erc20abi = `[{"constant":true,"inputs":[],"name":"name","outputs":[{"name":"
 // doing the parsing/processing without using go-ethereum/accounts/abi library, it is simple to get data from Transfer event
 const erc20TransferMethodSignature = "0xa9059cbb"
 const erc20TransferEventSignature = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+const erc20ApprovalEventSignature = "0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"
 const erc20NameSignature = "0x06fdde03"
 const erc20SymbolSignature = "0x95d89b41"
 const erc20DecimalsSignature = "0x313ce567"
 const erc20BalanceOf = "0x70a08231"
-
-var cachedContracts = make(map[string]*bchain.Erc20Contract)
-var cachedContractsMux sync.Mutex
+const erc1155TransferSingleEventSignature = "0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62"
+const erc1155TransferBatchEventSignature = "0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb"
 
 func addressFromPaddedHex(s string) (string, error) {
 	var t big.Int
@@ -56,30 +55,135 @@ func addressFromPaddedHex(s string) (string, error) {
 	return a.String(), nil
 }
 
+// parseUint256Array parses the dynamic uint256 array at given offset of the ABI encoded data
+func parseUint256Array(data string, offset *big.Int) ([]big.Int, error) {
+	// the offset is in bytes of the data, which is hex encoded, it must point to the length word inside of the data
+	if offset.Sign() < 0 || offset.Cmp(big.NewInt(int64(len(data)/2-32))) > 0 || offset.Int64()%32 != 0 {
+		return nil, errors.New("Invalid array offset")
+	}
+	i := int(offset.Int64() * 2)
+	var n big.Int
+	if _, ok := n.SetString(data[i:i+64], 16); !ok || n.Cmp(big.NewInt(int64((len(data)-i-64)/64))) > 0 {
+		return nil, errors.New("Invalid array length")
+	}
+	r := make([]big.Int, n.Int64())
+	for j := range r {
+		i += 64
+		if _, ok := r[j].SetString(data[i:i+64], 16); !ok {
+			return nil, errors.New("Data is not a number")
+		}
+	}
+	return r, nil
+}
+
+// erc1155GetMultiTokenValues parses the ids and values of TransferSingle or TransferBatch event
+func erc1155GetMultiTokenValues(l *rpcLog) ([]bchain.MultiTokenValue, error) {
+	data := l.Data
+	if has0xPrefix(data) {
+		data = data[2:]
+	}
+	if len(data) < 128 {
+		return nil, errors.New("Data is too short")
+	}
+	var a, b big.Int
+	if _, ok := a.SetString(data[:64], 16); !ok {
+		return nil, errors.New("Data is not a number")
+	}
+	if _, ok := b.SetString(data[64:128], 16); !ok {
+		return nil, errors.New("Data is not a number")
+	}
+	if l.Topics[0] == erc1155TransferSingleEventSignature {
+		return []bchain.MultiTokenValue{{Id: a, Value: b}}, nil
+	}
+	ids, err := parseUint256Array(data, &a)
+	if err != nil {
+		return nil, err
+	}
+	values, err := parseUint256Array(data, &b)
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) != len(values) {
+		return nil, errors.New("Different number of ids and values")
+	}
+	r := make([]bchain.MultiTokenValue, len(ids))
+	for i := range ids {
+		r[i] = bchain.MultiTokenValue{Id: ids[i], Value: values[i]}
+	}
+	return r, nil
+}
+
+// erc20GetTransfersFromLog returns ERC20 and ERC721 Transfer events and ERC1155 TransferSingle and TransferBatch events
 func erc20GetTransfersFromLog(logs []*rpcLog) ([]bchain.Erc20Transfer, error) {
 	var r []bchain.Erc20Transfer
 	for _, l := range logs {
-		if len(l.Topics) == 3 && l.Topics[0] == erc20TransferEventSignature {
-			var t big.Int
-			_, ok := t.SetString(l.Data, 0)
-			if !ok {
+		if len(l.Topics) == 0 {
+			continue
+		}
+		var t bchain.Erc20Transfer
+		var from, to string
+		var err error
+		switch {
+		case len(l.Topics) == 3 && l.Topics[0] == erc20TransferEventSignature:
+			if _, ok := t.Tokens.SetString(l.Data, 0); !ok {
 				return nil, errors.New("Data is not a number")
 			}
-			from, err := addressFromPaddedHex(l.Topics[1])
-			if err != nil {
-				return nil, err
+			t.Type = bchain.FungibleToken
+			from, to = l.Topics[1], l.Topics[2]
+		case len(l.Topics) == 4 && l.Topics[0] == erc20TransferEventSignature:
+			// ERC721 Transfer has the same signature, the tokenId is indexed
+			if _, ok := t.Tokens.SetString(l.Topics[3], 0); !ok {
+				return nil, errors.New("Token id is not a number")
 			}
-			to, err := addressFromPaddedHex(l.Topics[2])
-			if err != nil {
+			t.Type = bchain.NonFungibleToken
+			from, to = l.Topics[1], l.Topics[2]
+		case len(l.Topics) == 4 && (l.Topics[0] == erc1155TransferSingleEventSignature || l.Topics[0] == erc1155TransferBatchEventSignature):
+			if t.MultiTokenValues, err = erc1155GetMultiTokenValues(l); err != nil {
 				return nil, err
 			}
-			r = append(r, bchain.Erc20Transfer{
-				Contract: EIP55AddressFromAddress(l.Address),
-				From:     EIP55AddressFromAddress(from),
-				To:       EIP55AddressFromAddress(to),
-				Tokens:   t,
-			})
+			t.Type = bchain.MultiToken
+			// the first indexed topic is the operator
+			from, to = l.Topics[2], l.Topics[3]
+		default:
+			continue
+		}
+		if from, err = addressFromPaddedHex(from); err != nil {
+			return nil, err
 		}
+		if to, err = addressFromPaddedHex(to); err != nil {
+			return nil, err
+		}
+		t.Contract = EIP55AddressFromAddress(l.Address)
+		t.From = EIP55AddressFromAddress(from)
+		t.To = EIP55AddressFromAddress(to)
+		r = append(r, t)
+	}
+	return r, nil
+}
+
+// erc20GetApprovalsFromLog returns ERC20 Approval events, ERC721 Approval events of a single token (with indexed tokenId) are skipped
+func erc20GetApprovalsFromLog(logs []*rpcLog) ([]bchain.TokenApproval, error) {
+	var r []bchain.TokenApproval
+	for _, l := range logs {
+		if len(l.Topics) != 3 || l.Topics[0] != erc20ApprovalEventSignature {
+			continue
+		}
+		var a bchain.TokenApproval
+		if _, ok := a.Value.SetString(l.Data, 0); !ok {
+			return nil, errors.New("Data is not a number")
+		}
+		owner, err := addressFromPaddedHex(l.Topics[1])
+		if err != nil {
+			return nil, err
+		}
+		spender, err := addressFromPaddedHex(l.Topics[2])
+		if err != nil {
+			return nil, err
+		}
+		a.Contract = EIP55AddressFromAddress(l.Address)
+		a.Owner = EIP55AddressFromAddress(owner)
+		a.Spender = EIP55AddressFromAddress(spender)
+		r = append(r, a)
 	}
 	return r, nil
 }
@@ -177,53 +281,44 @@ func parseErc20StringProperty(contractDesc bchain.AddressDescriptor, data string
 }
 
 // EthereumTypeGetErc20ContractInfo returns information about ERC20 contract
+// the result is not cached, the callers are expected to persist it (see db.GetContractInfo)
 func (b *EthereumRPC) EthereumTypeGetErc20ContractInfo(contractDesc bchain.AddressDescriptor) (*bchain.Erc20Contract, error) {
-	cds := string(contractDesc)
-	cachedContractsMux.Lock()
-	contract, found := cachedContracts[cds]
-	cachedContractsMux.Unlock()
-	if !found {
-		address := EIP55Address(contractDesc)
-		data, err := b.ethCall(erc20NameSignature, address)
-		if err != nil {
-			// ignore the error from the eth_call - since geth v1.9.15 they changed the behavior
-			// and returning error "execution reverted" for some non contract addresses
-			// https://github.com/ethereum/go-ethereum/issues/21249#issuecomment-648647672
-			glog.Warning(errors.Annotatef(err, "erc20NameSignature %v", address))
-			return nil, nil
-			// return nil, errors.Annotatef(err, "erc20NameSignature %v", address)
-		}
-		name := parseErc20StringProperty(contractDesc, data)
-		if name != "" {
-			data, err = b.ethCall(erc20SymbolSignature, address)
-			if err != nil {
-				glog.Warning(errors.Annotatef(err, "erc20SymbolSignature %v", address))
-				return nil, nil
-				// return nil, errors.Annotatef(err, "erc20SymbolSignature %v", address)
-			}
-			symbol := parseErc20StringProperty(contractDesc, data)
-			data, err = b.ethCall(erc20DecimalsSignature, address)
-			if err != nil {
-				glog.Warning(errors.Annotatef(err, "erc20DecimalsSignature %v", address))
-				// return nil, errors.Annotatef(err, "erc20DecimalsSignature %v", address)
-			}
-			contract = &bchain.Erc20Contract{
-				Contract: address,
-				Name:     name,
-				Symbol:   symbol,
-			}
-			d := parseErc20NumericProperty(contractDesc, data)
-			if d != nil {
-				contract.Decimals = int(uint8(d.Uint64()))
-			} else {
-				contract.Decimals = EtherAmountDecimalPoint
-			}
-		} else {
-			contract = nil
-		}
-		cachedContractsMux.Lock()
-		cachedContracts[cds] = contract
-		cachedContractsMux.Unlock()
+	address := EIP55Address(contractDesc)
+	data, err := b.ethCall(erc20NameSignature, address)
+	if err != nil {
+		// ignore the error from the eth_call - since geth v1.9.15 they changed the behavior
+		// and returning error "execution reverted" for some non contract addresses
+		// https://github.com/ethereum/go-ethereum/issues/21249#issuecomment-648647672
+		glog.Warning(errors.Annotatef(err, "erc20NameSignature %v", address))
+		return nil, nil
+		// return nil, errors.Annotatef(err, "erc20NameSignature %v", address)
+	}
+	name := parseErc20StringProperty(contractDesc, data)
+	if name == "" {
+		return nil, nil
+	}
+	data, err = b.ethCall(erc20SymbolSignature, address)
+	if err != nil {
+		glog.Warning(errors.Annotatef(err, "erc20SymbolSignature %v", address))
+		return nil, nil
+		// return nil, errors.Annotatef(err, "erc20SymbolSignature %v", address)
+	}
+	symbol := parseErc20StringProperty(contractDesc, data)
+	data, err = b.ethCall(erc20DecimalsSignature, address)
+	if err != nil {
+		glog.Warning(errors.Annotatef(err, "erc20DecimalsSignature %v", address))
+		// return nil, errors.Annotatef(err, "erc20DecimalsSignature %v", address)
+	}
+	contract := &bchain.Erc20Contract{
+		Contract: address,
+		Name:     name,
+		Symbol:   symbol,
+	}
+	d := parseErc20NumericProperty(contractDesc, data)
+	if d != nil {
+		contract.Decimals = int(uint8(d.Uint64()))
+	} else {
+		contract.Decimals = EtherAmountDecimalPoint
 	}
 	return contract, nil
 }
@@ -97,6 +97,106 @@ func TestErc20_erc20GetTransfersFromLog(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "ERC721",
+			args: []*rpcLog{
+				{
+					Address: "0x5b4e4cdad4a0d8ed9d8ef8ad71fb0f7b5d4d42a1",
+					Topics: []string{
+						"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+						"0x0000000000000000000000004bda106325c335df99eab7fe363cac8a0ba2a24d",
+						"0x0000000000000000000000007b62eb7fe80350dc7ec945c0b73242cb9877fb1b",
+						"0x00000000000000000000000000000000000000000000000000000000000004d2",
+					},
+					Data: "0x",
+				},
+			},
+			want: []bchain.Erc20Transfer{
+				{
+					Type:     bchain.NonFungibleToken,
+					Contract: "0x5b4e4cdad4a0d8ed9d8ef8ad71fb0f7b5d4d42a1",
+					From:     "0x4bda106325c335df99eab7fe363cac8a0ba2a24d",
+					To:       "0x7b62eb7fe80350dc7ec945c0b73242cb9877fb1b",
+					Tokens:   *big.NewInt(1234),
+				},
+			},
+		},
+		{
+			name: "ERC1155",
+			args: []*rpcLog{
+				{ // TransferSingle
+					Address: "0x6c3fa1fc4bd2bc2b1f77dcd6c7b6d1e3e6a2b4e1",
+					Topics: []string{
+						"0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62",
+						"0x0000000000000000000000006f44cceb49b4a5812d54b6f494fc2febf25511ed",
+						"0x0000000000000000000000004bda106325c335df99eab7fe363cac8a0ba2a24d",
+						"0x0000000000000000000000007b62eb7fe80350dc7ec945c0b73242cb9877fb1b",
+					},
+					Data: "0x0000000000000000000000000000000000000000000000000000000000000001000000000000000000000000000000000000000000000000000000000000000a",
+				},
+				{ // TransferBatch
+					Address: "0x6c3fa1fc4bd2bc2b1f77dcd6c7b6d1e3e6a2b4e1",
+					Topics: []string{
+						"0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb",
+						"0x0000000000000000000000006f44cceb49b4a5812d54b6f494fc2febf25511ed",
+						"0x0000000000000000000000007b62eb7fe80350dc7ec945c0b73242cb9877fb1b",
+						"0x0000000000000000000000004bda106325c335df99eab7fe363cac8a0ba2a24d",
+					},
+					Data: "0x000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000000a00000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000100000000000000000000000000000000000000000000000000000000000001f400000000000000000000000000000000000000000000000000000000000000020000000000000000000000000000000000000000000000000000000000000003000000000000000000000000000000000000000000000000000000000000007b",
+				},
+			},
+			want: []bchain.Erc20Transfer{
+				{
+					Type:             bchain.MultiToken,
+					Contract:         "0x6c3fa1fc4bd2bc2b1f77dcd6c7b6d1e3e6a2b4e1",
+					From:             "0x4bda106325c335df99eab7fe363cac8a0ba2a24d",
+					To:               "0x7b62eb7fe80350dc7ec945c0b73242cb9877fb1b",
+					MultiTokenValues: []bchain.MultiTokenValue{{Id: *big.NewInt(1), Value: *big.NewInt(10)}},
+				},
+				{
+					Type:     bchain.MultiToken,
+					Contract: "0x6c3fa1fc4bd2bc2b1f77dcd6c7b6d1e3e6a2b4e1",
+					From:     "0x7b62eb7fe80350dc7ec945c0b73242cb9877fb1b",
+					To:       "0x4bda106325c335df99eab7fe363cac8a0ba2a24d",
+					MultiTokenValues: []bchain.MultiTokenValue{
+						{Id: *big.NewInt(1), Value: *big.NewInt(3)},
+						{Id: *big.NewInt(500), Value: *big.NewInt(123)},
+					},
+				},
+			},
+		},
+		{
+			name: "ERC1155 invalid batch",
+			args: []*rpcLog{
+				{
+					Address: "0x6c3fa1fc4bd2bc2b1f77dcd6c7b6d1e3e6a2b4e1",
+					Topics: []string{
+						"0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb",
+						"0x0000000000000000000000006f44cceb49b4a5812d54b6f494fc2febf25511ed",
+						"0x0000000000000000000000007b62eb7fe80350dc7ec945c0b73242cb9877fb1b",
+						"0x0000000000000000000000004bda106325c335df99eab7fe363cac8a0ba2a24d",
+					},
+					Data: "0x000000000000000000000000000000000000000000000000000000000000004000000000000000000000000000000000000000000000000000000000000010000000000000000000000000000000000000000000000000000000000000000001",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "ERC1155 batch with huge offset",
+			args: []*rpcLog{
+				{
+					Address: "0x6c3fa1fc4bd2bc2b1f77dcd6c7b6d1e3e6a2b4e1",
+					Topics: []string{
+						"0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb",
+						"0x0000000000000000000000006f44cceb49b4a5812d54b6f494fc2febf25511ed",
+						"0x0000000000000000000000007b62eb7fe80350dc7ec945c0b73242cb9877fb1b",
+						"0x0000000000000000000000004bda106325c335df99eab7fe363cac8a0ba2a24d",
+					},
+					Data: "0x000000000000000000000000000000000000000000000000400000000000000000000000000000000000000000000000000000000000000000000000000000400000000000000000000000000000000000000000000000000000000000000000",
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -113,6 +213,189 @@ func TestErc20_erc20GetTransfersFromLog(t *testing.T) {
 	}
 }
 
+func TestErc20_parseUint256Array(t *testing.T) {
+	word := func(v string) string {
+		return strings.Repeat("0", 64-len(v)) + v
+	}
+	tests := []struct {
+		name    string
+		data    string
+		offset  string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "array",
+			data:   word("20") + word("2") + word("1") + word("1f4"),
+			offset: "20",
+			want:   []string{"1", "500"},
+		},
+		{
+			name:   "empty array",
+			data:   word("20") + word("0"),
+			offset: "20",
+			want:   []string{},
+		},
+		{
+			name:    "offset not aligned",
+			data:    word("21") + word("0"),
+			offset:  "21",
+			wantErr: true,
+		},
+		{
+			name:    "offset after the data",
+			data:    word("40") + word("0"),
+			offset:  "40",
+			wantErr: true,
+		},
+		{
+			name:    "huge offset",
+			data:    word("4000000000000000") + word("0"),
+			offset:  "4000000000000000",
+			wantErr: true,
+		},
+		{
+			name:    "offset over int64",
+			data:    word("8000000000000000000000000000000000000000000000000000000000000000") + word("0"),
+			offset:  "8000000000000000000000000000000000000000000000000000000000000000",
+			wantErr: true,
+		},
+		{
+			name:    "length over the data",
+			data:    word("20") + word("2") + word("1"),
+			offset:  "20",
+			wantErr: true,
+		},
+		{
+			name:    "huge length",
+			data:    word("20") + word("4000000000000000") + word("1"),
+			offset:  "20",
+			wantErr: true,
+		},
+		{
+			name:    "length over int64",
+			data:    word("20") + word("ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff") + word("1"),
+			offset:  "20",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var offset big.Int
+			offset.SetString(tt.offset, 16)
+			got, err := parseUint256Array(tt.data, &offset)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseUint256Array() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			gs := make([]string, len(got))
+			for i := range got {
+				gs[i] = got[i].String()
+			}
+			if fmt.Sprint(gs) != fmt.Sprint(tt.want) {
+				t.Errorf("parseUint256Array() = %v, want %v", gs, tt.want)
+			}
+		})
+	}
+}
+
+func TestErc20_erc20GetApprovalsFromLog(t *testing.T) {
+	unlimited, _ := new(big.Int).SetString("ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", 16)
+	tests := []struct {
+		name    string
+		args    []*rpcLog
+		want    []bchain.TokenApproval
+		wantErr bool
+	}{
+		{
+			name: "ERC20 approvals",
+			args: []*rpcLog{
+				{ // Transfer
+					Address: "0x0d0f936ee4c93e25944694d6c121de94d9760f11",
+					Topics: []string{
+						"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+						"0x000000000000000000000000a3950b823cb063dd9afc0d27f35008b805b3ed53",
+						"0x0000000000000000000000004bda106325c335df99eab7fe363cac8a0ba2a24d",
+					},
+					Data: "0x0000000000000000000000000000000000000000000000006a8313d60b1f606b",
+				},
+				{ // Approval
+					Address: "0x0d0f936ee4c93e25944694d6c121de94d9760f11",
+					Topics: []string{
+						"0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925",
+						"0x000000000000000000000000a3950b823cb063dd9afc0d27f35008b805b3ed53",
+						"0x0000000000000000000000004bda106325c335df99eab7fe363cac8a0ba2a24d",
+					},
+					Data: "0x0000000000000000000000000000000000000000000000000000000000000123",
+				},
+				{ // unlimited Approval
+					Address: "0xc778417e063141139fce010982780140aa0cd5ab",
+					Topics: []string{
+						"0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925",
+						"0x0000000000000000000000004bda106325c335df99eab7fe363cac8a0ba2a24d",
+						"0x000000000000000000000000a3950b823cb063dd9afc0d27f35008b805b3ed53",
+					},
+					Data: "0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+				},
+				{ // ERC721 Approval of a token id
+					Address: "0x5c2b9b5b5b53ff3d9c7a1d0a1d4a5b0e20e0b3c1",
+					Topics: []string{
+						"0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925",
+						"0x0000000000000000000000004bda106325c335df99eab7fe363cac8a0ba2a24d",
+						"0x000000000000000000000000a3950b823cb063dd9afc0d27f35008b805b3ed53",
+						"0x0000000000000000000000000000000000000000000000000000000000000001",
+					},
+					Data: "0x",
+				},
+			},
+			want: []bchain.TokenApproval{
+				{
+					Contract: "0x0d0f936ee4c93e25944694d6c121de94d9760f11",
+					Owner:    "0xa3950b823cb063dd9afc0d27f35008b805b3ed53",
+					Spender:  "0x4bda106325c335df99eab7fe363cac8a0ba2a24d",
+					Value:    *big.NewInt(0x123),
+				},
+				{
+					Contract: "0xc778417e063141139fce010982780140aa0cd5ab",
+					Owner:    "0x4bda106325c335df99eab7fe363cac8a0ba2a24d",
+					Spender:  "0xa3950b823cb063dd9afc0d27f35008b805b3ed53",
+					Value:    *unlimited,
+				},
+			},
+		},
+		{
+			name: "invalid value",
+			args: []*rpcLog{
+				{
+					Address: "0x0d0f936ee4c93e25944694d6c121de94d9760f11",
+					Topics: []string{
+						"0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925",
+						"0x000000000000000000000000a3950b823cb063dd9afc0d27f35008b805b3ed53",
+						"0x0000000000000000000000004bda106325c335df99eab7fe363cac8a0ba2a24d",
+					},
+					Data: "0x00000000000000000000000000000000000000000000000000000000000001z3",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := erc20GetApprovalsFromLog(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("erc20GetApprovalsFromLog error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			// the addresses could have different case
+			if strings.ToLower(fmt.Sprint(got)) != strings.ToLower(fmt.Sprint(tt.want)) {
+				t.Errorf("erc20GetApprovalsFromLog = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestErc20_parseErc20StringProperty(t *testing.T) {
 	tests := []struct {
 		name string
@@ -4,10 +4,15 @@ import (
 	"encoding/hex"
 	"math/big"
 	"strconv"
+	"strings"
 
+	ethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/golang/protobuf/proto"
 	"github.com/juju/errors"
+	"github.com/martinboehm/btcutil/base58"
+	"github.com/martinboehm/btcutil/hdkeychain"
 	"github.com/trezor/blockbook/bchain"
 	"golang.org/x/crypto/sha3"
 )
@@ -18,41 +23,63 @@ const EthereumTypeAddressDescriptorLen = 20
 // EtherAmountDecimalPoint defines number of decimal points in Ether amounts
 const EtherAmountDecimalPoint = 18
 
+// eip1559TxType is the type of the transactions with dynamic fee
+const eip1559TxType = 2
+
+// EthereumSlip44 is the BIP-44 coin type of Ethereum, used if the coin type is not configured
+const EthereumSlip44 = 60
+
 // EthereumParser handle
 type EthereumParser struct {
 	*bchain.BaseParser
+	// Slip44 is the BIP-44 coin type shown in the derivation path of xpubs
+	Slip44 uint32
 }
 
 // NewEthereumParser returns new EthereumParser instance
 func NewEthereumParser(b int) *EthereumParser {
-	return &EthereumParser{&bchain.BaseParser{
-		BlockAddressesToKeep: b,
-		AmountDecimalPoint:   EtherAmountDecimalPoint,
-	}}
+	return &EthereumParser{
+		BaseParser: &bchain.BaseParser{
+			BlockAddressesToKeep: b,
+			AmountDecimalPoint:   EtherAmountDecimalPoint,
+		},
+		Slip44: EthereumSlip44,
+	}
 }
 
 type rpcHeader struct {
-	Hash       string `json:"hash"`
-	ParentHash string `json:"parentHash"`
-	Difficulty string `json:"difficulty"`
-	Number     string `json:"number"`
-	Time       string `json:"timestamp"`
-	Size       string `json:"size"`
-	Nonce      string `json:"nonce"`
+	Hash          string `json:"hash"`
+	ParentHash    string `json:"parentHash"`
+	Difficulty    string `json:"difficulty"`
+	Number        string `json:"number"`
+	Time          string `json:"timestamp"`
+	Size          string `json:"size"`
+	Nonce         string `json:"nonce"`
+	BaseFeePerGas string `json:"baseFeePerGas,omitempty"`
+}
+
+// AccessListItem is an address and storage keys accessed by EIP-2930 or EIP-1559 transaction
+type AccessListItem struct {
+	Address     string   `json:"address"`
+	StorageKeys []string `json:"storageKeys"`
 }
 
 type rpcTransaction struct {
-	AccountNonce     string `json:"nonce"`
-	GasPrice         string `json:"gasPrice"`
-	GasLimit         string `json:"gas"`
-	To               string `json:"to"` // nil means contract creation
-	Value            string `json:"value"`
-	Payload          string `json:"input"`
-	Hash             string `json:"hash"`
-	BlockNumber      string `json:"blockNumber"`
-	BlockHash        string `json:"blockHash,omitempty"`
-	From             string `json:"from"`
-	TransactionIndex string `json:"transactionIndex"`
+	AccountNonce         string           `json:"nonce"`
+	GasPrice             string           `json:"gasPrice"`
+	GasLimit             string           `json:"gas"`
+	To                   string           `json:"to"` // nil means contract creation
+	Value                string           `json:"value"`
+	Payload              string           `json:"input"`
+	Hash                 string           `json:"hash"`
+	BlockNumber          string           `json:"blockNumber"`
+	BlockHash            string           `json:"blockHash,omitempty"`
+	From                 string           `json:"from"`
+	TransactionIndex     string           `json:"transactionIndex"`
+	Type                 string           `json:"type,omitempty"`
+	MaxFeePerGas         string           `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas string           `json:"maxPriorityFeePerGas,omitempty"`
+	AccessList           []AccessListItem `json:"accessList,omitempty"`
 	// Signature values - ignored
 	// V string `json:"v"`
 	// R string `json:"r"`
@@ -71,14 +98,39 @@ type rpcLogWithTxHash struct {
 }
 
 type rpcReceipt struct {
-	GasUsed string    `json:"gasUsed"`
-	Status  string    `json:"status"`
-	Logs    []*rpcLog `json:"logs"`
+	GasUsed           string    `json:"gasUsed"`
+	Status            string    `json:"status"`
+	Logs              []*rpcLog `json:"logs"`
+	EffectiveGasPrice string    `json:"effectiveGasPrice,omitempty"`
+	ContractAddress   string    `json:"contractAddress,omitempty"`
 }
 
 type completeTransaction struct {
-	Tx      *rpcTransaction `json:"tx"`
-	Receipt *rpcReceipt     `json:"receipt,omitempty"`
+	Tx                *rpcTransaction                   `json:"tx"`
+	Receipt           *rpcReceipt                       `json:"receipt,omitempty"`
+	InternalTransfers []bchain.EthereumInternalTransfer `json:"internalTransfers,omitempty"`
+	// BaseFeePerGas is the base fee of the block containing the transaction
+	BaseFeePerGas string `json:"baseFeePerGas,omitempty"`
+}
+
+// rpcCallTrace is the result of the callTracer of debug_traceBlockByHash, debug_traceTransaction and debug_traceCall
+// the fields GasUsed, Output, RevertReason and Logs are used by the simulation of transactions,
+// the logs are returned only if the tracer is configured withLog
+type rpcCallTrace struct {
+	Type         string         `json:"type"`
+	From         string         `json:"from"`
+	To           string         `json:"to"`
+	Value        string         `json:"value"`
+	GasUsed      string         `json:"gasUsed,omitempty"`
+	Output       string         `json:"output,omitempty"`
+	Error        string         `json:"error"`
+	RevertReason string         `json:"revertReason,omitempty"`
+	Logs         []*rpcLog      `json:"logs,omitempty"`
+	Calls        []rpcCallTrace `json:"calls"`
+}
+
+type rpcTraceResult struct {
+	Result rpcCallTrace `json:"result"`
 }
 
 type rpcBlockTransactions struct {
@@ -96,7 +148,145 @@ func ethNumber(n string) (int64, error) {
 	return 0, errors.Errorf("Not a number: '%v'", n)
 }
 
-func (p *EthereumParser) ethTxToTx(tx *rpcTransaction, receipt *rpcReceipt, blocktime int64, confirmations uint32, fixEIP55 bool) (*bchain.Tx, error) {
+// getInternalTransfersFromTrace returns the transfers of value done by the calls nested in the call trace of a transaction
+// the top level call is the transaction itself and it is not returned, the calls which failed are skipped with all their subcalls
+// if the transaction itself failed, all its transfers were reverted and nothing is returned
+func getInternalTransfersFromTrace(trace *rpcCallTrace) ([]bchain.EthereumInternalTransfer, error) {
+	if trace.Error != "" {
+		return nil, nil
+	}
+	var r []bchain.EthereumInternalTransfer
+	var process func(calls []rpcCallTrace) error
+	process = func(calls []rpcCallTrace) error {
+		for i := range calls {
+			c := &calls[i]
+			if c.Error != "" {
+				continue
+			}
+			var t bchain.EthereumInternalTransfer
+			if c.Value != "" {
+				v, err := hexutil.DecodeBig(c.Value)
+				if err != nil {
+					return errors.Annotatef(err, "Value %v", c.Value)
+				}
+				t.Value = *v
+			}
+			switch c.Type {
+			case "CREATE", "CREATE2":
+				t.Type = bchain.CREATE
+			case "SELFDESTRUCT":
+				t.Type = bchain.SELFDESTRUCT
+			case "CALL":
+				t.Type = bchain.CALL
+			default:
+				// DELEGATECALL, STATICCALL and CALLCODE do not move value to other address
+				t.Value.SetInt64(0)
+			}
+			// calls without value are not interesting, except creation of contracts
+			if t.Value.Sign() > 0 || t.Type == bchain.CREATE {
+				t.From = EIP55AddressFromAddress(c.From)
+				t.To = EIP55AddressFromAddress(c.To)
+				r = append(r, t)
+			}
+			if err := process(c.Calls); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := process(trace.Calls); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+const errorStringSelector = "0x08c379a0"
+const panicSelector = "0x4e487b71"
+
+// RevertReasonFromOutput decodes the reason of a reverted call from its output encoded as Error(string) or Panic(uint256)
+func RevertReasonFromOutput(output string) string {
+	if !has0xPrefix(output) {
+		output = "0x" + output
+	}
+	switch {
+	case len(output) > len(errorStringSelector) && strings.HasPrefix(output, errorStringSelector):
+		return parseErc20StringProperty(nil, output[len(errorStringSelector):])
+	case len(output) == len(panicSelector)+64 && strings.HasPrefix(output, panicSelector):
+		if code, ok := new(big.Int).SetString(output[len(panicSelector):], 16); ok {
+			return "panic: 0x" + code.Text(16)
+		}
+	}
+	return ""
+}
+
+// getSimulationFromTrace returns the effect of the transaction from the trace of its execution by debug_traceCall with the callTracer withLog
+// the transfers of value and the token transfers are returned only if the execution succeeded,
+// the logs and the calls of the reverted internal calls are skipped
+func getSimulationFromTrace(trace *rpcCallTrace) (*bchain.TransactionSimulation, error) {
+	r := &bchain.TransactionSimulation{Traced: true}
+	if trace.GasUsed != "" {
+		gas, err := hexutil.DecodeUint64(trace.GasUsed)
+		if err != nil {
+			return nil, errors.Annotatef(err, "GasUsed %v", trace.GasUsed)
+		}
+		r.GasUsed = gas
+	}
+	if trace.Error != "" {
+		r.RevertReason = trace.RevertReason
+		if r.RevertReason == "" {
+			r.RevertReason = RevertReasonFromOutput(trace.Output)
+		}
+		if r.RevertReason == "" {
+			r.RevertReason = trace.Error
+		}
+		return r, nil
+	}
+	r.Success = true
+	if trace.Value != "" {
+		v, err := hexutil.DecodeBig(trace.Value)
+		if err != nil {
+			return nil, errors.Annotatef(err, "Value %v", trace.Value)
+		}
+		if v.Sign() > 0 {
+			t := bchain.EthereumInternalTransfer{
+				Type:  bchain.CALL,
+				From:  EIP55AddressFromAddress(trace.From),
+				To:    EIP55AddressFromAddress(trace.To),
+				Value: *v,
+			}
+			if trace.Type == "CREATE" || trace.Type == "CREATE2" {
+				t.Type = bchain.CREATE
+			}
+			r.ValueTransfers = append(r.ValueTransfers, t)
+		}
+	}
+	internal, err := getInternalTransfersFromTrace(trace)
+	if err != nil {
+		return nil, err
+	}
+	for i := range internal {
+		if internal[i].Value.Sign() > 0 {
+			r.ValueTransfers = append(r.ValueTransfers, internal[i])
+		}
+	}
+	var logs []*rpcLog
+	var collect func(c *rpcCallTrace)
+	collect = func(c *rpcCallTrace) {
+		logs = append(logs, c.Logs...)
+		for i := range c.Calls {
+			if c.Calls[i].Error == "" {
+				collect(&c.Calls[i])
+			}
+		}
+	}
+	collect(trace)
+	if r.TokenTransfers, err = erc20GetTransfersFromLog(logs); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (p *EthereumParser) ethTxToTx(tx *rpcTransaction, receipt *rpcReceipt, internalTransfers []bchain.EthereumInternalTransfer, baseFeePerGas string, blocktime int64, confirmations uint32, fixEIP55 bool) (*bchain.Tx, error) {
 	txid := tx.Hash
 	var (
 		fa, ta []string
@@ -114,6 +304,11 @@ func (p *EthereumParser) ethTxToTx(tx *rpcTransaction, receipt *rpcReceipt, bloc
 		}
 		ta = []string{tx.To}
 	}
+	if fixEIP55 {
+		for i := range tx.AccessList {
+			tx.AccessList[i].Address = EIP55AddressFromAddress(tx.AccessList[i].Address)
+		}
+	}
 	if fixEIP55 && receipt != nil && receipt.Logs != nil {
 		for _, l := range receipt.Logs {
 			if len(l.Address) > 2 {
@@ -122,8 +317,10 @@ func (p *EthereumParser) ethTxToTx(tx *rpcTransaction, receipt *rpcReceipt, bloc
 		}
 	}
 	ct := completeTransaction{
-		Tx:      tx,
-		Receipt: receipt,
+		Tx:                tx,
+		Receipt:           receipt,
+		InternalTransfers: internalTransfers,
+		BaseFeePerGas:     baseFeePerGas,
 	}
 	vs, err := hexutil.DecodeBig(tx.Value)
 	if err != nil {
@@ -306,6 +503,44 @@ func (p *EthereumParser) PackTx(tx *bchain.Tx, height uint32, blockTime int64) (
 	if pt.Tx.Value, err = hexDecodeBig(r.Tx.Value); err != nil {
 		return nil, errors.Annotatef(err, "Value %v", r.Tx.Value)
 	}
+	// the fields of typed transactions are not present in legacy transactions
+	if r.Tx.Type != "" {
+		if n, err = hexutil.DecodeUint64(r.Tx.Type); err != nil {
+			return nil, errors.Annotatef(err, "Type %v", r.Tx.Type)
+		}
+		pt.Tx.Type = uint32(n)
+	}
+	if r.Tx.MaxFeePerGas != "" {
+		if pt.Tx.MaxFeePerGas, err = hexDecodeBig(r.Tx.MaxFeePerGas); err != nil {
+			return nil, errors.Annotatef(err, "MaxFeePerGas %v", r.Tx.MaxFeePerGas)
+		}
+	}
+	if r.Tx.MaxPriorityFeePerGas != "" {
+		if pt.Tx.MaxPriorityFeePerGas, err = hexDecodeBig(r.Tx.MaxPriorityFeePerGas); err != nil {
+			return nil, errors.Annotatef(err, "MaxPriorityFeePerGas %v", r.Tx.MaxPriorityFeePerGas)
+		}
+	}
+	if len(r.Tx.AccessList) > 0 {
+		pt.Tx.AccessList = make([]*ProtoCompleteTransaction_TxType_AccessListType, len(r.Tx.AccessList))
+		for i, a := range r.Tx.AccessList {
+			pa := &ProtoCompleteTransaction_TxType_AccessListType{}
+			if pa.Address, err = hexDecode(a.Address); err != nil {
+				return nil, errors.Annotatef(err, "AccessList Address %v", a.Address)
+			}
+			pa.StorageKeys = make([][]byte, len(a.StorageKeys))
+			for j, k := range a.StorageKeys {
+				if pa.StorageKeys[j], err = hexutil.Decode(k); err != nil {
+					return nil, errors.Annotatef(err, "AccessList StorageKey %v", k)
+				}
+			}
+			pt.Tx.AccessList[i] = pa
+		}
+	}
+	if r.BaseFeePerGas != "" {
+		if pt.BaseFeePerGas, err = hexDecodeBig(r.BaseFeePerGas); err != nil {
+			return nil, errors.Annotatef(err, "BaseFeePerGas %v", r.BaseFeePerGas)
+		}
+	}
 	if r.Receipt != nil {
 		pt.Receipt = &ProtoCompleteTransaction_ReceiptType{}
 		if pt.Receipt.GasUsed, err = hexDecodeBig(r.Receipt.GasUsed); err != nil {
@@ -320,6 +555,11 @@ func (p *EthereumParser) PackTx(tx *bchain.Tx, height uint32, blockTime int64) (
 			// there is a potential for conflict with value 0x55 but this is not used by any chain at this moment
 			pt.Receipt.Status = []byte{'U'}
 		}
+		if r.Receipt.EffectiveGasPrice != "" {
+			if pt.Receipt.EffectiveGasPrice, err = hexDecodeBig(r.Receipt.EffectiveGasPrice); err != nil {
+				return nil, errors.Annotatef(err, "EffectiveGasPrice %v", r.Receipt.EffectiveGasPrice)
+			}
+		}
 		ptLogs := make([]*ProtoCompleteTransaction_ReceiptType_LogType, len(r.Receipt.Logs))
 		for i, l := range r.Receipt.Logs {
 			a, err := hexutil.Decode(l.Address)
@@ -346,6 +586,23 @@ func (p *EthereumParser) PackTx(tx *bchain.Tx, height uint32, blockTime int64) (
 		}
 		pt.Receipt.Log = ptLogs
 	}
+	if len(r.InternalTransfers) > 0 {
+		pt.InternalTransfers = make([]*ProtoCompleteTransaction_InternalTransferType, len(r.InternalTransfers))
+		for i := range r.InternalTransfers {
+			t := &r.InternalTransfers[i]
+			pi := &ProtoCompleteTransaction_InternalTransferType{
+				Type:  int32(t.Type),
+				Value: t.Value.Bytes(),
+			}
+			if pi.From, err = hexDecode(t.From); err != nil {
+				return nil, errors.Annotatef(err, "InternalTransfer From %v", t.From)
+			}
+			if pi.To, err = hexDecode(t.To); err != nil {
+				return nil, errors.Annotatef(err, "InternalTransfer To %v", t.To)
+			}
+			pt.InternalTransfers[i] = pi
+		}
+	}
 	return proto.Marshal(pt)
 }
 
@@ -371,6 +628,33 @@ func (p *EthereumParser) UnpackTx(buf []byte) (*bchain.Tx, uint32, error) {
 		TransactionIndex: hexutil.EncodeUint64(uint64(pt.Tx.TransactionIndex)),
 		Value:            hexEncodeBig(pt.Tx.Value),
 	}
+	// transactions packed before the support of typed transactions do not contain these fields
+	if pt.Tx.Type != 0 {
+		rt.Type = hexutil.EncodeUint64(uint64(pt.Tx.Type))
+	}
+	if len(pt.Tx.MaxFeePerGas) > 0 || pt.Tx.Type == eip1559TxType {
+		rt.MaxFeePerGas = hexEncodeBig(pt.Tx.MaxFeePerGas)
+	}
+	if len(pt.Tx.MaxPriorityFeePerGas) > 0 || pt.Tx.Type == eip1559TxType {
+		rt.MaxPriorityFeePerGas = hexEncodeBig(pt.Tx.MaxPriorityFeePerGas)
+	}
+	if len(pt.Tx.AccessList) > 0 {
+		rt.AccessList = make([]AccessListItem, len(pt.Tx.AccessList))
+		for i, a := range pt.Tx.AccessList {
+			keys := make([]string, len(a.StorageKeys))
+			for j, k := range a.StorageKeys {
+				keys[j] = hexutil.Encode(k)
+			}
+			rt.AccessList[i] = AccessListItem{
+				Address:     EIP55Address(a.Address),
+				StorageKeys: keys,
+			}
+		}
+	}
+	var baseFeePerGas string
+	if len(pt.BaseFeePerGas) > 0 {
+		baseFeePerGas = hexEncodeBig(pt.BaseFeePerGas)
+	}
 	var rr *rpcReceipt
 	if pt.Receipt != nil {
 		logs := make([]*rpcLog, len(pt.Receipt.Log))
@@ -395,8 +679,21 @@ func (p *EthereumParser) UnpackTx(buf []byte) (*bchain.Tx, uint32, error) {
 			Status:  status,
 			Logs:    logs,
 		}
+		if len(pt.Receipt.EffectiveGasPrice) > 0 {
+			rr.EffectiveGasPrice = hexEncodeBig(pt.Receipt.EffectiveGasPrice)
+		}
 	}
-	tx, err := p.ethTxToTx(&rt, rr, int64(pt.BlockTime), 0, false)
+	var it []bchain.EthereumInternalTransfer
+	if len(pt.InternalTransfers) > 0 {
+		it = make([]bchain.EthereumInternalTransfer, len(pt.InternalTransfers))
+		for i, t := range pt.InternalTransfers {
+			it[i].Type = bchain.EthereumInternalTransactionType(t.Type)
+			it[i].From = EIP55Address(t.From)
+			it[i].To = EIP55Address(t.To)
+			it[i].Value.SetBytes(t.Value)
+		}
+	}
+	tx, err := p.ethTxToTx(&rt, rr, it, baseFeePerGas, int64(pt.BlockTime), 0, false)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -439,6 +736,104 @@ func (p *EthereumParser) GetChainType() bchain.ChainType {
 	return bchain.ChainEthereumType
 }
 
+// parseXpub parses the extended public key, extended private keys are not accepted
+func parseXpub(xpub string) (*hdkeychain.ExtendedKey, error) {
+	extKey, err := hdkeychain.NewKeyFromString(xpub, base58.Sha256D)
+	if err != nil {
+		return nil, err
+	}
+	if extKey.IsPrivate() {
+		return nil, errors.New("Extended private key is not accepted")
+	}
+	return extKey, nil
+}
+
+// XpubDeriveAddresses derives from the account xpub the Ethereum addresses (the last 20 bytes of the keccak256 hash
+// of the uncompressed public key) of the BIP-44 path <account path>/change/index for the listed indexes
+func XpubDeriveAddresses(xpub string, change uint32, indexes []uint32) ([][]byte, error) {
+	extKey, err := parseXpub(xpub)
+	if err != nil {
+		return nil, err
+	}
+	changeExtKey, err := extKey.Child(change)
+	if err != nil {
+		return nil, err
+	}
+	r := make([][]byte, len(indexes))
+	for i, index := range indexes {
+		indexExtKey, err := changeExtKey.Child(index)
+		if err != nil {
+			return nil, err
+		}
+		pubKey, err := indexExtKey.ECPubKey()
+		if err != nil {
+			return nil, err
+		}
+		sha := sha3.NewLegacyKeccak256()
+		sha.Write(pubKey.SerializeUncompressed()[1:])
+		r[i] = sha.Sum(nil)[12:]
+	}
+	return r, nil
+}
+
+// XpubDerivationBasePath returns the BIP-44 base path of the account xpub for given coin type
+func XpubDerivationBasePath(xpub string, slip44 uint32) (string, error) {
+	extKey, err := parseXpub(xpub)
+	if err != nil {
+		return "", err
+	}
+	var c string
+	cn := extKey.ChildNum()
+	if cn >= 0x80000000 {
+		cn -= 0x80000000
+		c = "'"
+	}
+	c = strconv.Itoa(int(cn)) + c
+	if extKey.Depth() != 3 {
+		return "unknown/" + c, nil
+	}
+	return "m/44'/" + strconv.Itoa(int(slip44)) + "'/" + c, nil
+}
+
+// XpubIndexes returns the indexes in the range fromIndex to toIndex (excluded)
+func XpubIndexes(fromIndex uint32, toIndex uint32) ([]uint32, error) {
+	if toIndex <= fromIndex {
+		return nil, errors.New("toIndex<=fromIndex")
+	}
+	indexes := make([]uint32, toIndex-fromIndex)
+	for i := range indexes {
+		indexes[i] = fromIndex + uint32(i)
+	}
+	return indexes, nil
+}
+
+// DeriveAddressDescriptors derives address descriptors from given xpub for listed indexes
+func (p *EthereumParser) DeriveAddressDescriptors(xpub string, change uint32, indexes []uint32) ([]bchain.AddressDescriptor, error) {
+	addresses, err := XpubDeriveAddresses(xpub, change, indexes)
+	if err != nil {
+		return nil, err
+	}
+	ad := make([]bchain.AddressDescriptor, len(addresses))
+	for i := range addresses {
+		ad[i] = addresses[i]
+	}
+	return ad, nil
+}
+
+// DeriveAddressDescriptorsFromTo derives address descriptors from given xpub for addresses in index range
+func (p *EthereumParser) DeriveAddressDescriptorsFromTo(xpub string, change uint32, fromIndex uint32, toIndex uint32) ([]bchain.AddressDescriptor, error) {
+	indexes, err := XpubIndexes(fromIndex, toIndex)
+	if err != nil {
+		return nil, err
+	}
+	return p.DeriveAddressDescriptors(xpub, change, indexes)
+}
+
+// DerivationBasePath returns base path of xpub
+func (p *EthereumParser) DerivationBasePath(xpub string) (string, error) {
+	return XpubDerivationBasePath(xpub, p.Slip44)
+}
+
 // GetHeightFromTx returns ethereum specific data from bchain.Tx
 func GetHeightFromTx(tx *bchain.Tx) (uint32, error) {
 	var bn string
@@ -472,6 +867,75 @@ func (p *EthereumParser) EthereumTypeGetErc20FromTx(tx *bchain.Tx) ([]bchain.Erc
 	return r, nil
 }
 
+// EthereumTypeGetTokenApprovalsFromTx returns the allowances set by the ERC20 Approval events of the transaction
+func (p *EthereumParser) EthereumTypeGetTokenApprovalsFromTx(tx *bchain.Tx) ([]bchain.TokenApproval, error) {
+	csd, ok := tx.CoinSpecificData.(completeTransaction)
+	if !ok || csd.Receipt == nil {
+		return nil, nil
+	}
+	return erc20GetApprovalsFromLog(csd.Receipt.Logs)
+}
+
+// EthereumTypeGetNonceFromTx returns the nonce of the sender of the transaction
+func (p *EthereumParser) EthereumTypeGetNonceFromTx(tx *bchain.Tx) (uint64, error) {
+	csd, ok := tx.CoinSpecificData.(completeTransaction)
+	if !ok || csd.Tx == nil {
+		return 0, errors.New("Missing CoinSpecificData")
+	}
+	nonce, err := hexutil.DecodeUint64(csd.Tx.AccountNonce)
+	if err != nil {
+		return 0, errors.Annotatef(err, "Nonce %v", csd.Tx.AccountNonce)
+	}
+	return nonce, nil
+}
+
+// EthereumTypeGetInternalTransfersFromTx returns the transfers of value done by the contract calls inside of the transaction
+// the transfers are available only if the processing of internal transactions is enabled in the configuration
+func (p *EthereumParser) EthereumTypeGetInternalTransfersFromTx(tx *bchain.Tx) ([]bchain.EthereumInternalTransfer, error) {
+	csd, ok := tx.CoinSpecificData.(completeTransaction)
+	if !ok {
+		return nil, nil
+	}
+	return csd.InternalTransfers, nil
+}
+
+// EthereumTypeGetContractCreationsFromTx returns the contracts deployed by the transaction
+// the contract deployed by the transaction itself is returned first, followed by the contracts deployed by its internal calls,
+// which are available only if the processing of internal transactions is enabled in the configuration
+func (p *EthereumParser) EthereumTypeGetContractCreationsFromTx(tx *bchain.Tx) ([]bchain.ContractCreation, error) {
+	csd, ok := tx.CoinSpecificData.(completeTransaction)
+	if !ok || csd.Tx == nil {
+		return nil, nil
+	}
+	// failed transaction does not deploy any contract, the internal creations were reverted as well
+	if csd.Receipt != nil && csd.Receipt.Status == "0x0" {
+		return nil, nil
+	}
+	var r []bchain.ContractCreation
+	// transaction without to address deploys a contract
+	if len(csd.Tx.To) <= 2 && len(csd.Tx.From) > 2 {
+		var contract string
+		if csd.Receipt != nil && csd.Receipt.ContractAddress != "" {
+			contract = EIP55AddressFromAddress(csd.Receipt.ContractAddress)
+		} else {
+			// the receipts of the synchronized blocks contain only logs, compute the address from the sender and its nonce
+			nonce, err := hexutil.DecodeUint64(csd.Tx.AccountNonce)
+			if err != nil {
+				return nil, errors.Annotatef(err, "Nonce %v", csd.Tx.AccountNonce)
+			}
+			contract = crypto.CreateAddress(ethcommon.HexToAddress(csd.Tx.From), nonce).Hex()
+		}
+		r = append(r, bchain.ContractCreation{Contract: contract, Creator: EIP55AddressFromAddress(csd.Tx.From)})
+	}
+	for i := range csd.InternalTransfers {
+		t := &csd.InternalTransfers[i]
+		if t.Type == bchain.CREATE && len(t.To) > 2 {
+			r = append(r, bchain.ContractCreation{Contract: t.To, Creator: t.From})
+		}
+	}
+	return r, nil
+}
+
 // TxStatus is status of transaction
 type TxStatus int
 
@@ -485,12 +949,19 @@ const (
 
 // EthereumTxData contains ethereum specific transaction data
 type EthereumTxData struct {
-	Status   TxStatus `json:"status"` // 1 OK, 0 Fail, -1 pending, -2 unknown
-	Nonce    uint64   `json:"nonce"`
-	GasLimit *big.Int `json:"gaslimit"`
-	GasUsed  *big.Int `json:"gasused"`
-	GasPrice *big.Int `json:"gasprice"`
-	Data     string   `json:"data"`
+	Status               TxStatus         `json:"status"` // 1 OK, 0 Fail, -1 pending, -2 unknown
+	Nonce                uint64           `json:"nonce"`
+	GasLimit             *big.Int         `json:"gaslimit"`
+	GasUsed              *big.Int         `json:"gasused"`
+	GasPrice             *big.Int         `json:"gasprice"`
+	Data                 string           `json:"data"`
+	Type                 int              `json:"type"`
+	MaxFeePerGas         *big.Int         `json:"maxfeepergas,omitempty"`
+	MaxPriorityFeePerGas *big.Int         `json:"maxpriorityfeepergas,omitempty"`
+	AccessList           []AccessListItem `json:"accesslist,omitempty"`
+	BaseFeePerGas        *big.Int         `json:"basefeepergas,omitempty"`
+	// EffectiveGasPrice is the price per gas paid by a mined transaction
+	EffectiveGasPrice *big.Int `json:"effectivegasprice,omitempty"`
 }
 
 // GetEthereumTxData returns EthereumTxData from bchain.Tx
@@ -508,7 +979,14 @@ func GetEthereumTxDataFromSpecificData(coinSpecificData interface{}) *EthereumTx
 			etd.GasLimit, _ = hexutil.DecodeBig(csd.Tx.GasLimit)
 			etd.GasPrice, _ = hexutil.DecodeBig(csd.Tx.GasPrice)
 			etd.Data = csd.Tx.Payload
+			if t, err := hexutil.DecodeUint64(csd.Tx.Type); err == nil {
+				etd.Type = int(t)
+			}
+			etd.MaxFeePerGas, _ = hexutil.DecodeBig(csd.Tx.MaxFeePerGas)
+			etd.MaxPriorityFeePerGas, _ = hexutil.DecodeBig(csd.Tx.MaxPriorityFeePerGas)
+			etd.AccessList = csd.Tx.AccessList
 		}
+		etd.BaseFeePerGas, _ = hexutil.DecodeBig(csd.BaseFeePerGas)
 		if csd.Receipt != nil {
 			switch csd.Receipt.Status {
 			case "0x1":
@@ -519,7 +997,25 @@ func GetEthereumTxDataFromSpecificData(coinSpecificData interface{}) *EthereumTx
 				etd.Status = TxStatusFailure
 			}
 			etd.GasUsed, _ = hexutil.DecodeBig(csd.Receipt.GasUsed)
+			etd.EffectiveGasPrice = getEffectiveGasPrice(&etd, csd.Receipt)
 		}
 	}
 	return &etd
 }
+
+// getEffectiveGasPrice returns the price per gas paid by a mined transaction
+// older backends do not return effectiveGasPrice in the receipt, it is computed from the fee caps and the base fee of the block
+// for legacy transactions and for mined transactions returned by the backend, gasPrice is the effective price
+func getEffectiveGasPrice(etd *EthereumTxData, receipt *rpcReceipt) *big.Int {
+	if p, err := hexutil.DecodeBig(receipt.EffectiveGasPrice); err == nil {
+		return p
+	}
+	if etd.MaxFeePerGas != nil && etd.MaxPriorityFeePerGas != nil && etd.BaseFeePerGas != nil {
+		p := new(big.Int).Add(etd.BaseFeePerGas, etd.MaxPriorityFeePerGas)
+		if p.Cmp(etd.MaxFeePerGas) > 0 {
+			p.Set(etd.MaxFeePerGas)
+		}
+		return p
+	}
+	return etd.GasPrice
+}
@@ -400,3 +400,691 @@ func TestEthereumParser_GetEthereumTxData(t *testing.T) {
 		})
 	}
 }
+
+func Test_getInternalTransfersFromTrace(t *testing.T) {
+	tests := []struct {
+		name    string
+		trace   rpcCallTrace
+		want    []bchain.EthereumInternalTransfer
+		wantErr bool
+	}{
+		{
+			name: "no calls",
+			trace: rpcCallTrace{
+				Type:  "CALL",
+				From:  "0x3e3a3d69dc66ba10737f531ed088954a9ec89d97",
+				To:    "0x555ee11fbddc0e49a9bab358a8941ad95ffdb48f",
+				Value: "0x1bc0159d530e6000",
+			},
+		},
+		{
+			name: "nested calls",
+			trace: rpcCallTrace{
+				Type:  "CALL",
+				From:  "0x3e3a3d69dc66ba10737f531ed088954a9ec89d97",
+				To:    "0x479cc461fecd078f766ecc58533d6f69580cf3ac",
+				Value: "0x0",
+				Calls: []rpcCallTrace{
+					{
+						Type:  "CALL",
+						From:  "0x479cc461fecd078f766ecc58533d6f69580cf3ac",
+						To:    "0x555ee11fbddc0e49a9bab358a8941ad95ffdb48f",
+						Value: "0x3e8",
+					},
+					{
+						Type:  "STATICCALL",
+						From:  "0x479cc461fecd078f766ecc58533d6f69580cf3ac",
+						To:    "0x4af4114f73d1c1c903ac9e0361b379d1291808a2",
+						Value: "0x3e8",
+					},
+					{
+						Type:  "DELEGATECALL",
+						From:  "0x479cc461fecd078f766ecc58533d6f69580cf3ac",
+						To:    "0x4af4114f73d1c1c903ac9e0361b379d1291808a2",
+						Calls: []rpcCallTrace{
+							{
+								Type:  "CALL",
+								From:  "0x479cc461fecd078f766ecc58533d6f69580cf3ac",
+								To:    "0x20cd153de35d469ba46127a0c8f18626b59a256a",
+								Value: "0x64",
+							},
+						},
+					},
+					{
+						Type:  "CALL",
+						From:  "0x479cc461fecd078f766ecc58533d6f69580cf3ac",
+						To:    "0x9f4981531fda132e83c44680787dfa7ee31e4f8d",
+						Value: "0x0",
+					},
+					{
+						Type:  "CREATE2",
+						From:  "0x479cc461fecd078f766ecc58533d6f69580cf3ac",
+						To:    "0x0d0f936ee4c93e25944694d6c121de94d9760f11",
+						Value: "0x0",
+						Calls: []rpcCallTrace{
+							{
+								Type:  "SELFDESTRUCT",
+								From:  "0x0d0f936ee4c93e25944694d6c121de94d9760f11",
+								To:    "0x7b62eb7fe80350dc7ec945c0b73242cb9877fb1b",
+								Value: "0x1",
+							},
+						},
+					},
+				},
+			},
+			want: []bchain.EthereumInternalTransfer{
+				{
+					Type:  bchain.CALL,
+					From:  "0x479CC461fEcd078F766eCc58533D6F69580CF3AC",
+					To:    "0x555Ee11FBDDc0E49A9bAB358A8941AD95fFDB48f",
+					Value: *big.NewInt(1000),
+				},
+				{
+					Type:  bchain.CALL,
+					From:  "0x479CC461fEcd078F766eCc58533D6F69580CF3AC",
+					To:    "0x20cD153de35D469BA46127A0C8F18626b59a256A",
+					Value: *big.NewInt(100),
+				},
+				{
+					Type:  bchain.CREATE,
+					From:  "0x479CC461fEcd078F766eCc58533D6F69580CF3AC",
+					To:    "0x0d0F936Ee4c93e25944694D6C121de94D9760F11",
+					Value: *big.NewInt(0),
+				},
+				{
+					Type:  bchain.SELFDESTRUCT,
+					From:  "0x0d0F936Ee4c93e25944694D6C121de94D9760F11",
+					To:    "0x7B62EB7fe80350DC7EC945C0B73242cb9877FB1b",
+					Value: *big.NewInt(1),
+				},
+			},
+		},
+		{
+			name: "failed call with subcalls",
+			trace: rpcCallTrace{
+				Type:  "CALL",
+				From:  "0x3e3a3d69dc66ba10737f531ed088954a9ec89d97",
+				To:    "0x479cc461fecd078f766ecc58533d6f69580cf3ac",
+				Value: "0x0",
+				Calls: []rpcCallTrace{
+					{
+						Type:  "CALL",
+						From:  "0x479cc461fecd078f766ecc58533d6f69580cf3ac",
+						To:    "0x555ee11fbddc0e49a9bab358a8941ad95ffdb48f",
+						Value: "0x3e8",
+						Error: "execution reverted",
+						Calls: []rpcCallTrace{
+							{
+								Type:  "CALL",
+								From:  "0x555ee11fbddc0e49a9bab358a8941ad95ffdb48f",
+								To:    "0x20cd153de35d469ba46127a0c8f18626b59a256a",
+								Value: "0x64",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "failed transaction",
+			trace: rpcCallTrace{
+				Type:  "CALL",
+				From:  "0x3e3a3d69dc66ba10737f531ed088954a9ec89d97",
+				To:    "0x479cc461fecd078f766ecc58533d6f69580cf3ac",
+				Value: "0x0",
+				Error: "execution reverted",
+				Calls: []rpcCallTrace{
+					{
+						Type:  "CALL",
+						From:  "0x479cc461fecd078f766ecc58533d6f69580cf3ac",
+						To:    "0x555ee11fbddc0e49a9bab358a8941ad95ffdb48f",
+						Value: "0x3e8",
+					},
+					{
+						Type:  "CREATE",
+						From:  "0x479cc461fecd078f766ecc58533d6f69580cf3ac",
+						To:    "0x0d0f936ee4c93e25944694d6c121de94d9760f11",
+						Value: "0x0",
+					},
+				},
+			},
+		},
+		{
+			name: "invalid value",
+			trace: rpcCallTrace{
+				Type: "CALL",
+				Calls: []rpcCallTrace{
+					{
+						Type:  "CALL",
+						From:  "0x479cc461fecd078f766ecc58533d6f69580cf3ac",
+						To:    "0x555ee11fbddc0e49a9bab358a8941ad95ffdb48f",
+						Value: "xyz",
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getInternalTransfersFromTrace(&tt.trace)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getInternalTransfersFromTrace() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			// DeepEqual has problems with zero big.Int values
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("getInternalTransfersFromTrace() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getSimulationFromTrace(t *testing.T) {
+	revertOutput := "0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000014" +
+		"496e73756666696369656e742062616c616e6365000000000000000000000000"
+	transferLog := func(contract string) *rpcLog {
+		return &rpcLog{
+			Address: contract,
+			Topics: []string{
+				"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+				"0x000000000000000000000000479cc461fecd078f766ecc58533d6f69580cf3ac",
+				"0x000000000000000000000000555ee11fbddc0e49a9bab358a8941ad95ffdb48f",
+			},
+			Data: "0x00000000000000000000000000000000000000000000000000000000000003e8",
+		}
+	}
+	tests := []struct {
+		name    string
+		trace   rpcCallTrace
+		want    bchain.TransactionSimulation
+		wantErr bool
+	}{
+		{
+			name: "success",
+			trace: rpcCallTrace{
+				Type:    "CALL",
+				From:    "0x3e3a3d69dc66ba10737f531ed088954a9ec89d97",
+				To:      "0x479cc461fecd078f766ecc58533d6f69580cf3ac",
+				Value:   "0x64",
+				GasUsed: "0xc350",
+				Calls: []rpcCallTrace{
+					{
+						Type:  "CALL",
+						From:  "0x479cc461fecd078f766ecc58533d6f69580cf3ac",
+						To:    "0x4af4114f73d1c1c903ac9e0361b379d1291808a2",
+						Value: "0x0",
+						Logs:  []*rpcLog{transferLog("0x4af4114f73d1c1c903ac9e0361b379d1291808a2")},
+					},
+					{
+						Type:  "CALL",
+						From:  "0x479cc461fecd078f766ecc58533d6f69580cf3ac",
+						To:    "0x20cd153de35d469ba46127a0c8f18626b59a256a",
+						Value: "0x3e8",
+					},
+					{
+						// the logs and value transfers of the reverted call are not applied
+						Type:  "CALL",
+						From:  "0x479cc461fecd078f766ecc58533d6f69580cf3ac",
+						To:    "0x0d0f936ee4c93e25944694d6c121de94d9760f11",
+						Value: "0x1",
+						Error: "execution reverted",
+						Logs:  []*rpcLog{transferLog("0x0d0f936ee4c93e25944694d6c121de94d9760f11")},
+					},
+				},
+			},
+			want: bchain.TransactionSimulation{
+				Success: true,
+				GasUsed: 50000,
+				ValueTransfers: []bchain.EthereumInternalTransfer{
+					{
+						Type:  bchain.CALL,
+						From:  "0x3E3a3D69dc66bA10737F531ed088954a9EC89d97",
+						To:    "0x479CC461fEcd078F766eCc58533D6F69580CF3AC",
+						Value: *big.NewInt(100),
+					},
+					{
+						Type:  bchain.CALL,
+						From:  "0x479CC461fEcd078F766eCc58533D6F69580CF3AC",
+						To:    "0x20cD153de35D469BA46127A0C8F18626b59a256A",
+						Value: *big.NewInt(1000),
+					},
+				},
+				TokenTransfers: []bchain.Erc20Transfer{
+					{
+						Type:     bchain.FungibleToken,
+						Contract: "0x4af4114F73d1c1C903aC9E0361b379D1291808A2",
+						From:     "0x479CC461fEcd078F766eCc58533D6F69580CF3AC",
+						To:       "0x555Ee11FBDDc0E49A9bAB358A8941AD95fFDB48f",
+						Tokens:   *big.NewInt(1000),
+					},
+				},
+				Traced: true,
+			},
+		},
+		{
+			name: "reverted with reason",
+			trace: rpcCallTrace{
+				Type:    "CALL",
+				From:    "0x3e3a3d69dc66ba10737f531ed088954a9ec89d97",
+				To:      "0x479cc461fecd078f766ecc58533d6f69580cf3ac",
+				Value:   "0x64",
+				GasUsed: "0x5208",
+				Output:  revertOutput,
+				Error:   "execution reverted",
+				Logs:    []*rpcLog{transferLog("0x4af4114f73d1c1c903ac9e0361b379d1291808a2")},
+			},
+			want: bchain.TransactionSimulation{
+				GasUsed:      21000,
+				RevertReason: "Insufficient balance",
+				Traced:       true,
+			},
+		},
+		{
+			name: "out of gas",
+			trace: rpcCallTrace{
+				Type:    "CALL",
+				GasUsed: "0x5208",
+				Error:   "out of gas",
+			},
+			want: bchain.TransactionSimulation{
+				GasUsed:      21000,
+				RevertReason: "out of gas",
+				Traced:       true,
+			},
+		},
+		{
+			name: "invalid gas",
+			trace: rpcCallTrace{
+				Type:    "CALL",
+				GasUsed: "xyz",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getSimulationFromTrace(&tt.trace)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getSimulationFromTrace() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			// DeepEqual has problems with zero big.Int values
+			if fmt.Sprintf("%+v", *got) != fmt.Sprintf("%+v", tt.want) {
+				t.Errorf("getSimulationFromTrace() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRevertReasonFromOutput(t *testing.T) {
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{"0x08c379a0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000067061757365640000000000000000000000000000000000000000000000000000", "paused"},
+		{"0x4e487b710000000000000000000000000000000000000000000000000000000000000011", "panic: 0x11"},
+		{"0x", ""},
+		{"0x12345678", ""},
+	}
+	for _, tt := range tests {
+		if got := RevertReasonFromOutput(tt.output); got != tt.want {
+			t.Errorf("RevertReasonFromOutput(%v) = %v, want %v", tt.output, got, tt.want)
+		}
+	}
+}
+
+func TestEthereumParser_PackUnpackTx_InternalTransfers(t *testing.T) {
+	internalTransfers := []bchain.EthereumInternalTransfer{
+		{
+			Type:  bchain.CALL,
+			From:  "0x479CC461fEcd078F766eCc58533D6F69580CF3AC",
+			To:    "0x555Ee11FBDDc0E49A9bAB358A8941AD95fFDB48f",
+			Value: *big.NewInt(1000),
+		},
+		{
+			Type:  bchain.CREATE,
+			From:  "0x479CC461fEcd078F766eCc58533D6F69580CF3AC",
+			To:    "0x0d0F936Ee4c93e25944694D6C121de94D9760F11",
+			Value: *big.NewInt(0),
+		},
+	}
+	tx := testTx2
+	csd := tx.CoinSpecificData.(completeTransaction)
+	csd.InternalTransfers = internalTransfers
+	tx.CoinSpecificData = csd
+	p := NewEthereumParser(1)
+	b, err := p.PackTx(&tx, 4321000, 1534858022)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, height, err := p.UnpackTx(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if height != 4321000 {
+		t.Errorf("EthereumParser.UnpackTx() height = %v, want %v", height, 4321000)
+	}
+	it, err := p.EthereumTypeGetInternalTransfersFromTx(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(it) != fmt.Sprint(internalTransfers) {
+		t.Errorf("EthereumParser.UnpackTx() internal transfers = %+v, want %+v", it, internalTransfers)
+	}
+}
+
+func TestEthereumParser_PackUnpackTx_EIP1559(t *testing.T) {
+	tx := bchain.Tx{
+		Blocktime: 1534858022,
+		Time:      1534858022,
+		Txid:      "0xcd647151552b5132b2aef7c9be00dc6f73afc5901dde157aab131335baaa853b",
+		Vin: []bchain.Vin{
+			{
+				Addresses: []string{"0x3E3a3D69dc66bA10737F531ed088954a9EC89d97"},
+			},
+		},
+		Vout: []bchain.Vout{
+			{
+				ValueSat: *big.NewInt(1999622000000000000),
+				ScriptPubKey: bchain.ScriptPubKey{
+					Addresses: []string{"0x555Ee11FBDDc0E49A9bAB358A8941AD95fFDB48f"},
+				},
+			},
+		},
+		CoinSpecificData: completeTransaction{
+			Tx: &rpcTransaction{
+				AccountNonce:         "0xb26c",
+				GasPrice:             "0x430e23400",
+				GasLimit:             "0x5208",
+				To:                   "0x555Ee11FBDDc0E49A9bAB358A8941AD95fFDB48f",
+				Value:                "0x1bc0159d530e6000",
+				Payload:              "0x",
+				Hash:                 "0xcd647151552b5132b2aef7c9be00dc6f73afc5901dde157aab131335baaa853b",
+				BlockNumber:          "0x41eee8",
+				From:                 "0x3E3a3D69dc66bA10737F531ed088954a9EC89d97",
+				TransactionIndex:     "0xa",
+				Type:                 "0x2",
+				MaxFeePerGas:         "0x77359400",
+				MaxPriorityFeePerGas: "0x0",
+				AccessList: []AccessListItem{
+					{
+						Address:     "0x4af4114F73d1c1C903aC9E0361b379D1291808A2",
+						StorageKeys: []string{"0x0000000000000000000000000000000000000000000000000000000000000001"},
+					},
+				},
+			},
+			Receipt: &rpcReceipt{
+				GasUsed:           "0x5208",
+				Status:            "0x1",
+				Logs:              []*rpcLog{},
+				EffectiveGasPrice: "0x430e23400",
+			},
+			BaseFeePerGas: "0x430e23400",
+		},
+	}
+	p := NewEthereumParser(1)
+	b, err := p.PackTx(&tx, 4321000, 1534858022)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _, err := p.UnpackTx(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gs := got.CoinSpecificData.(completeTransaction)
+	ws := tx.CoinSpecificData.(completeTransaction)
+	if !reflect.DeepEqual(gs.Tx, ws.Tx) {
+		t.Errorf("EthereumParser.UnpackTx() gs.Tx got = %+v, want %+v", gs.Tx, ws.Tx)
+	}
+	if !reflect.DeepEqual(gs.Receipt, ws.Receipt) {
+		t.Errorf("EthereumParser.UnpackTx() gs.Receipt got = %+v, want %+v", gs.Receipt, ws.Receipt)
+	}
+	if gs.BaseFeePerGas != ws.BaseFeePerGas {
+		t.Errorf("EthereumParser.UnpackTx() gs.BaseFeePerGas got = %v, want %v", gs.BaseFeePerGas, ws.BaseFeePerGas)
+	}
+}
+
+func TestGetEthereumTxData_EffectiveGasPrice(t *testing.T) {
+	tests := []struct {
+		name string
+		csd  completeTransaction
+		want *big.Int
+	}{
+		{
+			name: "legacy",
+			csd: completeTransaction{
+				Tx:      &rpcTransaction{GasPrice: "0x64"},
+				Receipt: &rpcReceipt{GasUsed: "0x5208", Status: "0x1"},
+			},
+			want: big.NewInt(100),
+		},
+		{
+			name: "effectiveGasPrice in receipt",
+			csd: completeTransaction{
+				Tx:            &rpcTransaction{GasPrice: "0x64", Type: "0x2", MaxFeePerGas: "0x64", MaxPriorityFeePerGas: "0xa"},
+				Receipt:       &rpcReceipt{GasUsed: "0x5208", Status: "0x1", EffectiveGasPrice: "0x32"},
+				BaseFeePerGas: "0x14",
+			},
+			want: big.NewInt(50),
+		},
+		{
+			name: "base fee plus priority fee",
+			csd: completeTransaction{
+				Tx:            &rpcTransaction{GasPrice: "0x64", Type: "0x2", MaxFeePerGas: "0x64", MaxPriorityFeePerGas: "0xa"},
+				Receipt:       &rpcReceipt{GasUsed: "0x5208", Status: "0x1"},
+				BaseFeePerGas: "0x14",
+			},
+			want: big.NewInt(30),
+		},
+		{
+			name: "capped by max fee",
+			csd: completeTransaction{
+				Tx:            &rpcTransaction{GasPrice: "0x64", Type: "0x2", MaxFeePerGas: "0x64", MaxPriorityFeePerGas: "0xa"},
+				Receipt:       &rpcReceipt{GasUsed: "0x5208", Status: "0x1"},
+				BaseFeePerGas: "0x5f",
+			},
+			want: big.NewInt(100),
+		},
+		{
+			name: "pending",
+			csd: completeTransaction{
+				Tx: &rpcTransaction{GasPrice: "0x64", Type: "0x2", MaxFeePerGas: "0x64", MaxPriorityFeePerGas: "0xa"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetEthereumTxDataFromSpecificData(tt.csd)
+			if !reflect.DeepEqual(got.EffectiveGasPrice, tt.want) {
+				t.Errorf("GetEthereumTxDataFromSpecificData() EffectiveGasPrice = %v, want %v", got.EffectiveGasPrice, tt.want)
+			}
+		})
+	}
+}
+
+func TestEthereumParser_EthereumTypeGetContractCreationsFromTx(t *testing.T) {
+	from := "0x6ac7ea33f8831ea9dcc53393aaa88b25a785dbf0"
+	creator := "0x6AC7EA33F8831EA9dcC53393aAA88B25A785DBF0"
+	tests := []struct {
+		name string
+		csd  completeTransaction
+		want []bchain.ContractCreation
+	}{
+		{
+			name: "address from receipt",
+			csd: completeTransaction{
+				Tx:      &rpcTransaction{AccountNonce: "0x5", From: from},
+				Receipt: &rpcReceipt{Status: "0x1", ContractAddress: "0x479cc461fecd078f766ecc58533d6f69580cf3ac"},
+			},
+			want: []bchain.ContractCreation{{Contract: "0x479CC461fEcd078F766eCc58533D6F69580CF3AC", Creator: creator}},
+		},
+		{
+			name: "address computed from nonce",
+			csd: completeTransaction{
+				Tx:      &rpcTransaction{AccountNonce: "0x1", From: from, To: ""},
+				Receipt: &rpcReceipt{Status: "0x1"},
+			},
+			want: []bchain.ContractCreation{{Contract: "0x343c43A37D37dfF08AE8C4A11544c718AbB4fCF8", Creator: creator}},
+		},
+		{
+			name: "failed creation",
+			csd: completeTransaction{
+				Tx:      &rpcTransaction{AccountNonce: "0x0", From: from},
+				Receipt: &rpcReceipt{Status: "0x0"},
+			},
+		},
+		{
+			name: "internal creation",
+			csd: completeTransaction{
+				Tx:      &rpcTransaction{AccountNonce: "0x0", From: from, To: "0x479cc461fecd078f766ecc58533d6f69580cf3ac"},
+				Receipt: &rpcReceipt{Status: "0x1"},
+				InternalTransfers: []bchain.EthereumInternalTransfer{
+					{Type: bchain.CALL, From: "0x479CC461fEcd078F766eCc58533D6F69580CF3AC", To: creator},
+					{Type: bchain.CREATE, From: "0x479CC461fEcd078F766eCc58533D6F69580CF3AC", To: "0xCd234A471b72ba2F1Ccf0A70FCABA648a5eeCD8d"},
+				},
+			},
+			want: []bchain.ContractCreation{{Contract: "0xCd234A471b72ba2F1Ccf0A70FCABA648a5eeCD8d", Creator: "0x479CC461fEcd078F766eCc58533D6F69580CF3AC"}},
+		},
+		{
+			name: "internal creation of failed transaction",
+			csd: completeTransaction{
+				Tx:      &rpcTransaction{AccountNonce: "0x0", From: from, To: "0x479cc461fecd078f766ecc58533d6f69580cf3ac"},
+				Receipt: &rpcReceipt{Status: "0x0"},
+				InternalTransfers: []bchain.EthereumInternalTransfer{
+					{Type: bchain.CREATE, From: "0x479CC461fEcd078F766eCc58533D6F69580CF3AC", To: "0xCd234A471b72ba2F1Ccf0A70FCABA648a5eeCD8d"},
+				},
+			},
+		},
+	}
+	p := NewEthereumParser(1)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.EthereumTypeGetContractCreationsFromTx(&bchain.Tx{CoinSpecificData: tt.csd})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EthereumTypeGetContractCreationsFromTx() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEthereumParser_DeriveAddressDescriptorsFromTo(t *testing.T) {
+	tests := []struct {
+		name      string
+		xpub      string
+		fromIndex uint32
+		toIndex   uint32
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name:      "m/44'/60'/0'",
+			xpub:      "xpub6DCoCpSuQZB2jawqnGMEPS63ePKWkwWPH4TU45Q7LPXWuNd8TMtVxRrgjtEshuqpK3mdhaWHPFsBngh5GFZaM6si3yZdUsT8ddYM3PwnATt",
+			fromIndex: 0,
+			toIndex:   3,
+			want:      []string{"0x9858EfFD232B4033E47d90003D41EC34EcaEda94", "0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0", "0xb6716976A3ebe8D39aCEB04372f22Ff8e6802D7A"},
+		},
+		{
+			name:      "m/44'/60'/0' from index 2",
+			xpub:      "xpub6DCoCpSuQZB2jawqnGMEPS63ePKWkwWPH4TU45Q7LPXWuNd8TMtVxRrgjtEshuqpK3mdhaWHPFsBngh5GFZaM6si3yZdUsT8ddYM3PwnATt",
+			fromIndex: 2,
+			toIndex:   3,
+			want:      []string{"0xb6716976A3ebe8D39aCEB04372f22Ff8e6802D7A"},
+		},
+		{
+			name:      "invalid range",
+			xpub:      "xpub6DCoCpSuQZB2jawqnGMEPS63ePKWkwWPH4TU45Q7LPXWuNd8TMtVxRrgjtEshuqpK3mdhaWHPFsBngh5GFZaM6si3yZdUsT8ddYM3PwnATt",
+			fromIndex: 3,
+			toIndex:   3,
+			wantErr:   true,
+		},
+		{
+			name:      "private key",
+			xpub:      "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi",
+			fromIndex: 0,
+			toIndex:   1,
+			wantErr:   true,
+		},
+		{
+			name:      "address",
+			xpub:      "0x9858EfFD232B4033E47d90003D41EC34EcaEda94",
+			fromIndex: 0,
+			toIndex:   1,
+			wantErr:   true,
+		},
+	}
+	p := NewEthereumParser(1)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := p.DeriveAddressDescriptorsFromTo(tt.xpub, 0, tt.fromIndex, tt.toIndex)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DeriveAddressDescriptorsFromTo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			gotAddresses := make([]string, len(got))
+			for i, ad := range got {
+				gotAddresses[i] = EIP55Address(ad)
+			}
+			if len(gotAddresses) != len(tt.want) || (len(tt.want) > 0 && !reflect.DeepEqual(gotAddresses, tt.want)) {
+				t.Errorf("DeriveAddressDescriptorsFromTo() = %v, want %v", gotAddresses, tt.want)
+			}
+		})
+	}
+}
+
+func TestEthereumParser_DerivationBasePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		xpub    string
+		slip44  uint32
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "m/44'/60'/0'",
+			xpub:   "xpub6DCoCpSuQZB2jawqnGMEPS63ePKWkwWPH4TU45Q7LPXWuNd8TMtVxRrgjtEshuqpK3mdhaWHPFsBngh5GFZaM6si3yZdUsT8ddYM3PwnATt",
+			slip44: EthereumSlip44,
+			want:   "m/44'/60'/0'",
+		},
+		{
+			name:   "configured coin type",
+			xpub:   "xpub6DCoCpSuQZB2jawqnGMEPS63ePKWkwWPH4TU45Q7LPXWuNd8TMtVxRrgjtEshuqpK3mdhaWHPFsBngh5GFZaM6si3yZdUsT8ddYM3PwnATt",
+			slip44: 1,
+			want:   "m/44'/1'/0'",
+		},
+		{
+			name:   "master key",
+			xpub:   "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8",
+			slip44: EthereumSlip44,
+			want:   "unknown/0",
+		},
+		{
+			name:    "invalid",
+			xpub:    "xpub6DCoCpSuQZB2jawqnGMEPS63ePKWkwWPH4TU45Q7LPXWuNd8TMtVxRrgjtEshuqpK3mdhaWHPFsBngh5GFZaM6si3yZdUsT8ddYM3PwnATu",
+			slip44:  EthereumSlip44,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewEthereumParser(1)
+			p.Slip44 = tt.slip44
+			got, err := p.DerivationBasePath(tt.xpub)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DerivationBasePath() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("DerivationBasePath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -42,6 +43,8 @@ type Configuration struct {
 	BlockAddressesToKeep        int    `json:"block_addresses_to_keep"`
 	MempoolTxTimeoutHours       int    `json:"mempoolTxTimeoutHours"`
 	QueryBackendOnMempoolResync bool   `json:"queryBackendOnMempoolResync"`
+	ProcessInternalTransactions bool   `json:"processInternalTransactions"`
+	Slip44                      uint32 `json:"slip44,omitempty"`
 }
 
 // EthereumRPC is an interface to JSON-RPC eth service.
@@ -90,6 +93,9 @@ func NewEthereumRPC(config json.RawMessage, pushHandler func(bchain.Notification
 
 	// always create parser
 	s.Parser = NewEthereumParser(c.BlockAddressesToKeep)
+	if c.Slip44 != 0 {
+		s.Parser.Slip44 = c.Slip44
+	}
 	s.timeout = time.Duration(c.RPCTimeout) * time.Second
 
 	// new blocks notifications handling
@@ -185,7 +191,7 @@ func (b *EthereumRPC) CreateMempool(chain bchain.BlockChain) (bchain.Mempool, er
 }
 
 // InitializeMempool creates subscriptions to newHeads and newPendingTransactions
-func (b *EthereumRPC) InitializeMempool(addrDescForOutpoint bchain.AddrDescForOutpointFunc, onNewTxAddr bchain.OnNewTxAddrFunc, onNewTx bchain.OnNewTxFunc) error {
+func (b *EthereumRPC) InitializeMempool(addrDescForOutpoint bchain.AddrDescForOutpointFunc, onNewTxAddr bchain.OnNewTxAddrFunc, onNewTx bchain.OnNewTxFunc, onReplacedTx bchain.OnReplacedTxFunc) error {
 	if b.Mempool == nil {
 		return errors.New("Mempool not created")
 	}
@@ -201,6 +207,7 @@ func (b *EthereumRPC) InitializeMempool(addrDescForOutpoint bchain.AddrDescForOu
 
 	b.Mempool.OnNewTxAddr = onNewTxAddr
 	b.Mempool.OnNewTx = onNewTx
+	b.Mempool.OnReplacedTx = onReplacedTx
 
 	if err = b.subscribeEvents(); err != nil {
 		return err
@@ -493,6 +500,7 @@ func (b *EthereumRPC) getBlockRaw(hash string, height uint32, fullTxs bool) (jso
 	return raw, nil
 }
 
+// getERC20EventsForBlock returns the token transfer and approval events of the block indexed by the txid
 func (b *EthereumRPC) getERC20EventsForBlock(blockNumber string) (map[string][]*rpcLog, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
 	defer cancel()
@@ -500,7 +508,8 @@ func (b *EthereumRPC) getERC20EventsForBlock(blockNumber string) (map[string][]*
 	err := b.rpc.CallContext(ctx, &logs, "eth_getLogs", map[string]interface{}{
 		"fromBlock": blockNumber,
 		"toBlock":   blockNumber,
-		"topics":    []string{erc20TransferEventSignature},
+		// Transfer of ERC20 and ERC721, TransferSingle and TransferBatch of ERC1155 and Approval of ERC20
+		"topics": [][]string{{erc20TransferEventSignature, erc1155TransferSingleEventSignature, erc1155TransferBatchEventSignature, erc20ApprovalEventSignature}},
 	})
 	if err != nil {
 		return nil, errors.Annotatef(err, "blockNumber %v", blockNumber)
@@ -513,6 +522,56 @@ func (b *EthereumRPC) getERC20EventsForBlock(blockNumber string) (map[string][]*
 	return r, nil
 }
 
+// getInternalTransfersForBlock returns the internal transfers of all transactions of the block
+// it requires the backend with enabled debug API, which supports callTracer
+func (b *EthereumRPC) getInternalTransfersForBlock(blockHash string, txs []rpcTransaction) ([][]bchain.EthereumInternalTransfer, error) {
+	if !b.ChainConfig.ProcessInternalTransactions || len(txs) == 0 {
+		return nil, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	var traces []rpcTraceResult
+	err := b.rpc.CallContext(ctx, &traces, "debug_traceBlockByHash", blockHash, map[string]interface{}{"tracer": "callTracer"})
+	if err != nil {
+		return nil, errors.Annotatef(err, "debug_traceBlockByHash %v", blockHash)
+	}
+	if len(traces) != len(txs) {
+		return nil, errors.Errorf("debug_traceBlockByHash %v returned %v traces for %v transactions", blockHash, len(traces), len(txs))
+	}
+	r := make([][]bchain.EthereumInternalTransfer, len(traces))
+	for i := range traces {
+		if r[i], err = getInternalTransfersFromTrace(&traces[i].Result); err != nil {
+			return nil, errors.Annotatef(err, "txid %v", txs[i].Hash)
+		}
+	}
+	return r, nil
+}
+
+// getInternalTransfersForTx returns the internal transfers of a confirmed transaction
+func (b *EthereumRPC) getInternalTransfersForTx(ctx context.Context, hash ethcommon.Hash) ([]bchain.EthereumInternalTransfer, error) {
+	if !b.ChainConfig.ProcessInternalTransactions {
+		return nil, nil
+	}
+	var trace rpcCallTrace
+	err := b.rpc.CallContext(ctx, &trace, "debug_traceTransaction", hash, map[string]interface{}{"tracer": "callTracer"})
+	if err != nil {
+		return nil, errors.Annotatef(err, "debug_traceTransaction")
+	}
+	return getInternalTransfersFromTrace(&trace)
+}
+
+// getTransactionReceipt returns the receipt of a confirmed transaction
+func (b *EthereumRPC) getTransactionReceipt(txid string) (*rpcReceipt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	var receipt rpcReceipt
+	err := b.rpc.CallContext(ctx, &receipt, "eth_getTransactionReceipt", ethcommon.HexToHash(txid))
+	if err != nil {
+		return nil, errors.Annotatef(err, "eth_getTransactionReceipt %v", txid)
+	}
+	return &receipt, nil
+}
+
 // GetBlock returns block with given hash or height, hash has precedence if both passed
 func (b *EthereumRPC) GetBlock(hash string, height uint32) (*bchain.Block, error) {
 	raw, err := b.getBlockRaw(hash, height, true)
@@ -531,21 +590,37 @@ func (b *EthereumRPC) GetBlock(hash string, height uint32) (*bchain.Block, error
 	if err != nil {
 		return nil, errors.Annotatef(err, "hash %v, height %v", hash, height)
 	}
-	// get ERC20 events
+	// get the token transfer and approval events
 	logs, err := b.getERC20EventsForBlock(head.Number)
 	if err != nil {
 		return nil, err
 	}
+	// get internal transfers, if enabled
+	internalTransfers, err := b.getInternalTransfersForBlock(head.Hash, body.Transactions)
+	if err != nil {
+		return nil, err
+	}
 	btxs := make([]bchain.Tx, len(body.Transactions))
 	for i := range body.Transactions {
 		tx := &body.Transactions[i]
-		btx, err := b.Parser.ethTxToTx(tx, &rpcReceipt{Logs: logs[tx.Hash]}, bbh.Time, uint32(bbh.Confirmations), true)
+		var it []bchain.EthereumInternalTransfer
+		if internalTransfers != nil {
+			it = internalTransfers[i]
+		}
+		receipt := &rpcReceipt{Logs: logs[tx.Hash]}
+		// the receipt of a contract creation tells if the contract was created
+		if len(tx.To) <= 2 {
+			if receipt, err = b.getTransactionReceipt(tx.Hash); err != nil {
+				return nil, errors.Annotatef(err, "hash %v, height %v", hash, height)
+			}
+		}
+		btx, err := b.Parser.ethTxToTx(tx, receipt, it, head.BaseFeePerGas, bbh.Time, uint32(bbh.Confirmations), true)
 		if err != nil {
 			return nil, errors.Annotatef(err, "hash %v, height %v, txid %v", hash, height, tx.Hash)
 		}
 		btxs[i] = *btx
 		if b.mempoolInitialized {
-			b.Mempool.RemoveTransactionFromMempool(tx.Hash)
+			b.Mempool.RemoveConfirmedTransactionFromMempool(btx)
 		}
 	}
 	bbk := bchain.Block{
@@ -598,14 +673,14 @@ func (b *EthereumRPC) GetTransaction(txid string) (*bchain.Tx, error) {
 		return nil, err
 	} else if tx == nil {
 		if b.mempoolInitialized {
-			b.Mempool.RemoveTransactionFromMempool(txid)
+			b.Mempool.MarkTransactionMissing(txid)
 		}
 		return nil, bchain.ErrTxNotFound
 	}
 	var btx *bchain.Tx
 	if tx.BlockNumber == "" {
 		// mempool tx
-		btx, err = b.Parser.ethTxToTx(tx, nil, 0, 0, true)
+		btx, err = b.Parser.ethTxToTx(tx, nil, nil, "", 0, 0, true)
 		if err != nil {
 			return nil, errors.Annotatef(err, "txid %v", txid)
 		}
@@ -616,7 +691,8 @@ func (b *EthereumRPC) GetTransaction(txid string) (*bchain.Tx, error) {
 			return nil, err
 		}
 		var ht struct {
-			Time string `json:"timestamp"`
+			Time          string `json:"timestamp"`
+			BaseFeePerGas string `json:"baseFeePerGas"`
 		}
 		if err := json.Unmarshal(raw, &ht); err != nil {
 			return nil, errors.Annotatef(err, "hash %v", hash)
@@ -638,13 +714,17 @@ func (b *EthereumRPC) GetTransaction(txid string) (*bchain.Tx, error) {
 		if err != nil {
 			return nil, errors.Annotatef(err, "txid %v", txid)
 		}
-		btx, err = b.Parser.ethTxToTx(tx, &receipt, time, confirmations, true)
+		it, err := b.getInternalTransfersForTx(ctx, hash)
+		if err != nil {
+			return nil, errors.Annotatef(err, "txid %v", txid)
+		}
+		btx, err = b.Parser.ethTxToTx(tx, &receipt, it, ht.BaseFeePerGas, time, confirmations, true)
 		if err != nil {
 			return nil, errors.Annotatef(err, "txid %v", txid)
 		}
 		// remove tx from mempool if it is there
 		if b.mempoolInitialized {
-			b.Mempool.RemoveTransactionFromMempool(txid)
+			b.Mempool.RemoveConfirmedTransactionFromMempool(btx)
 		}
 	}
 	return btx, nil
@@ -688,10 +768,18 @@ func (b *EthereumRPC) EstimateFee(blocks int) (big.Int, error) {
 }
 
 // EstimateSmartFee returns fee estimation
+// if the chain supports EIP-1559, the estimation is the base fee of the next block and the priority fee of the level suitable for the given number of blocks
 func (b *EthereumRPC) EstimateSmartFee(blocks int, conservative bool) (big.Int, error) {
+	var r big.Int
+	fees, err := b.EthereumTypeGetEip1559Fees()
+	if err == nil {
+		f := fees.ForBlocks(blocks)
+		r.Add(&fees.BaseFeePerGas, &f.MaxPriorityFeePerGas)
+		return r, nil
+	}
+	glog.V(1).Infof("EthereumTypeGetEip1559Fees error %v, using eth_gasPrice", err)
 	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
 	defer cancel()
-	var r big.Int
 	gp, err := b.client.SuggestGasPrice(ctx)
 	if err == nil && b != nil {
 		r = *gp
@@ -699,6 +787,72 @@ func (b *EthereumRPC) EstimateSmartFee(blocks int, conservative bool) (big.Int,
 	return r, err
 }
 
+// number of the last blocks and the percentiles of the priority fees of their transactions used for the estimation of EIP-1559 fees
+const eip1559FeeHistoryBlocks = 20
+
+var eip1559RewardPercentiles = []float64{10, 50, 90}
+
+type rpcFeeHistory struct {
+	OldestBlock   string     `json:"oldestBlock"`
+	BaseFeePerGas []string   `json:"baseFeePerGas"`
+	GasUsedRatio  []float64  `json:"gasUsedRatio"`
+	Reward        [][]string `json:"reward"`
+}
+
+// EthereumTypeGetEip1559Fees returns the base fee of the next block and the fees of EIP-1559 transactions suggested for several priority levels
+func (b *EthereumRPC) EthereumTypeGetEip1559Fees() (*bchain.Eip1559Fees, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	var h rpcFeeHistory
+	if err := b.rpc.CallContext(ctx, &h, "eth_feeHistory", hexutil.Uint64(eip1559FeeHistoryBlocks), "latest", eip1559RewardPercentiles); err != nil {
+		return nil, err
+	}
+	return eip1559FeesFromFeeHistory(&h)
+}
+
+// eip1559FeesFromFeeHistory computes the suggested fees from the result of eth_feeHistory
+// the priority fee of each level is the median of the percentile of the priority fees paid in the last blocks
+// the max fee covers the growth of the base fee for several full blocks
+func eip1559FeesFromFeeHistory(h *rpcFeeHistory) (*bchain.Eip1559Fees, error) {
+	if len(h.BaseFeePerGas) == 0 {
+		return nil, errors.New("Missing baseFeePerGas")
+	}
+	var r bchain.Eip1559Fees
+	// the last base fee is the base fee of the next block
+	bf, err := hexutil.DecodeBig(h.BaseFeePerGas[len(h.BaseFeePerGas)-1])
+	if err != nil {
+		return nil, errors.Annotatef(err, "baseFeePerGas %v", h.BaseFeePerGas[len(h.BaseFeePerGas)-1])
+	}
+	if bf.Sign() == 0 {
+		return nil, errors.New("EIP-1559 is not active")
+	}
+	r.BaseFeePerGas.Set(bf)
+	levels := []*bchain.Eip1559Fee{&r.Low, &r.Medium, &r.High}
+	for i, l := range levels {
+		rewards := make([]*big.Int, 0, len(h.Reward))
+		for _, br := range h.Reward {
+			if i < len(br) {
+				v, err := hexutil.DecodeBig(br[i])
+				if err != nil {
+					return nil, errors.Annotatef(err, "reward %v", br[i])
+				}
+				rewards = append(rewards, v)
+			}
+		}
+		if len(rewards) > 0 {
+			sort.Slice(rewards, func(i, j int) bool { return rewards[i].Cmp(rewards[j]) < 0 })
+			l.MaxPriorityFeePerGas.Set(rewards[len(rewards)/2])
+		}
+		// higher priority level must not suggest lower fee
+		if i > 0 && l.MaxPriorityFeePerGas.Cmp(&levels[i-1].MaxPriorityFeePerGas) < 0 {
+			l.MaxPriorityFeePerGas.Set(&levels[i-1].MaxPriorityFeePerGas)
+		}
+		l.MaxFeePerGas.Mul(bf, big.NewInt(2))
+		l.MaxFeePerGas.Add(&l.MaxFeePerGas, &l.MaxPriorityFeePerGas)
+	}
+	return &r, nil
+}
+
 func getStringFromMap(p string, params map[string]interface{}) (string, bool) {
 	v, ok := params[p]
 	if ok {
@@ -708,10 +862,8 @@ func getStringFromMap(p string, params map[string]interface{}) (string, bool) {
 	return "", false
 }
 
-// EthereumTypeEstimateGas returns estimation of gas consumption for given transaction parameters
-func (b *EthereumRPC) EthereumTypeEstimateGas(params map[string]interface{}) (uint64, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
-	defer cancel()
+// callMsgFromParams converts the parameters of a transaction in the format of eth_call to ethereum.CallMsg
+func callMsgFromParams(params map[string]interface{}) ethereum.CallMsg {
 	msg := ethereum.CallMsg{}
 	s, ok := getStringFromMap("from", params)
 	if ok && len(s) > 0 {
@@ -738,7 +890,107 @@ func (b *EthereumRPC) EthereumTypeEstimateGas(params map[string]interface{}) (ui
 	if ok && len(s) > 0 {
 		msg.GasPrice, _ = hexutil.DecodeBig(s)
 	}
-	return b.client.EstimateGas(ctx, msg)
+	return msg
+}
+
+// callArgFromMsg converts ethereum.CallMsg to the transaction object of eth_call and debug_traceCall
+func callArgFromMsg(msg *ethereum.CallMsg) map[string]interface{} {
+	arg := map[string]interface{}{
+		"from": msg.From,
+	}
+	if msg.To != nil {
+		arg["to"] = msg.To
+	}
+	if len(msg.Data) > 0 {
+		arg["data"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	return arg
+}
+
+// EthereumTypeEstimateGas returns estimation of gas consumption for given transaction parameters
+func (b *EthereumRPC) EthereumTypeEstimateGas(params map[string]interface{}) (uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	return b.client.EstimateGas(ctx, callMsgFromParams(params))
+}
+
+// EthereumTypeSimulateTransaction executes the unsigned transaction given by the parameters in the format of eth_call against the latest block
+// the execution is traced by debug_traceCall, which requires the backend with enabled debug API,
+// if the trace is not available, the transaction is executed by eth_call and only the transfers decoded from the call data are returned
+func (b *EthereumRPC) EthereumTypeSimulateTransaction(params map[string]interface{}) (*bchain.TransactionSimulation, error) {
+	msg := callMsgFromParams(params)
+	ctx, cancel := context.WithTimeout(context.Background(), b.timeout)
+	defer cancel()
+	var trace rpcCallTrace
+	err := b.rpc.CallContext(ctx, &trace, "debug_traceCall", callArgFromMsg(&msg), "latest", map[string]interface{}{
+		"tracer":       "callTracer",
+		"tracerConfig": map[string]interface{}{"withLog": true},
+	})
+	if err == nil {
+		return getSimulationFromTrace(&trace)
+	}
+	glog.V(1).Infof("debug_traceCall error %v, simulating by eth_call", err)
+	return b.simulateByCall(ctx, &msg)
+}
+
+// simulateByCall executes the transaction by eth_call, the gas used is estimated by eth_estimateGas
+func (b *EthereumRPC) simulateByCall(ctx context.Context, msg *ethereum.CallMsg) (*bchain.TransactionSimulation, error) {
+	r := &bchain.TransactionSimulation{}
+	var output hexutil.Bytes
+	err := b.rpc.CallContext(ctx, &output, "eth_call", callArgFromMsg(msg), "latest")
+	if err != nil {
+		// the reverted call returns the error with the output of the call as data
+		de, ok := err.(rpc.DataError)
+		if !ok {
+			return nil, errors.Annotatef(err, "eth_call")
+		}
+		if data, ok := de.ErrorData().(string); ok {
+			r.RevertReason = RevertReasonFromOutput(data)
+		}
+		if r.RevertReason == "" {
+			r.RevertReason = err.Error()
+		}
+		return r, nil
+	}
+	r.Success = true
+	if r.GasUsed, err = b.client.EstimateGas(ctx, *msg); err != nil {
+		// the error returned by the node tells why the transaction cannot be executed, for example insufficient funds
+		if e, ok := err.(rpc.Error); ok {
+			return nil, &bchain.InvalidParamsError{Message: e.Error()}
+		}
+		return nil, errors.Annotatef(err, "eth_estimateGas")
+	}
+	tx := rpcTransaction{
+		From:    msg.From.Hex(),
+		Payload: hexutil.Encode(msg.Data),
+	}
+	if msg.To != nil {
+		tx.To = msg.To.Hex()
+	}
+	if msg.Value != nil && msg.Value.Sign() > 0 {
+		r.ValueTransfers = []bchain.EthereumInternalTransfer{{
+			Type:  bchain.CALL,
+			From:  tx.From,
+			To:    tx.To,
+			Value: *msg.Value,
+		}}
+		if msg.To == nil {
+			r.ValueTransfers[0].Type = bchain.CREATE
+		}
+	}
+	if r.TokenTransfers, err = erc20GetTransfersFromTx(&tx); err != nil {
+		return nil, err
+	}
+	return r, nil
 }
 
 // SendRawTransaction sends raw transaction
@@ -0,0 +1,83 @@
+// +build unittest
+
+package eth
+
+import (
+	"testing"
+)
+
+func Test_eip1559FeesFromFeeHistory(t *testing.T) {
+	tests := []struct {
+		name    string
+		h       rpcFeeHistory
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "fees",
+			h: rpcFeeHistory{
+				BaseFeePerGas: []string{"0x64", "0x6e", "0x78"},
+				Reward: [][]string{
+					{"0x1", "0x5", "0x14"},
+					{"0x3", "0x2", "0xa"},
+				},
+			},
+			// base fee, low, medium and high max priority fee and max fee
+			want: []string{"120", "3", "243", "5", "245", "20", "260"},
+		},
+		{
+			name: "higher level does not suggest lower fee",
+			h: rpcFeeHistory{
+				BaseFeePerGas: []string{"0x64", "0x64"},
+				Reward: [][]string{
+					{"0xa", "0x5", "0x7"},
+				},
+			},
+			want: []string{"100", "10", "210", "10", "210", "10", "210"},
+		},
+		{
+			name: "empty blocks",
+			h: rpcFeeHistory{
+				BaseFeePerGas: []string{"0x64", "0x64"},
+				Reward:        [][]string{},
+			},
+			want: []string{"100", "0", "200", "0", "200", "0", "200"},
+		},
+		{
+			name: "EIP-1559 not active",
+			h: rpcFeeHistory{
+				BaseFeePerGas: []string{"0x0", "0x0"},
+			},
+			wantErr: true,
+		},
+		{
+			name:    "missing base fee",
+			h:       rpcFeeHistory{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := eip1559FeesFromFeeHistory(&tt.h)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("eip1559FeesFromFeeHistory() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			g := []string{
+				got.BaseFeePerGas.String(),
+				got.Low.MaxPriorityFeePerGas.String(), got.Low.MaxFeePerGas.String(),
+				got.Medium.MaxPriorityFeePerGas.String(), got.Medium.MaxFeePerGas.String(),
+				got.High.MaxPriorityFeePerGas.String(), got.High.MaxFeePerGas.String(),
+			}
+			for i := range g {
+				if g[i] != tt.want[i] {
+					t.Errorf("eip1559FeesFromFeeHistory() = %v, want %v", g, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
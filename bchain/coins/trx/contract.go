@@ -1,7 +1,9 @@
 package trx
 
 import (
+	"encoding/hex"
 	"fmt"
+
 	"github.com/fatih/structs"
 	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
 	"github.com/golang/protobuf/ptypes"
@@ -27,6 +29,18 @@ func getContractInfo(contractType core.Transaction_Contract_ContractType, parame
 	}
 }
 
+// getCreatedContract returns the address of the smart contract created by a successful CreateSmartContract transaction
+func getCreatedContract(txinfo *core.TransactionInfo) string {
+	if txinfo == nil || txinfo.Result != core.TransactionInfo_SUCESS || len(txinfo.ContractAddress) == 0 {
+		return ""
+	}
+	// the address may be stored without the prefix like the addresses of the logs
+	if len(txinfo.ContractAddress) == TronTypeAddressDescriptorLen-1 {
+		return "41" + hex.EncodeToString(txinfo.ContractAddress)
+	}
+	return hex.EncodeToString(txinfo.ContractAddress)
+}
+
 func getContract(contractType core.Transaction_Contract_ContractType, parameter *any.Any) (map[string]interface{}, error) {
 	switch contractType {
 	case core.Transaction_Contract_AccountCreateContract:
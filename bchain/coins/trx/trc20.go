@@ -1,52 +1,58 @@
 package trx
 
 import (
+	"encoding/hex"
+
 	common2 "github.com/fbsobreira/gotron-sdk/pkg/common"
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
 	"github.com/trezor/blockbook/bchain"
 	"math/big"
-	"sync"
 )
 
-var cachedContracts = make(map[string]*bchain.Trc20Contract)
-var cachedContractsMux sync.Mutex
+const trc20ApprovalEventSignature = "8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"
 
-func (b *TrxRPC) TronTypeGetTrc20ContractInfo(contractDesc bchain.AddressDescriptor) (*bchain.Trc20Contract, error) {
-	cds := string(contractDesc)
-	cachedContractsMux.Lock()
-	contract, found := cachedContracts[cds]
-	cachedContractsMux.Unlock()
-	if !found {
-		address := common2.EncodeCheck(contractDesc)
-		symbol, err := b.conn.TRC20GetSymbol(address)
-		if err != nil {
-			symbol = ""
+// trc20GetApprovalsFromLog returns the TRC20 Approval events, the addresses in the logs are stored without the 0x41 prefix
+func trc20GetApprovalsFromLog(logs []*core.TransactionInfo_Log) []bchain.TokenApproval {
+	var r []bchain.TokenApproval
+	for _, l := range logs {
+		if len(l.Topics) != 3 || len(l.Topics[1]) != 32 || len(l.Topics[2]) != 32 || hex.EncodeToString(l.Topics[0]) != trc20ApprovalEventSignature {
+			continue
 		}
-
-		tokenDecimals, err := b.conn.TRC20GetDecimals(address)
-		if err != nil {
-			tokenDecimals = big.NewInt(0)
+		a := bchain.TokenApproval{
+			Contract: "41" + hex.EncodeToString(l.Address),
+			Owner:    "41" + hex.EncodeToString(l.Topics[1][12:]),
+			Spender:  "41" + hex.EncodeToString(l.Topics[2][12:]),
 		}
+		a.Value.SetBytes(l.Data)
+		r = append(r, a)
+	}
+	return r
+}
 
-		name, err := b.conn.TRC20GetName(address)
-		if err != nil {
-			name = ""
-		}
-		contract = &bchain.Trc20Contract{
-			Contract: address,
-			Name:     name,
-			Symbol:   symbol,
-			Decimals: int(tokenDecimals.Int64()),
-		}
+// TronTypeGetTrc20ContractInfo returns information about TRC20 contract
+// the result is not cached, the callers are expected to persist it (see db.GetContractInfo)
+func (b *TrxRPC) TronTypeGetTrc20ContractInfo(contractDesc bchain.AddressDescriptor) (*bchain.Trc20Contract, error) {
+	address := common2.EncodeCheck(contractDesc)
+	symbol, err := b.conn.TRC20GetSymbol(address)
+	if err != nil || symbol == "" {
+		return nil, nil
+	}
 
-		if symbol == "" {
-			contract = nil
-		}
+	tokenDecimals, err := b.conn.TRC20GetDecimals(address)
+	if err != nil {
+		tokenDecimals = big.NewInt(0)
+	}
 
-		cachedContractsMux.Lock()
-		cachedContracts[cds] = contract
-		cachedContractsMux.Unlock()
+	name, err := b.conn.TRC20GetName(address)
+	if err != nil {
+		name = ""
 	}
-	return contract, nil
+	return &bchain.Trc20Contract{
+		Contract: address,
+		Name:     name,
+		Symbol:   symbol,
+		Decimals: int(tokenDecimals.Int64()),
+	}, nil
 }
 
 func (b *TrxRPC) TronTypeGetTrc20ContractBalance(addrDesc, contractDesc bchain.AddressDescriptor) (*big.Int, error) {
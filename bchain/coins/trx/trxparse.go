@@ -6,14 +6,24 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/juju/errors"
 	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/bchain/coins/eth"
 	"math/big"
+	"sort"
 )
 
 const TronTypeAddressDescriptorLen = 21
 
+// TronSlip44 is the BIP-44 coin type of Tron, used if the coin type is not configured
+const TronSlip44 = 195
+
+// tronAddressPrefix is the first byte of Tron address descriptors
+const tronAddressPrefix = 0x41
+
 type TrxParser struct {
 	*bchain.BaseParser
 	rpc *TrxRPC
+	// Slip44 is the BIP-44 coin type shown in the derivation path of xpubs
+	Slip44 uint32
 }
 
 type trxCompleteTransaction struct {
@@ -60,7 +70,8 @@ func NewTrxParser(b int, rpc *TrxRPC) *TrxParser {
 			BlockAddressesToKeep: b,
 			AmountDecimalPoint:   18,
 		},
-		rpc}
+		rpc,
+		TronSlip44}
 }
 
 func (p *TrxParser) GetAddrDescFromAddress(address string) (bchain.AddressDescriptor, error) {
@@ -86,6 +97,34 @@ func (p *TrxParser) GetChainType() bchain.ChainType {
 	return bchain.ChainTronType
 }
 
+// DeriveAddressDescriptors derives address descriptors from given xpub for listed indexes,
+// the addresses are derived the same way as Ethereum addresses and prefixed by the Tron address prefix
+func (p *TrxParser) DeriveAddressDescriptors(xpub string, change uint32, indexes []uint32) ([]bchain.AddressDescriptor, error) {
+	addresses, err := eth.XpubDeriveAddresses(xpub, change, indexes)
+	if err != nil {
+		return nil, err
+	}
+	ad := make([]bchain.AddressDescriptor, len(addresses))
+	for i := range addresses {
+		ad[i] = append([]byte{tronAddressPrefix}, addresses[i]...)
+	}
+	return ad, nil
+}
+
+// DeriveAddressDescriptorsFromTo derives address descriptors from given xpub for addresses in index range
+func (p *TrxParser) DeriveAddressDescriptorsFromTo(xpub string, change uint32, fromIndex uint32, toIndex uint32) ([]bchain.AddressDescriptor, error) {
+	indexes, err := eth.XpubIndexes(fromIndex, toIndex)
+	if err != nil {
+		return nil, err
+	}
+	return p.DeriveAddressDescriptors(xpub, change, indexes)
+}
+
+// DerivationBasePath returns base path of xpub
+func (p *TrxParser) DerivationBasePath(xpub string) (string, error) {
+	return eth.XpubDerivationBasePath(xpub, p.Slip44)
+}
+
 func (p *TrxParser) PackTxid(txid string) ([]byte, error) {
 	if has0xPrefix(txid) {
 		txid = txid[2:]
@@ -127,6 +166,62 @@ func (p *TrxParser) TronTypeGetTrc20FromTx(tx *bchain.Tx) ([]bchain.Trc20Transfe
 	return nil, errors.New("no trxCompleteTransaction")
 }
 
+// TronTypeGetContractType returns the type of the contract of the transaction, e.g. TransferContract or TriggerSmartContract
+func (p *TrxParser) TronTypeGetContractType(tx *bchain.MempoolTx) (string, error) {
+	trx, ok := tx.CoinSpecificData.(*trxCompleteTransaction)
+	if !ok || trx.Tx == nil || trx.Tx.RawData == nil || len(trx.Tx.RawData.Contract) == 0 {
+		return "", errors.New("no trxCompleteTransaction")
+	}
+	return trx.Tx.RawData.Contract[0].Type.String(), nil
+}
+
+// TronTypeGetContractTypes returns the names of all contract types, in the form returned by TronTypeGetContractType
+func (p *TrxParser) TronTypeGetContractTypes() []string {
+	r := make([]string, 0, len(core.Transaction_Contract_ContractType_value))
+	for name := range core.Transaction_Contract_ContractType_value {
+		r = append(r, name)
+	}
+	sort.Strings(r)
+	return r
+}
+
+// TronTypeGetContractCreationsFromTx returns the smart contract created by a CreateSmartContract transaction
+func (p *TrxParser) TronTypeGetContractCreationsFromTx(tx *bchain.Tx) ([]bchain.ContractCreation, error) {
+	trx, ok := tx.CoinSpecificData.(*trxCompleteTransaction)
+	if !ok || trx.Tx == nil || trx.Tx.RawData == nil || len(trx.Tx.RawData.Contract) == 0 {
+		return nil, errors.New("no trxCompleteTransaction")
+	}
+	c := trx.Tx.RawData.Contract[0]
+	if c.Type != core.Transaction_Contract_CreateSmartContract {
+		return nil, nil
+	}
+	contract := getCreatedContract(trx.TxInfo)
+	if contract == "" {
+		return nil, nil
+	}
+	data, err := getContract(c.Type, c.Parameter)
+	if err != nil {
+		return nil, err
+	}
+	var creator string
+	if v, ok := data["OwnerAddress"].([]byte); ok {
+		creator = hex.EncodeToString(v)
+	}
+	return []bchain.ContractCreation{{Contract: contract, Creator: creator}}, nil
+}
+
+// TronTypeGetTokenApprovalsFromTx returns the allowances set by the TRC20 Approval events of the transaction
+func (p *TrxParser) TronTypeGetTokenApprovalsFromTx(tx *bchain.Tx) ([]bchain.TokenApproval, error) {
+	trx, ok := tx.CoinSpecificData.(*trxCompleteTransaction)
+	if !ok {
+		return nil, errors.New("no trxCompleteTransaction")
+	}
+	if trx.TxInfo == nil {
+		return nil, nil
+	}
+	return trc20GetApprovalsFromLog(trx.TxInfo.Log), nil
+}
+
 func (p *TrxParser) trxtotx(tx *core.Transaction, txinfo *core.TransactionInfo) (*bchain.Tx, error) {
 	complete, err := p.rpc.GetComplete(tx, txinfo)
 	if err != nil {
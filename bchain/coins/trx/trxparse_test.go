@@ -0,0 +1,151 @@
+// +build unittest
+
+package trx
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/fbsobreira/gotron-sdk/pkg/proto/core"
+	"github.com/trezor/blockbook/bchain"
+)
+
+func TestTrxParser_DeriveAddressDescriptorsFromTo(t *testing.T) {
+	p := NewTrxParser(1, nil)
+	// m/44'/195'/0'
+	xpub := "xpub6D1AabNHCupeiLM65ZR9UStMhJ1vCpyV4XbZdyhMZBiJXALQtmn9p42VTQckoHVn8WNqS7dqnJokZHAHcHGoaQgmv8D45oNUKx6DZMNZBCd"
+	got, err := p.DeriveAddressDescriptorsFromTo(xpub, 0, 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gotAddresses := make([]string, len(got))
+	for i, ad := range got {
+		gotAddresses[i] = hex.EncodeToString(ad)
+	}
+	want := []string{"41c8599111f29c1e1e061265b4af93ea1f274ad78a", "41b6e708a39781c96bd399c7657780ff9fe9f052a8"}
+	if !reflect.DeepEqual(gotAddresses, want) {
+		t.Errorf("DeriveAddressDescriptorsFromTo() = %v, want %v", gotAddresses, want)
+	}
+	basePath, err := p.DerivationBasePath(xpub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if basePath != "m/44'/195'/0'" {
+		t.Errorf("DerivationBasePath() = %v, want m/44'/195'/0'", basePath)
+	}
+}
+
+func TestTrxParser_TronTypeGetTokenApprovalsFromTx(t *testing.T) {
+	p := NewTrxParser(1, nil)
+	mustDecode := func(s string) []byte {
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	txInfo := &core.TransactionInfo{
+		Log: []*core.TransactionInfo_Log{
+			{ // Transfer
+				Address: mustDecode("a614f803b6fd780986a42c78ec9c7f77e6ded13c"),
+				Topics: [][]byte{
+					mustDecode(trc20TransferEventSignature),
+					mustDecode("000000000000000000000000c8599111f29c1e1e061265b4af93ea1f274ad78a"),
+					mustDecode("000000000000000000000000b6e708a39781c96bd399c7657780ff9fe9f052a8"),
+				},
+				Data: mustDecode("0000000000000000000000000000000000000000000000000000000000000064"),
+			},
+			{ // Approval
+				Address: mustDecode("a614f803b6fd780986a42c78ec9c7f77e6ded13c"),
+				Topics: [][]byte{
+					mustDecode(trc20ApprovalEventSignature),
+					mustDecode("000000000000000000000000c8599111f29c1e1e061265b4af93ea1f274ad78a"),
+					mustDecode("000000000000000000000000b6e708a39781c96bd399c7657780ff9fe9f052a8"),
+				},
+				Data: mustDecode("00000000000000000000000000000000000000000000000000000000000f4240"),
+			},
+		},
+	}
+	got, err := p.TronTypeGetTokenApprovalsFromTx(&bchain.Tx{CoinSpecificData: &trxCompleteTransaction{TxInfo: txInfo}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Contract != "41a614f803b6fd780986a42c78ec9c7f77e6ded13c" || got[0].Owner != "41c8599111f29c1e1e061265b4af93ea1f274ad78a" ||
+		got[0].Spender != "41b6e708a39781c96bd399c7657780ff9fe9f052a8" || got[0].Value.Int64() != 1000000 {
+		t.Errorf("TronTypeGetTokenApprovalsFromTx() = %+v", got)
+	}
+}
+
+func Test_getSimulationFromConstantContract(t *testing.T) {
+	from := "41c8599111f29c1e1e061265b4af93ea1f274ad78a"
+	contract := "41a614f803b6fd780986a42c78ec9c7f77e6ded13c"
+	transferData := "a9059cbb000000000000000000000000b6e708a39781c96bd399c7657780ff9fe9f052a80000000000000000000000000000000000000000000000000000000000000064"
+	transfer := bchain.Erc20Transfer{
+		Type:     bchain.FungibleToken,
+		Contract: contract,
+		From:     from,
+		To:       "41b6e708a39781c96bd399c7657780ff9fe9f052a8",
+		Tokens:   *big.NewInt(100),
+	}
+	tests := []struct {
+		name      string
+		data      string
+		callValue int64
+		res       string
+		want      *bchain.TransactionSimulation
+	}{
+		{
+			name: "transfer with logs",
+			data: transferData,
+			res: `{"result":{"result":true},"energy_used":13045,"constant_result":["0000000000000000000000000000000000000000000000000000000000000001"],
+				"logs":[{"address":"a614f803b6fd780986a42c78ec9c7f77e6ded13c","topics":["ddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+				"000000000000000000000000c8599111f29c1e1e061265b4af93ea1f274ad78a","000000000000000000000000b6e708a39781c96bd399c7657780ff9fe9f052a8"],
+				"data":"0000000000000000000000000000000000000000000000000000000000000064"}],"transaction":{"ret":[{}]}}`,
+			want: &bchain.TransactionSimulation{Success: true, GasUsed: 13045, TokenTransfers: []bchain.Erc20Transfer{transfer}, Traced: true},
+		},
+		{
+			name:      "transfer without logs",
+			data:      transferData,
+			callValue: 5,
+			res:       `{"result":{"result":true},"energy_used":13045,"constant_result":["0000000000000000000000000000000000000000000000000000000000000001"],"transaction":{"ret":[{}]}}`,
+			want: &bchain.TransactionSimulation{
+				Success:        true,
+				GasUsed:        13045,
+				ValueTransfers: []bchain.EthereumInternalTransfer{{Type: bchain.CALL, From: from, To: contract, Value: *big.NewInt(5)}},
+				TokenTransfers: []bchain.Erc20Transfer{transfer},
+			},
+		},
+		{
+			name: "revert with reason",
+			data: transferData,
+			res: `{"result":{"result":true},"energy_used":1234,"constant_result":["08c379a00000000000000000000000000000000000000000000000000000000000000020` +
+				`0000000000000000000000000000000000000000000000000000000000000014696e73756666696369656e742062616c616e6365000000000000000000000000"],
+				"transaction":{"ret":[{"contractRet":"REVERT"}]}}`,
+			want: &bchain.TransactionSimulation{GasUsed: 1234, RevertReason: "insufficient balance"},
+		},
+		{
+			name: "contract validate error",
+			data: transferData,
+			res:  `{"result":{"code":"CONTRACT_VALIDATE_ERROR","message":"636f6e7472616374206e6f7420657869737473"}}`,
+			want: &bchain.TransactionSimulation{RevertReason: "contract not exists"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var res trxConstantContractResult
+			if err := json.Unmarshal([]byte(tt.res), &res); err != nil {
+				t.Fatal(err)
+			}
+			got, err := getSimulationFromConstantContract(from, contract, tt.data, big.NewInt(tt.callValue), &res)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getSimulationFromConstantContract() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/golang/glog"
 	"github.com/juju/errors"
 	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/bchain/coins/eth"
 	"github.com/trezor/blockbook/common"
 	"google.golang.org/grpc"
 	"io"
@@ -20,6 +21,7 @@ import (
 	"net/http"
 	"runtime/debug"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -35,6 +37,7 @@ type Configuration struct {
 	MempoolWorkers       int    `json:"mempool_workers"`
 	MempoolSubWorkers    int    `json:"mempool_sub_workers"`
 	BlockAddressesToKeep int    `json:"block_addresses_to_keep"`
+	Slip44               uint32 `json:"slip44,omitempty"`
 
 	MempoolTxTimeoutHours       int  `json:"mempoolTxTimeoutHours"`
 	QueryBackendOnMempoolResync bool `json:"queryBackendOnMempoolResync"`
@@ -79,6 +82,9 @@ func NewTrxRPC(config json.RawMessage, pushHandler func(bchain.NotificationType)
 	}
 
 	s.Parser = NewTrxParser(c.BlockAddressesToKeep, s)
+	if c.Slip44 != 0 {
+		s.Parser.Slip44 = c.Slip44
+	}
 
 	return s, nil
 }
@@ -92,7 +98,7 @@ func (b *TrxRPC) CreateMempool(chain bchain.BlockChain) (bchain.Mempool, error)
 }
 
 // InitializeMempool creates ZeroMQ subscription and sets AddrDescForOutpointFunc to the Mempool
-func (b *TrxRPC) InitializeMempool(addrDescForOutpoint bchain.AddrDescForOutpointFunc, onNewTxAddr bchain.OnNewTxAddrFunc, onNewTx bchain.OnNewTxFunc) error {
+func (b *TrxRPC) InitializeMempool(addrDescForOutpoint bchain.AddrDescForOutpointFunc, onNewTxAddr bchain.OnNewTxAddrFunc, onNewTx bchain.OnNewTxFunc, onReplacedTx bchain.OnReplacedTxFunc) error {
 	if b.Mempool == nil {
 		return errors.New("Mempool not created")
 	}
@@ -177,7 +183,7 @@ func (b *TrxRPC) GetBlock(hash string, height uint32) (*bchain.Block, error) {
 
 	for _, tx := range block.Transactions {
 		var txinfo *core.TransactionInfo
-		if len(tx.Transaction.RawData.Contract) > 0 && tx.Transaction.RawData.Contract[0].Type == core.Transaction_Contract_TriggerSmartContract {
+		if len(tx.Transaction.RawData.Contract) > 0 && needsTransactionInfo(tx.Transaction.RawData.Contract[0].Type) {
 			v, ok := mapTrans[string(tx.Txid)]
 			if !ok {
 				glog.Error("can not find TransactionInfo")
@@ -263,7 +269,7 @@ func (b *TrxRPC) GetTransaction(txid string) (*bchain.Tx, error) {
 	}
 
 	txinfo, err := b.conn.GetTransactionInfoByID(txid)
-	if len(tx.RawData.Contract) > 0 && tx.RawData.Contract[0].Type == core.Transaction_Contract_TriggerSmartContract && err != nil {
+	if len(tx.RawData.Contract) > 0 && needsTransactionInfo(tx.RawData.Contract[0].Type) && err != nil {
 		return nil, err
 	}
 
@@ -307,6 +313,12 @@ func (b *TrxRPC) GetTransactionForMempool(txid string) (*bchain.Tx, error) {
 	return b.GetTransaction(txid)
 }
 
+// needsTransactionInfo returns true if the data of the contract are completed from the TransactionInfo,
+// the logs of smart contract calls and the address of a created smart contract
+func needsTransactionInfo(contractType core.Transaction_Contract_ContractType) bool {
+	return contractType == core.Transaction_Contract_TriggerSmartContract || contractType == core.Transaction_Contract_CreateSmartContract
+}
+
 func (b *TrxRPC) GetComplete(tx *core.Transaction, txinfo *core.TransactionInfo) (*trxCompleteTransaction, error) {
 	contractType := tx.RawData.Contract[0].Type
 	data, err := getContract(contractType, tx.RawData.Contract[0].Parameter)
@@ -348,6 +360,16 @@ func (b *TrxRPC) GetComplete(tx *core.Transaction, txinfo *core.TransactionInfo)
 			value.Address = value.Contract
 			res.Value = &value
 		}
+	} else if contractType == core.Transaction_Contract_CreateSmartContract {
+		// show the creation as a transfer from the owner to the created contract
+		if contract := getCreatedContract(txinfo); contract != "" {
+			if v, ok := data["OwnerAddress"]; ok && len(v.([]uint8)) > 0 {
+				value.From = hex.EncodeToString(v.([]byte))
+			}
+			value.To = contract
+			value.Address = value.To
+			res.Value = &value
+		}
 	}
 
 	return &res, nil
@@ -419,6 +441,134 @@ func (b *TrxRPC) SendRawTransaction(hex string) (string, error) {
 	return res.Txid, nil
 }
 
+// trxConstantContractResult is the response of /wallet/triggerconstantcontract,
+// the logs are returned only by the newer versions of the node
+type trxConstantContractResult struct {
+	Result struct {
+		Result  bool   `json:"result"`
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"result"`
+	EnergyUsed     uint64   `json:"energy_used"`
+	ConstantResult []string `json:"constant_result"`
+	Logs           []struct {
+		Address string   `json:"address"`
+		Topics  []string `json:"topics"`
+		Data    string   `json:"data"`
+	} `json:"logs"`
+	Transaction struct {
+		Ret []struct {
+			ContractRet string `json:"contractRet"`
+		} `json:"ret"`
+	} `json:"transaction"`
+}
+
+// TronTypeSimulateTransaction executes the unsigned call of a smart contract given by the parameters from, to, data and value
+// against the latest block by TriggerConstantContract, the addresses are in hex format, the value is the call value in sun
+func (b *TrxRPC) TronTypeSimulateTransaction(params map[string]interface{}) (*bchain.TransactionSimulation, error) {
+	from, _ := params["from"].(string)
+	to, _ := params["to"].(string)
+	data, _ := params["data"].(string)
+	data = strings.TrimPrefix(data, "0x")
+	for _, a := range []string{from, to} {
+		if _, err := b.Parser.GetAddrDescFromAddress(a); err != nil || len(a) != 2*TronTypeAddressDescriptorLen {
+			return nil, &bchain.InvalidParamsError{Message: "Invalid address " + a}
+		}
+	}
+	if _, err := hex.DecodeString(data); err != nil {
+		return nil, &bchain.InvalidParamsError{Message: "Invalid data " + data}
+	}
+	var callValue big.Int
+	if v, _ := params["value"].(string); v != "" {
+		if _, ok := callValue.SetString(v, 0); !ok || !callValue.IsInt64() || callValue.Sign() < 0 {
+			return nil, &bchain.InvalidParamsError{Message: "Invalid value " + v}
+		}
+	}
+	req := map[string]interface{}{
+		"owner_address":    from,
+		"contract_address": to,
+		"data":             data,
+		"call_value":       callValue.Int64(),
+		"visible":          false,
+	}
+	client := http.Client{Timeout: 25 * time.Second}
+	var res trxConstantContractResult
+	if err := b.PostCall(client, b.ChainConfig.RPCURL+"/wallet/triggerconstantcontract", req, &res); err != nil {
+		return nil, errors.Annotatef(err, "triggerconstantcontract")
+	}
+	return getSimulationFromConstantContract(from, to, data, &callValue, &res)
+}
+
+// getSimulationFromConstantContract returns the effect of the call of a smart contract from the result of TriggerConstantContract
+// the token transfers are taken from the logs if the node returns them, otherwise they are decoded from the call data
+func getSimulationFromConstantContract(from, to, data string, callValue *big.Int, res *trxConstantContractResult) (*bchain.TransactionSimulation, error) {
+	r := &bchain.TransactionSimulation{GasUsed: res.EnergyUsed}
+	var contractRet string
+	if len(res.Transaction.Ret) > 0 {
+		contractRet = res.Transaction.Ret[0].ContractRet
+	}
+	if !res.Result.Result || (contractRet != "" && contractRet != "SUCCESS") {
+		if len(res.ConstantResult) > 0 {
+			r.RevertReason = eth.RevertReasonFromOutput(res.ConstantResult[0])
+		}
+		if r.RevertReason == "" {
+			// the message is hex encoded
+			if m, err := hex.DecodeString(res.Result.Message); err == nil && len(m) > 0 {
+				r.RevertReason = string(m)
+			} else if res.Result.Message != "" {
+				r.RevertReason = res.Result.Message
+			} else if contractRet != "" {
+				r.RevertReason = contractRet
+			} else {
+				r.RevertReason = res.Result.Code
+			}
+		}
+		return r, nil
+	}
+	r.Success = true
+	if callValue.Sign() > 0 {
+		r.ValueTransfers = []bchain.EthereumInternalTransfer{{Type: bchain.CALL, From: from, To: to, Value: *callValue}}
+	}
+	if len(res.Logs) > 0 {
+		r.Traced = true
+		for _, l := range res.Logs {
+			if len(l.Topics) != 3 || l.Topics[0] != trc20TransferEventSignature || len(l.Topics[1]) != 64 || len(l.Topics[2]) != 64 {
+				continue
+			}
+			// the node returns the contract address without the 41 prefix
+			contract := l.Address
+			if len(contract) == 2*(TronTypeAddressDescriptorLen-1) {
+				contract = "41" + contract
+			}
+			t := bchain.Erc20Transfer{
+				Type:     bchain.FungibleToken,
+				Contract: contract,
+				From:     "41" + l.Topics[1][24:],
+				To:       "41" + l.Topics[2][24:],
+			}
+			if _, ok := t.Tokens.SetString(l.Data, 16); !ok {
+				return nil, errors.Errorf("Invalid transfer amount %v", l.Data)
+			}
+			r.TokenTransfers = append(r.TokenTransfers, t)
+		}
+		return r, nil
+	}
+	// transfer(address,uint256)
+	if len(data) == 8+128 && data[:8] == "a9059cbb" {
+		t := bchain.Erc20Transfer{
+			Type:     bchain.FungibleToken,
+			Contract: to,
+			From:     from,
+			To:       "41" + data[8+24:8+64],
+		}
+		if _, ok := t.Tokens.SetString(data[8+64:], 16); !ok {
+			return nil, errors.Errorf("Invalid transfer amount %v", data[8+64:])
+		}
+		r.TokenTransfers = append(r.TokenTransfers, t)
+	}
+	return r, nil
+}
+
 func safeDecodeResponse(body io.ReadCloser, res interface{}) (err error) {
 	var data []byte
 	defer func() {
@@ -1,6 +1,7 @@
 package bchain
 
 import (
+	"encoding/hex"
 	"math/big"
 	"time"
 
@@ -28,6 +29,8 @@ func NewMempoolBitcoinType(chain BlockChain, workers int, subworkers int) *Mempo
 			chain:        chain,
 			txEntries:    make(map[string]txEntry),
 			addrDescToTx: make(map[string][]Outpoint),
+			spentBy:      make(map[string]map[int32]string),
+			replacements: make(map[string][]MempoolReplacement),
 		},
 		chanTxid:      make(chan string, 1),
 		chanAddrIndex: make(chan txidio, 1),
@@ -45,11 +48,11 @@ func NewMempoolBitcoinType(chain BlockChain, workers int, subworkers int) *Mempo
 				}(j)
 			}
 			for txid := range m.chanTxid {
-				io, ok := m.getTxAddrs(txid, chanInput, chanResult)
+				tio, ok := m.getTxAddrs(txid, chanInput, chanResult)
 				if !ok {
-					io = []addrIndex{}
+					tio = txidio{txid: txid, io: []addrIndex{}}
 				}
-				m.chanAddrIndex <- txidio{txid, io}
+				m.chanAddrIndex <- tio
 			}
 		}(i)
 	}
@@ -99,11 +102,69 @@ func (m *MempoolBitcoinType) Notify(tx *Tx, height uint32) {
 	}
 }
 
-func (m *MempoolBitcoinType) getTxAddrs(txid string, chanInput chan chanInputPayload, chanResult chan *addrIndex) ([]addrIndex, bool) {
+// getTxVSize returns the virtual size of a serialized transaction, the witness data of segwit transactions are discounted
+func getTxVSize(b []byte) uint32 {
+	size := len(b)
+	// segwit transaction has marker 0x00 and flag 0x01 after the version
+	if size < 10 || b[4] != 0 || b[5] != 1 {
+		return uint32(size)
+	}
+	pos := 6
+	readVarInt := func() int {
+		if pos >= size {
+			return -1
+		}
+		v, l := 0, 0
+		switch b[pos] {
+		case 0xfd:
+			l = 2
+		case 0xfe:
+			l = 4
+		case 0xff:
+			l = 8
+		default:
+			pos++
+			return int(b[pos-1])
+		}
+		if pos+l >= size {
+			return -1
+		}
+		for i := l; i > 0; i-- {
+			v = v<<8 | int(b[pos+i])
+		}
+		pos += l + 1
+		if v < 0 || v > size {
+			return -1
+		}
+		return v
+	}
+	skipItems := func(fixedBefore, fixedAfter int) bool {
+		n := readVarInt()
+		for i := 0; i < n && pos < size; i++ {
+			pos += fixedBefore
+			l := readVarInt()
+			if l < 0 {
+				return false
+			}
+			pos += l + fixedAfter
+		}
+		return n >= 0 && pos <= size
+	}
+	// inputs (outpoint, script, sequence) and outputs (value, script)
+	if !skipItems(36, 4) || !skipItems(8, 0) || pos > size-4 {
+		return uint32(size)
+	}
+	// marker, flag and witnesses up to the locktime
+	witnessSize := size - 4 - pos + 2
+	weight := (size-witnessSize)*3 + size
+	return uint32((weight + 3) / 4)
+}
+
+func (m *MempoolBitcoinType) getTxAddrs(txid string, chanInput chan chanInputPayload, chanResult chan *addrIndex) (txidio, bool) {
 	tx, err := m.chain.GetTransactionForMempool(txid)
 	if err != nil {
 		glog.Error("cannot get transaction ", txid, ": ", err)
-		return nil, false
+		return txidio{}, false
 	}
 	glog.V(2).Info("mempool: gettxaddrs ", txid, ", ", len(tx.Vin), " inputs")
 	mtx := m.txToMempoolTx(tx)
@@ -122,11 +183,14 @@ func (m *MempoolBitcoinType) getTxAddrs(txid string, chanInput chan chanInputPay
 		}
 	}
 	dispatched := 0
+	missingInputs := false
+	inputs := make([]Outpoint, 0, len(tx.Vin))
 	for i := range tx.Vin {
 		input := &tx.Vin[i]
 		if input.Coinbase != "" {
 			continue
 		}
+		inputs = append(inputs, Outpoint{input.Txid, int32(input.Vout)})
 		payload := chanInputPayload{mtx, i}
 	loop:
 		for {
@@ -135,6 +199,8 @@ func (m *MempoolBitcoinType) getTxAddrs(txid string, chanInput chan chanInputPay
 			case ai := <-chanResult:
 				if ai != nil {
 					io = append(io, *ai)
+				} else {
+					missingInputs = true
 				}
 				dispatched--
 			// send input to be processed
@@ -148,12 +214,72 @@ func (m *MempoolBitcoinType) getTxAddrs(txid string, chanInput chan chanInputPay
 		ai := <-chanResult
 		if ai != nil {
 			io = append(io, *ai)
+		} else {
+			missingInputs = true
 		}
 	}
+	tio := txidio{txid: txid, io: io, inputs: inputs}
+	if !missingInputs {
+		tio.fee, tio.vsize = getTxFeeAndVSize(tx, mtx)
+	}
 	if m.OnNewTx != nil {
 		m.OnNewTx(mtx)
 	}
-	return io, true
+	return tio, true
+}
+
+// getTxFeeAndVSize returns the fee and the virtual size of the tx, vsize is 0 if they cannot be determined
+func getTxFeeAndVSize(tx *Tx, mtx *MempoolTx) (uint64, uint32) {
+	var in, out big.Int
+	for i := range mtx.Vin {
+		in.Add(&in, &mtx.Vin[i].ValueSat)
+	}
+	for i := range tx.Vout {
+		out.Add(&out, &tx.Vout[i].ValueSat)
+	}
+	fee := in.Sub(&in, &out)
+	if fee.Sign() < 0 || !fee.IsUint64() {
+		return 0, 0
+	}
+	b, err := hex.DecodeString(tx.Hex)
+	if err != nil || len(b) == 0 {
+		return 0, 0
+	}
+	return fee.Uint64(), getTxVSize(b)
+}
+
+// replacementsKeepSeconds is the time for which the replacements of the transactions are kept
+const replacementsKeepSeconds = 24 * 60 * 60
+
+type replacedTx struct {
+	replacement *MempoolReplacement
+	addrDescs   []AddressDescriptor
+}
+
+// registerInputs marks the inputs of a new transaction as spent by it and detects the replaced transactions,
+// i.e. the mempool transactions spending the same outpoints. The caller is responsible for locking!
+func (m *MempoolBitcoinType) registerInputs(txid string, inputs []Outpoint, txTime uint32) []replacedTx {
+	var replaced []replacedTx
+	for _, o := range inputs {
+		spent, found := m.spentBy[o.Txid]
+		if !found {
+			spent = make(map[int32]string)
+			m.spentBy[o.Txid] = spent
+		}
+		spending, found := spent[o.Vout]
+		if found && spending != txid {
+			if r, ok := m.addReplacement(spending, txid, txTime); ok {
+				glog.Info("mempool: tx ", spending, " replaced by ", txid)
+				var addrDescs []AddressDescriptor
+				if entry, exists := m.txEntries[spending]; exists {
+					addrDescs = entryAddrDescs(entry)
+				}
+				replaced = append(replaced, replacedTx{replacement: r, addrDescs: addrDescs})
+			}
+		}
+		spent[o.Vout] = txid
+	}
+	return replaced
 }
 
 // Resync gets mempool transactions and maps outputs to transactions.
@@ -167,12 +293,14 @@ func (m *MempoolBitcoinType) Resync() (int, error) {
 		return 0, err
 	}
 	glog.V(2).Info("mempool: resync ", len(txs), " txs")
-	onNewEntry := func(txid string, entry txEntry) {
-		if len(entry.addrIndexes) > 0 {
+	var replaced []replacedTx
+	onNewEntry := func(tio txidio, txTime uint32) {
+		if len(tio.io) > 0 {
 			m.mux.Lock()
-			m.txEntries[txid] = entry
-			for _, si := range entry.addrIndexes {
-				m.addrDescToTx[si.addrDesc] = append(m.addrDescToTx[si.addrDesc], Outpoint{txid, si.n})
+			replaced = append(replaced, m.registerInputs(tio.txid, tio.inputs, txTime)...)
+			m.txEntries[tio.txid] = txEntry{addrIndexes: tio.io, time: txTime, fee: tio.fee, vsize: tio.vsize, inputs: tio.inputs}
+			for _, si := range tio.io {
+				m.addrDescToTx[si.addrDesc] = append(m.addrDescToTx[si.addrDesc], Outpoint{tio.txid, si.n})
 			}
 			m.mux.Unlock()
 		}
@@ -190,7 +318,7 @@ func (m *MempoolBitcoinType) Resync() (int, error) {
 				select {
 				// store as many processed transactions as possible
 				case tio := <-m.chanAddrIndex:
-					onNewEntry(tio.txid, txEntry{tio.io, txTime})
+					onNewEntry(tio, txTime)
 					dispatched--
 				// send transaction to be processed
 				case m.chanTxid <- txid:
@@ -202,7 +330,7 @@ func (m *MempoolBitcoinType) Resync() (int, error) {
 	}
 	for i := 0; i < dispatched; i++ {
 		tio := <-m.chanAddrIndex
-		onNewEntry(tio.txid, txEntry{tio.io, txTime})
+		onNewEntry(tio, txTime)
 	}
 
 	for txid, entry := range m.txEntries {
@@ -212,6 +340,15 @@ func (m *MempoolBitcoinType) Resync() (int, error) {
 			m.mux.Unlock()
 		}
 	}
+	m.mux.Lock()
+	m.pruneReplacements(txTime - replacementsKeepSeconds)
+	m.mux.Unlock()
+	// notify about the replaced transactions after they were removed from the mempool
+	if m.OnReplacedTx != nil {
+		for i := range replaced {
+			m.OnReplacedTx(replaced[i].replacement, replaced[i].addrDescs)
+		}
+	}
 	glog.Info("mempool: resync finished in ", time.Since(start), ", ", len(m.txEntries), " transactions in mempool")
 	return len(m.txEntries), nil
 }
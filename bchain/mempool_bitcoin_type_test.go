@@ -0,0 +1,196 @@
+// +build unittest
+
+package bchain
+
+import (
+	"bytes"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/martinboehm/btcd/wire"
+)
+
+func serializeMsgTx(t *testing.T, tx *wire.MsgTx) []byte {
+	var b bytes.Buffer
+	if err := tx.Serialize(&b); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func Test_getTxVSize(t *testing.T) {
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{SignatureScript: make([]byte, 300), Sequence: 0xffffffff})
+	tx.AddTxIn(&wire.TxIn{Witness: wire.TxWitness{make([]byte, 72), make([]byte, 33)}, Sequence: 0xfffffffd})
+	tx.AddTxOut(&wire.TxOut{Value: 100000, PkScript: make([]byte, 22)})
+	tx.AddTxOut(&wire.TxOut{Value: 0, PkScript: make([]byte, 80)})
+	segwit := serializeMsgTx(t, tx)
+	want := uint32((tx.SerializeSizeStripped()*3 + tx.SerializeSize() + 3) / 4)
+	if got := getTxVSize(segwit); got != want {
+		t.Errorf("getTxVSize(segwit) = %v, want %v", got, want)
+	}
+	if got := getTxVSize(segwit[:40]); got != 40 {
+		t.Errorf("getTxVSize(truncated) = %v, want 40", got)
+	}
+	tx.TxIn[1].Witness = nil
+	legacy := serializeMsgTx(t, tx)
+	if got := getTxVSize(legacy); got != uint32(len(legacy)) {
+		t.Errorf("getTxVSize(legacy) = %v, want %v", got, len(legacy))
+	}
+}
+
+func Test_getTxFeeAndVSize(t *testing.T) {
+	tx := &Tx{
+		Hex:  "0100000001000000000000000000000000000000000000000000000000000000000000000000000000ffffffff0100000000000000000000000000",
+		Vout: []Vout{{ValueSat: *big.NewInt(9000)}, {ValueSat: *big.NewInt(500)}},
+	}
+	mtx := &MempoolTx{
+		Vin: []MempoolVin{{ValueSat: *big.NewInt(7000)}, {ValueSat: *big.NewInt(3000)}},
+	}
+	fee, vsize := getTxFeeAndVSize(tx, mtx)
+	if fee != 500 || vsize != uint32(len(tx.Hex)/2) {
+		t.Errorf("getTxFeeAndVSize() = %v, %v, want 500, %v", fee, vsize, len(tx.Hex)/2)
+	}
+	mtx.Vin[1].ValueSat = *big.NewInt(100)
+	if fee, vsize = getTxFeeAndVSize(tx, mtx); vsize != 0 {
+		t.Errorf("getTxFeeAndVSize() with negative fee = %v, %v, want vsize 0", fee, vsize)
+	}
+}
+
+func TestMempoolBitcoinType_replacements(t *testing.T) {
+	m := &MempoolBitcoinType{
+		BaseMempool: BaseMempool{
+			txEntries:    make(map[string]txEntry),
+			addrDescToTx: make(map[string][]Outpoint),
+			spentBy:      make(map[string]map[int32]string),
+			replacements: make(map[string][]MempoolReplacement),
+		},
+	}
+	add := func(txid string, addrDescs []string, inputs []Outpoint, time uint32) []replacedTx {
+		io := make([]addrIndex, len(addrDescs))
+		for i, ad := range addrDescs {
+			io[i] = addrIndex{ad, int32(i)}
+		}
+		replaced := m.registerInputs(txid, inputs, time)
+		m.txEntries[txid] = txEntry{addrIndexes: io, time: time, inputs: inputs}
+		return replaced
+	}
+	o1 := Outpoint{"prev1", 0}
+	o2 := Outpoint{"prev1", 1}
+	o3 := Outpoint{"prev2", 0}
+	if r := add("A", []string{"ad1", "ad2", "ad1"}, []Outpoint{o1, o2}, 1000); len(r) != 0 {
+		t.Fatalf("registerInputs(A) = %+v, want no replacements", r)
+	}
+	// B double spends both inputs of A, the replacement is reported once with unique addresses of A
+	r := add("B", []string{"ad3"}, []Outpoint{o2, o1, o3}, 1010)
+	if len(r) != 1 {
+		t.Fatalf("registerInputs(B) = %+v, want 1 replacement", r)
+	}
+	if want := (MempoolReplacement{Txid: "A", ReplacedBy: "B", Time: 1010}); *r[0].replacement != want {
+		t.Errorf("replacement = %+v, want %+v", *r[0].replacement, want)
+	}
+	if want := []AddressDescriptor{AddressDescriptor("ad1"), AddressDescriptor("ad2")}; !reflect.DeepEqual(r[0].addrDescs, want) {
+		t.Errorf("addrDescs = %v, want %v", r[0].addrDescs, want)
+	}
+	m.removeEntryFromMempool("A", m.txEntries["A"])
+	if len(m.spentBy) != 2 || len(m.spentBy["prev1"]) != 2 || m.spentBy[o1.Txid][o1.Vout] != "B" {
+		t.Errorf("spentBy after removal of A = %v", m.spentBy)
+	}
+	// C replaces B
+	if r = add("C", []string{"ad3"}, []Outpoint{o3}, 1020); len(r) != 1 || r[0].replacement.Txid != "B" {
+		t.Fatalf("registerInputs(C) = %+v, want replacement of B", r)
+	}
+	want := []MempoolReplacement{{Txid: "A", ReplacedBy: "B", Time: 1010}, {Txid: "B", ReplacedBy: "C", Time: 1020}}
+	if got := m.GetTxReplacements("B"); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetTxReplacements(B) = %+v, want %+v", got, want)
+	}
+	if got := m.GetTxReplacements("D"); got != nil {
+		t.Errorf("GetTxReplacements(D) = %+v, want nil", got)
+	}
+	m.pruneReplacements(1015)
+	if got := m.GetTxReplacements("A"); got != nil {
+		t.Errorf("GetTxReplacements(A) after prune = %+v, want nil", got)
+	}
+	if got, want := m.GetTxReplacements("B"), want[1:]; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetTxReplacements(B) after prune = %+v, want %+v", got, want)
+	}
+}
+
+func TestBaseMempool_GetTxPackage(t *testing.T) {
+	m := &MempoolBitcoinType{
+		BaseMempool: BaseMempool{
+			txEntries:    make(map[string]txEntry),
+			addrDescToTx: make(map[string][]Outpoint),
+			spentBy:      make(map[string]map[int32]string),
+			replacements: make(map[string][]MempoolReplacement),
+		},
+	}
+	add := func(txid string, outputs int, inputs []Outpoint, fee uint64, vsize uint32) {
+		io := make([]addrIndex, 0, outputs+len(inputs))
+		for i := 0; i < outputs; i++ {
+			io = append(io, addrIndex{"ad", int32(i)})
+		}
+		for _, o := range inputs {
+			io = append(io, addrIndex{"ad", ^o.Vout})
+		}
+		m.registerInputs(txid, inputs, 1000)
+		m.txEntries[txid] = txEntry{addrIndexes: io, time: 1000, fee: fee, vsize: vsize, inputs: inputs}
+	}
+	// P <- C1 <- G, P <- C2, U has unknown fee and spends confirmed output
+	add("P", 2, []Outpoint{{"confirmed", 0}}, 100, 100)
+	add("C1", 1, []Outpoint{{"P", 0}}, 1900, 100)
+	add("C2", 1, []Outpoint{{"P", 1}, {"confirmed", 1}}, 100, 100)
+	add("G", 1, []Outpoint{{"C1", 0}}, 200, 100)
+	add("U", 1, []Outpoint{{"confirmed", 2}}, 0, 0)
+	// D spends an output of Q without address
+	add("Q", 1, []Outpoint{{"confirmed", 3}}, 100, 100)
+	add("D", 1, []Outpoint{{"Q", 3}}, 300, 100)
+	tests := []struct {
+		txid string
+		want *MempoolTxPackage
+	}{
+		{
+			txid: "P",
+			want: &MempoolTxPackage{
+				Descendants:      []MempoolFeeEntry{{"C1", 1900, 100}, {"C2", 100, 100}, {"G", 200, 100}},
+				EffectiveFeeRate: 10,
+			},
+		},
+		{
+			txid: "G",
+			want: &MempoolTxPackage{
+				Ancestors:        []MempoolFeeEntry{{"C1", 1900, 100}, {"P", 100, 100}},
+				EffectiveFeeRate: 2200.0 / 300,
+			},
+		},
+		{
+			txid: "C2",
+			want: &MempoolTxPackage{
+				Ancestors:        []MempoolFeeEntry{{"P", 100, 100}},
+				EffectiveFeeRate: 1,
+			},
+		},
+		{
+			txid: "U",
+			want: &MempoolTxPackage{},
+		},
+		{
+			txid: "Q",
+			want: &MempoolTxPackage{
+				Descendants:      []MempoolFeeEntry{{"D", 300, 100}},
+				EffectiveFeeRate: 2,
+			},
+		},
+		{
+			txid: "X",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.txid, func(t *testing.T) {
+			if got := m.GetTxPackage(tt.txid); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetTxPackage() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -24,6 +24,8 @@ func NewMempoolEthereumType(chain BlockChain, mempoolTxTimeoutHours int, queryBa
 			chain:        chain,
 			txEntries:    make(map[string]txEntry),
 			addrDescToTx: make(map[string][]Outpoint),
+			replacements: make(map[string][]MempoolReplacement),
+			senderNonces: make(map[string]map[uint64]string),
 		},
 		mempoolTimeoutTime:   mempoolTimeoutTime,
 		queryBackendOnResync: queryBackendOnResync,
@@ -45,6 +47,23 @@ func appendAddress(io []addrIndex, i int32, a string, parser BlockChainParser) (
 	return io, addrDesc
 }
 
+// getSenderNonce returns the address descriptor of the sender of the transaction and its nonce
+func getSenderNonce(tx *Tx, parser BlockChainParser) (string, uint64, bool) {
+	if len(tx.Vin) == 0 || len(tx.Vin[0].Addresses) == 0 {
+		return "", 0, false
+	}
+	sender, err := parser.GetAddrDescFromAddress(tx.Vin[0].Addresses[0])
+	if err != nil || len(sender) == 0 {
+		return "", 0, false
+	}
+	nonce, err := parser.EthereumTypeGetNonceFromTx(tx)
+	if err != nil {
+		glog.Error("GetNonceFromTx for tx ", tx.Txid, ", ", err)
+		return "", 0, false
+	}
+	return string(sender), nonce, true
+}
+
 func (m *MempoolEthereumType) Notify(tx *Tx, txid string, height uint32) {
 	mtx := m.txToMempoolTx(tx)
 	mtx.Blockheight = height
@@ -143,10 +162,63 @@ func (m *MempoolEthereumType) createTxEntry(txid string, txTime uint32) (txEntry
 	if m.OnNewTx != nil {
 		m.OnNewTx(mtx)
 	}
-	return txEntry{addrIndexes: addrIndexes, time: txTime}, true
+	entry := txEntry{addrIndexes: addrIndexes, time: txTime}
+	entry.sender, entry.nonce, _ = getSenderNonce(tx, parser)
+	return entry, true
+}
+
+// replaceNonce removes the mempool transaction of the sender with the same nonce as txid, which can never be confirmed,
+// and records its replacement by txid. The caller is responsible for locking!
+func (m *MempoolEthereumType) replaceNonce(txid string, sender string, nonce uint64, txTime uint32) []replacedTx {
+	replacedTxid, found := m.senderNonces[sender][nonce]
+	if !found || replacedTxid == txid {
+		return nil
+	}
+	entry := m.txEntries[replacedTxid]
+	m.removeEntryFromMempool(replacedTxid, entry)
+	r, ok := m.addReplacement(replacedTxid, txid, txTime)
+	if !ok {
+		return nil
+	}
+	glog.Info("mempool: tx ", replacedTxid, " replaced by ", txid, " with the same nonce")
+	return []replacedTx{{replacement: r, addrDescs: entryAddrDescs(entry)}}
+}
+
+// registerNonce registers the nonce of the sender of a new transaction and detects the replaced transaction,
+// i.e. the mempool transaction of the sender with the same nonce. The caller is responsible for locking!
+func (m *MempoolEthereumType) registerNonce(txid string, entry *txEntry) []replacedTx {
+	if entry.sender == "" {
+		return nil
+	}
+	replaced := m.replaceNonce(txid, entry.sender, entry.nonce, entry.time)
+	nonces, found := m.senderNonces[entry.sender]
+	if !found {
+		nonces = make(map[uint64]string)
+		m.senderNonces[entry.sender] = nonces
+	}
+	nonces[entry.nonce] = txid
+	return replaced
 }
 
-// Resync ethereum type removes timed out transactions and returns number of transactions in mempool.
+// notifyReplaced notifies about the replaced and dropped transactions, it must be called without the lock
+func (m *MempoolEthereumType) notifyReplaced(replaced []replacedTx) {
+	if m.OnReplacedTx != nil {
+		for i := range replaced {
+			m.OnReplacedTx(replaced[i].replacement, replaced[i].addrDescs)
+		}
+	}
+}
+
+// droppedTx returns the notification about the transaction dropped from the mempool without a replacement
+func droppedTx(txid string, entry txEntry, txTime uint32) replacedTx {
+	return replacedTx{
+		replacement: &MempoolReplacement{Txid: txid, Time: txTime},
+		addrDescs:   entryAddrDescs(entry),
+	}
+}
+
+// Resync ethereum type removes timed out transactions and transactions no longer known to the backend
+// and returns number of transactions in mempool.
 // Transactions are added/removed by AddTransactionToMempool/RemoveTransactionFromMempool methods
 func (m *MempoolEthereumType) Resync() (int, error) {
 	if m.queryBackendOnResync {
@@ -158,6 +230,8 @@ func (m *MempoolEthereumType) Resync() (int, error) {
 			m.AddTransactionToMempool(txid)
 		}
 	}
+	m.checkMissingTransactions()
+	var dropped []replacedTx
 	m.mux.Lock()
 	entries := len(m.txEntries)
 	now := time.Now()
@@ -166,14 +240,17 @@ func (m *MempoolEthereumType) Resync() (int, error) {
 		for txid, entry := range m.txEntries {
 			if time.Unix(int64(entry.time), 0).Before(threshold) {
 				m.removeEntryFromMempool(txid, entry)
+				dropped = append(dropped, droppedTx(txid, entry, uint32(now.Unix())))
 			}
 		}
 		removed := entries - len(m.txEntries)
 		entries = len(m.txEntries)
 		glog.Info("Mempool: cleanup, removed ", removed, " transactions from mempool")
+		m.pruneReplacements(uint32(now.Unix()) - replacementsKeepSeconds)
 		m.nextTimeoutRun = now.Add(mempoolTimeoutRunPeriod)
 	}
 	m.mux.Unlock()
+	m.notifyReplaced(dropped)
 	glog.Info("Mempool: resync ", entries, " transactions in mempool")
 	return entries, nil
 }
@@ -192,11 +269,13 @@ func (m *MempoolEthereumType) AddTransactionToMempool(txid string) {
 			return
 		}
 		m.mux.Lock()
+		replaced := m.registerNonce(txid, &entry)
 		m.txEntries[txid] = entry
 		for _, si := range entry.addrIndexes {
 			m.addrDescToTx[si.addrDesc] = append(m.addrDescToTx[si.addrDesc], Outpoint{txid, si.n})
 		}
 		m.mux.Unlock()
+		m.notifyReplaced(replaced)
 	}
 }
 
@@ -212,3 +291,82 @@ func (m *MempoolEthereumType) RemoveTransactionFromMempool(txid string) {
 	}
 	m.mux.Unlock()
 }
+
+// RemoveConfirmedTransactionFromMempool removes the transaction confirmed in a block from mempool,
+// the mempool transaction of the same sender with the same nonce can never be confirmed and is removed as replaced by the confirmed transaction
+func (m *MempoolEthereumType) RemoveConfirmedTransactionFromMempool(tx *Tx) {
+	sender, nonce, hasNonce := getSenderNonce(tx, m.chain.GetChainParser())
+	var replaced []replacedTx
+	m.mux.Lock()
+	entry, exists := m.txEntries[tx.Txid]
+	if glog.V(1) {
+		glog.Info("RemoveConfirmedTransactionFromMempool ", tx.Txid, ", existed ", exists)
+	}
+	if exists {
+		m.removeEntryFromMempool(tx.Txid, entry)
+	}
+	if hasNonce {
+		replaced = m.replaceNonce(tx.Txid, sender, nonce, uint32(time.Now().Unix()))
+	}
+	m.mux.Unlock()
+	m.notifyReplaced(replaced)
+}
+
+// MarkTransactionMissing marks the mempool transaction not returned by the backend,
+// the backend may miss the transaction only temporarily, therefore it is checked again in the next Resync
+func (m *MempoolEthereumType) MarkTransactionMissing(txid string) {
+	m.mux.Lock()
+	entry, exists := m.txEntries[txid]
+	if glog.V(1) {
+		glog.Info("MarkTransactionMissing ", txid, ", existed ", exists)
+	}
+	if exists {
+		entry.missing = true
+		m.txEntries[txid] = entry
+	}
+	m.mux.Unlock()
+}
+
+// checkMissingTransactions queries the backend again for the transactions marked as missing,
+// the transactions still not known to the backend are dropped from mempool
+func (m *MempoolEthereumType) checkMissingTransactions() {
+	var missing []string
+	m.mux.Lock()
+	for txid, entry := range m.txEntries {
+		if entry.missing {
+			missing = append(missing, txid)
+		}
+	}
+	m.mux.Unlock()
+	for _, txid := range missing {
+		_, err := m.chain.GetTransactionForMempool(txid)
+		if err == ErrTxNotFound {
+			m.DropTransactionFromMempool(txid)
+		} else if err == nil {
+			m.mux.Lock()
+			if entry, exists := m.txEntries[txid]; exists {
+				entry.missing = false
+				m.txEntries[txid] = entry
+			}
+			m.mux.Unlock()
+		} else {
+			glog.Warning("cannot get transaction ", txid, ": ", err)
+		}
+	}
+}
+
+// DropTransactionFromMempool removes transaction no longer known to the backend from mempool and notifies about it
+func (m *MempoolEthereumType) DropTransactionFromMempool(txid string) {
+	m.mux.Lock()
+	entry, exists := m.txEntries[txid]
+	if glog.V(1) {
+		glog.Info("DropTransactionFromMempool ", txid, ", existed ", exists)
+	}
+	if exists {
+		m.removeEntryFromMempool(txid, entry)
+	}
+	m.mux.Unlock()
+	if exists {
+		m.notifyReplaced([]replacedTx{droppedTx(txid, entry, uint32(time.Now().Unix()))})
+	}
+}
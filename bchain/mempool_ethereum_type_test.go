@@ -0,0 +1,127 @@
+// +build unittest
+
+package bchain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMempoolEthereumType_nonces(t *testing.T) {
+	m := &MempoolEthereumType{
+		BaseMempool: BaseMempool{
+			txEntries:    make(map[string]txEntry),
+			addrDescToTx: make(map[string][]Outpoint),
+			replacements: make(map[string][]MempoolReplacement),
+			senderNonces: make(map[string]map[uint64]string),
+		},
+	}
+	var notified []MempoolReplacement
+	m.OnReplacedTx = func(r *MempoolReplacement, addrDescs []AddressDescriptor) {
+		notified = append(notified, *r)
+	}
+	add := func(txid string, sender string, nonce uint64, time uint32) []replacedTx {
+		entry := txEntry{addrIndexes: []addrIndex{{sender, ^int32(0)}, {"to", 0}}, time: time, sender: sender, nonce: nonce}
+		replaced := m.registerNonce(txid, &entry)
+		m.txEntries[txid] = entry
+		return replaced
+	}
+	if r := add("A", "s1", 5, 1000); len(r) != 0 {
+		t.Fatalf("registerNonce(A) = %+v, want no replacements", r)
+	}
+	add("B", "s1", 6, 1001)
+	add("C", "s2", 5, 1002)
+	want := []MempoolNonceEntry{{Txid: "A", Nonce: 5, Time: 1000}, {Txid: "B", Nonce: 6, Time: 1001}}
+	if got := m.GetAddrDescNonces(AddressDescriptor("s1")); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetAddrDescNonces(s1) = %+v, want %+v", got, want)
+	}
+	// D has the same nonce as A and replaces it
+	r := add("D", "s1", 5, 1010)
+	if len(r) != 1 {
+		t.Fatalf("registerNonce(D) = %+v, want 1 replacement", r)
+	}
+	if want := (MempoolReplacement{Txid: "A", ReplacedBy: "D", Time: 1010}); *r[0].replacement != want {
+		t.Errorf("replacement = %+v, want %+v", *r[0].replacement, want)
+	}
+	if want := []AddressDescriptor{AddressDescriptor("s1"), AddressDescriptor("to")}; !reflect.DeepEqual(r[0].addrDescs, want) {
+		t.Errorf("addrDescs = %v, want %v", r[0].addrDescs, want)
+	}
+	if _, found := m.txEntries["A"]; found {
+		t.Error("replaced tx A was not removed from mempool")
+	}
+	want = []MempoolNonceEntry{{Txid: "D", Nonce: 5, Time: 1010}, {Txid: "B", Nonce: 6, Time: 1001}}
+	if got := m.GetAddrDescNonces(AddressDescriptor("s1")); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetAddrDescNonces(s1) after replacement = %+v, want %+v", got, want)
+	}
+	// a tx with the same nonce as B confirmed in a block replaces B
+	if r = m.replaceNonce("E", "s1", 6, 1020); len(r) != 1 || r[0].replacement.Txid != "B" || r[0].replacement.ReplacedBy != "E" {
+		t.Fatalf("replaceNonce(E) = %+v, want replacement of B", r)
+	}
+	if got := m.GetTxReplacements("E"); len(got) != 1 || got[0].Txid != "B" {
+		t.Errorf("GetTxReplacements(E) = %+v", got)
+	}
+	// dropped tx is notified without ReplacedBy and its nonce is released
+	m.DropTransactionFromMempool("C")
+	if want := []MempoolReplacement{{Txid: "C", Time: notified[0].Time}}; !reflect.DeepEqual(notified, want) {
+		t.Errorf("notified = %+v, want %+v", notified, want)
+	}
+	if got := m.GetAddrDescNonces(AddressDescriptor("s2")); got != nil {
+		t.Errorf("GetAddrDescNonces(s2) after drop = %+v, want nil", got)
+	}
+	if len(m.senderNonces) != 1 {
+		t.Errorf("senderNonces = %+v, want only s1", m.senderNonces)
+	}
+}
+
+// missingTestChain returns the transactions from txs, the other transactions are not found
+type missingTestChain struct {
+	BlockChain
+	txs map[string]bool
+}
+
+func (c *missingTestChain) GetTransactionForMempool(txid string) (*Tx, error) {
+	if c.txs[txid] {
+		return &Tx{Txid: txid}, nil
+	}
+	return nil, ErrTxNotFound
+}
+
+func TestMempoolEthereumType_missing(t *testing.T) {
+	chain := &missingTestChain{txs: map[string]bool{"A": true}}
+	m := &MempoolEthereumType{
+		BaseMempool: BaseMempool{
+			chain:        chain,
+			txEntries:    make(map[string]txEntry),
+			addrDescToTx: make(map[string][]Outpoint),
+			replacements: make(map[string][]MempoolReplacement),
+			senderNonces: make(map[string]map[uint64]string),
+		},
+		nextTimeoutRun: time.Now().Add(time.Hour),
+	}
+	var notified []MempoolReplacement
+	m.OnReplacedTx = func(r *MempoolReplacement, addrDescs []AddressDescriptor) {
+		notified = append(notified, *r)
+	}
+	for _, txid := range []string{"A", "B"} {
+		m.txEntries[txid] = txEntry{addrIndexes: []addrIndex{{"s", ^int32(0)}}, time: 1000}
+		m.addrDescToTx["s"] = append(m.addrDescToTx["s"], Outpoint{txid, ^int32(0)})
+	}
+	// a single miss of the backend does not drop the txs
+	m.MarkTransactionMissing("A")
+	m.MarkTransactionMissing("B")
+	if len(m.txEntries) != 2 || len(notified) != 0 {
+		t.Fatalf("txs dropped after a single miss, entries %+v, notified %+v", m.txEntries, notified)
+	}
+	// the resync finds A again and drops B which is still missing
+	n, err := m.Resync()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 || m.txEntries["A"].missing {
+		t.Errorf("Resync() = %d, entries %+v, want only A not missing", n, m.txEntries)
+	}
+	if len(notified) != 1 || notified[0].Txid != "B" || notified[0].ReplacedBy != "" {
+		t.Errorf("notified = %+v, want dropped B", notified)
+	}
+}
@@ -90,6 +90,7 @@ type Tx struct {
 	Confirmations    uint32      `json:"confirmations,omitempty"`
 	Time             int64       `json:"time,omitempty"`
 	Blocktime        int64       `json:"blocktime,omitempty"`
+	VSize            int64       `json:"vsize,omitempty"`
 	CoinSpecificData interface{} `json:"-"`
 }
 
@@ -188,6 +189,16 @@ func (e *RPCError) Error() string {
 	return fmt.Sprintf("%d: %s", e.Code, e.Message)
 }
 
+// InvalidParamsError is returned if the parameters of a request are not valid or were refused by the backend,
+// unlike the other errors of the backend, its message can be returned to the user
+type InvalidParamsError struct {
+	Message string
+}
+
+func (e *InvalidParamsError) Error() string {
+	return e.Message
+}
+
 // AddressDescriptor is an opaque type obtained by parser.GetAddrDesc* methods
 type AddressDescriptor []byte
 
@@ -220,12 +231,109 @@ type Trc20Contract struct {
 	Decimals int    `json:"decimals"`
 }
 
-// Erc20Transfer contains a single ERC20 token transfer
+// TokenType is the standard of the token transferred by a contract
+type TokenType int
+
+const (
+	// FungibleToken is ERC20 token
+	FungibleToken TokenType = iota
+	// NonFungibleToken is ERC721 token
+	NonFungibleToken
+	// MultiToken is ERC1155 token
+	MultiToken
+)
+
+// MultiTokenValue is the id and the amount of a token transferred by an ERC1155 transfer
+type MultiTokenValue struct {
+	Id    big.Int
+	Value big.Int
+}
+
+// Erc20Transfer contains a single token transfer of ERC20, ERC721 or ERC1155 contract
+// Tokens is the amount of ERC20 transfer or the id of ERC721 token
 type Erc20Transfer struct {
+	Type             TokenType
+	Contract         string
+	From             string
+	To               string
+	Tokens           big.Int
+	MultiTokenValues []MultiTokenValue
+}
+
+// EthereumInternalTransactionType is the type of the call, which moved the value inside of a transaction
+type EthereumInternalTransactionType int
+
+const (
+	// CALL is a transfer of value by a call or by a value transfer to other address
+	CALL EthereumInternalTransactionType = iota
+	// CREATE is a transfer of value to a contract created by the transaction
+	CREATE
+	// SELFDESTRUCT is a transfer of the balance of a destroyed contract
+	SELFDESTRUCT
+)
+
+// EthereumInternalTransfer is a transfer of value done by a contract call inside of a transaction
+type EthereumInternalTransfer struct {
+	Type  EthereumInternalTransactionType
+	From  string
+	To    string
+	Value big.Int
+}
+
+// ContractCreation is a contract deployed by a transaction
+type ContractCreation struct {
+	Contract string
+	// Creator is the sender of the transaction or the contract, which deployed the contract
+	Creator string
+}
+
+// TokenApproval is an allowance set by the ERC20 or TRC20 Approval event,
+// the spender can transfer up to Value tokens of the contract from the owner
+type TokenApproval struct {
 	Contract string
-	From     string
-	To       string
-	Tokens   big.Int
+	Owner    string
+	Spender  string
+	Value    big.Int
+}
+
+// TransactionSimulation is the expected effect of an unsigned transaction executed against the latest block
+type TransactionSimulation struct {
+	Success bool
+	// RevertReason is the reason returned by the reverted contract or the error of the execution
+	RevertReason string
+	// GasUsed is the gas (Ethereum) or the energy (Tron) consumed by the execution
+	GasUsed uint64
+	// ValueTransfers are the transfers of the coin done by the transaction and by its internal calls
+	ValueTransfers []EthereumInternalTransfer
+	TokenTransfers []Erc20Transfer
+	// Traced is set if the transfers were taken from the trace of the execution,
+	// otherwise only the transfers decoded from the call data of the transaction are returned
+	Traced bool
+}
+
+// Eip1559Fee is the fee of an EIP-1559 transaction suggested for a priority level
+type Eip1559Fee struct {
+	MaxFeePerGas         big.Int
+	MaxPriorityFeePerGas big.Int
+}
+
+// Eip1559Fees contains the base fee of the next block and the fees of EIP-1559 transactions suggested for several priority levels
+type Eip1559Fees struct {
+	BaseFeePerGas big.Int
+	Low           Eip1559Fee
+	Medium        Eip1559Fee
+	High          Eip1559Fee
+}
+
+// ForBlocks returns the priority level of the fee suitable for the confirmation within the given number of blocks
+func (f *Eip1559Fees) ForBlocks(blocks int) *Eip1559Fee {
+	if blocks <= 2 {
+		return &f.High
+	}
+	if blocks <= 6 {
+		return &f.Medium
+	}
+	return &f.Low
 }
 
 type Trc20Transfer struct {
@@ -245,15 +353,57 @@ type MempoolTxidEntry struct {
 // MempoolTxidEntries is array of MempoolTxidEntry
 type MempoolTxidEntries []MempoolTxidEntry
 
+// MempoolFeeEntry contains the fee and the virtual size of a mempool transaction
+type MempoolFeeEntry struct {
+	Txid  string
+	Fee   uint64
+	VSize uint32
+}
+
+// MempoolTxPackage contains the unconfirmed ancestors and descendants of a mempool transaction
+type MempoolTxPackage struct {
+	Ancestors   []MempoolFeeEntry
+	Descendants []MempoolFeeEntry
+	// EffectiveFeeRate is the fee rate in satoshi per vbyte of the best package of ancestors, with which the transaction can be mined
+	// it is 0 if the fee of the transaction is not known
+	EffectiveFeeRate float64
+}
+
+// MempoolReplacement links a mempool transaction with the conflicting transaction which replaced it
+// ReplacedBy is empty in the notification about a transaction dropped from the mempool without a replacement
+type MempoolReplacement struct {
+	Txid       string
+	ReplacedBy string
+	Time       uint32
+}
+
+// MempoolNonceEntry is a mempool transaction of an EthereumType chain with the nonce of its sender
+type MempoolNonceEntry struct {
+	Txid  string
+	Nonce uint64
+	Time  uint32
+}
+
 // OnNewBlockFunc is used to send notification about a new block
 type OnNewBlockFunc func(hash string, height uint32)
 
+// OnDisconnectBlocksFunc is used to send notification about blocks removed from the chain by a reorg
+type OnDisconnectBlocksFunc func(lower uint32, higher uint32)
+
 // OnNewTxAddrFunc is used to send notification about a new transaction/address
 type OnNewTxAddrFunc func(tx *Tx, desc AddressDescriptor)
 
 // OnNewTxFunc is used to send notification about a new transaction/address
 type OnNewTxFunc func(tx *MempoolTx)
 
+// OnReplacedTxFunc is used to send notification about a mempool transaction replaced by a conflicting transaction
+// or dropped from the mempool (EthereumType only)
+// addrDescs are the address descriptors of the inputs and outputs of the replaced transaction
+type OnReplacedTxFunc func(replacement *MempoolReplacement, addrDescs []AddressDescriptor)
+
+// OnMempoolResyncFunc is used to send notification about a finished synchronization of mempool
+type OnMempoolResyncFunc func()
+
 // AddrDescForOutpointFunc returns address descriptor and value for given outpoint or nil if outpoint not found
 type AddrDescForOutpointFunc func(outpoint Outpoint) (AddressDescriptor, *big.Int)
 
@@ -265,7 +415,7 @@ type BlockChain interface {
 	// create mempool but do not initialize it
 	CreateMempool(BlockChain) (Mempool, error)
 	// initialize mempool, create ZeroMQ (or other) subscription
-	InitializeMempool(AddrDescForOutpointFunc, OnNewTxAddrFunc, OnNewTxFunc) error
+	InitializeMempool(AddrDescForOutpointFunc, OnNewTxAddrFunc, OnNewTxFunc, OnReplacedTxFunc) error
 	// shutdown mempool, ZeroMQ and block chain connections
 	Shutdown(ctx context.Context) error
 	// chain info
@@ -297,11 +447,14 @@ type BlockChain interface {
 	EthereumTypeEstimateGas(params map[string]interface{}) (uint64, error)
 	EthereumTypeGetErc20ContractInfo(contractDesc AddressDescriptor) (*Erc20Contract, error)
 	EthereumTypeGetErc20ContractBalance(addrDesc, contractDesc AddressDescriptor) (*big.Int, error)
+	EthereumTypeGetEip1559Fees() (*Eip1559Fees, error)
+	EthereumTypeSimulateTransaction(params map[string]interface{}) (*TransactionSimulation, error)
 
 	TronTypeGetBalance(addrDesc AddressDescriptor) (*big.Int, error)
 	TronTypeGetTrc20ContractInfo(contractDesc AddressDescriptor) (*Trc20Contract, error)
 	TronTypeGetTrc20ContractBalance(addrDesc, contractDesc AddressDescriptor) (*big.Int, error)
 	TronTypeGetTransactionNotify(tx *Tx) bool
+	TronTypeSimulateTransaction(params map[string]interface{}) (*TransactionSimulation, error)
 
 	Reconnect(url string) error
 }
@@ -347,7 +500,15 @@ type BlockChainParser interface {
 	DeriveAddressDescriptorsFromTo(xpub string, change uint32, fromIndex uint32, toIndex uint32) ([]AddressDescriptor, error)
 	// EthereumType specific
 	EthereumTypeGetErc20FromTx(tx *Tx) ([]Erc20Transfer, error)
+	EthereumTypeGetInternalTransfersFromTx(tx *Tx) ([]EthereumInternalTransfer, error)
+	EthereumTypeGetContractCreationsFromTx(tx *Tx) ([]ContractCreation, error)
+	EthereumTypeGetTokenApprovalsFromTx(tx *Tx) ([]TokenApproval, error)
+	EthereumTypeGetNonceFromTx(tx *Tx) (uint64, error)
 	TronTypeGetTrc20FromTx(tx *Tx) ([]Trc20Transfer, error)
+	TronTypeGetContractType(tx *MempoolTx) (string, error)
+	TronTypeGetContractTypes() []string
+	TronTypeGetContractCreationsFromTx(tx *Tx) ([]ContractCreation, error)
+	TronTypeGetTokenApprovalsFromTx(tx *Tx) ([]TokenApproval, error)
 }
 
 // Mempool defines common interface to mempool
@@ -356,5 +517,9 @@ type Mempool interface {
 	GetTransactions(address string) ([]Outpoint, error)
 	GetAddrDescTransactions(addrDesc AddressDescriptor) ([]Outpoint, error)
 	GetAllEntries() MempoolTxidEntries
+	GetAllFeeEntries() []MempoolFeeEntry
 	GetTransactionTime(txid string) uint32
+	GetTxReplacements(txid string) []MempoolReplacement
+	GetTxPackage(txid string) *MempoolTxPackage
+	GetAddrDescNonces(addrDesc AddressDescriptor) []MempoolNonceEntry
 }
@@ -26,6 +26,7 @@ import (
 	"github.com/trezor/blockbook/db"
 	"github.com/trezor/blockbook/fiat"
 	"github.com/trezor/blockbook/server"
+	"google.golang.org/grpc"
 )
 
 // debounce too close requests for resync
@@ -67,6 +68,14 @@ var (
 
 	publicBinding = flag.String("public", "", "public http server binding [address]:port[/path] (default no public server)")
 
+	enableWebhooks = flag.Bool("webhooks", false, "enable webhook notifications for the watchlist managed by the internal server")
+
+	grpcBinding = flag.String("grpc", "", "gRPC server binding [address]:port (default no gRPC server)")
+
+	apiKeysConfig = flag.String("apikeys", "", "path to the json file with API keys and rate limits of the public interface (default no rate limiting)")
+
+	contractOverridesConfig = flag.String("contractoverrides", "", "path to the json file with operator overrides of the contract metadata (default no overrides)")
+
 	certFiles = flag.String("certfile", "", "to enable SSL specify path to certificate files without extension, expecting <certfile>.crt and <certfile>.key (default no SSL)")
 
 	explorerURL = flag.String("explorer", "", "address of blockchain explorer")
@@ -74,11 +83,14 @@ var (
 	noTxCache = flag.Bool("notxcache", false, "disable tx cache")
 
 	enableSubNewTx = flag.Bool("enablesubnewtx", false, "enable support for subscribing to all new transactions")
+	wsSessionTTL   = flag.Int("wssessionttl", 0, "time in seconds for which the subscriptions and missed notifications of a disconnected websocket session are kept, 0 disables resumable sessions")
 
 	computeColumnStats  = flag.Bool("computedbstats", false, "compute column stats and exit")
 	computeFeeStatsFlag = flag.Bool("computefeestats", false, "compute fee stats for blocks in blockheight-blockuntil range and exit")
 	dbStatsPeriodHours  = flag.Int("dbstatsperiod", 24, "period of db stats collection in hours, 0 disables stats collection")
 
+	contractRefreshPeriodHours = flag.Int("contractrefreshperiod", 24, "period of refresh of the stored contract metadata in hours, 0 disables the refresh")
+
 	// resync index at least each resyncIndexPeriodMs (could be more often if invoked by message from ZeroMQ)
 	resyncIndexPeriodMs = flag.Int("resyncindexperiod", 935093, "resync index period in milliseconds")
 
@@ -101,8 +113,11 @@ var (
 	syncWorker                    *db.SyncWorker
 	internalState                 *common.InternalState
 	callbacksOnNewBlock           []bchain.OnNewBlockFunc
+	callbacksOnDisconnectBlocks   []bchain.OnDisconnectBlocksFunc
+	callbacksOnMempoolResync      []bchain.OnMempoolResyncFunc
 	callbacksOnNewTxAddr          []bchain.OnNewTxAddrFunc
 	callbacksOnNewTx              []bchain.OnNewTxFunc
+	callbacksOnReplacedTx         []bchain.OnReplacedTxFunc
 	callbacksOnNewFiatRatesTicker []fiat.OnNewFiatRatesTicker
 	chanOsSignal                  chan os.Signal
 	inShutdown                    int32
@@ -175,6 +190,15 @@ func mainWithExitCode() int {
 		return exitCodeFatal
 	}
 
+	initInternalFeeEstimator(*blockchain)
+
+	if *contractOverridesConfig != "" {
+		if err = api.InitContractOverrides(*contractOverridesConfig, chain.GetChainParser()); err != nil {
+			glog.Error("contractOverrides: ", err)
+			return exitCodeFatal
+		}
+	}
+
 	index, err = db.NewRocksDB(*dbPath, *dbCache, *dbMaxOpenFiles, chain.GetChainParser(), metrics, chain)
 	if err != nil {
 		glog.Error("rocksDB: ", err)
@@ -241,6 +265,7 @@ func mainWithExitCode() int {
 		glog.Errorf("NewSyncWorker %v", err)
 		return exitCodeFatal
 	}
+	syncWorker.SetOnDisconnectBlocks(onDisconnectBlocks)
 
 	// set the DbState to open at this moment, after all important workers are initialized
 	internalState.DbState = common.DbStateOpen
@@ -268,9 +293,21 @@ func mainWithExitCode() int {
 		glog.Error("blockbookAppInfoMetric ", err)
 	}
 
+	var webhooks *server.Webhooks
+	if *enableWebhooks {
+		webhooks, err = server.NewWebhooks(index, chain, mempool, txCache, metrics, internalState)
+		if err != nil {
+			glog.Error("webhooks: ", err)
+			return exitCodeFatal
+		}
+		callbacksOnNewBlock = append(callbacksOnNewBlock, webhooks.OnNewBlock)
+		callbacksOnNewTxAddr = append(callbacksOnNewTxAddr, webhooks.OnNewTxAddr)
+		webhooks.Run()
+	}
+
 	var internalServer *server.InternalServer
 	if *internalBinding != "" {
-		internalServer, err = startInternalServer()
+		internalServer, err = startInternalServer(webhooks)
 		if err != nil {
 			glog.Error("internal server: ", err)
 			return exitCodeFatal
@@ -286,11 +323,25 @@ func mainWithExitCode() int {
 		}
 	}
 
+	var grpcServer *server.GrpcServer
+	if *grpcBinding != "" {
+		grpcServer, err = startGrpcServer()
+		if err != nil {
+			glog.Error("grpc server: ", err)
+			return exitCodeFatal
+		}
+		callbacksOnNewBlock = append(callbacksOnNewBlock, grpcServer.OnNewBlock)
+		callbacksOnNewTx = append(callbacksOnNewTx, grpcServer.OnNewTx)
+	}
+
 	if publicServer != nil {
 		// start full public interface
 		callbacksOnNewBlock = append(callbacksOnNewBlock, publicServer.OnNewBlock)
+		callbacksOnDisconnectBlocks = append(callbacksOnDisconnectBlocks, publicServer.OnDisconnectBlocks)
+		callbacksOnMempoolResync = append(callbacksOnMempoolResync, publicServer.OnMempoolResync)
 		callbacksOnNewTxAddr = append(callbacksOnNewTxAddr, publicServer.OnNewTxAddr)
 		callbacksOnNewTx = append(callbacksOnNewTx, publicServer.OnNewTx)
+		callbacksOnReplacedTx = append(callbacksOnReplacedTx, publicServer.OnReplacedTx)
 		callbacksOnNewFiatRatesTicker = append(callbacksOnNewFiatRatesTicker, publicServer.OnNewFiatRatesTicker)
 		publicServer.ConnectFullPublicInterface()
 	}
@@ -311,7 +362,7 @@ func mainWithExitCode() int {
 		if chain.GetChainParser().GetChainType() == bchain.ChainBitcoinType {
 			addrDescForOutpoint = index.AddrDescForOutpoint
 		}
-		err = chain.InitializeMempool(addrDescForOutpoint, onNewTxAddr, onNewTx)
+		err = chain.InitializeMempool(addrDescForOutpoint, onNewTxAddr, onNewTx, onReplacedTx)
 		if err != nil {
 			glog.Error("initializeMempool ", err)
 			return exitCodeFatal
@@ -346,10 +397,10 @@ func mainWithExitCode() int {
 		}
 	}
 
-	if internalServer != nil || publicServer != nil || chain != nil {
+	if internalServer != nil || publicServer != nil || grpcServer != nil || webhooks != nil || chain != nil {
 		// start fiat rates downloader only if not shutting down immediately
 		initFiatRatesDownloader(index, *blockchain)
-		waitForSignalAndShutdown(internalServer, publicServer, chain, 10*time.Second)
+		waitForSignalAndShutdown(internalServer, publicServer, grpcServer, webhooks, chain, 10*time.Second)
 	}
 
 	if *synchronize {
@@ -387,8 +438,8 @@ func getBlockChainWithRetry(coin string, configfile string, pushHandler func(bch
 	}
 }
 
-func startInternalServer() (*server.InternalServer, error) {
-	internalServer, err := server.NewInternalServer(*internalBinding, *certFiles, index, chain, mempool, txCache, metrics, internalState)
+func startInternalServer(webhooks *server.Webhooks) (*server.InternalServer, error) {
+	internalServer, err := server.NewInternalServer(*internalBinding, *certFiles, index, chain, mempool, txCache, metrics, internalState, webhooks)
 	if err != nil {
 		return nil, err
 	}
@@ -407,8 +458,15 @@ func startInternalServer() (*server.InternalServer, error) {
 }
 
 func startPublicServer() (*server.PublicServer, error) {
+	var rateLimiter *server.RateLimiter
+	if *apiKeysConfig != "" {
+		var err error
+		if rateLimiter, err = server.NewRateLimiter(*apiKeysConfig, metrics); err != nil {
+			return nil, err
+		}
+	}
 	// start public server in limited functionality, extend it after sync is finished by calling ConnectFullPublicInterface
-	publicServer, err := server.NewPublicServer(*publicBinding, *certFiles, index, chain, mempool, txCache, *explorerURL, metrics, internalState, *debugMode, *enableSubNewTx)
+	publicServer, err := server.NewPublicServer(*publicBinding, *certFiles, index, chain, mempool, txCache, *explorerURL, metrics, internalState, *debugMode, *enableSubNewTx, rateLimiter, time.Duration(*wsSessionTTL)*time.Second)
 	if err != nil {
 		return nil, err
 	}
@@ -426,6 +484,25 @@ func startPublicServer() (*server.PublicServer, error) {
 	return publicServer, err
 }
 
+func startGrpcServer() (*server.GrpcServer, error) {
+	grpcServer, err := server.NewGrpcServer(*grpcBinding, *certFiles, index, chain, mempool, txCache, metrics, internalState)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		err = grpcServer.Run()
+		if err != nil {
+			if err == grpc.ErrServerStopped {
+				glog.Info("grpc server: closed")
+			} else {
+				glog.Error(err)
+				return
+			}
+		}
+	}()
+	return grpcServer, nil
+}
+
 func performRollback() error {
 	bestHeight, bestHash, err := index.GetBestBlock()
 	if err != nil {
@@ -560,6 +637,28 @@ func onNewBlockHash(hash string, height uint32) {
 	}
 }
 
+func onDisconnectBlocks(lower uint32, higher uint32) {
+	defer func() {
+		if r := recover(); r != nil {
+			glog.Error("onDisconnectBlocks recovered from panic: ", r)
+		}
+	}()
+	for _, c := range callbacksOnDisconnectBlocks {
+		c(lower, higher)
+	}
+}
+
+func onMempoolResync() {
+	defer func() {
+		if r := recover(); r != nil {
+			glog.Error("onMempoolResync recovered from panic: ", r)
+		}
+	}()
+	for _, c := range callbacksOnMempoolResync {
+		c()
+	}
+}
+
 func onNewFiatRatesTicker(ticker *db.CurrencyRatesTicker) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -581,7 +680,7 @@ func syncMempoolLoop() {
 			glog.Error("syncMempoolLoop ", errors.ErrorStack(err))
 		} else {
 			internalState.FinishedMempoolSync(count)
-
+			onMempoolResync()
 		}
 	})
 	glog.Info("syncMempoolLoop stopped")
@@ -594,8 +693,10 @@ func storeInternalStateLoop() {
 		close(chanStoreInternalStateDone)
 	}()
 	signal.Notify(stopCompute, syscall.SIGHUP, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGTERM)
-	var computeRunning bool
+	var computeRunning, refreshRunning bool
 	lastCompute := time.Now()
+	lastRefresh := time.Now()
+	refreshPeriod := time.Duration(*contractRefreshPeriodHours) * time.Hour
 	lastAppInfo := time.Now()
 	logAppInfoPeriod := 15 * time.Minute
 	// randomize the duration between ComputeInternalStateColumnStats to avoid peaks after reboot of machine with multiple blockbooks
@@ -617,6 +718,17 @@ func storeInternalStateLoop() {
 				computeRunning = false
 			}()
 		}
+		if (*contractRefreshPeriodHours) > 0 && !refreshRunning && lastRefresh.Add(refreshPeriod).Before(time.Now()) {
+			refreshRunning = true
+			go func() {
+				err := index.RefreshContractInfos(refreshPeriod, stopCompute)
+				if err != nil {
+					glog.Error("refreshContractInfos error: ", err)
+				}
+				lastRefresh = time.Now()
+				refreshRunning = false
+			}()
+		}
 		if err := index.StoreInternalState(internalState); err != nil {
 			glog.Error("storeInternalStateLoop ", errors.ErrorStack(err))
 		}
@@ -653,6 +765,17 @@ func onNewTx(tx *bchain.MempoolTx) {
 	}
 }
 
+func onReplacedTx(replacement *bchain.MempoolReplacement, addrDescs []bchain.AddressDescriptor) {
+	defer func() {
+		if r := recover(); r != nil {
+			glog.Error("onReplacedTx recovered from panic: ", r)
+		}
+	}()
+	for _, c := range callbacksOnReplacedTx {
+		c(replacement, addrDescs)
+	}
+}
+
 func pushSynchronizationHandler(nt bchain.NotificationType) {
 	glog.V(1).Info("MQ: notification ", nt)
 	if atomic.LoadInt32(&inShutdown) != 0 {
@@ -667,7 +790,7 @@ func pushSynchronizationHandler(nt bchain.NotificationType) {
 	}
 }
 
-func waitForSignalAndShutdown(internal *server.InternalServer, public *server.PublicServer, chain bchain.BlockChain, timeout time.Duration) {
+func waitForSignalAndShutdown(internal *server.InternalServer, public *server.PublicServer, grpcServer *server.GrpcServer, webhooks *server.Webhooks, chain bchain.BlockChain, timeout time.Duration) {
 	sig := <-chanOsSignal
 	atomic.StoreInt32(&inShutdown, 1)
 	glog.Infof("shutdown: %v", sig)
@@ -687,6 +810,18 @@ func waitForSignalAndShutdown(internal *server.InternalServer, public *server.Pu
 		}
 	}
 
+	if grpcServer != nil {
+		if err := grpcServer.Shutdown(ctx); err != nil {
+			glog.Error("grpc server: shutdown error: ", err)
+		}
+	}
+
+	if webhooks != nil {
+		if err := webhooks.Close(ctx); err != nil {
+			glog.Error("webhooks: shutdown error: ", err)
+		}
+	}
+
 	if chain != nil {
 		if err := chain.Shutdown(ctx); err != nil {
 			glog.Error("rpc: shutdown error: ", err)
@@ -718,6 +853,31 @@ func computeFeeStats(stopCompute chan os.Signal, blockFrom, blockTo int, db *db.
 	return err
 }
 
+func initInternalFeeEstimator(configfile string) {
+	data, err := ioutil.ReadFile(configfile)
+	if err != nil {
+		glog.Errorf("Error reading file %v, %v", configfile, err)
+		return
+	}
+
+	var config struct {
+		AlternativeEstimateFee       string `json:"alternative_estimate_fee"`
+		AlternativeEstimateFeeParams string `json:"alternative_estimate_fee_params"`
+	}
+
+	err = json.Unmarshal(data, &config)
+	if err != nil {
+		glog.Errorf("Error parsing config file %v, %v", configfile, err)
+		return
+	}
+
+	if config.AlternativeEstimateFee == "internal" {
+		if err = api.InitInternalFeeEstimator(config.AlternativeEstimateFeeParams); err != nil {
+			glog.Error("InitInternalFeeEstimator error ", err, " Reverting to default estimateFee functionality")
+		}
+	}
+}
+
 func initFiatRatesDownloader(db *db.RocksDB, configfile string) {
 	data, err := ioutil.ReadFile(configfile)
 	if err != nil {
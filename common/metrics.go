@@ -16,6 +16,16 @@ type Metrics struct {
 	WebsocketSubscribes      *prometheus.GaugeVec
 	WebsocketClients         prometheus.Gauge
 	WebsocketReqDuration     *prometheus.HistogramVec
+	WebsocketDropped         prometheus.Counter
+	WebsocketCoalesced       prometheus.Counter
+	WebsocketSlowConsumers   prometheus.Counter
+	GrpcRequests             *prometheus.CounterVec
+	GrpcSubscribes           *prometheus.GaugeVec
+	GrpcReqDuration          *prometheus.HistogramVec
+	WebhookDeliveries        *prometheus.CounterVec
+	ThrottledRequests        *prometheus.CounterVec
+	ErrorResponses           *prometheus.CounterVec
+	WebhookQueueSize         prometheus.Gauge
 	IndexResyncDuration      prometheus.Histogram
 	MempoolResyncDuration    prometheus.Histogram
 	TxCacheEfficiency        *prometheus.CounterVec
@@ -106,6 +116,83 @@ func GetMetrics(coin string) (*Metrics, error) {
 		},
 		[]string{"method"},
 	)
+	metrics.WebsocketDropped = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name:        "blockbook_websocket_dropped_messages",
+			Help:        "Total number of websocket messages dropped because of disconnected slow clients",
+			ConstLabels: Labels{"coin": coin},
+		},
+	)
+	metrics.WebsocketCoalesced = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name:        "blockbook_websocket_coalesced_messages",
+			Help:        "Total number of websocket notifications replaced by a newer notification before they were sent to slow clients",
+			ConstLabels: Labels{"coin": coin},
+		},
+	)
+	metrics.WebsocketSlowConsumers = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name:        "blockbook_websocket_slow_consumers",
+			Help:        "Total number of slow consumer warnings sent to websocket clients",
+			ConstLabels: Labels{"coin": coin},
+		},
+	)
+	metrics.GrpcRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "blockbook_grpc_requests",
+			Help:        "Total number of gRPC requests by method and status",
+			ConstLabels: Labels{"coin": coin},
+		},
+		[]string{"method", "status"},
+	)
+	metrics.GrpcSubscribes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name:        "blockbook_grpc_subscribes",
+			Help:        "Number of gRPC subscriptions by method",
+			ConstLabels: Labels{"coin": coin},
+		},
+		[]string{"method"},
+	)
+	metrics.GrpcReqDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:        "blockbook_grpc_req_duration",
+			Help:        "gRPC request duration by method (in microseconds)",
+			Buckets:     []float64{1, 5, 10, 25, 50, 75, 100, 250},
+			ConstLabels: Labels{"coin": coin},
+		},
+		[]string{"method"},
+	)
+	metrics.WebhookDeliveries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "blockbook_webhook_deliveries",
+			Help:        "Total number of webhook delivery attempts by event and status",
+			ConstLabels: Labels{"coin": coin},
+		},
+		[]string{"event", "status"},
+	)
+	metrics.ThrottledRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "blockbook_throttled_requests",
+			Help:        "Total number of requests rejected by rate limiter by interface, method and reason",
+			ConstLabels: Labels{"coin": coin},
+		},
+		[]string{"interface", "method", "reason"},
+	)
+	metrics.ErrorResponses = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name:        "blockbook_error_responses",
+			Help:        "Total number of error responses of API by interface and code (HTTP status or websocket error type)",
+			ConstLabels: Labels{"coin": coin},
+		},
+		[]string{"interface", "code"},
+	)
+	metrics.WebhookQueueSize = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name:        "blockbook_webhook_queue_size",
+			Help:        "Number of webhook deliveries waiting in the queue",
+			ConstLabels: Labels{"coin": coin},
+		},
+	)
 	metrics.IndexResyncDuration = prometheus.NewHistogram(
 		prometheus.HistogramOpts{
 			Name:        "blockbook_index_resync_duration",
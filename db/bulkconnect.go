@@ -15,8 +15,13 @@ import (
 // 2) rocksdb seems to handle better fewer larger batches than continuous stream of smaller batches
 
 type bulkAddresses struct {
-	bi        BlockInfo
-	addresses addressesMap
+	bi                BlockInfo
+	addresses         addressesMap
+	contractTransfers addressesMap
+	feeStats          *BlockFeeStats
+	approvals         tokenApprovalsMap
+	// storeBlockTxs is set if blockTxs of the block are stored, the index of the approvals is stored only together with them
+	storeBlockTxs bool
 }
 
 // BulkConnect is used to connect blocks in bulk, faster but if interrupted inconsistent way
@@ -28,6 +33,7 @@ type BulkConnect struct {
 	txAddressesMap     map[string]*TxAddresses
 	balances           map[string]*AddrBalance
 	addressContracts   map[string]*AddrContracts
+	contractUpdates    map[string]*contractUpdate
 	height             uint32
 }
 
@@ -49,6 +55,7 @@ func (d *RocksDB) InitBulkConnect() (*BulkConnect, error) {
 		txAddressesMap:   make(map[string]*TxAddresses),
 		balances:         make(map[string]*AddrBalance),
 		addressContracts: make(map[string]*AddrContracts),
+		contractUpdates:  make(map[string]*contractUpdate),
 	}
 	if err := d.SetInconsistentState(true); err != nil {
 		return nil, err
@@ -160,9 +167,16 @@ func (b *BulkConnect) storeBulkAddresses(wb *gorocksdb.WriteBatch) error {
 		if err := b.d.storeAddresses(wb, ba.bi.Height, ba.addresses); err != nil {
 			return err
 		}
+		if err := b.d.storeAddressesMap(wb, cfContractTransfers, ba.bi.Height, ba.contractTransfers); err != nil {
+			return err
+		}
 		if err := b.d.writeHeight(wb, ba.bi.Height, &ba.bi, opInsert); err != nil {
 			return err
 		}
+		if err := b.d.storeBlockFeeStats(wb, ba.bi.Height, ba.feeStats); err != nil {
+			return err
+		}
+		b.d.storeTokenApprovals(wb, ba.bi.Height, ba.approvals, ba.storeBlockTxs)
 	}
 	b.bulkAddressesCount = 0
 	b.bulkAddresses = b.bulkAddresses[:0]
@@ -174,6 +188,8 @@ func (b *BulkConnect) connectBlockBitcoinType(block *bchain.Block, storeBlockTxs
 	if err := b.d.processAddressesBitcoinType(block, addresses, b.txAddressesMap, b.balances); err != nil {
 		return err
 	}
+	// the fee stats must be computed before the txAddresses are stored and removed from the map
+	feeStats := b.d.computeBlockFeeStats(block, b.txAddressesMap)
 	var storeAddressesChan, storeBalancesChan chan error
 	var sa bool
 	if len(b.txAddressesMap) > maxBulkTxAddresses || len(b.balances) > maxBulkBalances {
@@ -196,6 +212,7 @@ func (b *BulkConnect) connectBlockBitcoinType(block *bchain.Block, storeBlockTxs
 			Height: block.Height,
 		},
 		addresses: addresses,
+		feeStats:  feeStats,
 	})
 	b.bulkAddressesCount += len(addresses)
 	// open WriteBatch only if going to write
@@ -314,9 +331,31 @@ func (b *BulkConnect) parallelStoreTronAddressContracts(c chan error, all bool)
 	c <- nil
 }
 
+// storeContractUpdates stores the cached changes of the contracts, the column contracts is updated under the lock
+// as the metadata of the contracts can be concurrently downloaded and stored
+func (b *BulkConnect) storeContractUpdates() error {
+	if len(b.contractUpdates) == 0 {
+		return nil
+	}
+	wb := gorocksdb.NewWriteBatch()
+	defer wb.Destroy()
+	b.d.contractsMux.Lock()
+	defer b.d.contractsMux.Unlock()
+	if err := b.d.storeContractUpdates(wb, b.contractUpdates); err != nil {
+		return err
+	}
+	if err := b.d.db.Write(b.d.wo, wb); err != nil {
+		return err
+	}
+	b.contractUpdates = make(map[string]*contractUpdate)
+	return nil
+}
+
 func (b *BulkConnect) connectBlockEthereumType(block *bchain.Block, storeBlockTxs bool) error {
 	addresses := make(addressesMap)
-	blockTxs, err := b.d.processAddressesEthereumType(block, addresses, b.addressContracts)
+	contractTransfers := make(addressesMap)
+	approvals := make(tokenApprovalsMap)
+	blockTxs, err := b.d.processAddressesEthereumType(block, addresses, b.addressContracts, contractTransfers, approvals, b.contractUpdates)
 	if err != nil {
 		return err
 	}
@@ -335,9 +374,12 @@ func (b *BulkConnect) connectBlockEthereumType(block *bchain.Block, storeBlockTx
 			Size:   uint32(block.Size),
 			Height: block.Height,
 		},
-		addresses: addresses,
+		addresses:         addresses,
+		contractTransfers: contractTransfers,
+		approvals:         approvals,
+		storeBlockTxs:     storeBlockTxs,
 	})
-	b.bulkAddressesCount += len(addresses)
+	b.bulkAddressesCount += len(addresses) + len(contractTransfers) + len(approvals)
 	// open WriteBatch only if going to write
 	if sa || b.bulkAddressesCount > maxBulkAddresses || storeBlockTxs {
 		start := time.Now()
@@ -359,6 +401,9 @@ func (b *BulkConnect) connectBlockEthereumType(block *bchain.Block, storeBlockTx
 		}
 		if bac > b.bulkAddressesCount {
 			glog.Info("rocksdb: height ", b.height, ", stored ", bac, " addresses, done in ", time.Since(start))
+			if err := b.storeContractUpdates(); err != nil {
+				return err
+			}
 		}
 	}
 	if storeAddrContracts != nil {
@@ -371,7 +416,9 @@ func (b *BulkConnect) connectBlockEthereumType(block *bchain.Block, storeBlockTx
 
 func (b *BulkConnect) connectBlockTronType(block *bchain.Block, storeBlockTxs bool) error {
 	addresses := make(addressesMap)
-	blockTxs, err := b.d.processAddressesAndContractsTronType(block, addresses, b.addressContracts)
+	contractTransfers := make(addressesMap)
+	approvals := make(tokenApprovalsMap)
+	blockTxs, err := b.d.processAddressesAndContractsTronType(block, addresses, b.addressContracts, contractTransfers, approvals, b.contractUpdates)
 	if err != nil {
 		return err
 	}
@@ -390,9 +437,12 @@ func (b *BulkConnect) connectBlockTronType(block *bchain.Block, storeBlockTxs bo
 			Size:   uint32(block.Size),
 			Height: block.Height,
 		},
-		addresses: addresses,
+		addresses:         addresses,
+		contractTransfers: contractTransfers,
+		approvals:         approvals,
+		storeBlockTxs:     storeBlockTxs,
 	})
-	b.bulkAddressesCount += len(addresses)
+	b.bulkAddressesCount += len(addresses) + len(contractTransfers) + len(approvals)
 	// open WriteBatch only if going to write
 	if sa || b.bulkAddressesCount > maxBulkAddresses || storeBlockTxs {
 		start := time.Now()
@@ -414,6 +464,9 @@ func (b *BulkConnect) connectBlockTronType(block *bchain.Block, storeBlockTxs bo
 		}
 		if bac > b.bulkAddressesCount {
 			glog.Info("rocksdb: height ", b.height, ", stored ", bac, " addresses, done in ", time.Since(start))
+			if err := b.storeContractUpdates(); err != nil {
+				return err
+			}
 		}
 	}
 	if storeAddrContracts != nil {
@@ -466,6 +519,9 @@ func (b *BulkConnect) Close() error {
 		return err
 	}
 	glog.Info("rocksdb: height ", b.height, ", stored ", bac, " addresses, done in ", time.Since(start))
+	if err := b.storeContractUpdates(); err != nil {
+		return err
+	}
 	if storeTxAddressesChan != nil {
 		if err := <-storeTxAddressesChan; err != nil {
 			return err
@@ -11,6 +11,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strconv"
+	"sync"
 	"time"
 	"unsafe"
 
@@ -22,8 +23,21 @@ import (
 	"github.com/trezor/blockbook/common"
 )
 
+// dbVersion is the internal data format version of the Bitcoin type and Tron type databases
 const dbVersion = 5
 
+// dbVersionEthereumType is the internal data format version of the Ethereum type databases,
+// the format of the columns addressContracts and blockTxs was changed by the indexing of ERC721 and ERC1155 tokens
+const dbVersionEthereumType = 6
+
+// getDbVersion returns the internal data format version required for the chain type
+func getDbVersion(chainType bchain.ChainType) uint32 {
+	if chainType == bchain.ChainEthereumType {
+		return dbVersionEthereumType
+	}
+	return dbVersion
+}
+
 const packedHeightBytes = 4
 const maxAddrDescLen = 1024
 
@@ -99,6 +113,11 @@ type RocksDB struct {
 	maxOpenFiles int
 	cbs          connectBlockStats
 	chain        bchain.BlockChain
+	// contractsMux serializes the updates of the column contracts done by the indexing of blocks and by the download of the metadata
+	contractsMux sync.Mutex
+	// noMetadata caches the addresses without a contract record for which the backend did not return any token metadata
+	noMetadata    map[string]int64
+	noMetadataMux sync.Mutex
 }
 
 const (
@@ -108,21 +127,30 @@ const (
 	cfBlockTxs
 	cfTransactions
 	cfFiatRates
+	cfWebhooks
 	// BitcoinType
 	cfAddressBalance
 	cfTxAddresses
+	cfBlockFeeStats
+	// EthereumType and TronType
+	cfAddressContracts    = cfAddressBalance
+	cfContractTransfers   = cfTxAddresses
+	cfContracts           = cfBlockFeeStats
+	cfContractHolders     = cfBlockFeeStats + 1
+	cfTokenApprovals      = cfBlockFeeStats + 2
+	cfContractHolderRanks = cfBlockFeeStats + 3
 	// EthereumType
-	cfAddressContracts = cfAddressBalance
+	cfAddressTokenIds = cfBlockFeeStats + 4
 )
 
 // common columns
 var cfNames []string
-var cfBaseNames = []string{"default", "height", "addresses", "blockTxs", "transactions", "fiatRates"}
+var cfBaseNames = []string{"default", "height", "addresses", "blockTxs", "transactions", "fiatRates", "webhooks"}
 
 // type specific columns
-var cfNamesBitcoinType = []string{"addressBalance", "txAddresses"}
-var cfNamesEthereumType = []string{"addressContracts"}
-var cfNamesTronType = []string{"addressContracts"}
+var cfNamesBitcoinType = []string{"addressBalance", "txAddresses", "blockFeeStats"}
+var cfNamesEthereumType = []string{"addressContracts", "contractTransfers", "contracts", "contractHolders", "tokenApprovals", "contractHolderRanks", "addressTokenIds"}
+var cfNamesTronType = []string{"addressContracts", "contractTransfers", "contracts", "contractHolders", "tokenApprovals", "contractHolderRanks"}
 
 func openDB(path string, c *gorocksdb.Cache, openFiles int) (*gorocksdb.DB, []*gorocksdb.ColumnFamilyHandle, error) {
 	// opts with bloom filter
@@ -130,8 +158,8 @@ func openDB(path string, c *gorocksdb.Cache, openFiles int) (*gorocksdb.DB, []*g
 	// opts for addresses without bloom filter
 	// from documentation: if most of your queries are executed using iterators, you shouldn't set bloom filter
 	optsAddresses := createAndSetDBOptions(0, c, openFiles)
-	// default, height, addresses, blockTxids, transactions
-	cfOptions := []*gorocksdb.Options{opts, opts, optsAddresses, opts, opts, opts}
+	// default, height, addresses, blockTxids, transactions, fiatRates, webhooks
+	cfOptions := []*gorocksdb.Options{opts, opts, optsAddresses, opts, opts, opts, opts}
 	// append type specific options
 	count := len(cfNames) - len(cfOptions)
 	for i := 0; i < count; i++ {
@@ -147,10 +175,10 @@ func openDB(path string, c *gorocksdb.Cache, openFiles int) (*gorocksdb.DB, []*g
 // NewRocksDB opens an internal handle to RocksDB environment.  Close
 // needs to be called to release it.
 func NewRocksDB(path string, cacheSize, maxOpenFiles int, parser bchain.BlockChainParser, metrics *common.Metrics, chain bchain.BlockChain) (d *RocksDB, err error) {
-	glog.Infof("rocksdb: opening %s, required data version %v, cache size %v, max open files %v", path, dbVersion, cacheSize, maxOpenFiles)
+	chainType := parser.GetChainType()
+	glog.Infof("rocksdb: opening %s, required data version %v, cache size %v, max open files %v", path, getDbVersion(chainType), cacheSize, maxOpenFiles)
 
 	cfNames = append([]string{}, cfBaseNames...)
-	chainType := parser.GetChainType()
 	if chainType == bchain.ChainBitcoinType {
 		cfNames = append(cfNames, cfNamesBitcoinType...)
 	} else if chainType == bchain.ChainEthereumType {
@@ -168,7 +196,7 @@ func NewRocksDB(path string, cacheSize, maxOpenFiles int, parser bchain.BlockCha
 	}
 	wo := gorocksdb.NewDefaultWriteOptions()
 	ro := gorocksdb.NewDefaultReadOptions()
-	return &RocksDB{path, db, wo, ro, cfh, parser, nil, metrics, c, maxOpenFiles, connectBlockStats{}, chain}, nil
+	return &RocksDB{path, db, wo, ro, cfh, parser, nil, metrics, c, maxOpenFiles, connectBlockStats{}, chain, sync.Mutex{}, make(map[string]int64), sync.Mutex{}}, nil
 }
 
 func (d *RocksDB) closeDB() error {
@@ -376,12 +404,24 @@ func (d *RocksDB) GetTransactions(address string, lower uint32, higher uint32, f
 // GetAddrDescTransactions finds all input/output transactions for address descriptor
 // Transaction are passed to callback function in the order from newest block to the oldest
 func (d *RocksDB) GetAddrDescTransactions(addrDesc bchain.AddressDescriptor, lower uint32, higher uint32, fn GetTransactionsCallback) (err error) {
+	return d.getTransactions(cfAddresses, addrDesc, lower, higher, fn)
+}
+
+// GetContractTransfers finds all transactions with token transfers of the contract (EthereumType and TronType only)
+// Transaction are passed to callback function in the order from newest block to the oldest,
+// indexes contain the indexes of the transfers of the contract in the list of token transfers of the transaction
+func (d *RocksDB) GetContractTransfers(contract bchain.AddressDescriptor, lower uint32, higher uint32, fn GetTransactionsCallback) (err error) {
+	return d.getTransactions(cfContractTransfers, contract, lower, higher, fn)
+}
+
+// getTransactions iterates over the transactions of addrDesc in the column cf with the layout of the column addresses
+func (d *RocksDB) getTransactions(cf int, addrDesc bchain.AddressDescriptor, lower uint32, higher uint32, fn GetTransactionsCallback) (err error) {
 	txidUnpackedLen := d.chainParser.PackedTxidLen()
 	addrDescLen := len(addrDesc)
 	startKey := packAddressKey(addrDesc, higher)
 	stopKey := packAddressKey(addrDesc, lower)
 	indexes := make([]int32, 0, 16)
-	it := d.db.NewIteratorCF(d.ro, d.cfh[cfAddresses])
+	it := d.db.NewIteratorCF(d.ro, d.cfh[cf])
 	defer it.Close()
 	for it.Seek(startKey); it.Valid(); it.Next() {
 		key := it.Key().Data()
@@ -396,7 +436,7 @@ func (d *RocksDB) GetAddrDescTransactions(addrDesc bchain.AddressDescriptor, low
 		}
 		val := it.Value().Data()
 		if glog.V(2) {
-			glog.Infof("rocksdb: addresses %s: %s", hex.EncodeToString(key), hex.EncodeToString(val))
+			glog.Infof("rocksdb: %s %s: %s", cfNames[cf], hex.EncodeToString(key), hex.EncodeToString(val))
 		}
 		_, height, err := unpackAddressKey(key)
 		if err != nil {
@@ -416,7 +456,7 @@ func (d *RocksDB) GetAddrDescTransactions(addrDesc bchain.AddressDescriptor, low
 				if index&1 == 1 {
 					break
 				} else if len(val) == 0 {
-					glog.Warningf("rocksdb: addresses contain incorrect data %s: %s", hex.EncodeToString(key), hex.EncodeToString(val))
+					glog.Warningf("rocksdb: %s contain incorrect data %s: %s", cfNames[cf], hex.EncodeToString(key), hex.EncodeToString(val))
 					break
 				}
 			}
@@ -428,7 +468,7 @@ func (d *RocksDB) GetAddrDescTransactions(addrDesc bchain.AddressDescriptor, low
 			}
 		}
 		if len(val) != 0 {
-			glog.Warningf("rocksdb: addresses contain incorrect data %s: %s", hex.EncodeToString(key), hex.EncodeToString(val))
+			glog.Warningf("rocksdb: %s contain incorrect data %s: %s", cfNames[cf], hex.EncodeToString(key), hex.EncodeToString(val))
 		}
 	}
 	return nil
@@ -467,30 +507,59 @@ func (d *RocksDB) ConnectBlock(block *bchain.Block) error {
 		if err := d.storeBalances(wb, balances); err != nil {
 			return err
 		}
+		if err := d.storeBlockFeeStats(wb, block.Height, d.computeBlockFeeStats(block, txAddressesMap)); err != nil {
+			return err
+		}
 		if err := d.storeAndCleanupBlockTxs(wb, block); err != nil {
 			return err
 		}
 	} else if chainType == bchain.ChainEthereumType {
 		addressContracts := make(map[string]*AddrContracts)
-		blockTxs, err := d.processAddressesEthereumType(block, addresses, addressContracts)
+		contractTransfers := make(addressesMap)
+		contractUpdates := make(map[string]*contractUpdate)
+		approvals := make(tokenApprovalsMap)
+		blockTxs, err := d.processAddressesEthereumType(block, addresses, addressContracts, contractTransfers, approvals, contractUpdates)
 		if err != nil {
 			return err
 		}
 		if err := d.storeAddressContracts(wb, addressContracts); err != nil {
 			return err
 		}
+		if err := d.storeAddressesMap(wb, cfContractTransfers, block.Height, contractTransfers); err != nil {
+			return err
+		}
+		// the lock is held until the write batch is written
+		d.contractsMux.Lock()
+		defer d.contractsMux.Unlock()
+		if err := d.storeContractUpdates(wb, contractUpdates); err != nil {
+			return err
+		}
+		d.storeTokenApprovals(wb, block.Height, approvals, true)
 		if err := d.storeAndCleanupBlockTxsEthereumType(wb, block, blockTxs); err != nil {
 			return err
 		}
 	} else if chainType == bchain.ChainTronType {
 		addressContracts := make(map[string]*AddrContracts)
-		blockTxs, err := d.processAddressesAndContractsTronType(block, addresses, addressContracts)
+		contractTransfers := make(addressesMap)
+		contractUpdates := make(map[string]*contractUpdate)
+		approvals := make(tokenApprovalsMap)
+		blockTxs, err := d.processAddressesAndContractsTronType(block, addresses, addressContracts, contractTransfers, approvals, contractUpdates)
 		if err != nil {
 			return err
 		}
 		if err := d.storeTronAddressContracts(wb, addressContracts); err != nil {
 			return err
 		}
+		if err := d.storeAddressesMap(wb, cfContractTransfers, block.Height, contractTransfers); err != nil {
+			return err
+		}
+		// the lock is held until the write batch is written
+		d.contractsMux.Lock()
+		defer d.contractsMux.Unlock()
+		if err := d.storeContractUpdates(wb, contractUpdates); err != nil {
+			return err
+		}
+		d.storeTokenApprovals(wb, block.Height, approvals, true)
 		if err := d.storeAndCleanupBlockTxsTronType(wb, block, blockTxs); err != nil {
 			return err
 		}
@@ -865,11 +934,15 @@ func addToAddressesMap(addresses addressesMap, strAddrDesc string, btxID []byte,
 }
 
 func (d *RocksDB) storeAddresses(wb *gorocksdb.WriteBatch, height uint32, addresses addressesMap) error {
+	return d.storeAddressesMap(wb, cfAddresses, height, addresses)
+}
+
+func (d *RocksDB) storeAddressesMap(wb *gorocksdb.WriteBatch, cf int, height uint32, addresses addressesMap) error {
 	for addrDesc, txi := range addresses {
 		ba := bchain.AddressDescriptor(addrDesc)
 		key := packAddressKey(ba, height)
 		val := d.packTxIndexes(txi)
-		wb.PutCF(d.cfh[cfAddresses], key, val)
+		wb.PutCF(d.cfh[cf], key, val)
 	}
 	return nil
 }
@@ -916,6 +989,10 @@ func (d *RocksDB) cleanupBlockTxs(wb *gorocksdb.WriteBatch, block *bchain.Block)
 			}
 			val.Free()
 			d.db.DeleteCF(d.wo, d.cfh[cfBlockTxs], key)
+			// the index of the token approvals is stored together with blockTxs
+			if chainType := d.chainParser.GetChainType(); chainType == bchain.ChainEthereumType || chainType == bchain.ChainTronType {
+				d.db.DeleteCF(d.wo, d.cfh[cfTokenApprovals], key)
+			}
 		}
 	}
 	return nil
@@ -987,6 +1064,95 @@ func (d *RocksDB) getBlockTxs(height uint32) ([]blockTxs, error) {
 	return bt, nil
 }
 
+// BlockTxAddresses is a transaction of a block with the address descriptors indexed for it
+type BlockTxAddresses struct {
+	Txid      string
+	AddrDescs []bchain.AddressDescriptor
+}
+
+// GetBlockTxAddresses returns the transactions of the block at given height with their indexed addresses
+// it returns nil if the data for the block are not kept in db (only the last blocks are stored)
+func (d *RocksDB) GetBlockTxAddresses(height uint32) ([]BlockTxAddresses, error) {
+	var rv []BlockTxAddresses
+	add := func(btxID []byte, addrDescs []bchain.AddressDescriptor) error {
+		txid, err := d.chainParser.UnpackTxid(btxID)
+		if err != nil {
+			return err
+		}
+		rv = append(rv, BlockTxAddresses{Txid: txid, AddrDescs: addrDescs})
+		return nil
+	}
+	ethereumTypeAddrDescs := func(from, to bchain.AddressDescriptor, contracts []ethBlockTxContract) []bchain.AddressDescriptor {
+		ads := make([]bchain.AddressDescriptor, 0, 2+len(contracts))
+		for _, ad := range []bchain.AddressDescriptor{from, to} {
+			if len(ad) > 0 {
+				ads = append(ads, ad)
+			}
+		}
+		for i := range contracts {
+			if len(contracts[i].addr) > 0 {
+				ads = append(ads, contracts[i].addr)
+			}
+		}
+		return ads
+	}
+	switch d.chainParser.GetChainType() {
+	case bchain.ChainEthereumType:
+		bt, err := d.getBlockTxsEthereumType(height)
+		if err != nil || bt == nil {
+			return nil, err
+		}
+		rv = make([]BlockTxAddresses, 0, len(bt))
+		for i := range bt {
+			if err = add(bt[i].btxID, ethereumTypeAddrDescs(bt[i].from, bt[i].to, bt[i].contracts)); err != nil {
+				return nil, err
+			}
+		}
+	case bchain.ChainTronType:
+		bt, err := d.getBlockTxsTronType(height)
+		if err != nil || bt == nil {
+			return nil, err
+		}
+		rv = make([]BlockTxAddresses, 0, len(bt))
+		for i := range bt {
+			if err = add(bt[i].btxID, ethereumTypeAddrDescs(bt[i].from, bt[i].to, bt[i].contracts)); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		bt, err := d.getBlockTxs(height)
+		// each block contains at least the coinbase transaction, no data means the block is not stored
+		if err != nil || len(bt) == 0 {
+			return nil, err
+		}
+		rv = make([]BlockTxAddresses, 0, len(bt))
+		for i := range bt {
+			ta, err := d.getTxAddresses(bt[i].btxID)
+			if err != nil {
+				return nil, err
+			}
+			if ta == nil {
+				return nil, nil
+			}
+			ads := make([]bchain.AddressDescriptor, 0, len(ta.Inputs)+len(ta.Outputs))
+			for j := range ta.Inputs {
+				if len(ta.Inputs[j].AddrDesc) > 0 {
+					ads = append(ads, ta.Inputs[j].AddrDesc)
+				}
+			}
+			for j := range ta.Outputs {
+				if len(ta.Outputs[j].AddrDesc) > 0 {
+					ads = append(ads, ta.Outputs[j].AddrDesc)
+				}
+			}
+			if err = add(bt[i].btxID, ads); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return rv, nil
+}
+
 // GetAddrDescBalance returns AddrBalance for given addrDesc
 func (d *RocksDB) GetAddrDescBalance(addrDesc bchain.AddressDescriptor, detail AddressBalanceDetail) (*AddrBalance, error) {
 	val, err := d.db.GetCF(d.ro, d.cfh[cfAddressBalance], addrDesc)
@@ -1543,6 +1709,7 @@ func (d *RocksDB) disconnectBlock(height uint32, blockTxs []blockTxs) error {
 	key := packUint(height)
 	wb.DeleteCF(d.cfh[cfBlockTxs], key)
 	wb.DeleteCF(d.cfh[cfHeight], key)
+	wb.DeleteCF(d.cfh[cfBlockFeeStats], key)
 	d.storeTxAddresses(wb, txAddressesToUpdate)
 	d.storeBalancesDisconnect(wb, balances)
 	for s := range txsToDelete {
@@ -1740,16 +1907,17 @@ func (d *RocksDB) LoadInternalState(rpcCoin string) (*common.InternalState, erro
 		}
 	}
 	// make sure that column stats match the columns
+	version := getDbVersion(d.chainParser.GetChainType())
 	sc := is.DbColumns
 	nc := make([]common.InternalStateColumn, len(cfNames))
 	for i := 0; i < len(nc); i++ {
 		nc[i].Name = cfNames[i]
-		nc[i].Version = dbVersion
+		nc[i].Version = version
 		for j := 0; j < len(sc); j++ {
 			if sc[j].Name == nc[i].Name {
 				// check the version of the column, if it does not match, the db is not compatible
-				if sc[j].Version != dbVersion {
-					return nil, errors.Errorf("DB version %v of column '%v' does not match the required version %v. DB is not compatible.", sc[j].Version, sc[j].Name, dbVersion)
+				if sc[j].Version != version {
+					return nil, errors.Errorf("DB version %v of column '%v' does not match the required version %v. DB is not compatible.", sc[j].Version, sc[j].Name, version)
 				}
 				nc[i].Rows = sc[j].Rows
 				nc[i].KeyBytes = sc[j].KeyBytes
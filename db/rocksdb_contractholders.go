@@ -0,0 +1,347 @@
+package db
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/tecbot/gorocksdb"
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/bchain/coins/trx"
+)
+
+// ContractHolder is an address holding a fungible token of a contract and its balance
+type ContractHolder struct {
+	AddrDesc bchain.AddressDescriptor
+	Balance  big.Int
+}
+
+// holderBalanceChange is the balance of the holder after the block at height
+type holderBalanceChange struct {
+	height  uint32
+	balance big.Int
+}
+
+// maxContractHolderBalancesScan is the maximum number of the stored balances of the holders of a contract,
+// which are read to get the holders after a block in the past
+const maxContractHolderBalancesScan = 1000000
+
+// ErrTooManyContractHolderBalances is returned if the holders of a contract after a block in the past cannot be returned
+// because the contract has more than maxContractHolderBalancesScan stored balances
+var ErrTooManyContractHolderBalances = errors.New("Too many balances of the contract holders")
+
+// holderBalance is the running balance of a holder of a fungible token during the connect of blocks
+// the balance is loaded from the column contractHolders and updated by the transfers,
+// changes contain the balances after the connected blocks which are not stored yet,
+// stored is the current balance stored in the columns contractHolders and contractHolderRanks
+type holderBalance struct {
+	balance big.Int
+	stored  big.Int
+	changes []holderBalanceChange
+}
+
+// packContractHolderKey packs the key of the column contractHolders, the height is packed
+// as binary complement to get the balances of a holder ordered from the newest to the oldest
+func packContractHolderKey(contract, holder bchain.AddressDescriptor, height uint32) []byte {
+	buf := make([]byte, len(contract)+len(holder)+packedHeightBytes)
+	copy(buf, contract)
+	copy(buf[len(contract):], holder)
+	binary.BigEndian.PutUint32(buf[len(contract)+len(holder):], ^height)
+	return buf
+}
+
+// getContractHolderBalance returns the balance of the holder stored in the column contractHolders,
+// it is the balance after the newest block with a transfer of the holder
+func (d *RocksDB) getContractHolderBalance(contract, holder bchain.AddressDescriptor) (big.Int, error) {
+	prefix := packContractHolderKey(contract, holder, 0)
+	prefix = prefix[:len(prefix)-packedHeightBytes]
+	it := d.db.NewIteratorCF(d.ro, d.cfh[cfContractHolders])
+	defer it.Close()
+	it.Seek(prefix)
+	if it.Valid() {
+		key := it.Key().Data()
+		if len(key) == len(prefix)+packedHeightBytes && bytes.HasPrefix(key, prefix) {
+			b, _ := unpackBigint(it.Value().Data())
+			return b, nil
+		}
+	}
+	return big.Int{}, nil
+}
+
+// packContractHolderRankKey packs the key of the column contractHolderRanks, the balance is packed as bigint
+// with all bytes complemented to get the holders of a contract ordered from the highest balance
+func packContractHolderRankKey(contract, holder bchain.AddressDescriptor, balance *big.Int) []byte {
+	varBuf := make([]byte, maxPackedBigintBytes)
+	l := packBigint(balance, varBuf)
+	buf := make([]byte, 0, len(contract)+l+len(holder))
+	buf = append(buf, contract...)
+	for _, b := range varBuf[:l] {
+		buf = append(buf, ^b)
+	}
+	return append(buf, holder...)
+}
+
+// unpackContractHolderRankKey unpacks the holder and its balance from the key of the column contractHolderRanks
+func unpackContractHolderRankKey(key []byte, contractLen int) (bchain.AddressDescriptor, big.Int, error) {
+	if len(key) <= contractLen {
+		return nil, big.Int{}, errors.New("Invalid contract holder rank key")
+	}
+	l := int(^key[contractLen]) + 1
+	if len(key) < contractLen+l {
+		return nil, big.Int{}, errors.New("Invalid contract holder rank key")
+	}
+	varBuf := make([]byte, l)
+	for i := range varBuf {
+		varBuf[i] = ^key[contractLen+i]
+	}
+	b, _ := unpackBigint(varBuf)
+	return append(bchain.AddressDescriptor(nil), key[contractLen+l:]...), b, nil
+}
+
+// updateHolderRank moves the holder in the column contractHolderRanks from the balance from to the balance to,
+// the holders with zero balance are not stored
+func (d *RocksDB) updateHolderRank(wb *gorocksdb.WriteBatch, contract, holder bchain.AddressDescriptor, from, to *big.Int) {
+	if from.Cmp(to) == 0 {
+		return
+	}
+	if from.Sign() > 0 {
+		wb.DeleteCF(d.cfh[cfContractHolderRanks], packContractHolderRankKey(contract, holder, from))
+	}
+	if to.Sign() > 0 {
+		wb.PutCF(d.cfh[cfContractHolderRanks], packContractHolderRankKey(contract, holder, to), []byte{})
+	}
+}
+
+// isZeroHolder returns true for the zero address, also in the Tron format with the 0x41 prefix
+func isZeroHolder(holder bchain.AddressDescriptor) bool {
+	if len(holder) == trx.TronTypeAddressDescriptorLen && holder[0] == 0x41 {
+		return isZeroAddress(holder[1:])
+	}
+	return isZeroAddress(holder)
+}
+
+// addHolderBalanceChange applies the amount of a fungible token transfer to the balance of the holder
+// and counts the holders which start or stop holding the token
+func (d *RocksDB) addHolderBalanceChange(cu *contractUpdate, contract, holder bchain.AddressDescriptor, amount *big.Int, add bool, height uint32) error {
+	// the zero address is used for mints and burns, it is not a holder
+	if isZeroHolder(holder) {
+		return nil
+	}
+	if cu.holderBalances == nil {
+		cu.holderBalances = make(map[string]*holderBalance)
+	}
+	hb, found := cu.holderBalances[string(holder)]
+	if !found {
+		b, err := d.getContractHolderBalance(contract, holder)
+		if err != nil {
+			return err
+		}
+		hb = &holderBalance{balance: b}
+		hb.stored.Set(&b)
+		cu.holderBalances[string(holder)] = hb
+	}
+	held := hb.balance.Sign() > 0
+	if add {
+		hb.balance.Add(&hb.balance, amount)
+	} else {
+		hb.balance.Sub(&hb.balance, amount)
+		// the history of the token may be incomplete (for example mints without transfer events), do not keep negative balance
+		if hb.balance.Sign() < 0 {
+			hb.balance.SetInt64(0)
+		}
+	}
+	if h := hb.balance.Sign() > 0; h != held {
+		if h {
+			cu.holders++
+		} else {
+			cu.holders--
+		}
+	}
+	if n := len(hb.changes); n > 0 && hb.changes[n-1].height == height {
+		hb.changes[n-1].balance.Set(&hb.balance)
+	} else {
+		hb.changes = append(hb.changes, holderBalanceChange{height: height})
+		hb.changes[len(hb.changes)-1].balance.Set(&hb.balance)
+	}
+	return nil
+}
+
+// storeHolderBalances stores the balances of the holders after the connected blocks and their current balances in the column contractHolderRanks
+func (d *RocksDB) storeHolderBalances(wb *gorocksdb.WriteBatch, contract bchain.AddressDescriptor, holderBalances map[string]*holderBalance) {
+	varBuf := make([]byte, maxPackedBigintBytes)
+	for h, hb := range holderBalances {
+		for i := range hb.changes {
+			c := &hb.changes[i]
+			l := packBigint(&c.balance, varBuf)
+			wb.PutCF(d.cfh[cfContractHolders], packContractHolderKey(contract, bchain.AddressDescriptor(h), c.height), varBuf[:l])
+		}
+		if len(hb.changes) > 0 {
+			d.updateHolderRank(wb, contract, bchain.AddressDescriptor(h), &hb.stored, &hb.balance)
+			hb.stored.Set(&hb.balance)
+		}
+		hb.changes = hb.changes[:0]
+	}
+}
+
+// disconnectHolderBalances removes the balances of the holders after the disconnected block, restores their previous balances
+// in the column contractHolderRanks and counts the holders which did not hold the token before the block
+func (d *RocksDB) disconnectHolderBalances(wb *gorocksdb.WriteBatch, height uint32, holders map[string]map[string]struct{}, contractUpdates map[string]*contractUpdate) error {
+	it := d.db.NewIteratorCF(d.ro, d.cfh[cfContractHolders])
+	defer it.Close()
+	for c, hs := range holders {
+		contract := bchain.AddressDescriptor(c)
+		for h := range hs {
+			key := packContractHolderKey(contract, bchain.AddressDescriptor(h), height)
+			it.Seek(key)
+			// the balance is not stored if the holder did not change it in the block, for example by a transfer to self
+			if !it.Valid() || !bytes.Equal(it.Key().Data(), key) {
+				continue
+			}
+			b, _ := unpackBigint(it.Value().Data())
+			held := b.Sign() > 0
+			wb.DeleteCF(d.cfh[cfContractHolders], key)
+			var before big.Int
+			it.Next()
+			if it.Valid() {
+				k := it.Key().Data()
+				if len(k) == len(key) && bytes.Equal(k[:len(key)-packedHeightBytes], key[:len(key)-packedHeightBytes]) {
+					before, _ = unpackBigint(it.Value().Data())
+				}
+			}
+			d.updateHolderRank(wb, contract, bchain.AddressDescriptor(h), &b, &before)
+			heldBefore := before.Sign() > 0
+			if held != heldBefore {
+				if held {
+					getContractUpdate(contractUpdates, contract).holders--
+				} else {
+					getContractUpdate(contractUpdates, contract).holders++
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// addDisconnectedHolder collects the holders of the fungible tokens which took part in a disconnected block
+func addDisconnectedHolder(holders map[string]map[string]struct{}, contract, holder bchain.AddressDescriptor) {
+	if len(holder) == 0 || len(contract) == 0 {
+		return
+	}
+	hs, found := holders[string(contract)]
+	if !found {
+		hs = make(map[string]struct{})
+		holders[string(contract)] = hs
+	}
+	hs[string(holder)] = struct{}{}
+}
+
+// GetContractHolders returns count holders of the fungible token of the contract with non-zero balance after the block at height
+// starting from the index from, the current holders if height is 0, sorted by the balance from the highest,
+// together with the total number of the holders
+// the current holders are read from the column contractHolderRanks, the holders after the block at height are derived
+// from the stored balances of all holders of the contract, ErrTooManyContractHolderBalances is returned if there are too many of them
+func (d *RocksDB) GetContractHolders(contract bchain.AddressDescriptor, height uint32, from, count int) ([]ContractHolder, int, error) {
+	if from < 0 {
+		from = 0
+	}
+	if count < 0 {
+		count = 0
+	}
+	if height == 0 {
+		return d.getCurrentContractHolders(contract, from, count)
+	}
+	holders, err := d.getContractHoldersAtHeight(contract, height)
+	if err != nil {
+		return nil, 0, err
+	}
+	total := len(holders)
+	if from >= total {
+		return []ContractHolder{}, total, nil
+	}
+	if from+count < total {
+		holders = holders[:from+count]
+	}
+	return holders[from:], total, nil
+}
+
+// getCurrentContractHolders returns the current holders of the contract from the column contractHolderRanks,
+// the total number of the holders is taken from the contract info
+func (d *RocksDB) getCurrentContractHolders(contract bchain.AddressDescriptor, from, count int) ([]ContractHolder, int, error) {
+	var total int
+	ci, err := d.getStoredContractInfo(contract)
+	if err != nil {
+		return nil, 0, err
+	}
+	if ci != nil {
+		total = int(ci.Holders)
+	}
+	holders := make([]ContractHolder, 0, count)
+	it := d.db.NewIteratorCF(d.ro, d.cfh[cfContractHolderRanks])
+	defer it.Close()
+	i := 0
+	for it.Seek(contract); it.Valid() && len(holders) < count; it.Next() {
+		key := it.Key().Data()
+		if !bytes.HasPrefix(key, contract) {
+			break
+		}
+		if i++; i <= from {
+			continue
+		}
+		holder, b, err := unpackContractHolderRankKey(key, len(contract))
+		if err != nil {
+			return nil, 0, err
+		}
+		holders = append(holders, ContractHolder{AddrDesc: holder, Balance: b})
+	}
+	if total < from+len(holders) {
+		total = from + len(holders)
+	}
+	return holders, total, nil
+}
+
+// getContractHoldersAtHeight returns all holders of the contract with non-zero balance after the block at height sorted by the balance,
+// the balances of all holders of the contract are read and sorted
+func (d *RocksDB) getContractHoldersAtHeight(contract bchain.AddressDescriptor, height uint32) ([]ContractHolder, error) {
+	holders := make([]ContractHolder, 0, 16)
+	var lastHolder []byte
+	scanned := 0
+	it := d.db.NewIteratorCF(d.ro, d.cfh[cfContractHolders])
+	defer it.Close()
+	for it.Seek(contract); it.Valid(); it.Next() {
+		key := it.Key().Data()
+		if !bytes.HasPrefix(key, contract) {
+			break
+		}
+		if scanned++; scanned > maxContractHolderBalancesScan {
+			return nil, ErrTooManyContractHolderBalances
+		}
+		if len(key) <= len(contract)+packedHeightBytes {
+			continue
+		}
+		holder := key[len(contract) : len(key)-packedHeightBytes]
+		// the balance of the holder at height was already found
+		if lastHolder != nil && bytes.Equal(holder, lastHolder) {
+			continue
+		}
+		if ^unpackUint(key[len(key)-packedHeightBytes:]) > height {
+			continue
+		}
+		lastHolder = append(lastHolder[:0], holder...)
+		b, _ := unpackBigint(it.Value().Data())
+		if b.Sign() > 0 {
+			holders = append(holders, ContractHolder{
+				AddrDesc: append(bchain.AddressDescriptor(nil), holder...),
+				Balance:  b,
+			})
+		}
+	}
+	sort.Slice(holders, func(i, j int) bool {
+		if c := holders[i].Balance.Cmp(&holders[j].Balance); c != 0 {
+			return c > 0
+		}
+		return bytes.Compare(holders[i].AddrDesc, holders[j].AddrDesc) < 0
+	})
+	return holders, nil
+}
@@ -0,0 +1,170 @@
+// +build unittest
+
+package db
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/tests/dbtestdata"
+)
+
+func TestRocksDB_ContractHolders(t *testing.T) {
+	d := setupRocksDB(t, &testEthereumParser{
+		EthereumParser: ethereumTestnetParser(),
+	})
+	defer closeAndDestroyRocksDB(t, d)
+
+	contract4a := addressToAddrDesc(dbtestdata.EthAddrContract4a, d.chainParser)
+	contract0d := addressToAddrDesc(dbtestdata.EthAddrContract0d, d.chainParser)
+	type holder struct {
+		addr    string
+		balance string
+	}
+	verifyHolders := func(name string, contract []byte, height uint32, want []holder) {
+		t.Helper()
+		holders, total, err := d.GetContractHolders(contract, height, 0, 100)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if total != len(want) {
+			t.Errorf("%s: GetContractHolders() total = %d, want %d", name, total, len(want))
+		}
+		got := make([]holder, len(holders))
+		for i := range holders {
+			got[i] = holder{holders[i].AddrDesc.String(), holders[i].Balance.String()}
+		}
+		if len(got) != len(want) {
+			t.Fatalf("%s: GetContractHolders() = %+v, want %+v", name, got, want)
+		}
+		for i := range want {
+			w := holder{bchain.AddressDescriptor(addressToAddrDesc(want[i].addr, d.chainParser)).String(), want[i].balance}
+			if got[i] != w {
+				t.Errorf("%s: GetContractHolders()[%d] = %+v, want %+v", name, i, got[i], w)
+			}
+		}
+	}
+
+	if err := d.ConnectBlock(dbtestdata.GetTestEthereumTypeBlock1(d.chainParser)); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.ConnectBlock(dbtestdata.GetTestEthereumTypeBlock2(d.chainParser)); err != nil {
+		t.Fatal(err)
+	}
+	// the balances are derived from the indexed transfers, the senders without indexed incoming transfers hold nothing
+	verifyHolders("current", contract4a, 0, []holder{
+		{dbtestdata.EthAddr55, "10000000854307892726464"},
+		{dbtestdata.EthAddr4b, "871180000950184"},
+	})
+	verifyHolders("snapshot", contract4a, 4321000, []holder{
+		{dbtestdata.EthAddr55, "10000000000000000000000"},
+	})
+	verifyHolders("before first transfer", contract4a, 4320999, []holder{})
+	verifyHolders("0d", contract0d, 0, []holder{
+		{dbtestdata.EthAddr7b, "7675000000000000000"},
+	})
+	// the current holders are paged in the column contractHolderRanks
+	holders, total, err := d.GetContractHolders(contract4a, 0, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 2 || len(holders) != 1 || !bytes.Equal(holders[0].AddrDesc, addressToAddrDesc(dbtestdata.EthAddr4b, d.chainParser)) || holders[0].Balance.String() != "871180000950184" {
+		t.Errorf("GetContractHolders() second page = %+v, total %d", holders, total)
+	}
+	if holders, total, err = d.GetContractHolders(contract4a, 4321000, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || len(holders) != 0 {
+		t.Errorf("GetContractHolders() snapshot beyond the last holder = %+v, total %d", holders, total)
+	}
+	b, err := d.getContractHolderBalance(contract4a, addressToAddrDesc(dbtestdata.EthAddr20, d.chainParser))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Sign() != 0 {
+		t.Errorf("getContractHolderBalance() = %v, want 0", b.String())
+	}
+
+	if err = d.DisconnectBlockRangeEthereumType(4321001, 4321001); err != nil {
+		t.Fatal(err)
+	}
+	verifyHolders("after disconnect", contract4a, 0, []holder{
+		{dbtestdata.EthAddr55, "10000000000000000000000"},
+	})
+	verifyHolders("0d after disconnect", contract0d, 0, []holder{})
+	ci, err := d.getStoredContractInfo(contract0d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ci == nil || ci.Holders != 0 {
+		t.Errorf("contract info of 0d after disconnect = %+v", ci)
+	}
+
+	// connect the block again, the balances are continued from the stored ones
+	if err = d.ConnectBlock(dbtestdata.GetTestEthereumTypeBlock2(d.chainParser)); err != nil {
+		t.Fatal(err)
+	}
+	verifyHolders("reconnect", contract4a, 0, []holder{
+		{dbtestdata.EthAddr55, "10000000854307892726464"},
+		{dbtestdata.EthAddr4b, "871180000950184"},
+	})
+	if ci, err = d.getStoredContractInfo(contract4a); err != nil {
+		t.Fatal(err)
+	}
+	if ci.Holders != 2 {
+		t.Errorf("contract info after reconnect = %+v", ci)
+	}
+}
+
+func TestRocksDB_addHolderBalanceChange(t *testing.T) {
+	d := setupRocksDB(t, &testEthereumParser{
+		EthereumParser: ethereumTestnetParser(),
+	})
+	defer closeAndDestroyRocksDB(t, d)
+
+	contract := addressToAddrDesc(dbtestdata.EthAddrContract4a, d.chainParser)
+	holder := addressToAddrDesc(dbtestdata.EthAddr55, d.chainParser)
+	zero := make([]byte, len(holder))
+	cu := &contractUpdate{}
+	if err := d.addHolderBalanceChange(cu, contract, holder, big.NewInt(100), true, 10); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.addHolderBalanceChange(cu, contract, zero, big.NewInt(100), false, 10); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.addHolderBalanceChange(cu, contract, holder, big.NewInt(40), false, 10); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.addHolderBalanceChange(cu, contract, holder, big.NewInt(100), false, 11); err != nil {
+		t.Fatal(err)
+	}
+	hb := cu.holderBalances[string(holder)]
+	if cu.holders != 0 || len(cu.holderBalances) != 1 || len(hb.changes) != 2 ||
+		hb.changes[0].height != 10 || hb.changes[0].balance.Int64() != 60 || hb.changes[1].height != 11 || hb.changes[1].balance.Sign() != 0 {
+		t.Errorf("addHolderBalanceChange() = %+v, %+v", cu, hb)
+	}
+}
+
+func Test_packUnpackContractHolderRankKey(t *testing.T) {
+	contract := []byte{1, 2, 3}
+	holder := []byte{4, 5}
+	balances := []*big.Int{big.NewInt(0), big.NewInt(255), big.NewInt(256), big.NewInt(1000000), new(big.Int).Lsh(big.NewInt(1), 200)}
+	var prev []byte
+	for i, b := range balances {
+		key := packContractHolderRankKey(contract, holder, b)
+		h, got, err := unpackContractHolderRankKey(key, len(contract))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(h, holder) || got.Cmp(b) != 0 {
+			t.Errorf("unpackContractHolderRankKey() = %v, %v, want %v, %v", h, got.String(), holder, b.String())
+		}
+		// higher balance must be ordered first
+		if i > 0 && bytes.Compare(key, prev) >= 0 {
+			t.Errorf("packContractHolderRankKey(%v) is not ordered before the lower balance", b.String())
+		}
+		prev = key
+	}
+}
@@ -0,0 +1,465 @@
+package db
+
+import (
+	"os"
+	"time"
+
+	vlq "github.com/bsm/go-vlq"
+	"github.com/golang/glog"
+	"github.com/juju/errors"
+	"github.com/tecbot/gorocksdb"
+	"github.com/trezor/blockbook/bchain"
+)
+
+// ContractInfo is the metadata of a contract stored in the column contracts
+type ContractInfo struct {
+	Contract string
+	// Standard is the token standard of the contract (ERC20, ERC721, ERC1155, TRC20), empty if not known
+	Standard string
+	Name     string
+	Symbol   string
+	Decimals int
+	// CreatedInBlock and CreationTxid identify the transaction which created the contract, zero values if not known
+	CreatedInBlock uint32
+	CreationTxid   string
+	// Creator is the address which deployed the contract, empty if not known
+	Creator string
+	// LastUpdate is the unix time of the last download of the metadata from the backend, 0 if the metadata were not downloaded yet
+	LastUpdate int64
+	// Holders is the number of addresses which have the token of the contract in their list of tokens
+	Holders uint
+	// Transfers is the number of token transfers of the contract
+	Transfers uint
+}
+
+// HasTokenMetadata returns true if the backend returned the token metadata of the contract
+func (ci *ContractInfo) HasTokenMetadata() bool {
+	return ci.Name != "" || ci.Symbol != ""
+}
+
+func appendString(s string, buf, varBuf []byte) []byte {
+	l := packVaruint(uint(len(s)), varBuf)
+	buf = append(buf, varBuf[:l]...)
+	return append(buf, s...)
+}
+
+func unpackString(buf []byte) (string, int, error) {
+	l, ofs := unpackVaruint(buf)
+	if ofs <= 0 || ofs+int(l) > len(buf) {
+		return "", 0, errors.New("Invalid string")
+	}
+	return string(buf[ofs : ofs+int(l)]), ofs + int(l), nil
+}
+
+func (d *RocksDB) packContractInfo(ci *ContractInfo) ([]byte, error) {
+	var btxID []byte
+	if ci.CreationTxid != "" {
+		var err error
+		if btxID, err = d.chainParser.PackTxid(ci.CreationTxid); err != nil {
+			return nil, err
+		}
+	}
+	buf := make([]byte, 0, 64)
+	varBuf := make([]byte, vlq.MaxLen64)
+	buf = appendString(ci.Standard, buf, varBuf)
+	buf = appendString(ci.Name, buf, varBuf)
+	buf = appendString(ci.Symbol, buf, varBuf)
+	l := packVaruint(uint(ci.Decimals), varBuf)
+	buf = append(buf, varBuf[:l]...)
+	l = packVaruint(uint(ci.CreatedInBlock), varBuf)
+	buf = append(buf, varBuf[:l]...)
+	buf = appendString(string(btxID), buf, varBuf)
+	l = vlq.PutInt(varBuf, ci.LastUpdate)
+	buf = append(buf, varBuf[:l]...)
+	var creator bchain.AddressDescriptor
+	if ci.Creator != "" {
+		var err error
+		if creator, err = d.chainParser.GetAddrDescFromAddress(ci.Creator); err != nil {
+			return nil, err
+		}
+	}
+	buf = appendString(string(creator), buf, varBuf)
+	l = packVaruint(ci.Holders, varBuf)
+	buf = append(buf, varBuf[:l]...)
+	l = packVaruint(ci.Transfers, varBuf)
+	buf = append(buf, varBuf[:l]...)
+	return buf, nil
+}
+
+func (d *RocksDB) unpackContractInfo(contract bchain.AddressDescriptor, buf []byte) (*ContractInfo, error) {
+	var ci ContractInfo
+	var err error
+	var l int
+	if ci.Standard, l, err = unpackString(buf); err != nil {
+		return nil, err
+	}
+	buf = buf[l:]
+	if ci.Name, l, err = unpackString(buf); err != nil {
+		return nil, err
+	}
+	buf = buf[l:]
+	if ci.Symbol, l, err = unpackString(buf); err != nil {
+		return nil, err
+	}
+	buf = buf[l:]
+	decimals, l := unpackVaruint(buf)
+	ci.Decimals = int(decimals)
+	buf = buf[l:]
+	height, l := unpackVaruint(buf)
+	ci.CreatedInBlock = uint32(height)
+	buf = buf[l:]
+	btxID, l, err := unpackString(buf)
+	if err != nil {
+		return nil, err
+	}
+	buf = buf[l:]
+	if len(btxID) > 0 {
+		if ci.CreationTxid, err = d.chainParser.UnpackTxid([]byte(btxID)); err != nil {
+			return nil, err
+		}
+	}
+	ci.LastUpdate, l = vlq.Int(buf)
+	if l <= 0 {
+		return nil, errors.New("Invalid contract info")
+	}
+	buf = buf[l:]
+	// the creator and the statistics are not present in the contract infos stored by older versions
+	if len(buf) > 0 {
+		creator, l, err := unpackString(buf)
+		if err != nil {
+			return nil, err
+		}
+		buf = buf[l:]
+		if len(creator) > 0 {
+			addresses, _, _ := d.chainParser.GetAddressesFromAddrDesc(bchain.AddressDescriptor(creator))
+			if len(addresses) > 0 {
+				ci.Creator = addresses[0]
+			}
+		}
+		ci.Holders, l = unpackVaruint(buf)
+		buf = buf[l:]
+		ci.Transfers, _ = unpackVaruint(buf)
+	}
+	addresses, _, _ := d.chainParser.GetAddressesFromAddrDesc(contract)
+	if len(addresses) > 0 {
+		ci.Contract = addresses[0]
+	}
+	return &ci, nil
+}
+
+// getStoredContractInfo returns the contract info from the column contracts, nil if not found
+func (d *RocksDB) getStoredContractInfo(contract bchain.AddressDescriptor) (*ContractInfo, error) {
+	val, err := d.db.GetCF(d.ro, d.cfh[cfContracts], contract)
+	if err != nil {
+		return nil, err
+	}
+	defer val.Free()
+	buf := val.Data()
+	if len(buf) == 0 {
+		return nil, nil
+	}
+	return d.unpackContractInfo(contract, buf)
+}
+
+// StoreContractInfo stores the contract info to the column contracts
+func (d *RocksDB) StoreContractInfo(contract bchain.AddressDescriptor, ci *ContractInfo) error {
+	buf, err := d.packContractInfo(ci)
+	if err != nil {
+		return err
+	}
+	return d.db.PutCF(d.wo, d.cfh[cfContracts], contract, buf)
+}
+
+// fetchContractInfo downloads the token metadata of the contract from the backend
+// it returns nil if the backend is not available
+func (d *RocksDB) fetchContractInfo(contract bchain.AddressDescriptor) (*ContractInfo, error) {
+	if d.chain == nil {
+		return nil, nil
+	}
+	ci := ContractInfo{LastUpdate: time.Now().Unix()}
+	switch d.chainParser.GetChainType() {
+	case bchain.ChainEthereumType:
+		c, err := d.chain.EthereumTypeGetErc20ContractInfo(contract)
+		if err != nil {
+			return nil, err
+		}
+		if c != nil {
+			ci.Name, ci.Symbol, ci.Decimals = c.Name, c.Symbol, c.Decimals
+		}
+	case bchain.ChainTronType:
+		c, err := d.chain.TronTypeGetTrc20ContractInfo(contract)
+		if err != nil {
+			return nil, err
+		}
+		if c != nil {
+			ci.Name, ci.Symbol, ci.Decimals = c.Name, c.Symbol, c.Decimals
+		}
+	default:
+		return nil, nil
+	}
+	addresses, _, _ := d.chainParser.GetAddressesFromAddrDesc(contract)
+	if len(addresses) > 0 {
+		ci.Contract = addresses[0]
+	}
+	return &ci, nil
+}
+
+const (
+	noMetadataExpiration = 24 * time.Hour
+	maxNoMetadataCached  = 100000
+)
+
+// isNoMetadataCached returns true if the backend recently did not return any token metadata for the address
+func (d *RocksDB) isNoMetadataCached(addrDesc bchain.AddressDescriptor) bool {
+	d.noMetadataMux.Lock()
+	defer d.noMetadataMux.Unlock()
+	t, found := d.noMetadata[string(addrDesc)]
+	if !found {
+		return false
+	}
+	if time.Since(time.Unix(t, 0)) > noMetadataExpiration {
+		delete(d.noMetadata, string(addrDesc))
+		return false
+	}
+	return true
+}
+
+// cacheNoMetadata remembers the address without the token metadata so that it is not requested from the backend on every call
+func (d *RocksDB) cacheNoMetadata(addrDesc bchain.AddressDescriptor) {
+	d.noMetadataMux.Lock()
+	defer d.noMetadataMux.Unlock()
+	if len(d.noMetadata) >= maxNoMetadataCached {
+		d.noMetadata = make(map[string]int64)
+	}
+	d.noMetadata[string(addrDesc)] = time.Now().Unix()
+}
+
+// GetContractInfo returns the metadata of the contract from the column contracts
+// if the contract is not yet in the column or its metadata were not downloaded yet, the metadata are downloaded from the backend and stored
+// standard is the token standard of the contract known by the caller, it is stored if not yet known
+// contracts without token metadata and with unknown standard (for example plain addresses) are not stored, they are only remembered in memory for a limited time
+// the function returns nil if the contract is not in the column and the backend is not available
+func (d *RocksDB) GetContractInfo(contract bchain.AddressDescriptor, standard string) (*ContractInfo, error) {
+	ci, err := d.getStoredContractInfo(contract)
+	if err != nil {
+		return nil, err
+	}
+	// the contracts stored by the indexing of the blocks do not have the metadata until they are requested
+	if ci == nil && standard == "" && d.isNoMetadataCached(contract) {
+		return nil, nil
+	}
+	fetch := ci == nil || ci.LastUpdate == 0
+	if !fetch && (ci.Standard != "" || standard == "") {
+		return ci, nil
+	}
+	var nci *ContractInfo
+	if fetch {
+		if nci, err = d.fetchContractInfo(contract); err != nil || nci == nil {
+			return ci, err
+		}
+		if ci == nil && standard == "" && !nci.HasTokenMetadata() {
+			d.cacheNoMetadata(contract)
+			return nci, nil
+		}
+	}
+	// the contract info is concurrently updated by the indexing of the blocks, merge the changes to the stored data under the lock
+	d.contractsMux.Lock()
+	defer d.contractsMux.Unlock()
+	if ci, err = d.getStoredContractInfo(contract); err != nil {
+		return nil, err
+	}
+	if ci == nil {
+		if nci == nil {
+			return nil, nil
+		}
+		ci = nci
+	} else if nci != nil {
+		ci.Name, ci.Symbol, ci.Decimals, ci.LastUpdate = nci.Name, nci.Symbol, nci.Decimals, nci.LastUpdate
+	}
+	if ci.Standard == "" {
+		ci.Standard = standard
+	}
+	if err = d.StoreContractInfo(contract, ci); err != nil {
+		return nil, err
+	}
+	return ci, nil
+}
+
+// RefreshContractInfos downloads again from the backend the metadata of the contracts, which were not updated in the last olderThan period
+// if the backend does not return the token metadata of a contract, the previously stored metadata is kept
+func (d *RocksDB) RefreshContractInfos(olderThan time.Duration, stop chan os.Signal) error {
+	if d.chain == nil {
+		return nil
+	}
+	chainType := d.chainParser.GetChainType()
+	if chainType != bchain.ChainEthereumType && chainType != bchain.ChainTronType {
+		return nil
+	}
+	glog.Info("db: RefreshContractInfos start")
+	start := time.Now()
+	before := start.Add(-olderThan).Unix()
+	var checked, refreshed int
+	it := d.db.NewIteratorCF(d.ro, d.cfh[cfContracts])
+	defer it.Close()
+	for it.SeekToFirst(); it.Valid(); it.Next() {
+		select {
+		case <-stop:
+			return ErrOperationInterrupted
+		default:
+		}
+		checked++
+		contract := append(bchain.AddressDescriptor{}, it.Key().Data()...)
+		ci, err := d.unpackContractInfo(contract, it.Value().Data())
+		if err != nil {
+			glog.Warningf("db: RefreshContractInfos: contract %s: %v", contract, err)
+			continue
+		}
+		// skip the contracts which are not tokens, they were stored only because of their creation
+		if ci.LastUpdate > before || (ci.Standard == "" && !ci.HasTokenMetadata()) {
+			continue
+		}
+		nci, err := d.fetchContractInfo(contract)
+		if err != nil {
+			glog.Warningf("db: RefreshContractInfos: contract %s: %v", ci.Contract, err)
+			continue
+		}
+		if nci == nil {
+			continue
+		}
+		if err = d.updateFetchedContractInfo(contract, nci); err != nil {
+			return err
+		}
+		refreshed++
+	}
+	glog.Info("db: RefreshContractInfos finished, checked ", checked, ", refreshed ", refreshed, " contracts in ", time.Since(start))
+	return it.Err()
+}
+
+// updateFetchedContractInfo stores the refreshed metadata of the contract to the column contracts
+// if the backend did not return the token metadata, the previously stored metadata are kept
+func (d *RocksDB) updateFetchedContractInfo(contract bchain.AddressDescriptor, nci *ContractInfo) error {
+	d.contractsMux.Lock()
+	defer d.contractsMux.Unlock()
+	ci, err := d.getStoredContractInfo(contract)
+	if err != nil || ci == nil {
+		return err
+	}
+	if nci.HasTokenMetadata() {
+		ci.Name, ci.Symbol, ci.Decimals = nci.Name, nci.Symbol, nci.Decimals
+	}
+	ci.LastUpdate = nci.LastUpdate
+	return d.StoreContractInfo(contract, ci)
+}
+
+// contractStandard returns the token standard of an EthereumType contract by the type of its transfers
+func contractStandard(transferType bchain.TokenType) string {
+	switch transferType {
+	case bchain.NonFungibleToken:
+		return "ERC721"
+	case bchain.MultiToken:
+		return "ERC1155"
+	}
+	return "ERC20"
+}
+
+// contractUpdate is a change of the data of a contract done by the connected or disconnected blocks
+type contractUpdate struct {
+	standard  string
+	holders   int
+	transfers int
+	// creator, createdInBlock and creationBtxID are set if the contract was created in a connected block
+	creator        bchain.AddressDescriptor
+	createdInBlock uint32
+	creationBtxID  []byte
+	// disconnectedFrom is set if the contract took part in a disconnected block, which is the lowest disconnected block,
+	// the creation of the contract is removed if it was created in this or higher block
+	disconnectedFrom uint32
+	// holderBalances are the running balances of the holders of the fungible token indexed by the holder address descriptor
+	holderBalances map[string]*holderBalance
+}
+
+func getContractUpdate(contractUpdates map[string]*contractUpdate, contract bchain.AddressDescriptor) *contractUpdate {
+	u, found := contractUpdates[string(contract)]
+	if !found {
+		u = &contractUpdate{}
+		contractUpdates[string(contract)] = u
+	}
+	return u
+}
+
+// addContractCreation records the creation of a contract by a transaction of a connected block
+func (d *RocksDB) addContractCreation(contractUpdates map[string]*contractUpdate, creation *bchain.ContractCreation, height uint32, btxID []byte) (bchain.AddressDescriptor, error) {
+	contract, err := d.chainParser.GetAddrDescFromAddress(creation.Contract)
+	if err != nil {
+		return nil, err
+	}
+	creator, err := d.chainParser.GetAddrDescFromAddress(creation.Creator)
+	if err != nil {
+		return nil, err
+	}
+	u := getContractUpdate(contractUpdates, contract)
+	u.creator = creator
+	u.createdInBlock = height
+	u.creationBtxID = btxID
+	return contract, nil
+}
+
+// countContractTransfers returns the number of the transfers of the contract in the block
+func (d *RocksDB) countContractTransfers(contract bchain.AddressDescriptor, height uint32) (int, error) {
+	n := 0
+	err := d.GetContractTransfers(contract, height, height, func(txid string, height uint32, indexes []int32) error {
+		n += len(indexes)
+		return nil
+	})
+	return n, err
+}
+
+func addDelta(v uint, delta int) uint {
+	if delta < 0 && uint(-delta) > v {
+		return 0
+	}
+	return uint(int(v) + delta)
+}
+
+// storeContractUpdates applies the changes of the contracts to the column contracts
+// the caller must hold contractsMux until the write batch is written to the db
+func (d *RocksDB) storeContractUpdates(wb *gorocksdb.WriteBatch, contractUpdates map[string]*contractUpdate) error {
+	for c, u := range contractUpdates {
+		contract := bchain.AddressDescriptor(c)
+		d.storeHolderBalances(wb, contract, u.holderBalances)
+		ci, err := d.getStoredContractInfo(contract)
+		if err != nil {
+			return err
+		}
+		if ci == nil {
+			// do not store addresses which only took part in the disconnected blocks
+			if u.creationBtxID == nil && u.holders <= 0 && u.transfers <= 0 {
+				continue
+			}
+			ci = &ContractInfo{}
+		}
+		if ci.Standard == "" {
+			ci.Standard = u.standard
+		}
+		ci.Holders = addDelta(ci.Holders, u.holders)
+		ci.Transfers = addDelta(ci.Transfers, u.transfers)
+		if u.creationBtxID != nil {
+			if ci.CreationTxid, err = d.chainParser.UnpackTxid(u.creationBtxID); err != nil {
+				return err
+			}
+			ci.CreatedInBlock = u.createdInBlock
+			ci.Creator = ""
+			if addresses, _, _ := d.chainParser.GetAddressesFromAddrDesc(u.creator); len(addresses) > 0 {
+				ci.Creator = addresses[0]
+			}
+		} else if u.disconnectedFrom > 0 && ci.CreatedInBlock >= u.disconnectedFrom {
+			ci.CreatedInBlock, ci.CreationTxid, ci.Creator = 0, "", ""
+		}
+		buf, err := d.packContractInfo(ci)
+		if err != nil {
+			return err
+		}
+		wb.PutCF(d.cfh[cfContracts], contract, buf)
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+// +build unittest
+
+package db
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/bchain/coins/eth"
+	"github.com/trezor/blockbook/tests/dbtestdata"
+)
+
+// contractInfoTestChain returns the ERC20 metadata of the contracts from a map and counts the calls
+type contractInfoTestChain struct {
+	bchain.BlockChain
+	contracts map[string]*bchain.Erc20Contract
+	calls     int
+}
+
+func (c *contractInfoTestChain) EthereumTypeGetErc20ContractInfo(contractDesc bchain.AddressDescriptor) (*bchain.Erc20Contract, error) {
+	c.calls++
+	return c.contracts[string(contractDesc)], nil
+}
+
+func TestRocksDB_PackUnpackContractInfo(t *testing.T) {
+	d := setupRocksDB(t, &testEthereumParser{
+		EthereumParser: ethereumTestnetParser(),
+	})
+	defer closeAndDestroyRocksDB(t, d)
+
+	contract := addressToAddrDesc(dbtestdata.EthAddrContract4a, d.chainParser)
+	tests := []struct {
+		name string
+		ci   ContractInfo
+	}{
+		{
+			name: "empty",
+			ci:   ContractInfo{Contract: "0x4af4114F73d1c1C903aC9E0361b379D1291808A2"},
+		},
+		{
+			name: "full",
+			ci: ContractInfo{
+				Contract:       "0x4af4114F73d1c1C903aC9E0361b379D1291808A2",
+				Standard:       "ERC20",
+				Name:           "Verity",
+				Symbol:         "VTY",
+				Decimals:       18,
+				CreatedInBlock: 4321000,
+				CreationTxid:   "0xcd647151552b5132b2aef7c9be00dc6f73afc5901dde157aab131335baaa853b",
+				Creator:        "0x555Ee11FBDDc0E49A9bAB358A8941AD95fFDB48f",
+				LastUpdate:     1600000000,
+				Holders:        2,
+				Transfers:      17,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf, err := d.packContractInfo(&tt.ci)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := d.unpackContractInfo(contract, buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(*got, tt.ci) {
+				t.Errorf("unpackContractInfo() = %+v, want %+v", *got, tt.ci)
+			}
+		})
+	}
+	if _, err := d.unpackContractInfo(contract, []byte{0x05, 'E'}); err == nil {
+		t.Error("unpackContractInfo() of invalid data: expected error")
+	}
+}
+
+func TestRocksDB_GetContractInfo(t *testing.T) {
+	d := setupRocksDB(t, &testEthereumParser{
+		EthereumParser: ethereumTestnetParser(),
+	})
+	defer closeAndDestroyRocksDB(t, d)
+
+	contract4a := addressToAddrDesc(dbtestdata.EthAddrContract4a, d.chainParser)
+	contract47 := addressToAddrDesc(dbtestdata.EthAddrContract47, d.chainParser)
+	address := addressToAddrDesc(dbtestdata.EthAddr3e, d.chainParser)
+	chain := &contractInfoTestChain{
+		contracts: map[string]*bchain.Erc20Contract{
+			string(contract4a): {Contract: "0x4af4114F73d1c1C903aC9E0361b379D1291808A2", Name: "Verity", Symbol: "VTY", Decimals: 18},
+		},
+	}
+
+	// without the backend the contract info is not available
+	ci, err := d.GetContractInfo(contract4a, "ERC20")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ci != nil {
+		t.Fatalf("GetContractInfo() without backend = %+v, want nil", ci)
+	}
+
+	d.chain = chain
+	ci, err = d.GetContractInfo(contract4a, "ERC20")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ci == nil || ci.Name != "Verity" || ci.Symbol != "VTY" || ci.Decimals != 18 || ci.Standard != "ERC20" || ci.LastUpdate == 0 {
+		t.Fatalf("GetContractInfo() = %+v", ci)
+	}
+	// the second call is served from the column contracts
+	if ci, err = d.GetContractInfo(contract4a, ""); err != nil {
+		t.Fatal(err)
+	}
+	if chain.calls != 1 || ci.Name != "Verity" || ci.Standard != "ERC20" {
+		t.Fatalf("GetContractInfo() second call = %+v, backend calls %d", ci, chain.calls)
+	}
+
+	// contract without token metadata is stored if its standard is known, the standard is updated later
+	if ci, err = d.GetContractInfo(contract47, ""); err != nil {
+		t.Fatal(err)
+	}
+	if ci == nil || ci.HasTokenMetadata() {
+		t.Fatalf("GetContractInfo() of contract without metadata = %+v", ci)
+	}
+	if ci, err = d.GetContractInfo(contract47, "ERC721"); err != nil {
+		t.Fatal(err)
+	}
+	if ci, err = d.getStoredContractInfo(contract47); err != nil {
+		t.Fatal(err)
+	}
+	if ci == nil || ci.Standard != "ERC721" || chain.calls != 3 {
+		t.Fatalf("getStoredContractInfo() = %+v, backend calls %d", ci, chain.calls)
+	}
+
+	// plain address is not stored
+	if ci, err = d.GetContractInfo(address, ""); err != nil {
+		t.Fatal(err)
+	}
+	if ci == nil || ci.HasTokenMetadata() {
+		t.Fatalf("GetContractInfo() of plain address = %+v", ci)
+	}
+	if ci, err = d.getStoredContractInfo(address); err != nil || ci != nil {
+		t.Fatalf("getStoredContractInfo() of plain address = %+v, %v", ci, err)
+	}
+	// the missing metadata of the plain address are remembered and not requested again from the backend
+	calls := chain.calls
+	if ci, err = d.GetContractInfo(address, ""); err != nil || ci != nil {
+		t.Fatalf("GetContractInfo() of plain address second call = %+v, %v", ci, err)
+	}
+	if chain.calls != calls {
+		t.Fatalf("GetContractInfo() of plain address second call, backend calls %d, want %d", chain.calls, calls)
+	}
+
+	// refresh updates only the outdated contracts and keeps the metadata if the backend does not return it
+	ci, _ = d.getStoredContractInfo(contract4a)
+	ci.LastUpdate = time.Now().Add(-48 * time.Hour).Unix()
+	if err = d.StoreContractInfo(contract4a, ci); err != nil {
+		t.Fatal(err)
+	}
+	chain.contracts[string(contract4a)] = &bchain.Erc20Contract{Name: "Verity Token", Symbol: "VTY", Decimals: 6}
+	chain.calls = 0
+	if err = d.RefreshContractInfos(24*time.Hour, nil); err != nil {
+		t.Fatal(err)
+	}
+	if chain.calls != 1 {
+		t.Errorf("RefreshContractInfos() backend calls %d, want 1", chain.calls)
+	}
+	ci, _ = d.getStoredContractInfo(contract4a)
+	if ci.Name != "Verity Token" || ci.Decimals != 6 || ci.Standard != "ERC20" || time.Since(time.Unix(ci.LastUpdate, 0)) > time.Hour {
+		t.Errorf("refreshed contract info = %+v", ci)
+	}
+	delete(chain.contracts, string(contract4a))
+	if err = d.RefreshContractInfos(0, nil); err != nil {
+		t.Fatal(err)
+	}
+	ci, _ = d.getStoredContractInfo(contract4a)
+	if ci.Name != "Verity Token" || ci.Symbol != "VTY" || ci.Decimals != 6 {
+		t.Errorf("contract info after failed refresh = %+v", ci)
+	}
+}
+
+// contractCreationsEthereumParser returns the configured contract creations of the transactions
+type contractCreationsEthereumParser struct {
+	*eth.EthereumParser
+	creations map[string][]bchain.ContractCreation
+}
+
+func (p *contractCreationsEthereumParser) EthereumTypeGetContractCreationsFromTx(tx *bchain.Tx) ([]bchain.ContractCreation, error) {
+	return p.creations[tx.Txid], nil
+}
+
+func TestRocksDB_ContractCreationAndCounts(t *testing.T) {
+	d := setupRocksDB(t, &contractCreationsEthereumParser{
+		EthereumParser: ethereumTestnetParser(),
+		creations: map[string][]bchain.ContractCreation{
+			"0x" + dbtestdata.EthTxidB2T2: {
+				{Contract: "0x" + dbtestdata.EthAddrContract47, Creator: "0x" + dbtestdata.EthAddr4b},
+			},
+		},
+	})
+	defer closeAndDestroyRocksDB(t, d)
+
+	contract4a := addressToAddrDesc(dbtestdata.EthAddrContract4a, d.chainParser)
+	contract47 := addressToAddrDesc(dbtestdata.EthAddrContract47, d.chainParser)
+	getContractInfo := func(contract bchain.AddressDescriptor) *ContractInfo {
+		ci, err := d.getStoredContractInfo(contract)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ci == nil {
+			t.Fatalf("getStoredContractInfo(%v) = nil", contract)
+		}
+		return ci
+	}
+
+	if err := d.ConnectBlock(dbtestdata.GetTestEthereumTypeBlock1(d.chainParser)); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.ConnectBlock(dbtestdata.GetTestEthereumTypeBlock2(d.chainParser)); err != nil {
+		t.Fatal(err)
+	}
+	ci := getContractInfo(contract47)
+	if ci.CreatedInBlock != 4321001 || ci.CreationTxid != "0x"+dbtestdata.EthTxidB2T2 || ci.Creator != "0x4Bda106325C335dF99eab7fE363cAC8A0ba2a24D" {
+		t.Errorf("contract info of created contract = %+v", ci)
+	}
+	ci = getContractInfo(contract4a)
+	if ci.Standard != "ERC20" || ci.Holders != 2 || ci.Transfers != 3 || ci.CreatedInBlock != 0 || ci.LastUpdate != 0 {
+		t.Errorf("contract info after connect = %+v", ci)
+	}
+
+	if err := d.DisconnectBlockRangeEthereumType(4321001, 4321001); err != nil {
+		t.Fatal(err)
+	}
+	ci = getContractInfo(contract47)
+	if ci.CreatedInBlock != 0 || ci.CreationTxid != "" || ci.Creator != "" {
+		t.Errorf("contract info of created contract after disconnect = %+v", ci)
+	}
+	ci = getContractInfo(contract4a)
+	if ci.Holders != 1 || ci.Transfers != 1 {
+		t.Errorf("contract info after disconnect = %+v", ci)
+	}
+}
@@ -3,8 +3,8 @@ package db
 import (
 	"bytes"
 	"encoding/hex"
+	"math/big"
 
-	vlq "github.com/bsm/go-vlq"
 	"github.com/golang/glog"
 	"github.com/juju/errors"
 	"github.com/tecbot/gorocksdb"
@@ -13,13 +13,19 @@ import (
 )
 
 // AddrContract is Contract address with number of transactions done by given address
+// for ERC721 and ERC1155 contracts it contains also the number of token ids held by the address,
+// the held ids are stored in the column addressTokenIds and read by GetAddrDescTokenHoldings
 type AddrContract struct {
-	Contract bchain.AddressDescriptor
-	Txs      uint
-	Symbol   string
-	Decimals int
-	Name     string
-	Amount   string
+	Type             bchain.TokenType
+	Contract         bchain.AddressDescriptor
+	Txs              uint
+	HeldIds          uint                     // number of ERC721 or ERC1155 token ids held by the address
+	Ids              []big.Int                // ids of ERC721 tokens held by the address, set by GetAddrDescTokenHoldings
+	MultiTokenValues []bchain.MultiTokenValue // ids and amounts of ERC1155 tokens held by the address, set by GetAddrDescTokenHoldings
+	Symbol           string
+	Decimals         int
+	Name             string
+	Amount           string
 }
 
 // AddrContracts contains number of transactions and contracts for an address
@@ -27,12 +33,67 @@ type AddrContracts struct {
 	TotalTxs       uint
 	NonContractTxs uint
 	Contracts      []AddrContract
+	// tokenIds are the changed holdings of the token ids not stored yet, the key is packTokenIdKey,
+	// the value is the held amount (1 for ERC721 token) or nil if the id is no longer held
+	tokenIds map[string]*big.Int
+}
+
+// hasTokenIds returns true for ERC721 and ERC1155 contracts, for which the number of held token ids is stored
+func (ac *AddrContract) hasTokenIds() bool {
+	return ac.Type == bchain.NonFungibleToken || ac.Type == bchain.MultiToken
+}
+
+// unpackBigintChecked unpacks big int, it returns error instead of panic if buf is too short
+func unpackBigintChecked(buf []byte) (big.Int, int, error) {
+	if len(buf) == 0 || int(buf[0]) >= len(buf) {
+		return big.Int{}, 0, errors.New("Invalid packed big int")
+	}
+	v, l := unpackBigint(buf)
+	return v, l, nil
+}
+
+// packMultiTokenValues appends the ids and amounts of ERC1155 tokens to buf
+func packMultiTokenValues(buf []byte, mtvs []bchain.MultiTokenValue, varBuf []byte) []byte {
+	l := packVaruint(uint(len(mtvs)), varBuf)
+	buf = append(buf, varBuf[:l]...)
+	for i := range mtvs {
+		l = packBigint(&mtvs[i].Id, varBuf)
+		buf = append(buf, varBuf[:l]...)
+		l = packBigint(&mtvs[i].Value, varBuf)
+		buf = append(buf, varBuf[:l]...)
+	}
+	return buf
+}
+
+// unpackMultiTokenValues unpacks the ids and amounts of ERC1155 tokens, it returns the number of read bytes
+func unpackMultiTokenValues(buf []byte) ([]bchain.MultiTokenValue, int, error) {
+	n, p := unpackVaruint(buf)
+	if p == 0 || n > uint(len(buf)) {
+		return nil, 0, errors.New("Invalid multi token values")
+	}
+	var err error
+	var l int
+	mtvs := make([]bchain.MultiTokenValue, n)
+	for i := range mtvs {
+		if mtvs[i].Id, l, err = unpackBigintChecked(buf[p:]); err != nil {
+			return nil, 0, err
+		}
+		p += l
+		if mtvs[i].Value, l, err = unpackBigintChecked(buf[p:]); err != nil {
+			return nil, 0, err
+		}
+		p += l
+	}
+	return mtvs, p, nil
 }
 
 func (d *RocksDB) storeAddressContracts(wb *gorocksdb.WriteBatch, acm map[string]*AddrContracts) error {
 	buf := make([]byte, 64)
-	varBuf := make([]byte, vlq.MaxLen64)
+	varBuf := make([]byte, maxPackedBigintBytes)
 	for addrDesc, acs := range acm {
+		if acs != nil {
+			d.storeTokenIds(wb, bchain.AddressDescriptor(addrDesc), acs.tokenIds, varBuf)
+		}
 		// address with 0 contracts is removed from db - happens on disconnect
 		if acs == nil || (acs.NonContractTxs == 0 && len(acs.Contracts) == 0) {
 			wb.DeleteCF(d.cfh[cfAddressContracts], bchain.AddressDescriptor(addrDesc))
@@ -42,10 +103,16 @@ func (d *RocksDB) storeAddressContracts(wb *gorocksdb.WriteBatch, acm map[string
 			buf = append(buf, varBuf[:l]...)
 			l = packVaruint(acs.NonContractTxs, varBuf)
 			buf = append(buf, varBuf[:l]...)
-			for _, ac := range acs.Contracts {
+			for i := range acs.Contracts {
+				ac := &acs.Contracts[i]
 				buf = append(buf, ac.Contract...)
-				l = packVaruint(ac.Txs, varBuf)
+				// the type of the contract is stored in the lowest 2 bits of the number of transactions
+				l = packVaruint(uint(ac.Type)+ac.Txs<<2, varBuf)
 				buf = append(buf, varBuf[:l]...)
+				if ac.hasTokenIds() {
+					l = packVaruint(ac.HeldIds, varBuf)
+					buf = append(buf, varBuf[:l]...)
+				}
 			}
 			wb.PutCF(d.cfh[cfAddressContracts], bchain.AddressDescriptor(addrDesc), buf)
 		}
@@ -74,12 +141,20 @@ func (d *RocksDB) GetAddrDescContracts(addrDesc bchain.AddressDescriptor) (*Addr
 			return nil, errors.New("Invalid data stored in cfAddressContracts for AddrDesc " + addrDesc.String())
 		}
 		txs, l := unpackVaruint(buf[eth.EthereumTypeAddressDescriptorLen:])
-		contract := append(bchain.AddressDescriptor(nil), buf[:eth.EthereumTypeAddressDescriptorLen]...)
-		c = append(c, AddrContract{
-			Contract: contract,
-			Txs:      txs,
-		})
+		ac := AddrContract{
+			Type:     bchain.TokenType(txs & 3),
+			Contract: append(bchain.AddressDescriptor(nil), buf[:eth.EthereumTypeAddressDescriptorLen]...),
+			Txs:      txs >> 2,
+		}
 		buf = buf[eth.EthereumTypeAddressDescriptorLen+l:]
+		if ac.hasTokenIds() {
+			ac.HeldIds, l = unpackVaruint(buf)
+			if l == 0 {
+				return nil, errors.New("Invalid data stored in cfAddressContracts for AddrDesc " + addrDesc.String())
+			}
+			buf = buf[l:]
+		}
+		c = append(c, ac)
 	}
 	return &AddrContracts{
 		TotalTxs:       tt,
@@ -97,6 +172,22 @@ func findContractInAddressContracts(contract bchain.AddressDescriptor, contracts
 	return 0, false
 }
 
+// holdsTokens returns true if the address holds some ERC721 or ERC1155 tokens of the contract
+func (ac *AddrContract) holdsTokens() bool {
+	return ac.HeldIds > 0
+}
+
+// countHolder updates the number of holders of the contract if the address started or stopped holding its tokens
+func countHolder(cu *contractUpdate, held, holds bool) {
+	if held != holds {
+		if holds {
+			cu.holders++
+		} else {
+			cu.holders--
+		}
+	}
+}
+
 func isZeroAddress(addrDesc bchain.AddressDescriptor) bool {
 	for _, b := range addrDesc {
 		if b != 0 {
@@ -106,7 +197,7 @@ func isZeroAddress(addrDesc bchain.AddressDescriptor) bool {
 	return true
 }
 
-func (d *RocksDB) addToAddressesAndContractsEthereumType(addrDesc bchain.AddressDescriptor, btxID []byte, index int32, contract bchain.AddressDescriptor, addresses addressesMap, addressContracts map[string]*AddrContracts, addTxCount bool) error {
+func (d *RocksDB) addToAddressesAndContractsEthereumType(addrDesc bchain.AddressDescriptor, btxID []byte, index int32, contract bchain.AddressDescriptor, transfer *bchain.Erc20Transfer, addresses addressesMap, addressContracts map[string]*AddrContracts, contractUpdates map[string]*contractUpdate, addTxCount bool) error {
 	var err error
 	strAddrDesc := string(addrDesc)
 	ac, e := addressContracts[strAddrDesc]
@@ -134,7 +225,15 @@ func (d *RocksDB) addToAddressesAndContractsEthereumType(addrDesc bchain.Address
 			i, found := findContractInAddressContracts(contract, ac.Contracts)
 			if !found {
 				i = len(ac.Contracts)
-				ac.Contracts = append(ac.Contracts, AddrContract{Contract: contract})
+				ac.Contracts = append(ac.Contracts, AddrContract{Contract: contract, Type: transfer.Type})
+			}
+			// the holdings do not change by a transfer to self
+			if transfer.From != transfer.To {
+				held := ac.Contracts[i].holdsTokens()
+				if err = d.updateTokenHoldings(addrDesc, ac, &ac.Contracts[i], transfer.Type, &transfer.Tokens, transfer.MultiTokenValues, index >= 0); err != nil {
+					return err
+				}
+				countHolder(getContractUpdate(contractUpdates, contract), held, ac.Contracts[i].holdsTokens())
 			}
 			// index 0 is for ETH transfers, contract indexes start with 1
 			if index < 0 {
@@ -155,8 +254,20 @@ func (d *RocksDB) addToAddressesAndContractsEthereumType(addrDesc bchain.Address
 	return nil
 }
 
+// directions of the token transfer of the address stored in blockTxs
+const (
+	transferSent     = 0
+	transferReceived = 1
+	// from and to addresses are the same, the token holdings were not changed
+	transferSelf = 2
+)
+
 type ethBlockTxContract struct {
-	addr, contract bchain.AddressDescriptor
+	addr, contract   bchain.AddressDescriptor
+	transferType     bchain.TokenType
+	direction        int
+	value            big.Int // id of ERC721 token
+	multiTokenValues []bchain.MultiTokenValue
 }
 
 type ethBlockTx struct {
@@ -165,7 +276,11 @@ type ethBlockTx struct {
 	contracts []ethBlockTxContract
 }
 
-func (d *RocksDB) processAddressesEthereumType(block *bchain.Block, addresses addressesMap, addressContracts map[string]*AddrContracts) ([]ethBlockTx, error) {
+// processAddressesEthereumType indexes the addresses and contracts of the block
+// contractTransfers collects for each token contract the transactions with its transfers and the indexes of the transfers
+// approvals collects the ERC20 Approval events of the block for the owners and the spenders
+// contractUpdates collects the created contracts and the changes of the numbers of holders and transfers of the contracts
+func (d *RocksDB) processAddressesEthereumType(block *bchain.Block, addresses addressesMap, addressContracts map[string]*AddrContracts, contractTransfers addressesMap, approvals tokenApprovalsMap, contractUpdates map[string]*contractUpdate) ([]ethBlockTx, error) {
 	blockTxs := make([]ethBlockTx, len(block.Txs))
 	for txi, tx := range block.Txs {
 		btxID, err := d.chainParser.PackTxid(tx.Txid)
@@ -185,11 +300,33 @@ func (d *RocksDB) processAddressesEthereumType(block *bchain.Block, addresses ad
 				}
 				continue
 			}
-			if err = d.addToAddressesAndContractsEthereumType(to, btxID, 0, nil, addresses, addressContracts, true); err != nil {
+			if err = d.addToAddressesAndContractsEthereumType(to, btxID, 0, nil, nil, addresses, addressContracts, contractUpdates, true); err != nil {
 				return nil, err
 			}
 			blockTx.to = to
 		}
+		// store the contracts created by the transaction
+		creations, err := d.chainParser.EthereumTypeGetContractCreationsFromTx(&tx)
+		if err != nil {
+			glog.Warningf("rocksdb: GetContractCreationsFromTx %v - height %d, tx %v", err, block.Height, tx.Txid)
+		}
+		for i := range creations {
+			c := &creations[i]
+			contract, err := d.addContractCreation(contractUpdates, c, block.Height, btxID)
+			if err != nil {
+				glog.Warningf("rocksdb: GetContractCreationsFromTx %v - height %d, tx %v, creation %v", err, block.Height, tx.Txid, c)
+				continue
+			}
+			// the contract deployed by the transaction itself is stored as its output address,
+			// the contracts deployed by internal calls are stored as the participants of the internal transfers
+			if to == nil && len(tx.Vin) == 1 && len(tx.Vin[0].Addresses) == 1 && c.Creator == tx.Vin[0].Addresses[0] {
+				if err = d.addToAddressesAndContractsEthereumType(contract, btxID, 0, nil, nil, addresses, addressContracts, contractUpdates, true); err != nil {
+					return nil, err
+				}
+				blockTx.to = contract
+				to = contract
+			}
+		}
 		// there is only one input address in EthereumType transaction, store it in format txid ^0
 		if len(tx.Vin) == 1 && len(tx.Vin[0].Addresses) == 1 {
 			from, err = d.chainParser.GetAddrDescFromAddress(tx.Vin[0].Addresses[0])
@@ -199,7 +336,7 @@ func (d *RocksDB) processAddressesEthereumType(block *bchain.Block, addresses ad
 				}
 				continue
 			}
-			if err = d.addToAddressesAndContractsEthereumType(from, btxID, ^int32(0), nil, addresses, addressContracts, !bytes.Equal(from, to)); err != nil {
+			if err = d.addToAddressesAndContractsEthereumType(from, btxID, ^int32(0), nil, nil, addresses, addressContracts, contractUpdates, !bytes.Equal(from, to)); err != nil {
 				return nil, err
 			}
 			blockTx.from = from
@@ -211,7 +348,8 @@ func (d *RocksDB) processAddressesEthereumType(block *bchain.Block, addresses ad
 		}
 		blockTx.contracts = make([]ethBlockTxContract, len(erc20)*2)
 		j := 0
-		for i, t := range erc20 {
+		for i := range erc20 {
+			t := &erc20[i]
 			var contract, from, to bchain.AddressDescriptor
 			contract, err = d.chainParser.GetAddrDescFromAddress(t.Contract)
 			if err == nil {
@@ -224,7 +362,19 @@ func (d *RocksDB) processAddressesEthereumType(block *bchain.Block, addresses ad
 				glog.Warningf("rocksdb: GetErc20FromTx %v - height %d, tx %v, transfer %v", err, block.Height, tx.Txid, t)
 				continue
 			}
-			if err = d.addToAddressesAndContractsEthereumType(to, btxID, int32(i), contract, addresses, addressContracts, true); err != nil {
+			addToAddressesMap(contractTransfers, string(contract), btxID, int32(i))
+			cu := getContractUpdate(contractUpdates, contract)
+			cu.standard = contractStandard(t.Type)
+			cu.transfers++
+			if t.Type == bchain.FungibleToken && !bytes.Equal(from, to) {
+				if err = d.addHolderBalanceChange(cu, contract, from, &t.Tokens, false, block.Height); err != nil {
+					return nil, err
+				}
+				if err = d.addHolderBalanceChange(cu, contract, to, &t.Tokens, true, block.Height); err != nil {
+					return nil, err
+				}
+			}
+			if err = d.addToAddressesAndContractsEthereumType(to, btxID, int32(i), contract, t, addresses, addressContracts, contractUpdates, true); err != nil {
 				return nil, err
 			}
 			eq := bytes.Equal(from, to)
@@ -232,7 +382,8 @@ func (d *RocksDB) processAddressesEthereumType(block *bchain.Block, addresses ad
 			j++
 			bc.addr = from
 			bc.contract = contract
-			if err = d.addToAddressesAndContractsEthereumType(from, btxID, ^int32(i), contract, addresses, addressContracts, !eq); err != nil {
+			bc.setTransfer(t, transferSent)
+			if err = d.addToAddressesAndContractsEthereumType(from, btxID, ^int32(i), contract, t, addresses, addressContracts, contractUpdates, !eq); err != nil {
 				return nil, err
 			}
 			// add to address to blockTx.contracts only if it is different from from address
@@ -241,17 +392,83 @@ func (d *RocksDB) processAddressesEthereumType(block *bchain.Block, addresses ad
 				j++
 				bc.addr = to
 				bc.contract = contract
+				bc.setTransfer(t, transferReceived)
+			} else {
+				bc.direction = transferSelf
 			}
 		}
 		blockTx.contracts = blockTx.contracts[:j]
+		// store erc20 approvals
+		tas, err := d.chainParser.EthereumTypeGetTokenApprovalsFromTx(&tx)
+		if err != nil {
+			glog.Warningf("rocksdb: GetTokenApprovalsFromTx %v - height %d, tx %v", err, block.Height, tx.Txid)
+		}
+		d.addTokenApprovals(approvals, tas, btxID, block.Height, tx.Txid)
+		// store the participants of internal transfers
+		internal, err := d.chainParser.EthereumTypeGetInternalTransfersFromTx(&tx)
+		if err != nil {
+			glog.Warningf("rocksdb: GetInternalTransfersFromTx %v - height %d, tx %v", err, block.Height, tx.Txid)
+		}
+		for i := range internal {
+			t := &internal[i]
+			var from, to bchain.AddressDescriptor
+			from, err = d.chainParser.GetAddrDescFromAddress(t.From)
+			if err == nil {
+				to, err = d.chainParser.GetAddrDescFromAddress(t.To)
+			}
+			if err != nil {
+				glog.Warningf("rocksdb: GetInternalTransfersFromTx %v - height %d, tx %v, transfer %v", err, block.Height, tx.Txid, t)
+				continue
+			}
+			if err = d.addInternalTransferAddressEthereumType(to, btxID, 0, blockTx, addresses, addressContracts, contractUpdates); err != nil {
+				return nil, err
+			}
+			if err = d.addInternalTransferAddressEthereumType(from, btxID, ^int32(0), blockTx, addresses, addressContracts, contractUpdates); err != nil {
+				return nil, err
+			}
+		}
 	}
 	return blockTxs, nil
 }
 
+// addInternalTransferAddressEthereumType indexes a participant of an internal transfer in the same way as the participants of the transaction itself
+// if the address did not take part in the transfer of value of the transaction yet, the transaction is counted
+// and the address is stored in blockTx.contracts with nil contract to be able to disconnect it
+func (d *RocksDB) addInternalTransferAddressEthereumType(addrDesc bchain.AddressDescriptor, btxID []byte, index int32, blockTx *ethBlockTx, addresses addressesMap, addressContracts map[string]*AddrContracts, contractUpdates map[string]*contractUpdate) error {
+	counted := false
+	for _, t := range addresses[string(addrDesc)] {
+		if bytes.Equal(btxID, t.btxID) {
+			for _, i := range t.indexes {
+				if i == index {
+					return nil
+				}
+				if i == 0 || i == ^int32(0) {
+					counted = true
+				}
+			}
+			break
+		}
+	}
+	if err := d.addToAddressesAndContractsEthereumType(addrDesc, btxID, index, nil, nil, addresses, addressContracts, contractUpdates, !counted); err != nil {
+		return err
+	}
+	if !counted {
+		blockTx.contracts = append(blockTx.contracts, ethBlockTxContract{addr: addrDesc})
+	}
+	return nil
+}
+
+func (bc *ethBlockTxContract) setTransfer(t *bchain.Erc20Transfer, direction int) {
+	bc.transferType = t.Type
+	bc.direction = direction
+	bc.value = t.Tokens
+	bc.multiTokenValues = t.MultiTokenValues
+}
+
 func (d *RocksDB) storeAndCleanupBlockTxsEthereumType(wb *gorocksdb.WriteBatch, block *bchain.Block, blockTxs []ethBlockTx) error {
 	pl := d.chainParser.PackedTxidLen()
 	buf := make([]byte, 0, (pl+2*eth.EthereumTypeAddressDescriptorLen)*len(blockTxs))
-	varBuf := make([]byte, vlq.MaxLen64)
+	varBuf := make([]byte, maxPackedBigintBytes)
 	zeroAddress := make([]byte, eth.EthereumTypeAddressDescriptorLen)
 	appendAddress := func(a bchain.AddressDescriptor) {
 		if len(a) != eth.EthereumTypeAddressDescriptorLen {
@@ -271,6 +488,15 @@ func (d *RocksDB) storeAndCleanupBlockTxsEthereumType(wb *gorocksdb.WriteBatch,
 			c := &blockTx.contracts[j]
 			appendAddress(c.addr)
 			appendAddress(c.contract)
+			l = packVaruint(uint(c.transferType)<<2+uint(c.direction), varBuf)
+			buf = append(buf, varBuf[:l]...)
+			switch c.transferType {
+			case bchain.NonFungibleToken:
+				l = packBigint(&c.value, varBuf)
+				buf = append(buf, varBuf[:l]...)
+			case bchain.MultiToken:
+				buf = packMultiTokenValues(buf, c.multiTokenValues, varBuf)
+			}
 		}
 	}
 	key := packUint(block.Height)
@@ -334,6 +560,26 @@ func (d *RocksDB) getBlockTxsEthereumType(height uint32) ([]ethBlockTx, error) {
 			if err != nil {
 				return nil, err
 			}
+			tt, l := unpackVaruint(buf[i:])
+			if l == 0 {
+				glog.Error("rocksdb: Inconsistent data in blockTxs ", hex.EncodeToString(buf))
+				return nil, errors.New("Inconsistent data in blockTxs")
+			}
+			i += l
+			contracts[j].transferType = bchain.TokenType(tt >> 2)
+			contracts[j].direction = int(tt & 3)
+			switch contracts[j].transferType {
+			case bchain.NonFungibleToken:
+				if contracts[j].value, l, err = unpackBigintChecked(buf[i:]); err != nil {
+					return nil, err
+				}
+				i += l
+			case bchain.MultiToken:
+				if contracts[j].multiTokenValues, l, err = unpackMultiTokenValues(buf[i:]); err != nil {
+					return nil, err
+				}
+				i += l
+			}
 		}
 		bt = append(bt, ethBlockTx{
 			btxID:     txid,
@@ -345,10 +591,12 @@ func (d *RocksDB) getBlockTxsEthereumType(height uint32) ([]ethBlockTx, error) {
 	return bt, nil
 }
 
-func (d *RocksDB) disconnectBlockTxsEthereumType(wb *gorocksdb.WriteBatch, height uint32, blockTxs []ethBlockTx, contracts map[string]*AddrContracts) error {
+func (d *RocksDB) disconnectBlockTxsEthereumType(wb *gorocksdb.WriteBatch, height uint32, blockTxs []ethBlockTx, contracts map[string]*AddrContracts, contractUpdates map[string]*contractUpdate) error {
 	glog.Info("Disconnecting block ", height, " containing ", len(blockTxs), " transactions")
 	addresses := make(map[string]map[string]struct{})
-	disconnectAddress := func(btxID []byte, addrDesc, contract bchain.AddressDescriptor) error {
+	transferContracts := make(map[string]struct{})
+	holders := make(map[string]map[string]struct{})
+	disconnectAddress := func(btxID []byte, addrDesc bchain.AddressDescriptor, btc *ethBlockTxContract) error {
 		var err error
 		// do not process empty address
 		if len(addrDesc) == 0 {
@@ -380,15 +628,24 @@ func (d *RocksDB) disconnectBlockTxsEthereumType(wb *gorocksdb.WriteBatch, heigh
 			if !ftx {
 				c.TotalTxs--
 			}
-			if contract == nil {
+			if btc == nil {
 				if c.NonContractTxs > 0 {
 					c.NonContractTxs--
 				} else {
 					glog.Warning("AddressContracts ", addrDesc, ", EthTxs would be negative, tx ", hex.EncodeToString(btxID))
 				}
 			} else {
+				contract := btc.contract
 				i, found := findContractInAddressContracts(contract, c.Contracts)
 				if found {
+					// revert the change of the token holdings
+					if btc.direction != transferSelf {
+						held := c.Contracts[i].holdsTokens()
+						if err = d.updateTokenHoldings(addrDesc, c, &c.Contracts[i], btc.transferType, &btc.value, btc.multiTokenValues, btc.direction == transferSent); err != nil {
+							return err
+						}
+						countHolder(getContractUpdate(contractUpdates, contract), held, c.Contracts[i].holdsTokens())
+					}
 					if c.Contracts[i].Txs > 0 {
 						c.Contracts[i].Txs--
 						if c.Contracts[i].Txs == 0 {
@@ -417,10 +674,29 @@ func (d *RocksDB) disconnectBlockTxsEthereumType(wb *gorocksdb.WriteBatch, heigh
 				return err
 			}
 		}
-		for _, c := range blockTx.contracts {
-			if err := disconnectAddress(blockTx.btxID, c.addr, c.contract); err != nil {
+		// the created contracts are stored as the output address or as the participants of the internal transfers
+		if blockTx.to != nil {
+			getContractUpdate(contractUpdates, blockTx.to).disconnectedFrom = height
+		}
+		for j := range blockTx.contracts {
+			c := &blockTx.contracts[j]
+			// the participants of internal transfers are stored without contract
+			if c.contract == nil {
+				if err := disconnectAddress(blockTx.btxID, c.addr, nil); err != nil {
+					return err
+				}
+				if c.addr != nil {
+					getContractUpdate(contractUpdates, c.addr).disconnectedFrom = height
+				}
+				continue
+			}
+			if err := disconnectAddress(blockTx.btxID, c.addr, c); err != nil {
 				return err
 			}
+			transferContracts[string(c.contract)] = struct{}{}
+			if c.transferType == bchain.FungibleToken {
+				addDisconnectedHolder(holders, c.contract, c.addr)
+			}
 		}
 		wb.DeleteCF(d.cfh[cfTransactions], blockTx.btxID)
 	}
@@ -428,7 +704,19 @@ func (d *RocksDB) disconnectBlockTxsEthereumType(wb *gorocksdb.WriteBatch, heigh
 		key := packAddressKey([]byte(a), height)
 		wb.DeleteCF(d.cfh[cfAddresses], key)
 	}
-	return nil
+	for c := range transferContracts {
+		n, err := d.countContractTransfers(bchain.AddressDescriptor(c), height)
+		if err != nil {
+			return err
+		}
+		getContractUpdate(contractUpdates, bchain.AddressDescriptor(c)).transfers -= n
+		key := packAddressKey([]byte(c), height)
+		wb.DeleteCF(d.cfh[cfContractTransfers], key)
+	}
+	if err := d.disconnectTokenApprovals(wb, height); err != nil {
+		return err
+	}
+	return d.disconnectHolderBalances(wb, height, holders, contractUpdates)
 }
 
 // DisconnectBlockRangeEthereumType removes all data belonging to blocks in range lower-higher
@@ -449,8 +737,9 @@ func (d *RocksDB) DisconnectBlockRangeEthereumType(lower uint32, higher uint32)
 	wb := gorocksdb.NewWriteBatch()
 	defer wb.Destroy()
 	contracts := make(map[string]*AddrContracts)
+	contractUpdates := make(map[string]*contractUpdate)
 	for height := higher; height >= lower; height-- {
-		if err := d.disconnectBlockTxsEthereumType(wb, height, blocks[height-lower], contracts); err != nil {
+		if err := d.disconnectBlockTxsEthereumType(wb, height, blocks[height-lower], contracts, contractUpdates); err != nil {
 			return err
 		}
 		key := packUint(height)
@@ -458,6 +747,11 @@ func (d *RocksDB) DisconnectBlockRangeEthereumType(lower uint32, higher uint32)
 		wb.DeleteCF(d.cfh[cfHeight], key)
 	}
 	d.storeAddressContracts(wb, contracts)
+	d.contractsMux.Lock()
+	defer d.contractsMux.Unlock()
+	if err := d.storeContractUpdates(wb, contractUpdates); err != nil {
+		return err
+	}
 	err := d.db.Write(d.wo, wb)
 	if err == nil {
 		d.is.RemoveLastBlockTimes(int(higher-lower) + 1)
@@ -4,10 +4,12 @@ package db
 
 import (
 	"encoding/hex"
+	"math/big"
 	"reflect"
 	"testing"
 
 	"github.com/juju/errors"
+	"github.com/trezor/blockbook/bchain"
 	"github.com/trezor/blockbook/bchain/coins/eth"
 	"github.com/trezor/blockbook/tests/dbtestdata"
 )
@@ -45,8 +47,8 @@ func verifyAfterEthereumTypeBlock1(t *testing.T, d *RocksDB, afterDisconnect boo
 
 	if err := checkColumn(d, cfAddressContracts, []keyPair{
 		{dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr3e, d.chainParser), "0101", nil},
-		{dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr55, d.chainParser), "0201" + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) + "01", nil},
-		{dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr20, d.chainParser), "0101" + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) + "01", nil},
+		{dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr55, d.chainParser), "0201" + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) + "04", nil},
+		{dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr20, d.chainParser), "0101" + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) + "04", nil},
 		{dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser), "0101", nil},
 	}); err != nil {
 		{
@@ -54,6 +56,14 @@ func verifyAfterEthereumTypeBlock1(t *testing.T, d *RocksDB, afterDisconnect boo
 		}
 	}
 
+	if err := checkColumn(d, cfContractTransfers, []keyPair{
+		{addressKeyHex(dbtestdata.EthAddrContract4a, 4321000, d), txIndexesHex(dbtestdata.EthTxidB1T2, []int32{0}), nil},
+	}); err != nil {
+		{
+			t.Fatal(err)
+		}
+	}
+
 	var blockTxsKp []keyPair
 	if afterDisconnect {
 		blockTxsKp = []keyPair{}
@@ -66,8 +76,8 @@ func verifyAfterEthereumTypeBlock1(t *testing.T, d *RocksDB, afterDisconnect boo
 					dbtestdata.EthTxidB1T2 +
 					dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr20, d.chainParser) + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) +
 					"02" +
-					dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr20, d.chainParser) + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) +
-					dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr55, d.chainParser) + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser),
+					dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr20, d.chainParser) + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) + "00" +
+					dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr55, d.chainParser) + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) + "01",
 				nil,
 			},
 		}
@@ -114,12 +124,12 @@ func verifyAfterEthereumTypeBlock2(t *testing.T, d *RocksDB) {
 
 	if err := checkColumn(d, cfAddressContracts, []keyPair{
 		{dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr3e, d.chainParser), "0101", nil},
-		{dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr55, d.chainParser), "0402" + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) + "02" + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract0d, d.chainParser) + "01", nil},
-		{dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr20, d.chainParser), "0101" + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) + "01", nil},
+		{dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr55, d.chainParser), "0402" + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) + "08" + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract0d, d.chainParser) + "04", nil},
+		{dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr20, d.chainParser), "0101" + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) + "04", nil},
 		{dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser), "0101", nil},
 		{dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr9f, d.chainParser), "0101", nil},
-		{dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr4b, d.chainParser), "0101" + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract0d, d.chainParser) + "02" + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) + "02", nil},
-		{dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr7b, d.chainParser), "0100" + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) + "01" + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract0d, d.chainParser) + "01", nil},
+		{dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr4b, d.chainParser), "0101" + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract0d, d.chainParser) + "08" + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) + "08", nil},
+		{dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr7b, d.chainParser), "0100" + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) + "04" + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract0d, d.chainParser) + "04", nil},
 		{dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract47, d.chainParser), "0101", nil},
 	}); err != nil {
 		{
@@ -127,6 +137,16 @@ func verifyAfterEthereumTypeBlock2(t *testing.T, d *RocksDB) {
 		}
 	}
 
+	if err := checkColumn(d, cfContractTransfers, []keyPair{
+		{addressKeyHex(dbtestdata.EthAddrContract4a, 4321000, d), txIndexesHex(dbtestdata.EthTxidB1T2, []int32{0}), nil},
+		{addressKeyHex(dbtestdata.EthAddrContract4a, 4321001, d), txIndexesHex(dbtestdata.EthTxidB2T2, []int32{1, 2}), nil},
+		{addressKeyHex(dbtestdata.EthAddrContract0d, 4321001, d), txIndexesHex(dbtestdata.EthTxidB2T2, []int32{0, 3}), nil},
+	}); err != nil {
+		{
+			t.Fatal(err)
+		}
+	}
+
 	if err := checkColumn(d, cfBlockTxs, []keyPair{
 		{
 			"0041eee9",
@@ -135,14 +155,14 @@ func verifyAfterEthereumTypeBlock2(t *testing.T, d *RocksDB) {
 				dbtestdata.EthTxidB2T2 +
 				dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr4b, d.chainParser) + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract47, d.chainParser) +
 				"08" +
-				dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr55, d.chainParser) + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract0d, d.chainParser) +
-				dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr4b, d.chainParser) + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract0d, d.chainParser) +
-				dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr4b, d.chainParser) + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) +
-				dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr55, d.chainParser) + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) +
-				dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr7b, d.chainParser) + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) +
-				dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr4b, d.chainParser) + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) +
-				dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr4b, d.chainParser) + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract0d, d.chainParser) +
-				dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr7b, d.chainParser) + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract0d, d.chainParser),
+				dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr55, d.chainParser) + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract0d, d.chainParser) + "00" +
+				dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr4b, d.chainParser) + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract0d, d.chainParser) + "01" +
+				dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr4b, d.chainParser) + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) + "00" +
+				dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr55, d.chainParser) + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) + "01" +
+				dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr7b, d.chainParser) + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) + "00" +
+				dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr4b, d.chainParser) + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract4a, d.chainParser) + "01" +
+				dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr4b, d.chainParser) + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract0d, d.chainParser) + "00" +
+				dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr7b, d.chainParser) + dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract0d, d.chainParser) + "01",
 			nil,
 		},
 	}); err != nil {
@@ -203,6 +223,29 @@ func TestRocksDB_Index_EthereumType(t *testing.T) {
 	}, nil)
 	verifyGetTransactions(t, d, "mtGXQvBowMkBpnhLckhxhbwYK44Gs9eBad", 500000, 1000000, []txidIndex{}, errors.New("Address missing"))
 
+	// get transfers of a contract
+	contract, err := d.chainParser.GetAddrDescFromAddress("0x" + dbtestdata.EthAddrContract4a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var transfers []txidIndex
+	if err = d.GetContractTransfers(contract, 0, 10000000, func(txid string, height uint32, indexes []int32) error {
+		for _, index := range indexes {
+			transfers = append(transfers, txidIndex{txid, index})
+		}
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	wantTransfers := []txidIndex{
+		{"0x" + dbtestdata.EthTxidB2T2, 1},
+		{"0x" + dbtestdata.EthTxidB2T2, 2},
+		{"0x" + dbtestdata.EthTxidB1T2, 0},
+	}
+	if !reflect.DeepEqual(transfers, wantTransfers) {
+		t.Errorf("GetContractTransfers() = %+v, want %+v", transfers, wantTransfers)
+	}
+
 	// GetBestBlock
 	height, hash, err := d.GetBestBlock()
 	if err != nil {
@@ -302,3 +345,77 @@ func TestRocksDB_Index_EthereumType(t *testing.T) {
 	}
 
 }
+
+// internalTransfersEthereumParser returns the configured internal transfers of the transactions
+type internalTransfersEthereumParser struct {
+	*eth.EthereumParser
+	internalTransfers map[string][]bchain.EthereumInternalTransfer
+}
+
+func (p *internalTransfersEthereumParser) EthereumTypeGetInternalTransfersFromTx(tx *bchain.Tx) ([]bchain.EthereumInternalTransfer, error) {
+	return p.internalTransfers[tx.Txid], nil
+}
+
+func TestRocksDB_Index_EthereumType_InternalTransfers(t *testing.T) {
+	d := setupRocksDB(t, &internalTransfersEthereumParser{
+		EthereumParser: ethereumTestnetParser(),
+		internalTransfers: map[string][]bchain.EthereumInternalTransfer{
+			"0x" + dbtestdata.EthTxidB2T2: {
+				{Type: bchain.CALL, From: "0x" + dbtestdata.EthAddrContract47, To: "0x" + dbtestdata.EthAddr20, Value: *big.NewInt(1000)},
+				{Type: bchain.CALL, From: "0x" + dbtestdata.EthAddrContract47, To: "0x" + dbtestdata.EthAddr20, Value: *big.NewInt(2000)},
+				{Type: bchain.CALL, From: "0x" + dbtestdata.EthAddrContract47, To: "0x" + dbtestdata.EthAddr55, Value: *big.NewInt(3000)},
+			},
+		},
+	})
+	defer closeAndDestroyRocksDB(t, d)
+
+	if err := d.ConnectBlock(dbtestdata.GetTestEthereumTypeBlock1(d.chainParser)); err != nil {
+		t.Fatal(err)
+	}
+	addr20, err := d.chainParser.GetAddrDescFromAddress(dbtestdata.EthAddr20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acs, err := d.GetAddrDescContracts(addr20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	totalTxs := acs.TotalTxs
+
+	if err := d.ConnectBlock(dbtestdata.GetTestEthereumTypeBlock2(d.chainParser)); err != nil {
+		t.Fatal(err)
+	}
+	// the recipient of the internal transfers is indexed only once
+	verifyGetTransactions(t, d, "0x"+dbtestdata.EthAddr20, 0, 10000000, []txidIndex{
+		{"0x" + dbtestdata.EthTxidB2T2, 0},
+		{"0x" + dbtestdata.EthTxidB1T2, ^0},
+		{"0x" + dbtestdata.EthTxidB1T2, ^1},
+	}, nil)
+	// the contract is already the recipient of the transaction
+	verifyGetTransactions(t, d, "0x"+dbtestdata.EthAddrContract47, 4321001, 4321001, []txidIndex{
+		{"0x" + dbtestdata.EthTxidB2T2, 0},
+		{"0x" + dbtestdata.EthTxidB2T2, ^0},
+	}, nil)
+	acs, err = d.GetAddrDescContracts(addr20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acs.TotalTxs != totalTxs+1 {
+		t.Errorf("GetAddrDescContracts() TotalTxs = %d, want %d", acs.TotalTxs, totalTxs+1)
+	}
+
+	if err = d.DisconnectBlockRangeEthereumType(4321001, 4321001); err != nil {
+		t.Fatal(err)
+	}
+	verifyGetTransactions(t, d, "0x"+dbtestdata.EthAddr20, 0, 10000000, []txidIndex{
+		{"0x" + dbtestdata.EthTxidB1T2, ^0},
+		{"0x" + dbtestdata.EthTxidB1T2, ^1},
+	}, nil)
+	acs, err = d.GetAddrDescContracts(addr20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acs.TotalTxs != totalTxs {
+		t.Errorf("GetAddrDescContracts() after disconnect TotalTxs = %d, want %d", acs.TotalTxs, totalTxs)
+	}
+}
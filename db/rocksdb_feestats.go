@@ -0,0 +1,123 @@
+package db
+
+import (
+	"math"
+	"math/big"
+	"sort"
+
+	vlq "github.com/bsm/go-vlq"
+	"github.com/juju/errors"
+	"github.com/tecbot/gorocksdb"
+	"github.com/trezor/blockbook/bchain"
+)
+
+// BlockFeeStats contains the fee rate deciles of the transactions of a block
+// the fee rates are in satoshi per 1000 bytes of the virtual size of the transaction
+type BlockFeeStats struct {
+	TxCount         uint32
+	DecilesFeePerKb [11]int64
+}
+
+func packBlockFeeStats(s *BlockFeeStats) []byte {
+	buf := make([]byte, 0, vlq.MaxLen32+len(s.DecilesFeePerKb)*vlq.MaxLen64)
+	varBuf := make([]byte, vlq.MaxLen64)
+	l := packVaruint(uint(s.TxCount), varBuf)
+	buf = append(buf, varBuf[:l]...)
+	for _, f := range s.DecilesFeePerKb {
+		l = packVaruint(uint(f), varBuf)
+		buf = append(buf, varBuf[:l]...)
+	}
+	return buf
+}
+
+func unpackBlockFeeStats(buf []byte) (*BlockFeeStats, error) {
+	var s BlockFeeStats
+	txCount, l := unpackVaruint(buf)
+	if l <= 0 {
+		return nil, errors.New("Invalid block fee stats")
+	}
+	s.TxCount = uint32(txCount)
+	buf = buf[l:]
+	for i := range s.DecilesFeePerKb {
+		f, l := unpackVaruint(buf)
+		if l <= 0 {
+			return nil, errors.New("Invalid block fee stats")
+		}
+		s.DecilesFeePerKb[i] = int64(f)
+		buf = buf[l:]
+	}
+	return &s, nil
+}
+
+// computeBlockFeeStats computes the fee rate deciles of the block transactions from their input and output values in txAddressesMap
+// it returns nil if there is no transaction with known virtual size and fee, e.g. if the parser of the coin does not set the virtual size
+func (d *RocksDB) computeBlockFeeStats(block *bchain.Block, txAddressesMap map[string]*TxAddresses) *BlockFeeStats {
+	feesPerKb := make([]int64, 0, len(block.Txs))
+	var fee big.Int
+	for i := range block.Txs {
+		tx := &block.Txs[i]
+		if tx.VSize <= 0 || (len(tx.Vin) > 0 && tx.Vin[0].Coinbase != "") {
+			continue
+		}
+		btxID, err := d.chainParser.PackTxid(tx.Txid)
+		if err != nil {
+			continue
+		}
+		ta, found := txAddressesMap[string(btxID)]
+		if !found {
+			continue
+		}
+		fee.SetInt64(0)
+		for j := range ta.Inputs {
+			fee.Add(&fee, &ta.Inputs[j].ValueSat)
+		}
+		for j := range ta.Outputs {
+			fee.Sub(&fee, &ta.Outputs[j].ValueSat)
+		}
+		// the values of inputs are not known if the index does not contain the spent outputs
+		if fee.Sign() < 0 || !fee.IsInt64() {
+			continue
+		}
+		feesPerKb = append(feesPerKb, int64(float64(fee.Int64())/float64(tx.VSize)*1000))
+	}
+	n := len(feesPerKb)
+	if n == 0 {
+		return nil
+	}
+	s := BlockFeeStats{TxCount: uint32(n)}
+	sort.Slice(feesPerKb, func(i, j int) bool { return feesPerKb[i] < feesPerKb[j] })
+	for k := range s.DecilesFeePerKb {
+		index := int(math.Floor(0.5+float64(k)*float64(n+1)/10)) - 1
+		if index < 0 {
+			index = 0
+		} else if index >= n {
+			index = n - 1
+		}
+		s.DecilesFeePerKb[k] = feesPerKb[index]
+	}
+	return &s
+}
+
+func (d *RocksDB) storeBlockFeeStats(wb *gorocksdb.WriteBatch, height uint32, s *BlockFeeStats) error {
+	if s != nil {
+		wb.PutCF(d.cfh[cfBlockFeeStats], packUint(height), packBlockFeeStats(s))
+	}
+	return nil
+}
+
+// GetBlockFeeStats returns the fee stats of the block at given height, nil if the stats are not stored
+func (d *RocksDB) GetBlockFeeStats(height uint32) (*BlockFeeStats, error) {
+	if d.chainParser.GetChainType() != bchain.ChainBitcoinType {
+		return nil, nil
+	}
+	val, err := d.db.GetCF(d.ro, d.cfh[cfBlockFeeStats], packUint(height))
+	if err != nil {
+		return nil, err
+	}
+	defer val.Free()
+	buf := val.Data()
+	if len(buf) == 0 {
+		return nil, nil
+	}
+	return unpackBlockFeeStats(buf)
+}
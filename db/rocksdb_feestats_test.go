@@ -0,0 +1,96 @@
+// +build unittest
+
+package db
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/tecbot/gorocksdb"
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/tests/dbtestdata"
+)
+
+func Test_packBlockFeeStats_unpackBlockFeeStats(t *testing.T) {
+	tests := []BlockFeeStats{
+		{},
+		{TxCount: 1, DecilesFeePerKb: [11]int64{1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000, 1000}},
+		{TxCount: 2345, DecilesFeePerKb: [11]int64{0, 1, 1012, 2000, 4567, 10000, 23456, 54321, 123456, 1000000, 987654321}},
+	}
+	for _, tt := range tests {
+		got, err := unpackBlockFeeStats(packBlockFeeStats(&tt))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(*got, tt) {
+			t.Errorf("unpackBlockFeeStats() = %+v, want %+v", *got, tt)
+		}
+	}
+	if _, err := unpackBlockFeeStats([]byte{0x05, 0x01}); err == nil {
+		t.Error("unpackBlockFeeStats() of truncated data, want error")
+	}
+}
+
+func TestRocksDB_BlockFeeStats(t *testing.T) {
+	d := setupRocksDB(t, &testBitcoinParser{
+		BitcoinParser: bitcoinTestnetParser(),
+	})
+	defer closeAndDestroyRocksDB(t, d)
+
+	txids := []string{dbtestdata.TxidB1T1, dbtestdata.TxidB1T2, dbtestdata.TxidB2T1, dbtestdata.TxidB2T2, dbtestdata.TxidB2T3}
+	block := &bchain.Block{
+		BlockHeader: bchain.BlockHeader{Height: 100},
+		Txs: []bchain.Tx{
+			// coinbase is skipped
+			{Txid: txids[0], VSize: 100, Vin: []bchain.Vin{{Coinbase: "03"}}},
+			{Txid: txids[1], VSize: 200},
+			{Txid: txids[2], VSize: 250},
+			// unknown virtual size is skipped
+			{Txid: txids[3]},
+			{Txid: txids[4], VSize: 141},
+		},
+	}
+	values := [][2]int64{{0, 5000000000}, {100000, 98000}, {50000, 25000}, {20000, 10000}, {30000, 29859}}
+	txAddressesMap := make(map[string]*TxAddresses)
+	for i, txid := range txids {
+		btxID, err := d.chainParser.PackTxid(txid)
+		if err != nil {
+			t.Fatal(err)
+		}
+		txAddressesMap[string(btxID)] = &TxAddresses{
+			Inputs:  []TxInput{{ValueSat: *big.NewInt(values[i][0])}},
+			Outputs: []TxOutput{{ValueSat: *big.NewInt(values[i][1])}},
+		}
+	}
+	stats := d.computeBlockFeeStats(block, txAddressesMap)
+	want := &BlockFeeStats{
+		TxCount:         3,
+		DecilesFeePerKb: [11]int64{1000, 1000, 1000, 1000, 10000, 10000, 10000, 100000, 100000, 100000, 100000},
+	}
+	if !reflect.DeepEqual(stats, want) {
+		t.Errorf("computeBlockFeeStats() = %+v, want %+v", stats, want)
+	}
+	if got := d.computeBlockFeeStats(&bchain.Block{Txs: block.Txs[:1]}, txAddressesMap); got != nil {
+		t.Errorf("computeBlockFeeStats() of block with coinbase only = %+v, want nil", got)
+	}
+
+	wb := gorocksdb.NewWriteBatch()
+	defer wb.Destroy()
+	if err := d.storeBlockFeeStats(wb, 100, stats); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.db.Write(d.wo, wb); err != nil {
+		t.Fatal(err)
+	}
+	got, err := d.GetBlockFeeStats(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetBlockFeeStats() = %+v, want %+v", got, want)
+	}
+	if got, err = d.GetBlockFeeStats(101); err != nil || got != nil {
+		t.Errorf("GetBlockFeeStats() of missing block = %+v, %v, want nil", got, err)
+	}
+}
@@ -48,7 +48,7 @@ func setupRocksDB(t *testing.T, p bchain.BlockChainParser) *RocksDB {
 	if err != nil {
 		t.Fatal(err)
 	}
-	d, err := NewRocksDB(tmp, 100000, -1, p, nil)
+	d, err := NewRocksDB(tmp, 100000, -1, p, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -586,6 +586,31 @@ func TestRocksDB_Index_BitcoinType(t *testing.T) {
 	}, nil)
 	verifyGetTransactions(t, d, "mtGXQvBowMkBpnhLckhxhbwYK44Gs9eBad", 500000, 1000000, []txidIndex{}, errors.New("checksum mismatch"))
 
+	// GetBlockTxAddresses, only the last block is kept in the blockTxs column
+	btas, err := d.GetBlockTxAddresses(225494)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(btas) != 4 || btas[0].Txid != dbtestdata.TxidB2T1 || btas[3].Txid != dbtestdata.TxidB2T4 {
+		t.Fatalf("GetBlockTxAddresses: got %+v", btas)
+	}
+	addr2, _ := d.chainParser.GetAddrDescFromAddress(dbtestdata.Addr2)
+	addr6, _ := d.chainParser.GetAddrDescFromAddress(dbtestdata.Addr6)
+	for _, ad := range []bchain.AddressDescriptor{addr2, addr6} {
+		found := false
+		for _, a := range btas[0].AddrDescs {
+			if string(a) == string(ad) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("GetBlockTxAddresses: %v not found in %v", ad, btas[0].AddrDescs)
+		}
+	}
+	if btas, err = d.GetBlockTxAddresses(225493); err != nil || btas != nil {
+		t.Errorf("GetBlockTxAddresses of pruned block: got %+v, %v, expected nil", btas, err)
+	}
+
 	// GetBestBlock
 	height, hash, err := d.GetBestBlock()
 	if err != nil {
@@ -803,6 +828,36 @@ func Test_BulkConnect_BitcoinType(t *testing.T) {
 	}
 }
 
+func TestRocksDB_LoadInternalState_version(t *testing.T) {
+	tests := []struct {
+		name    string
+		parser  bchain.BlockChainParser
+		version uint32
+	}{
+		{name: "BitcoinType", parser: bitcoinTestnetParser(), version: dbVersion},
+		{name: "EthereumType", parser: ethereumTestnetParser(), version: dbVersionEthereumType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := setupRocksDB(t, tt.parser)
+			defer closeAndDestroyRocksDB(t, d)
+			for _, c := range d.is.DbColumns {
+				if c.Version != tt.version {
+					t.Fatalf("column %v version = %v, want %v", c.Name, c.Version, tt.version)
+				}
+			}
+			// a column of another version is not compatible
+			d.is.DbColumns[0].Version = tt.version - 1
+			if err := d.StoreInternalState(d.is); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := d.LoadInternalState("coin-unittest"); err == nil {
+				t.Error("LoadInternalState() of incompatible version, expected error")
+			}
+		})
+	}
+}
+
 func Test_packBigint_unpackBigint(t *testing.T) {
 	bigbig1, _ := big.NewInt(0).SetString("123456789123456789012345", 10)
 	bigbig2, _ := big.NewInt(0).SetString("12345678912345678901234512389012345123456789123456789012345123456789123456789012345", 10)
@@ -0,0 +1,213 @@
+package db
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/golang/glog"
+	"github.com/juju/errors"
+	"github.com/tecbot/gorocksdb"
+	"github.com/trezor/blockbook/bchain"
+)
+
+// TokenApproval is an Approval event of a fungible token, in which the address took part as the owner or as the spender
+type TokenApproval struct {
+	Height   uint32
+	Txid     string
+	Contract bchain.AddressDescriptor
+	Owner    bchain.AddressDescriptor
+	Spender  bchain.AddressDescriptor
+	Value    big.Int
+}
+
+// tokenApproval is an Approval event stored for the owner or the spender, counterparty is the other side of the approval
+type tokenApproval struct {
+	btxID        []byte
+	contract     bchain.AddressDescriptor
+	spender      bool
+	counterparty bchain.AddressDescriptor
+	value        big.Int
+}
+
+// tokenApprovalsMap collects the Approval events of a block indexed by the address descriptors of the owners and the spenders,
+// the events of an address are in the order of the block
+type tokenApprovalsMap map[string][]tokenApproval
+
+// addTokenApprovals adds the Approval events of the transaction to the owners and the spenders
+// the approval of the owner to itself is stored only once
+func (d *RocksDB) addTokenApprovals(approvals tokenApprovalsMap, tas []bchain.TokenApproval, btxID []byte, height uint32, txid string) {
+	for i := range tas {
+		ta := &tas[i]
+		contract, err := d.chainParser.GetAddrDescFromAddress(ta.Contract)
+		var owner, spender bchain.AddressDescriptor
+		if err == nil {
+			owner, err = d.chainParser.GetAddrDescFromAddress(ta.Owner)
+			if err == nil {
+				spender, err = d.chainParser.GetAddrDescFromAddress(ta.Spender)
+			}
+		}
+		if err != nil {
+			glog.Warningf("rocksdb: GetTokenApprovalsFromTx %v - height %d, tx %v, approval %v", err, height, txid, ta)
+			continue
+		}
+		approvals[string(owner)] = append(approvals[string(owner)], tokenApproval{
+			btxID:        btxID,
+			contract:     contract,
+			counterparty: spender,
+			value:        ta.Value,
+		})
+		if !bytes.Equal(owner, spender) {
+			approvals[string(spender)] = append(approvals[string(spender)], tokenApproval{
+				btxID:        btxID,
+				contract:     contract,
+				spender:      true,
+				counterparty: owner,
+				value:        ta.Value,
+			})
+		}
+	}
+}
+
+func packTokenApprovals(tas []tokenApproval, buf, varBuf []byte) []byte {
+	for i := range tas {
+		ta := &tas[i]
+		buf = append(buf, ta.btxID...)
+		buf = appendString(string(ta.contract), buf, varBuf)
+		if ta.spender {
+			buf = append(buf, 1)
+		} else {
+			buf = append(buf, 0)
+		}
+		buf = appendString(string(ta.counterparty), buf, varBuf)
+		l := packBigint(&ta.value, varBuf)
+		buf = append(buf, varBuf[:l]...)
+	}
+	return buf
+}
+
+func unpackTokenApprovals(buf []byte, txidUnpackedLen int) ([]tokenApproval, error) {
+	var tas []tokenApproval
+	for len(buf) > 0 {
+		var ta tokenApproval
+		if len(buf) < txidUnpackedLen {
+			return nil, errors.New("Invalid token approval")
+		}
+		ta.btxID = append([]byte(nil), buf[:txidUnpackedLen]...)
+		buf = buf[txidUnpackedLen:]
+		s, l, err := unpackString(buf)
+		if err != nil {
+			return nil, err
+		}
+		ta.contract = bchain.AddressDescriptor(s)
+		buf = buf[l:]
+		if len(buf) == 0 {
+			return nil, errors.New("Invalid token approval")
+		}
+		ta.spender = buf[0] == 1
+		if s, l, err = unpackString(buf[1:]); err != nil {
+			return nil, err
+		}
+		ta.counterparty = bchain.AddressDescriptor(s)
+		buf = buf[1+l:]
+		if ta.value, l, err = unpackBigintChecked(buf); err != nil {
+			return nil, err
+		}
+		buf = buf[l:]
+		tas = append(tas, ta)
+	}
+	return tas, nil
+}
+
+// storeTokenApprovals stores the Approval events of the block for the owners and the spenders
+// if storeIndex is set, the addresses are also stored under the height of the block to be able to disconnect the block,
+// the index is stored together with blockTxs and removed in cleanupBlockTxs
+func (d *RocksDB) storeTokenApprovals(wb *gorocksdb.WriteBatch, height uint32, approvals tokenApprovalsMap, storeIndex bool) {
+	if len(approvals) == 0 {
+		return
+	}
+	buf := make([]byte, 0, 128)
+	varBuf := make([]byte, maxPackedBigintBytes)
+	addrDescs := make([]string, 0, len(approvals))
+	for addrDesc, tas := range approvals {
+		buf = packTokenApprovals(tas, buf[:0], varBuf)
+		wb.PutCF(d.cfh[cfTokenApprovals], packAddressKey(bchain.AddressDescriptor(addrDesc), height), buf)
+		addrDescs = append(addrDescs, addrDesc)
+	}
+	if storeIndex {
+		sort.Strings(addrDescs)
+		buf = buf[:0]
+		for _, addrDesc := range addrDescs {
+			buf = appendString(addrDesc, buf, varBuf)
+		}
+		wb.PutCF(d.cfh[cfTokenApprovals], packUint(height), buf)
+	}
+}
+
+// disconnectTokenApprovals removes the Approval events of the disconnected block using the index of the addresses stored under its height
+func (d *RocksDB) disconnectTokenApprovals(wb *gorocksdb.WriteBatch, height uint32) error {
+	key := packUint(height)
+	val, err := d.db.GetCF(d.ro, d.cfh[cfTokenApprovals], key)
+	if err != nil {
+		return err
+	}
+	defer val.Free()
+	buf := val.Data()
+	for len(buf) > 0 {
+		addrDesc, l, err := unpackString(buf)
+		if err != nil {
+			return err
+		}
+		wb.DeleteCF(d.cfh[cfTokenApprovals], packAddressKey(bchain.AddressDescriptor(addrDesc), height))
+		buf = buf[l:]
+	}
+	wb.DeleteCF(d.cfh[cfTokenApprovals], key)
+	return nil
+}
+
+// GetTokenApprovals returns the Approval events of the fungible tokens, in which the address took part as the owner or as the spender,
+// ordered from the newest to the oldest
+func (d *RocksDB) GetTokenApprovals(addrDesc bchain.AddressDescriptor) ([]TokenApproval, error) {
+	txidUnpackedLen := d.chainParser.PackedTxidLen()
+	var r []TokenApproval
+	it := d.db.NewIteratorCF(d.ro, d.cfh[cfTokenApprovals])
+	defer it.Close()
+	for it.Seek(addrDesc); it.Valid(); it.Next() {
+		key := it.Key().Data()
+		if !bytes.HasPrefix(key, addrDesc) {
+			break
+		}
+		if len(key) != len(addrDesc)+packedHeightBytes {
+			continue
+		}
+		_, height, err := unpackAddressKey(key)
+		if err != nil {
+			return nil, err
+		}
+		tas, err := unpackTokenApprovals(it.Value().Data(), txidUnpackedLen)
+		if err != nil {
+			return nil, errors.Annotatef(err, "address %v, height %v", addrDesc, height)
+		}
+		// the events are stored in the order of the block
+		for i := len(tas) - 1; i >= 0; i-- {
+			ta := &tas[i]
+			txid, err := d.chainParser.UnpackTxid(ta.btxID)
+			if err != nil {
+				return nil, err
+			}
+			a := TokenApproval{
+				Height:   height,
+				Txid:     txid,
+				Contract: ta.contract,
+				Owner:    addrDesc,
+				Spender:  ta.counterparty,
+				Value:    ta.value,
+			}
+			if ta.spender {
+				a.Owner, a.Spender = ta.counterparty, addrDesc
+			}
+			r = append(r, a)
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,211 @@
+// +build unittest
+
+package db
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/bchain/coins/eth"
+	"github.com/trezor/blockbook/tests/dbtestdata"
+)
+
+// tokenApprovalsEthereumParser returns the configured token approvals of the transactions
+type tokenApprovalsEthereumParser struct {
+	*eth.EthereumParser
+	approvals map[string][]bchain.TokenApproval
+}
+
+func (p *tokenApprovalsEthereumParser) EthereumTypeGetTokenApprovalsFromTx(tx *bchain.Tx) ([]bchain.TokenApproval, error) {
+	return p.approvals[tx.Txid], nil
+}
+
+func TestRocksDB_TokenApprovals(t *testing.T) {
+	unlimited, _ := new(big.Int).SetString("ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", 16)
+	d := setupRocksDB(t, &tokenApprovalsEthereumParser{
+		EthereumParser: ethereumTestnetParser(),
+		approvals: map[string][]bchain.TokenApproval{
+			"0x" + dbtestdata.EthTxidB1T2: {
+				{Contract: "0x" + dbtestdata.EthAddrContract4a, Owner: "0x" + dbtestdata.EthAddr55, Spender: "0x" + dbtestdata.EthAddr4b, Value: *big.NewInt(100)},
+			},
+			"0x" + dbtestdata.EthTxidB2T2: {
+				{Contract: "0x" + dbtestdata.EthAddrContract4a, Owner: "0x" + dbtestdata.EthAddr55, Spender: "0x" + dbtestdata.EthAddr4b},
+				{Contract: "0x" + dbtestdata.EthAddrContract0d, Owner: "0x" + dbtestdata.EthAddr55, Spender: "0x" + dbtestdata.EthAddr9f, Value: *unlimited},
+				{Contract: "0x" + dbtestdata.EthAddrContract0d, Owner: "0x" + dbtestdata.EthAddr20, Spender: "0x" + dbtestdata.EthAddr20, Value: *big.NewInt(1)},
+			},
+		},
+	})
+	defer closeAndDestroyRocksDB(t, d)
+
+	type approval struct {
+		height                   uint32
+		txid                     string
+		contract, owner, spender string
+		value                    string
+	}
+	verifyApprovals := func(name, address string, want []approval) {
+		t.Helper()
+		tas, err := d.GetTokenApprovals(addressToAddrDesc(address, d.chainParser))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tas) != len(want) {
+			t.Fatalf("%s: GetTokenApprovals() returned %d approvals, want %d", name, len(tas), len(want))
+		}
+		for i := range want {
+			w := &want[i]
+			got := approval{tas[i].Height, tas[i].Txid, tas[i].Contract.String(), tas[i].Owner.String(), tas[i].Spender.String(), tas[i].Value.String()}
+			exp := approval{w.height, "0x" + w.txid,
+				bchain.AddressDescriptor(addressToAddrDesc(w.contract, d.chainParser)).String(),
+				bchain.AddressDescriptor(addressToAddrDesc(w.owner, d.chainParser)).String(),
+				bchain.AddressDescriptor(addressToAddrDesc(w.spender, d.chainParser)).String(),
+				w.value,
+			}
+			if got != exp {
+				t.Errorf("%s: GetTokenApprovals()[%d] = %+v, want %+v", name, i, got, exp)
+			}
+		}
+	}
+
+	if err := d.ConnectBlock(dbtestdata.GetTestEthereumTypeBlock1(d.chainParser)); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.ConnectBlock(dbtestdata.GetTestEthereumTypeBlock2(d.chainParser)); err != nil {
+		t.Fatal(err)
+	}
+	// the approvals are returned from the newest
+	verifyApprovals("owner", dbtestdata.EthAddr55, []approval{
+		{4321001, dbtestdata.EthTxidB2T2, dbtestdata.EthAddrContract0d, dbtestdata.EthAddr55, dbtestdata.EthAddr9f, unlimited.String()},
+		{4321001, dbtestdata.EthTxidB2T2, dbtestdata.EthAddrContract4a, dbtestdata.EthAddr55, dbtestdata.EthAddr4b, "0"},
+		{4321000, dbtestdata.EthTxidB1T2, dbtestdata.EthAddrContract4a, dbtestdata.EthAddr55, dbtestdata.EthAddr4b, "100"},
+	})
+	verifyApprovals("spender", dbtestdata.EthAddr4b, []approval{
+		{4321001, dbtestdata.EthTxidB2T2, dbtestdata.EthAddrContract4a, dbtestdata.EthAddr55, dbtestdata.EthAddr4b, "0"},
+		{4321000, dbtestdata.EthTxidB1T2, dbtestdata.EthAddrContract4a, dbtestdata.EthAddr55, dbtestdata.EthAddr4b, "100"},
+	})
+	verifyApprovals("self", dbtestdata.EthAddr20, []approval{
+		{4321001, dbtestdata.EthTxidB2T2, dbtestdata.EthAddrContract0d, dbtestdata.EthAddr20, dbtestdata.EthAddr20, "1"},
+	})
+	verifyApprovals("no approvals", dbtestdata.EthAddr7b, []approval{})
+
+	if err := d.DisconnectBlockRangeEthereumType(4321001, 4321001); err != nil {
+		t.Fatal(err)
+	}
+	verifyApprovals("owner after disconnect", dbtestdata.EthAddr55, []approval{
+		{4321000, dbtestdata.EthTxidB1T2, dbtestdata.EthAddrContract4a, dbtestdata.EthAddr55, dbtestdata.EthAddr4b, "100"},
+	})
+	verifyApprovals("spender after disconnect", dbtestdata.EthAddr9f, []approval{})
+	verifyApprovals("self after disconnect", dbtestdata.EthAddr20, []approval{})
+	val, err := d.db.GetCF(d.ro, d.cfh[cfTokenApprovals], packUint(4321001))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer val.Free()
+	if val.Data() != nil {
+		t.Errorf("index of the disconnected block was not removed")
+	}
+}
+
+// approvalsTestBackend serves the eth_getBlockByNumber and eth_getLogs calls of the synchronization of a block
+// with a transaction emitting an ERC20 Approval event, the logs are filtered by the requested topics as the node does
+type approvalsTestBackend struct {
+	block json.RawMessage
+	logs  []map[string]interface{}
+}
+
+func (b *approvalsTestBackend) GetBlockByNumber(number string, fullTxs bool) (interface{}, error) {
+	if number == "latest" {
+		return &types.Header{Number: big.NewInt(4321000), Difficulty: big.NewInt(1)}, nil
+	}
+	return b.block, nil
+}
+
+func (b *approvalsTestBackend) GetLogs(filter map[string]interface{}) ([]map[string]interface{}, error) {
+	topics := make(map[string]struct{})
+	if t, ok := filter["topics"].([]interface{}); ok && len(t) > 0 {
+		if t0, ok := t[0].([]interface{}); ok {
+			for _, s := range t0 {
+				topics[s.(string)] = struct{}{}
+			}
+		}
+	}
+	r := []map[string]interface{}{}
+	for _, l := range b.logs {
+		if _, found := topics[l["topics"].([]string)[0]]; found {
+			r = append(r, l)
+		}
+	}
+	return r, nil
+}
+
+func TestRocksDB_TokenApprovalsFromSyncedBlock(t *testing.T) {
+	txid := "0x" + dbtestdata.EthTxidB1T2
+	backend := &approvalsTestBackend{
+		block: json.RawMessage(`{
+			"hash": "0xeccd6b0031015a19cb7d4e10f28590ba65a6a54ad1baa322b50fe5ad16903895",
+			"parentHash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+			"difficulty": "0x1",
+			"number": "0x41eee8",
+			"timestamp": "0x5b7bfd26",
+			"size": "0x1000",
+			"nonce": "0x0000000000000000",
+			"transactions": [{
+				"hash": "` + txid + `",
+				"nonce": "0xd0",
+				"gasPrice": "0x1",
+				"gas": "0x10000",
+				"to": "0x` + dbtestdata.EthAddrContract4a + `",
+				"value": "0x0",
+				"input": "0x095ea7b3",
+				"blockNumber": "0x41eee8",
+				"from": "0x` + dbtestdata.EthAddr55 + `",
+				"transactionIndex": "0x0"
+			}]
+		}`),
+		logs: []map[string]interface{}{{
+			"address": "0x" + dbtestdata.EthAddrContract4a,
+			"topics": []string{
+				"0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925",
+				"0x000000000000000000000000" + dbtestdata.EthAddr55,
+				"0x000000000000000000000000" + dbtestdata.EthAddr4b,
+			},
+			"data":            "0x0000000000000000000000000000000000000000000000000000000000000064",
+			"transactionHash": txid,
+		}},
+	}
+	srv := rpc.NewServer()
+	defer srv.Stop()
+	if err := srv.RegisterName("eth", backend); err != nil {
+		t.Fatal(err)
+	}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+	config, _ := json.Marshal(&eth.Configuration{RPCURL: ts.URL, RPCTimeout: 5})
+	chain, err := eth.NewEthereumRPC(config, func(bchain.NotificationType) {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, err := chain.GetBlock("", 4321000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := setupRocksDB(t, &testEthereumParser{EthereumParser: chain.GetChainParser().(*eth.EthereumParser)})
+	defer closeAndDestroyRocksDB(t, d)
+	if err := d.ConnectBlock(block); err != nil {
+		t.Fatal(err)
+	}
+	tas, err := d.GetTokenApprovals(addressToAddrDesc(dbtestdata.EthAddr4b, d.chainParser))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tas) != 1 || tas[0].Txid != txid || tas[0].Height != 4321000 || tas[0].Value.String() != "100" ||
+		tas[0].Owner.String() != bchain.AddressDescriptor(addressToAddrDesc(dbtestdata.EthAddr55, d.chainParser)).String() {
+		t.Fatalf("GetTokenApprovals() = %+v, want the approval of the synced block", tas)
+	}
+}
@@ -0,0 +1,144 @@
+package db
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/juju/errors"
+	"github.com/tecbot/gorocksdb"
+	"github.com/trezor/blockbook/bchain"
+)
+
+// packTokenIdKey packs the key of the held token id in the column addressTokenIds without the address
+func packTokenIdKey(contract bchain.AddressDescriptor, id *big.Int) string {
+	varBuf := make([]byte, maxPackedBigintBytes)
+	l := packBigint(id, varBuf)
+	buf := make([]byte, 0, len(contract)+l)
+	buf = append(buf, contract...)
+	return string(append(buf, varBuf[:l]...))
+}
+
+// getTokenIdHolding returns the held amount of the token id, nil if the id is not held by the address,
+// the changes not stored yet are taken from acs.tokenIds
+func (d *RocksDB) getTokenIdHolding(addrDesc bchain.AddressDescriptor, acs *AddrContracts, key string) (*big.Int, error) {
+	if v, found := acs.tokenIds[key]; found {
+		return v, nil
+	}
+	val, err := d.db.GetCF(d.ro, d.cfh[cfAddressTokenIds], append(append([]byte(nil), addrDesc...), key...))
+	if err != nil {
+		return nil, err
+	}
+	defer val.Free()
+	buf := val.Data()
+	if len(buf) == 0 {
+		return nil, nil
+	}
+	v, _, err := unpackBigintChecked(buf)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cfAddressTokenIds for AddrDesc %v", addrDesc)
+	}
+	return &v, nil
+}
+
+// updateTokenIdHolding adds the token id to the holdings of the address or removes it from them,
+// value is the amount of ERC1155 token, it is nil for ERC721 token
+func (d *RocksDB) updateTokenIdHolding(addrDesc bchain.AddressDescriptor, acs *AddrContracts, ac *AddrContract, id, value *big.Int, add bool) error {
+	key := packTokenIdKey(ac.Contract, id)
+	held, err := d.getTokenIdHolding(addrDesc, acs, key)
+	if err != nil {
+		return err
+	}
+	var v *big.Int
+	switch {
+	case add && value == nil:
+		if held != nil {
+			return nil
+		}
+		v = big.NewInt(1)
+	case add:
+		v = new(big.Int).Set(value)
+		if held != nil {
+			v.Add(v, held)
+		}
+	case held == nil:
+		// the history of the address may be incomplete
+		return nil
+	case value != nil:
+		v = new(big.Int).Sub(held, value)
+		// do not keep zero or negative amounts
+		if v.Sign() <= 0 {
+			v = nil
+		}
+	}
+	if held == nil && v != nil {
+		ac.HeldIds++
+	} else if held != nil && v == nil {
+		ac.HeldIds--
+	}
+	if acs.tokenIds == nil {
+		acs.tokenIds = make(map[string]*big.Int)
+	}
+	acs.tokenIds[key] = v
+	return nil
+}
+
+// updateTokenHoldings adds the ERC721 or ERC1155 tokens of a transfer to the holdings of the address or removes them from it,
+// the changes are kept in acs.tokenIds until they are stored by storeAddressContracts
+func (d *RocksDB) updateTokenHoldings(addrDesc bchain.AddressDescriptor, acs *AddrContracts, ac *AddrContract, transferType bchain.TokenType, id *big.Int, mtvs []bchain.MultiTokenValue, add bool) error {
+	switch transferType {
+	case bchain.NonFungibleToken:
+		return d.updateTokenIdHolding(addrDesc, acs, ac, id, nil, add)
+	case bchain.MultiToken:
+		for i := range mtvs {
+			if err := d.updateTokenIdHolding(addrDesc, acs, ac, &mtvs[i].Id, &mtvs[i].Value, add); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// storeTokenIds stores the changed holdings of the token ids of the address to the column addressTokenIds
+func (d *RocksDB) storeTokenIds(wb *gorocksdb.WriteBatch, addrDesc bchain.AddressDescriptor, tokenIds map[string]*big.Int, varBuf []byte) {
+	for k, v := range tokenIds {
+		key := append(append([]byte(nil), addrDesc...), k...)
+		if v == nil {
+			wb.DeleteCF(d.cfh[cfAddressTokenIds], key)
+		} else {
+			l := packBigint(v, varBuf)
+			wb.PutCF(d.cfh[cfAddressTokenIds], key, varBuf[:l])
+		}
+	}
+}
+
+// GetAddrDescTokenHoldings fills the ids of the ERC721 tokens or the ids and amounts of the ERC1155 tokens of the contract held by the address
+func (d *RocksDB) GetAddrDescTokenHoldings(addrDesc bchain.AddressDescriptor, ac *AddrContract) error {
+	ac.Ids = nil
+	ac.MultiTokenValues = nil
+	if ac.HeldIds == 0 {
+		return nil
+	}
+	prefix := append(append([]byte(nil), addrDesc...), ac.Contract...)
+	it := d.db.NewIteratorCF(d.ro, d.cfh[cfAddressTokenIds])
+	defer it.Close()
+	for it.Seek(prefix); it.Valid(); it.Next() {
+		key := it.Key().Data()
+		if !bytes.HasPrefix(key, prefix) {
+			break
+		}
+		id, _, err := unpackBigintChecked(key[len(prefix):])
+		if err != nil {
+			return errors.Annotatef(err, "cfAddressTokenIds for AddrDesc %v", addrDesc)
+		}
+		if ac.Type == bchain.MultiToken {
+			v, _, err := unpackBigintChecked(it.Value().Data())
+			if err != nil {
+				return errors.Annotatef(err, "cfAddressTokenIds for AddrDesc %v", addrDesc)
+			}
+			ac.MultiTokenValues = append(ac.MultiTokenValues, bchain.MultiTokenValue{Id: id, Value: v})
+		} else {
+			ac.Ids = append(ac.Ids, id)
+		}
+	}
+	return nil
+}
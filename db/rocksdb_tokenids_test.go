@@ -0,0 +1,214 @@
+// +build unittest
+
+package db
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/tecbot/gorocksdb"
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/bchain/coins/eth"
+	"github.com/trezor/blockbook/tests/dbtestdata"
+)
+
+func TestRocksDB_TokenIds(t *testing.T) {
+	d := setupRocksDB(t, &testEthereumParser{
+		EthereumParser: ethereumTestnetParser(),
+	})
+	defer closeAndDestroyRocksDB(t, d)
+
+	addrDesc := addressToAddrDesc(dbtestdata.EthAddr55, d.chainParser)
+	addrHex := dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr55, d.chainParser)
+	nftHex := dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract0d, d.chainParser)
+	multiHex := dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract47, d.chainParser)
+	bigId, _ := new(big.Int).SetString("123456789012345678901234567890123456789012345678901234567890", 10)
+
+	update := func(acs *AddrContracts, i int, id *big.Int, mtvs []bchain.MultiTokenValue, add bool) {
+		t.Helper()
+		if err := d.updateTokenHoldings(addrDesc, acs, &acs.Contracts[i], acs.Contracts[i].Type, id, mtvs, add); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store := func(acs *AddrContracts) {
+		t.Helper()
+		wb := gorocksdb.NewWriteBatch()
+		defer wb.Destroy()
+		if err := d.storeAddressContracts(wb, map[string]*AddrContracts{string(addrDesc): acs}); err != nil {
+			t.Fatal(err)
+		}
+		if err := d.db.Write(d.wo, wb); err != nil {
+			t.Fatal(err)
+		}
+	}
+	holdings := func(ac *AddrContract) string {
+		t.Helper()
+		if err := d.GetAddrDescTokenHoldings(addrDesc, ac); err != nil {
+			t.Fatal(err)
+		}
+		r := fmt.Sprint(ac.HeldIds, ":")
+		for i := range ac.Ids {
+			r += " " + ac.Ids[i].String()
+		}
+		for i := range ac.MultiTokenValues {
+			r += " " + ac.MultiTokenValues[i].Id.String() + "=" + ac.MultiTokenValues[i].Value.String()
+		}
+		return r
+	}
+	stored := func() *AddrContracts {
+		t.Helper()
+		acs, err := d.GetAddrDescContracts(addrDesc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if acs == nil || len(acs.Contracts) != 2 {
+			t.Fatalf("GetAddrDescContracts() = %+v, want 2 contracts", acs)
+		}
+		return acs
+	}
+
+	acs := &AddrContracts{
+		TotalTxs: 3,
+		Contracts: []AddrContract{
+			{Type: bchain.NonFungibleToken, Contract: addressToAddrDesc(dbtestdata.EthAddrContract0d, d.chainParser), Txs: 2},
+			{Type: bchain.MultiToken, Contract: addressToAddrDesc(dbtestdata.EthAddrContract47, d.chainParser), Txs: 1},
+		},
+	}
+	// the transfers in the same block see the holdings not stored yet
+	update(acs, 0, big.NewInt(10), nil, true)
+	update(acs, 0, bigId, nil, true)
+	update(acs, 0, big.NewInt(10), nil, true)
+	update(acs, 0, big.NewInt(30), nil, false)
+	update(acs, 1, nil, []bchain.MultiTokenValue{
+		{Id: *big.NewInt(1), Value: *big.NewInt(100)},
+		{Id: *big.NewInt(2), Value: *big.NewInt(5)},
+	}, true)
+	update(acs, 1, nil, []bchain.MultiTokenValue{{Id: *big.NewInt(1), Value: *big.NewInt(30)}}, false)
+	update(acs, 1, nil, []bchain.MultiTokenValue{{Id: *big.NewInt(2), Value: *big.NewInt(5)}}, false)
+	update(acs, 1, nil, []bchain.MultiTokenValue{{Id: *big.NewInt(3), Value: *big.NewInt(1)}}, false)
+	if acs.Contracts[0].HeldIds != 2 || acs.Contracts[1].HeldIds != 1 {
+		t.Fatalf("HeldIds = %d, %d, want 2, 1", acs.Contracts[0].HeldIds, acs.Contracts[1].HeldIds)
+	}
+	store(acs)
+	if err := checkColumn(d, cfAddressContracts, []keyPair{
+		{
+			addrHex,
+			"0300" +
+				nftHex + varuintToHex(uint(bchain.NonFungibleToken)+2<<2) + varuintToHex(2) +
+				multiHex + varuintToHex(uint(bchain.MultiToken)+1<<2) + varuintToHex(1),
+			nil,
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkColumn(d, cfAddressTokenIds, []keyPair{
+		{addrHex + nftHex + bigintToHex(big.NewInt(10)), bigintToHex(big.NewInt(1)), nil},
+		{addrHex + nftHex + bigintToHex(bigId), bigintToHex(big.NewInt(1)), nil},
+		{addrHex + multiHex + bigintToHex(big.NewInt(1)), bigintToHex(big.NewInt(70)), nil},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	acs = stored()
+	if got, want := holdings(&acs.Contracts[0]), "2: 10 "+bigId.String(); got != want {
+		t.Errorf("GetAddrDescTokenHoldings() = %q, want %q", got, want)
+	}
+	if got, want := holdings(&acs.Contracts[1]), "1: 1=70"; got != want {
+		t.Errorf("GetAddrDescTokenHoldings() = %q, want %q", got, want)
+	}
+
+	// the transfers in the next block see the stored holdings
+	update(acs, 0, big.NewInt(10), nil, false)
+	update(acs, 1, nil, []bchain.MultiTokenValue{{Id: *big.NewInt(1), Value: *big.NewInt(70)}}, false)
+	store(acs)
+	if err := checkColumn(d, cfAddressTokenIds, []keyPair{
+		{addrHex + nftHex + bigintToHex(bigId), bigintToHex(big.NewInt(1)), nil},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	acs = stored()
+	if got, want := holdings(&acs.Contracts[0]), "1: "+bigId.String(); got != want {
+		t.Errorf("GetAddrDescTokenHoldings() = %q, want %q", got, want)
+	}
+	if got, want := holdings(&acs.Contracts[1]), "0:"; got != want {
+		t.Errorf("GetAddrDescTokenHoldings() = %q, want %q", got, want)
+	}
+
+	// truncated data must not panic
+	if _, _, err := unpackMultiTokenValues([]byte{2, 1}); err == nil {
+		t.Error("unpackMultiTokenValues() of truncated data, expected error")
+	}
+}
+
+// tokenTransfersEthereumParser returns the configured token transfers of the transactions
+type tokenTransfersEthereumParser struct {
+	*eth.EthereumParser
+	transfers map[string][]bchain.Erc20Transfer
+}
+
+func (p *tokenTransfersEthereumParser) EthereumTypeGetErc20FromTx(tx *bchain.Tx) ([]bchain.Erc20Transfer, error) {
+	return p.transfers[tx.Txid], nil
+}
+
+func TestRocksDB_TokenIds_ConnectDisconnect(t *testing.T) {
+	d := setupRocksDB(t, &tokenTransfersEthereumParser{
+		EthereumParser: ethereumTestnetParser(),
+		transfers: map[string][]bchain.Erc20Transfer{
+			"0x" + dbtestdata.EthTxidB1T1: {
+				{Type: bchain.NonFungibleToken, Contract: "0x" + dbtestdata.EthAddrContract0d, From: "0x" + dbtestdata.EthAddr3e, To: "0x" + dbtestdata.EthAddr55, Tokens: *big.NewInt(7)},
+				{Type: bchain.MultiToken, Contract: "0x" + dbtestdata.EthAddrContract47, From: "0x" + dbtestdata.EthAddr3e, To: "0x" + dbtestdata.EthAddr55, MultiTokenValues: []bchain.MultiTokenValue{
+					{Id: *big.NewInt(1), Value: *big.NewInt(10)},
+				}},
+			},
+			"0x" + dbtestdata.EthTxidB2T1: {
+				{Type: bchain.NonFungibleToken, Contract: "0x" + dbtestdata.EthAddrContract0d, From: "0x" + dbtestdata.EthAddr55, To: "0x" + dbtestdata.EthAddr9f, Tokens: *big.NewInt(7)},
+				{Type: bchain.MultiToken, Contract: "0x" + dbtestdata.EthAddrContract47, From: "0x" + dbtestdata.EthAddr55, To: "0x" + dbtestdata.EthAddr9f, MultiTokenValues: []bchain.MultiTokenValue{
+					{Id: *big.NewInt(1), Value: *big.NewInt(4)},
+				}},
+			},
+		},
+	})
+	defer closeAndDestroyRocksDB(t, d)
+
+	addr55Hex := dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr55, d.chainParser)
+	addr9fHex := dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddr9f, d.chainParser)
+	nftHex := dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract0d, d.chainParser)
+	multiHex := dbtestdata.AddressToPubKeyHex(dbtestdata.EthAddrContract47, d.chainParser)
+	afterBlock1 := []keyPair{
+		{addr55Hex + nftHex + bigintToHex(big.NewInt(7)), bigintToHex(big.NewInt(1)), nil},
+		{addr55Hex + multiHex + bigintToHex(big.NewInt(1)), bigintToHex(big.NewInt(10)), nil},
+	}
+
+	if err := d.ConnectBlock(dbtestdata.GetTestEthereumTypeBlock1(d.chainParser)); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkColumn(d, cfAddressTokenIds, afterBlock1); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.ConnectBlock(dbtestdata.GetTestEthereumTypeBlock2(d.chainParser)); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkColumn(d, cfAddressTokenIds, []keyPair{
+		{addr55Hex + multiHex + bigintToHex(big.NewInt(1)), bigintToHex(big.NewInt(6)), nil},
+		{addr9fHex + nftHex + bigintToHex(big.NewInt(7)), bigintToHex(big.NewInt(1)), nil},
+		{addr9fHex + multiHex + bigintToHex(big.NewInt(1)), bigintToHex(big.NewInt(4)), nil},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := d.DisconnectBlockRangeEthereumType(4321001, 4321001); err != nil {
+		t.Fatal(err)
+	}
+	if err := checkColumn(d, cfAddressTokenIds, afterBlock1); err != nil {
+		t.Fatal(err)
+	}
+	acs, err := d.GetAddrDescContracts(addressToAddrDesc(dbtestdata.EthAddr55, d.chainParser))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range acs.Contracts {
+		if acs.Contracts[i].HeldIds != 1 {
+			t.Errorf("GetAddrDescContracts() after disconnect contract %d HeldIds = %d, want 1", i, acs.Contracts[i].HeldIds)
+		}
+	}
+}
@@ -67,7 +67,7 @@ func (d *RocksDB) GetTronAddrDescContracts(addrDesc bchain.AddressDescriptor) (*
 	}, nil
 }
 
-func (d *RocksDB) addToAddressesAndContractsTronType(addrDesc bchain.AddressDescriptor, btxID []byte, index int32, contract bchain.AddressDescriptor, addresses addressesMap, addressContracts map[string]*AddrContracts, addTxCount bool) error {
+func (d *RocksDB) addToAddressesAndContractsTronType(addrDesc bchain.AddressDescriptor, btxID []byte, index int32, contract bchain.AddressDescriptor, addresses addressesMap, addressContracts map[string]*AddrContracts, contractUpdates map[string]*contractUpdate, addTxCount bool) error {
 	var err error
 	strAddrDesc := hex.EncodeToString(addrDesc)
 	ac, e := addressContracts[strAddrDesc]
@@ -97,7 +97,7 @@ func (d *RocksDB) addToAddressesAndContractsTronType(addrDesc bchain.AddressDesc
 				i = len(ac.Contracts)
 				var c AddrContract
 				c.Contract = contract
-				ci, err := d.chain.TronTypeGetTrc20ContractInfo(contract)
+				ci, err := d.GetContractInfo(contract, "TRC20")
 				if err == nil && ci != nil {
 					c.Name = ci.Name
 					c.Symbol = ci.Symbol
@@ -127,7 +127,9 @@ func (d *RocksDB) addToAddressesAndContractsTronType(addrDesc bchain.AddressDesc
 	return nil
 }
 
-func (d *RocksDB) processAddressesAndContractsTronType(block *bchain.Block, addresses addressesMap, addressContracts map[string]*AddrContracts) ([]tronBlockTx, error) {
+// processAddressesAndContractsTronType indexes the addresses and contracts of the block
+// approvals collects the TRC20 Approval events of the block for the owners and the spenders, also of the transactions without transfers
+func (d *RocksDB) processAddressesAndContractsTronType(block *bchain.Block, addresses addressesMap, addressContracts map[string]*AddrContracts, contractTransfers addressesMap, approvals tokenApprovalsMap, contractUpdates map[string]*contractUpdate) ([]tronBlockTx, error) {
 	var blockTxs []tronBlockTx
 	for _, tx := range block.Txs {
 		btxID, err := d.chainParser.PackTxid(tx.Txid)
@@ -135,6 +137,12 @@ func (d *RocksDB) processAddressesAndContractsTronType(block *bchain.Block, addr
 			return nil, err
 		}
 
+		tas, err := d.chainParser.TronTypeGetTokenApprovalsFromTx(&tx)
+		if err != nil {
+			glog.Warningf("rocksdb: GetTokenApprovalsFromTx %v - height %d, tx %v", err, block.Height, tx.Txid)
+		}
+		d.addTokenApprovals(approvals, tas, btxID, block.Height, tx.Txid)
+
 		values, err := d.chainParser.TronTypeGetTrc20FromTx(&tx)
 		if err != nil {
 			return nil, err
@@ -158,7 +166,7 @@ func (d *RocksDB) processAddressesAndContractsTronType(block *bchain.Block, addr
 				}
 				continue
 			}
-			if err = d.addToAddressesAndContractsTronType(to, btxID, 0, nil, addresses, addressContracts, true); err != nil {
+			if err = d.addToAddressesAndContractsTronType(to, btxID, 0, nil, addresses, addressContracts, contractUpdates, true); err != nil {
 				return nil, err
 			}
 			blockTx.to = to
@@ -172,11 +180,21 @@ func (d *RocksDB) processAddressesAndContractsTronType(block *bchain.Block, addr
 				}
 				continue
 			}
-			if err = d.addToAddressesAndContractsTronType(from, btxID, ^int32(0), nil, addresses, addressContracts, !bytes.Equal(from, to)); err != nil {
+			if err = d.addToAddressesAndContractsTronType(from, btxID, ^int32(0), nil, addresses, addressContracts, contractUpdates, !bytes.Equal(from, to)); err != nil {
 				return nil, err
 			}
 			blockTx.from = from
 		}
+		// store the smart contract created by the transaction, it is stored also as the output address of the transaction
+		creations, err := d.chainParser.TronTypeGetContractCreationsFromTx(&tx)
+		if err != nil {
+			glog.Warningf("rocksdb: GetContractCreationsFromTx %v - height %d, tx %v", err, block.Height, tx.Txid)
+		}
+		for i := range creations {
+			if _, err = d.addContractCreation(contractUpdates, &creations[i], block.Height, btxID); err != nil {
+				glog.Warningf("rocksdb: GetContractCreationsFromTx %v - height %d, tx %v, creation %v", err, block.Height, tx.Txid, creations[i])
+			}
+		}
 		// store erc20 transfers
 		trc20, err := d.chainParser.TronTypeGetTrc20FromTx(&tx)
 		if err != nil {
@@ -200,7 +218,19 @@ func (d *RocksDB) processAddressesAndContractsTronType(block *bchain.Block, addr
 				glog.Warningf("rocksdb: GetTrc20FromTx %v - height %d, tx %v, transfer %v", err, block.Height, tx.Txid, t)
 				continue
 			}
-			if err = d.addToAddressesAndContractsTronType(to, btxID, int32(i), contract, addresses, addressContracts, true); err != nil {
+			addToAddressesMap(contractTransfers, string(contract), btxID, int32(i))
+			cu := getContractUpdate(contractUpdates, contract)
+			cu.standard = "TRC20"
+			cu.transfers++
+			if !bytes.Equal(from, to) {
+				if err = d.addHolderBalanceChange(cu, contract, from, &t.Amount, false, block.Height); err != nil {
+					return nil, err
+				}
+				if err = d.addHolderBalanceChange(cu, contract, to, &t.Amount, true, block.Height); err != nil {
+					return nil, err
+				}
+			}
+			if err = d.addToAddressesAndContractsTronType(to, btxID, int32(i), contract, addresses, addressContracts, contractUpdates, true); err != nil {
 				return nil, err
 			}
 			eq := bytes.Equal(from, to)
@@ -208,7 +238,7 @@ func (d *RocksDB) processAddressesAndContractsTronType(block *bchain.Block, addr
 			j++
 			bc.addr = from
 			bc.contract = contract
-			if err = d.addToAddressesAndContractsTronType(from, btxID, ^int32(i), contract, addresses, addressContracts, !eq); err != nil {
+			if err = d.addToAddressesAndContractsTronType(from, btxID, ^int32(i), contract, addresses, addressContracts, contractUpdates, !eq); err != nil {
 				return nil, err
 			}
 			// add to address to blockTx.contracts only if it is different from from address
@@ -323,9 +353,11 @@ func (d *RocksDB) getBlockTxsTronType(height uint32) ([]tronBlockTx, error) {
 	return bt, nil
 }
 
-func (d *RocksDB) disconnectBlockTxsTronType(wb *gorocksdb.WriteBatch, height uint32, blockTxs []tronBlockTx, contracts map[string]*AddrContracts) error {
+func (d *RocksDB) disconnectBlockTxsTronType(wb *gorocksdb.WriteBatch, height uint32, blockTxs []tronBlockTx, contracts map[string]*AddrContracts, contractUpdates map[string]*contractUpdate) error {
 	glog.Info("Disconnecting block ", height, " containing ", len(blockTxs), " transactions")
 	addresses := make(map[string]map[string]struct{})
+	transferContracts := make(map[string]struct{})
+	holders := make(map[string]map[string]struct{})
 	disconnectAddress := func(btxID []byte, addrDesc, contract bchain.AddressDescriptor) error {
 		var err error
 		// do not process empty address
@@ -395,10 +427,16 @@ func (d *RocksDB) disconnectBlockTxsTronType(wb *gorocksdb.WriteBatch, height ui
 				return err
 			}
 		}
+		// the created smart contract is stored as the output address
+		if blockTx.to != nil {
+			getContractUpdate(contractUpdates, blockTx.to).disconnectedFrom = height
+		}
 		for _, c := range blockTx.contracts {
 			if err := disconnectAddress(blockTx.btxID, c.addr, c.contract); err != nil {
 				return err
 			}
+			transferContracts[string(c.contract)] = struct{}{}
+			addDisconnectedHolder(holders, c.contract, c.addr)
 		}
 		wb.DeleteCF(d.cfh[cfTransactions], blockTx.btxID)
 	}
@@ -406,7 +444,19 @@ func (d *RocksDB) disconnectBlockTxsTronType(wb *gorocksdb.WriteBatch, height ui
 		key := packAddressKey([]byte(a), height)
 		wb.DeleteCF(d.cfh[cfAddresses], key)
 	}
-	return nil
+	for c := range transferContracts {
+		n, err := d.countContractTransfers(bchain.AddressDescriptor(c), height)
+		if err != nil {
+			return err
+		}
+		getContractUpdate(contractUpdates, bchain.AddressDescriptor(c)).transfers -= n
+		key := packAddressKey([]byte(c), height)
+		wb.DeleteCF(d.cfh[cfContractTransfers], key)
+	}
+	if err := d.disconnectTokenApprovals(wb, height); err != nil {
+		return err
+	}
+	return d.disconnectHolderBalances(wb, height, holders, contractUpdates)
 }
 
 // DisconnectBlockRangeEthereumType removes all data belonging to blocks in range lower-higher
@@ -427,8 +477,9 @@ func (d *RocksDB) DisconnectBlockRangeTronType(lower uint32, higher uint32) erro
 	wb := gorocksdb.NewWriteBatch()
 	defer wb.Destroy()
 	contracts := make(map[string]*AddrContracts)
+	contractUpdates := make(map[string]*contractUpdate)
 	for height := higher; height >= lower; height-- {
-		if err := d.disconnectBlockTxsTronType(wb, height, blocks[height-lower], contracts); err != nil {
+		if err := d.disconnectBlockTxsTronType(wb, height, blocks[height-lower], contracts, contractUpdates); err != nil {
 			return err
 		}
 		key := packUint(height)
@@ -436,6 +487,11 @@ func (d *RocksDB) DisconnectBlockRangeTronType(lower uint32, higher uint32) erro
 		wb.DeleteCF(d.cfh[cfHeight], key)
 	}
 	d.storeTronAddressContracts(wb, contracts)
+	d.contractsMux.Lock()
+	defer d.contractsMux.Unlock()
+	if err := d.storeContractUpdates(wb, contractUpdates); err != nil {
+		return err
+	}
 	err := d.db.Write(d.wo, wb)
 	if err == nil {
 		d.is.RemoveLastBlockTimes(int(higher-lower) + 1)
@@ -0,0 +1,213 @@
+package db
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/juju/errors"
+	"github.com/tecbot/gorocksdb"
+	"github.com/trezor/blockbook/bchain"
+)
+
+// the column webhooks contains several kinds of records distinguished by the key prefix
+const (
+	webhookWatchPrefix        = byte('a')
+	webhookConfirmationPrefix = byte('c')
+	webhookQueuePrefix        = byte('q')
+	webhookDeadLetterPrefix   = byte('x')
+)
+
+var webhookHeightKey = []byte{'h'}
+
+// WebhookWatch is a registration of a webhook for notifications about transactions of an address
+type WebhookWatch struct {
+	Address string `json:"address"`
+	URL     string `json:"url"`
+	Secret  string `json:"secret,omitempty"`
+	// Confirmations is the number of confirmations after which the last notification is sent, values 0 and 1 mean no such notification
+	Confirmations uint32 `json:"confirmations,omitempty"`
+}
+
+// WebhookDelivery is a webhook notification waiting for the delivery
+type WebhookDelivery struct {
+	ID          uint64          `json:"id"`
+	URL         string          `json:"url"`
+	Secret      string          `json:"secret,omitempty"`
+	Event       string          `json:"event"`
+	Payload     json.RawMessage `json:"payload"`
+	Attempts    int             `json:"attempts"`
+	NextAttempt time.Time       `json:"nextAttempt"`
+	LastError   string          `json:"lastError,omitempty"`
+}
+
+// WebhookConfirmation is a transaction of a watched address waiting for the required number of confirmations
+type WebhookConfirmation struct {
+	ID     uint64       `json:"id"`
+	Height uint32       `json:"height"`
+	Txid   string       `json:"txid"`
+	Watch  WebhookWatch `json:"watch"`
+}
+
+func packWebhookID(prefix byte, id uint64) []byte {
+	buf := make([]byte, 9)
+	buf[0] = prefix
+	binary.BigEndian.PutUint64(buf[1:], id)
+	return buf
+}
+
+func packWebhookConfirmationKey(height uint32, id uint64) []byte {
+	buf := make([]byte, 13)
+	buf[0] = webhookConfirmationPrefix
+	binary.BigEndian.PutUint32(buf[1:], height)
+	binary.BigEndian.PutUint64(buf[5:], id)
+	return buf
+}
+
+// iterateWebhooks calls fn for all records in the column webhooks with the given prefix
+func (d *RocksDB) iterateWebhooks(prefix byte, fn func(key, val []byte) (bool, error)) error {
+	it := d.db.NewIteratorCF(d.ro, d.cfh[cfWebhooks])
+	defer it.Close()
+	for it.Seek([]byte{prefix}); it.Valid(); it.Next() {
+		key := it.Key().Data()
+		if len(key) == 0 || key[0] != prefix {
+			break
+		}
+		cont, err := fn(key, it.Value().Data())
+		if err != nil {
+			return err
+		}
+		if !cont {
+			break
+		}
+	}
+	return it.Err()
+}
+
+// GetWebhookWatches returns all watched addresses, the map is indexed by the address descriptor
+func (d *RocksDB) GetWebhookWatches() (map[string][]WebhookWatch, error) {
+	r := make(map[string][]WebhookWatch)
+	err := d.iterateWebhooks(webhookWatchPrefix, func(key, val []byte) (bool, error) {
+		var w []WebhookWatch
+		if err := json.Unmarshal(val, &w); err != nil {
+			return false, errors.Annotatef(err, "webhook watch %x", key)
+		}
+		r[string(key[1:])] = w
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// StoreWebhookWatches stores the watches of an address descriptor, empty watches remove the address from the watchlist
+func (d *RocksDB) StoreWebhookWatches(addrDesc bchain.AddressDescriptor, watches []WebhookWatch) error {
+	key := append([]byte{webhookWatchPrefix}, addrDesc...)
+	if len(watches) == 0 {
+		return d.db.DeleteCF(d.wo, d.cfh[cfWebhooks], key)
+	}
+	buf, err := json.Marshal(watches)
+	if err != nil {
+		return err
+	}
+	return d.db.PutCF(d.wo, d.cfh[cfWebhooks], key, buf)
+}
+
+func webhookDeliveryPrefix(deadLetter bool) byte {
+	if deadLetter {
+		return webhookDeadLetterPrefix
+	}
+	return webhookQueuePrefix
+}
+
+// GetWebhookDeliveries returns the deliveries waiting in the queue or in the dead-letter list, ordered by id
+func (d *RocksDB) GetWebhookDeliveries(deadLetter bool) ([]*WebhookDelivery, error) {
+	r := make([]*WebhookDelivery, 0)
+	err := d.iterateWebhooks(webhookDeliveryPrefix(deadLetter), func(key, val []byte) (bool, error) {
+		var wd WebhookDelivery
+		if err := json.Unmarshal(val, &wd); err != nil {
+			glog.Error("rocksdb: webhook delivery ", binary.BigEndian.Uint64(key[1:]), " unmarshal error ", err)
+			return true, nil
+		}
+		r = append(r, &wd)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// StoreWebhookDelivery stores the delivery to the queue or to the dead-letter list
+func (d *RocksDB) StoreWebhookDelivery(wd *WebhookDelivery, deadLetter bool) error {
+	buf, err := json.Marshal(wd)
+	if err != nil {
+		return err
+	}
+	wb := gorocksdb.NewWriteBatch()
+	defer wb.Destroy()
+	if deadLetter {
+		wb.DeleteCF(d.cfh[cfWebhooks], packWebhookID(webhookQueuePrefix, wd.ID))
+	}
+	wb.PutCF(d.cfh[cfWebhooks], packWebhookID(webhookDeliveryPrefix(deadLetter), wd.ID), buf)
+	return d.db.Write(d.wo, wb)
+}
+
+// DeleteWebhookDelivery removes the delivery from the queue or from the dead-letter list
+func (d *RocksDB) DeleteWebhookDelivery(id uint64, deadLetter bool) error {
+	return d.db.DeleteCF(d.wo, d.cfh[cfWebhooks], packWebhookID(webhookDeliveryPrefix(deadLetter), id))
+}
+
+// StoreWebhookConfirmation stores a transaction waiting for the required number of confirmations
+func (d *RocksDB) StoreWebhookConfirmation(wc *WebhookConfirmation) error {
+	buf, err := json.Marshal(wc)
+	if err != nil {
+		return err
+	}
+	return d.db.PutCF(d.wo, d.cfh[cfWebhooks], packWebhookConfirmationKey(wc.Height, wc.ID), buf)
+}
+
+// GetWebhookConfirmations returns transactions waiting for confirmations which reach the required number of confirmations at the height or lower
+func (d *RocksDB) GetWebhookConfirmations(height uint32) ([]*WebhookConfirmation, error) {
+	var r []*WebhookConfirmation
+	err := d.iterateWebhooks(webhookConfirmationPrefix, func(key, val []byte) (bool, error) {
+		if len(key) != 13 || binary.BigEndian.Uint32(key[1:]) > height {
+			return false, nil
+		}
+		var wc WebhookConfirmation
+		if err := json.Unmarshal(val, &wc); err != nil {
+			return false, errors.Annotatef(err, "webhook confirmation %x", key)
+		}
+		r = append(r, &wc)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// DeleteWebhookConfirmation removes the transaction waiting for confirmations
+func (d *RocksDB) DeleteWebhookConfirmation(wc *WebhookConfirmation) error {
+	return d.db.DeleteCF(d.wo, d.cfh[cfWebhooks], packWebhookConfirmationKey(wc.Height, wc.ID))
+}
+
+// GetWebhookHeight returns the height of the last block processed by webhooks, 0 if no block was processed yet
+func (d *RocksDB) GetWebhookHeight() (uint32, error) {
+	val, err := d.db.GetCF(d.ro, d.cfh[cfWebhooks], webhookHeightKey)
+	if err != nil {
+		return 0, err
+	}
+	defer val.Free()
+	if len(val.Data()) != 4 {
+		return 0, nil
+	}
+	return unpackUint(val.Data()), nil
+}
+
+// StoreWebhookHeight stores the height of the last block processed by webhooks
+func (d *RocksDB) StoreWebhookHeight(height uint32) error {
+	return d.db.PutCF(d.wo, d.cfh[cfWebhooks], webhookHeightKey, packUint(height))
+}
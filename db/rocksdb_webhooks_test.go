@@ -0,0 +1,132 @@
+// +build unittest
+
+package db
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/trezor/blockbook/bchain"
+)
+
+func TestRocksDB_Webhooks(t *testing.T) {
+	d := setupRocksDB(t, &testBitcoinParser{
+		BitcoinParser: bitcoinTestnetParser(),
+	})
+	defer closeAndDestroyRocksDB(t, d)
+
+	// watches
+	addrDesc := bchain.AddressDescriptor{0x00, 0x14, 0x01, 0x02}
+	watches := []WebhookWatch{
+		{Address: "addr1", URL: "https://example.com/hook", Secret: "secret", Confirmations: 6},
+		{Address: "addr1", URL: "https://example.org/hook"},
+	}
+	if err := d.StoreWebhookWatches(addrDesc, watches); err != nil {
+		t.Fatal(err)
+	}
+	got, err := d.GetWebhookWatches()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string][]WebhookWatch{string(addrDesc): watches}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetWebhookWatches() = %+v, want %+v", got, want)
+	}
+	if err := d.StoreWebhookWatches(addrDesc, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got, err = d.GetWebhookWatches(); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("GetWebhookWatches() after removal = %+v, want empty", got)
+	}
+
+	// deliveries
+	nextAttempt := time.Unix(1600000000, 0).UTC()
+	deliveries := []*WebhookDelivery{
+		{ID: 2, URL: "https://example.com/hook", Event: "mempool", Payload: json.RawMessage(`{"txid":"a"}`), NextAttempt: nextAttempt},
+		{ID: 1, URL: "https://example.com/hook", Event: "confirmed", Payload: json.RawMessage(`{"txid":"b"}`), NextAttempt: nextAttempt},
+		{ID: 258, URL: "https://example.org/hook", Event: "confirmations", Payload: json.RawMessage(`{"txid":"c"}`), NextAttempt: nextAttempt},
+	}
+	for _, wd := range deliveries {
+		if err := d.StoreWebhookDelivery(wd, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	deliveries[1].Attempts = 12
+	deliveries[1].LastError = "HTTP status 500"
+	if err := d.StoreWebhookDelivery(deliveries[1], true); err != nil {
+		t.Fatal(err)
+	}
+	queue, err := d.GetWebhookDeliveries(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []*WebhookDelivery{deliveries[0], deliveries[2]}; !reflect.DeepEqual(queue, want) {
+		t.Errorf("GetWebhookDeliveries(false) = %+v, want %+v", queue, want)
+	}
+	dead, err := d.GetWebhookDeliveries(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []*WebhookDelivery{deliveries[1]}; !reflect.DeepEqual(dead, want) {
+		t.Errorf("GetWebhookDeliveries(true) = %+v, want %+v", dead, want)
+	}
+	if err := d.DeleteWebhookDelivery(2, false); err != nil {
+		t.Fatal(err)
+	}
+	if queue, err = d.GetWebhookDeliveries(false); err != nil {
+		t.Fatal(err)
+	}
+	if want := []*WebhookDelivery{deliveries[2]}; !reflect.DeepEqual(queue, want) {
+		t.Errorf("GetWebhookDeliveries(false) after delete = %+v, want %+v", queue, want)
+	}
+
+	// confirmations
+	confirmations := []*WebhookConfirmation{
+		{ID: 10, Height: 300, Txid: "c", Watch: watches[0]},
+		{ID: 11, Height: 256, Txid: "a", Watch: watches[0]},
+		{ID: 12, Height: 1000, Txid: "b", Watch: watches[1]},
+	}
+	for _, wc := range confirmations {
+		if err := d.StoreWebhookConfirmation(wc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	wcs, err := d.GetWebhookConfirmations(300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []*WebhookConfirmation{confirmations[1], confirmations[0]}; !reflect.DeepEqual(wcs, want) {
+		t.Errorf("GetWebhookConfirmations(300) = %+v, want %+v", wcs, want)
+	}
+	if err := d.DeleteWebhookConfirmation(confirmations[1]); err != nil {
+		t.Fatal(err)
+	}
+	if wcs, err = d.GetWebhookConfirmations(999); err != nil {
+		t.Fatal(err)
+	}
+	if want := []*WebhookConfirmation{confirmations[0]}; !reflect.DeepEqual(wcs, want) {
+		t.Errorf("GetWebhookConfirmations(999) = %+v, want %+v", wcs, want)
+	}
+
+	// height
+	h, err := d.GetWebhookHeight()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h != 0 {
+		t.Errorf("GetWebhookHeight() = %v, want 0", h)
+	}
+	if err := d.StoreWebhookHeight(225494); err != nil {
+		t.Fatal(err)
+	}
+	if h, err = d.GetWebhookHeight(); err != nil {
+		t.Fatal(err)
+	}
+	if h != 225494 {
+		t.Errorf("GetWebhookHeight() = %v, want 225494", h)
+	}
+}
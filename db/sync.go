@@ -23,6 +23,7 @@ type SyncWorker struct {
 	chanOsSignal           chan os.Signal
 	metrics                *common.Metrics
 	is                     *common.InternalState
+	onDisconnectBlocks     bchain.OnDisconnectBlocksFunc
 }
 
 // NewSyncWorker creates new SyncWorker and returns its handle
@@ -43,6 +44,11 @@ func NewSyncWorker(db *RocksDB, chain bchain.BlockChain, syncWorkers, syncChunk
 	}, nil
 }
 
+// SetOnDisconnectBlocks sets the callback called when blocks are disconnected during handling of a fork
+func (w *SyncWorker) SetOnDisconnectBlocks(onDisconnectBlocks bchain.OnDisconnectBlocksFunc) {
+	w.onDisconnectBlocks = onDisconnectBlocks
+}
+
 var errSynced = errors.New("synced")
 var errFork = errors.New("fork")
 
@@ -204,6 +210,9 @@ func (w *SyncWorker) handleFork(localBestHeight uint32, localBestHash string, on
 	if err := w.DisconnectBlocks(height+1, localBestHeight, hashes); err != nil {
 		return err
 	}
+	if w.onDisconnectBlocks != nil {
+		w.onDisconnectBlocks(height+1, localBestHeight)
+	}
 	return w.resyncIndex(onNewBlock, initialSync)
 }
 
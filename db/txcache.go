@@ -114,27 +114,29 @@ func (c *TxCache) GetTransaction(txid string) (*bchain.Tx, int, error) {
 	return tx, int(h), nil
 }
 
-func (c *TxCache) GetTransactionSpecific(txid string) (json.RawMessage, error) {
+// GetTransactionSpecific returns the backend specific data of the transaction and the transaction from which they were obtained
+// the transaction is taken either from RocksDB or if not present from blockchain, if it is confirmed, it is stored in the RocksDB
+func (c *TxCache) GetTransactionSpecific(txid string) (json.RawMessage, *bchain.Tx, error) {
 	var tx *bchain.Tx
 	var h uint32
 	var err error
 	if c.enabled {
 		tx, h, err = c.db.GetTx(txid)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		if tx != nil {
 			_, bestheight, _ := c.is.GetSyncState()
 			tx.Confirmations = bestheight - h + 1
 			c.metrics.TxCacheEfficiency.With(common.Labels{"status": "hit"}).Inc()
 			glog.Info("GetTransactionSpecific by cache : ", txid)
-			return c.chain.GetTransactionSpecific(tx)
+			return c.getTransactionSpecific(tx)
 		}
 	}
 	glog.Info("GetTransactionSpecific by chain : ", txid)
 	tx, err = c.chain.GetTransaction(txid)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	if c.chainType == bchain.ChainTronType {
 		tx.Confirmations = c.is.BestHeight - h + 1
@@ -145,7 +147,7 @@ func (c *TxCache) GetTransactionSpecific(txid string) (json.RawMessage, error) {
 		if c.chainType == bchain.ChainBitcoinType {
 			ta, err := c.db.GetTxAddresses(txid)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			switch {
 			case ta == nil:
@@ -157,7 +159,7 @@ func (c *TxCache) GetTransactionSpecific(txid string) (json.RawMessage, error) {
 					// Get the height from the backend's bestblock.
 					h, err = c.chain.GetBestBlockHeight()
 					if err != nil {
-						return nil, err
+						return nil, nil, err
 					}
 				}
 			default:
@@ -166,16 +168,16 @@ func (c *TxCache) GetTransactionSpecific(txid string) (json.RawMessage, error) {
 		} else if c.chainType == bchain.ChainEthereumType {
 			h, err = eth.GetHeightFromTx(tx)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 		} else if c.chainType == bchain.ChainTronType {
 			h, err = trx.GetHeightFromTx(tx)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			tx.BlockHeight = h
 		} else {
-			return nil, errors.New("Unknown chain type")
+			return nil, nil, errors.New("Unknown chain type")
 		}
 		if c.enabled {
 			err = c.db.PutTx(tx, h, tx.Blocktime)
@@ -185,5 +187,13 @@ func (c *TxCache) GetTransactionSpecific(txid string) (json.RawMessage, error) {
 			}
 		}
 	}
-	return c.chain.GetTransactionSpecific(tx)
+	return c.getTransactionSpecific(tx)
+}
+
+func (c *TxCache) getTransactionSpecific(tx *bchain.Tx) (json.RawMessage, *bchain.Tx, error) {
+	sj, err := c.chain.GetTransactionSpecific(tx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sj, tx, nil
 }
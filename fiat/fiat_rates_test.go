@@ -35,7 +35,7 @@ func setupRocksDB(t *testing.T, parser bchain.BlockChainParser) (*db.RocksDB, *c
 	if err != nil {
 		t.Fatal(err)
 	}
-	d, err := db.NewRocksDB(tmp, 100000, -1, parser, nil)
+	d, err := db.NewRocksDB(tmp, 100000, -1, parser, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
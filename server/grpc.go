@@ -0,0 +1,667 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/juju/errors"
+	"github.com/trezor/blockbook/api"
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/common"
+	"github.com/trezor/blockbook/db"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+const grpcOutChannelSize = 500
+
+// grpcSubscription is a server stream of a subscription, messages are passed to the stream through the out channel
+type grpcSubscription struct {
+	out   chan interface{}
+	done  chan struct{}
+	alive bool
+	lock  sync.Mutex
+}
+
+// DataOut sends the message to the subscriber, if the subscriber is not able to receive the messages, the subscription is closed
+func (c *grpcSubscription) DataOut(data interface{}) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.alive {
+		if len(c.out) < grpcOutChannelSize-1 {
+			c.out <- data
+		} else {
+			glog.Warning("gRPC subscription channel full, closing the subscription")
+			c.alive = false
+			close(c.done)
+		}
+	}
+}
+
+// GrpcServer is a handle to gRPC server
+type GrpcServer struct {
+	UnimplementedBlockbookServer
+	binding                   string
+	certFiles                 string
+	server                    *grpc.Server
+	db                        *db.RocksDB
+	txCache                   *db.TxCache
+	chain                     bchain.BlockChain
+	chainParser               bchain.BlockChainParser
+	mempool                   bchain.Mempool
+	metrics                   *common.Metrics
+	is                        *common.InternalState
+	api                       *api.Worker
+	newBlockSubscriptions     map[*grpcSubscription]struct{}
+	newBlockSubscriptionsLock sync.Mutex
+	addressSubscriptions      map[string]map[*grpcSubscription]struct{}
+	addressSubscriptionsLock  sync.Mutex
+}
+
+// NewGrpcServer creates new gRPC interface to blockbook and returns its handle
+func NewGrpcServer(binding string, certFiles string, db *db.RocksDB, chain bchain.BlockChain, mempool bchain.Mempool, txCache *db.TxCache, metrics *common.Metrics, is *common.InternalState) (*GrpcServer, error) {
+	api, err := api.NewWorker(db, chain, mempool, txCache, metrics, is)
+	if err != nil {
+		return nil, err
+	}
+	var opts []grpc.ServerOption
+	if certFiles != "" {
+		creds, err := credentials.NewServerTLSFromFile(fmt.Sprint(certFiles, ".crt"), fmt.Sprint(certFiles, ".key"))
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+	s := &GrpcServer{
+		binding:               binding,
+		certFiles:             certFiles,
+		server:                grpc.NewServer(opts...),
+		db:                    db,
+		txCache:               txCache,
+		chain:                 chain,
+		chainParser:           chain.GetChainParser(),
+		mempool:               mempool,
+		metrics:               metrics,
+		is:                    is,
+		api:                   api,
+		newBlockSubscriptions: make(map[*grpcSubscription]struct{}),
+		addressSubscriptions:  make(map[string]map[*grpcSubscription]struct{}),
+	}
+	RegisterBlockbookServer(s.server, s)
+	return s, nil
+}
+
+// Run starts the server
+func (s *GrpcServer) Run() error {
+	l, err := net.Listen("tcp", s.binding)
+	if err != nil {
+		return err
+	}
+	glog.Info("grpc server: starting to listen on ", s.binding)
+	return s.server.Serve(l)
+}
+
+// Close closes the server
+func (s *GrpcServer) Close() {
+	glog.Infof("grpc server: closing")
+	s.server.Stop()
+}
+
+// Shutdown shuts down the server, waiting for the pending requests until the context is done
+func (s *GrpcServer) Shutdown(ctx context.Context) error {
+	glog.Infof("grpc server: shutdown")
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
+
+// request wraps the handling of a unary request by metrics, logging and conversion of errors
+func (s *GrpcServer) request(method string, f func() (interface{}, error)) (res interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			glog.Error("grpc ", method, " recovered from panic: ", r)
+			debug.PrintStack()
+			res = nil
+			err = status.Error(codes.Internal, "Internal error")
+		}
+	}()
+	t := time.Now()
+	defer func() {
+		s.metrics.GrpcReqDuration.With(common.Labels{"method": method}).Observe(float64(time.Since(t)) / 1e3) // in microseconds
+	}()
+	res, err = f()
+	if err == nil {
+		glog.V(1).Info("grpc ", method, " success")
+		s.metrics.GrpcRequests.With(common.Labels{"method": method, "status": "success"}).Inc()
+		return res, nil
+	}
+	s.metrics.GrpcRequests.With(common.Labels{"method": method, "status": "failure"}).Inc()
+	if apiErr, ok := err.(*api.APIError); ok && apiErr.Public {
+		return nil, status.Error(codes.InvalidArgument, apiErr.Error())
+	}
+	glog.Error("grpc ", method, ": ", errors.ErrorStack(err))
+	return nil, status.Error(codes.Internal, err.Error())
+}
+
+func grpcAddressFilter(req *ProtoGetAddressRequest) (api.AccountDetails, *api.AddressFilter, int) {
+	var opt api.AccountDetails
+	switch req.Details {
+	case ProtoGetAddressRequest_DetailsTokens:
+		opt = api.AccountDetailsTokens
+	case ProtoGetAddressRequest_DetailsTokenBalances:
+		opt = api.AccountDetailsTokenBalances
+	case ProtoGetAddressRequest_DetailsTxidHistory:
+		opt = api.AccountDetailsTxidHistory
+	case ProtoGetAddressRequest_DetailsTxHistoryLight:
+		opt = api.AccountDetailsTxHistoryLight
+	case ProtoGetAddressRequest_DetailsTxHistory:
+		opt = api.AccountDetailsTxHistory
+	default:
+		opt = api.AccountDetailsBasic
+	}
+	var tokensToReturn api.TokensToReturn
+	switch req.Tokens {
+	case ProtoGetAddressRequest_TokensUsed:
+		tokensToReturn = api.TokensToReturnUsed
+	case ProtoGetAddressRequest_TokensDerived:
+		tokensToReturn = api.TokensToReturnDerived
+	default:
+		tokensToReturn = api.TokensToReturnNonzeroBalance
+	}
+	pageSize := int(req.PageSize)
+	if pageSize == 0 || pageSize > txsInAPI {
+		pageSize = txsInAPI
+	}
+	return opt, &api.AddressFilter{
+		FromHeight:     req.FromHeight,
+		ToHeight:       req.ToHeight,
+		Contract:       req.Contract,
+		Vout:           api.AddressFilterVoutOff,
+		TokensToReturn: tokensToReturn,
+	}, pageSize
+}
+
+// GetAddress returns balances and transactions of an address
+func (s *GrpcServer) GetAddress(ctx context.Context, req *ProtoGetAddressRequest) (*ProtoAddress, error) {
+	res, err := s.request("GetAddress", func() (interface{}, error) {
+		opt, filter, pageSize := grpcAddressFilter(req)
+		a, err := s.api.GetAddress(req.Address, int(req.Page), pageSize, opt, filter)
+		if err != nil {
+			return nil, err
+		}
+		return addressToProto(a), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*ProtoAddress), nil
+}
+
+// GetXpubAddress returns balances and transactions of an xpub or output descriptor
+func (s *GrpcServer) GetXpubAddress(ctx context.Context, req *ProtoGetAddressRequest) (*ProtoAddress, error) {
+	res, err := s.request("GetXpubAddress", func() (interface{}, error) {
+		opt, filter, pageSize := grpcAddressFilter(req)
+		a, err := s.api.GetXpubAddress(req.Address, int(req.Page), pageSize, opt, filter, int(req.Gap))
+		if err == api.ErrUnsupportedXpub {
+			err = api.NewAPIError("XPUB functionality is not supported", true)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return addressToProto(a), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*ProtoAddress), nil
+}
+
+// GetTransaction returns a transaction
+func (s *GrpcServer) GetTransaction(ctx context.Context, req *ProtoGetTransactionRequest) (*ProtoTx, error) {
+	res, err := s.request("GetTransaction", func() (interface{}, error) {
+		tx, err := s.api.GetTransaction(req.Txid, req.Spending, false)
+		if err != nil {
+			return nil, err
+		}
+		return txToProto(tx), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*ProtoTx), nil
+}
+
+// GetAddressUtxo returns unspent outputs of an address, xpub or output descriptor
+func (s *GrpcServer) GetAddressUtxo(ctx context.Context, req *ProtoGetAddressUtxoRequest) (*ProtoUtxos, error) {
+	res, err := s.request("GetAddressUtxo", func() (interface{}, error) {
+		utxo, err := s.api.GetXpubUtxo(req.Address, req.OnlyConfirmed, int(req.Gap))
+		if err != nil {
+			utxo, err = s.api.GetAddressUtxo(req.Address, req.OnlyConfirmed)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return utxosToProto(utxo), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*ProtoUtxos), nil
+}
+
+// GetBlock returns a block with transactions
+func (s *GrpcServer) GetBlock(ctx context.Context, req *ProtoGetBlockRequest) (*ProtoBlock, error) {
+	res, err := s.request("GetBlock", func() (interface{}, error) {
+		b, err := s.api.GetBlock(req.Block, int(req.Page), txsInAPI)
+		if err != nil {
+			return nil, err
+		}
+		return blockToProto(b), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*ProtoBlock), nil
+}
+
+// SendTransaction sends the transaction to the backend
+func (s *GrpcServer) SendTransaction(ctx context.Context, req *ProtoSendTransactionRequest) (*ProtoSendTransactionResponse, error) {
+	res, err := s.request("SendTransaction", func() (interface{}, error) {
+		if len(req.Hex) == 0 {
+			return nil, api.NewAPIError("Missing tx blob", true)
+		}
+		txid, err := s.chain.SendRawTransaction(req.Hex)
+		if err != nil {
+			return nil, api.NewAPIError(err.Error(), true)
+		}
+		return &ProtoSendTransactionResponse{Txid: txid}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*ProtoSendTransactionResponse), nil
+}
+
+// EstimateFee returns estimated fee per unit of size for the requested numbers of blocks
+func (s *GrpcServer) EstimateFee(ctx context.Context, req *ProtoEstimateFeeRequest) (*ProtoEstimateFeeResponse, error) {
+	res, err := s.request("EstimateFee", func() (interface{}, error) {
+		r := &ProtoEstimateFeeResponse{FeePerUnit: make([]string, len(req.Blocks))}
+		for i, b := range req.Blocks {
+			if s.chainParser.GetChainType() == bchain.ChainBitcoinType {
+				fee, err := s.api.BitcoinTypeEstimateFee(int(b), req.Conservative)
+				if err != nil {
+					return nil, err
+				}
+				r.FeePerUnit[i] = fee.String()
+			} else {
+				fee, err := s.chain.EstimateSmartFee(int(b), req.Conservative)
+				if err != nil {
+					return nil, err
+				}
+				r.FeePerUnit[i] = fee.String()
+			}
+		}
+		return r, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.(*ProtoEstimateFeeResponse), nil
+}
+
+// stream sends the messages of the subscription to the server stream until the client disconnects or the subscription is closed
+func (s *GrpcServer) stream(ctx context.Context, c *grpcSubscription, send func(interface{}) error) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-c.done:
+			return status.Error(codes.ResourceExhausted, "Subscriber is not able to receive messages")
+		case m := <-c.out:
+			if err := send(m); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func newGrpcSubscription() *grpcSubscription {
+	return &grpcSubscription{
+		out:   make(chan interface{}, grpcOutChannelSize),
+		done:  make(chan struct{}),
+		alive: true,
+	}
+}
+
+// SubscribeNewBlock streams info about new blocks
+func (s *GrpcServer) SubscribeNewBlock(req *ProtoSubscribeNewBlockRequest, stream Blockbook_SubscribeNewBlockServer) error {
+	c := newGrpcSubscription()
+	s.newBlockSubscriptionsLock.Lock()
+	s.newBlockSubscriptions[c] = struct{}{}
+	s.metrics.GrpcSubscribes.With((common.Labels{"method": "SubscribeNewBlock"})).Set(float64(len(s.newBlockSubscriptions)))
+	s.newBlockSubscriptionsLock.Unlock()
+	defer func() {
+		s.newBlockSubscriptionsLock.Lock()
+		delete(s.newBlockSubscriptions, c)
+		s.metrics.GrpcSubscribes.With((common.Labels{"method": "SubscribeNewBlock"})).Set(float64(len(s.newBlockSubscriptions)))
+		s.newBlockSubscriptionsLock.Unlock()
+	}()
+	return s.stream(stream.Context(), c, func(m interface{}) error {
+		return stream.Send(m.(*ProtoNewBlock))
+	})
+}
+
+// SubscribeAddresses streams transactions affecting the addresses
+func (s *GrpcServer) SubscribeAddresses(req *ProtoSubscribeAddressesRequest, stream Blockbook_SubscribeAddressesServer) error {
+	addrDescs := make([]string, len(req.Addresses))
+	for i, a := range req.Addresses {
+		addrDesc, err := s.chainParser.GetAddrDescFromAddress(a)
+		if err != nil {
+			return status.Error(codes.InvalidArgument, fmt.Sprint("Invalid address ", a, ": ", err))
+		}
+		addrDescs[i] = string(addrDesc)
+	}
+	c := newGrpcSubscription()
+	s.addressSubscriptionsLock.Lock()
+	for _, ads := range addrDescs {
+		as, ok := s.addressSubscriptions[ads]
+		if !ok {
+			as = make(map[*grpcSubscription]struct{})
+			s.addressSubscriptions[ads] = as
+		}
+		as[c] = struct{}{}
+	}
+	s.metrics.GrpcSubscribes.With((common.Labels{"method": "SubscribeAddresses"})).Set(float64(len(s.addressSubscriptions)))
+	s.addressSubscriptionsLock.Unlock()
+	defer func() {
+		s.addressSubscriptionsLock.Lock()
+		for _, ads := range addrDescs {
+			if as, ok := s.addressSubscriptions[ads]; ok {
+				delete(as, c)
+				if len(as) == 0 {
+					delete(s.addressSubscriptions, ads)
+				}
+			}
+		}
+		s.metrics.GrpcSubscribes.With((common.Labels{"method": "SubscribeAddresses"})).Set(float64(len(s.addressSubscriptions)))
+		s.addressSubscriptionsLock.Unlock()
+	}()
+	return s.stream(stream.Context(), c, func(m interface{}) error {
+		return stream.Send(m.(*ProtoAddressTx))
+	})
+}
+
+func (s *GrpcServer) onNewBlockAsync(hash string, height uint32) {
+	s.newBlockSubscriptionsLock.Lock()
+	defer s.newBlockSubscriptionsLock.Unlock()
+	for c := range s.newBlockSubscriptions {
+		c.DataOut(&ProtoNewBlock{
+			Height: height,
+			Hash:   hash,
+		})
+	}
+	glog.Info("grpc broadcasting new block ", height, " ", hash, " to ", len(s.newBlockSubscriptions), " subscribers")
+}
+
+// OnNewBlock is a callback that streams info about new block to subscribed clients
+func (s *GrpcServer) OnNewBlock(hash string, height uint32) {
+	go s.onNewBlockAsync(hash, height)
+}
+
+func (s *GrpcServer) getNewTxSubscriptions(tx *bchain.MempoolTx) map[string]struct{} {
+	s.addressSubscriptionsLock.Lock()
+	defer s.addressSubscriptionsLock.Unlock()
+	subscribed := make(map[string]struct{})
+	check := func(addrDesc bchain.AddressDescriptor) {
+		if len(addrDesc) > 0 {
+			if as, ok := s.addressSubscriptions[string(addrDesc)]; ok && len(as) > 0 {
+				subscribed[string(addrDesc)] = struct{}{}
+			}
+		}
+	}
+	for i := range tx.Vin {
+		check(tx.Vin[i].AddrDesc)
+	}
+	for i := range tx.Vout {
+		addrDesc, err := s.chainParser.GetAddrDescFromVout(&tx.Vout[i])
+		if err == nil {
+			check(addrDesc)
+		}
+	}
+	for i := range tx.Erc20 {
+		if addrDesc, err := s.chainParser.GetAddrDescFromAddress(tx.Erc20[i].From); err == nil {
+			check(addrDesc)
+		}
+		if addrDesc, err := s.chainParser.GetAddrDescFromAddress(tx.Erc20[i].To); err == nil {
+			check(addrDesc)
+		}
+	}
+	for i := range tx.Trc20 {
+		if addrDesc, err := s.chainParser.GetAddrDescFromAddress(tx.Trc20[i].From); err == nil {
+			check(addrDesc)
+		}
+		if addrDesc, err := s.chainParser.GetAddrDescFromAddress(tx.Trc20[i].To); err == nil {
+			check(addrDesc)
+		}
+	}
+	return subscribed
+}
+
+func (s *GrpcServer) onNewTxAsync(tx *bchain.MempoolTx, subscribed map[string]struct{}) {
+	atx, err := s.api.GetTransactionFromMempoolTx(tx)
+	if err != nil {
+		glog.Error("GetTransactionFromMempoolTx error ", err, " for ", tx.Txid)
+		return
+	}
+	ptx := txToProto(atx)
+	s.addressSubscriptionsLock.Lock()
+	defer s.addressSubscriptionsLock.Unlock()
+	for stringAddressDescriptor := range subscribed {
+		addr, _, err := s.chainParser.GetAddressesFromAddrDesc(bchain.AddressDescriptor(stringAddressDescriptor))
+		if err != nil || len(addr) != 1 {
+			continue
+		}
+		for c := range s.addressSubscriptions[stringAddressDescriptor] {
+			c.DataOut(&ProtoAddressTx{
+				Address: addr[0],
+				Tx:      ptx,
+			})
+		}
+	}
+}
+
+// OnNewTx is a callback that streams a new transaction to clients subscribed to its addresses
+func (s *GrpcServer) OnNewTx(tx *bchain.MempoolTx) {
+	subscribed := s.getNewTxSubscriptions(tx)
+	if len(subscribed) > 0 {
+		go s.onNewTxAsync(tx, subscribed)
+	}
+}
+
+func txToProto(tx *api.Tx) *ProtoTx {
+	p := &ProtoTx{
+		Txid:          tx.Txid,
+		Version:       tx.Version,
+		LockTime:      tx.Locktime,
+		Vin:           make([]*ProtoTx_VinType, len(tx.Vin)),
+		Vout:          make([]*ProtoTx_VoutType, len(tx.Vout)),
+		BlockHash:     tx.Blockhash,
+		BlockHeight:   int32(tx.Blockheight),
+		Confirmations: tx.Confirmations,
+		BlockTime:     tx.Blocktime,
+		Size:          int32(tx.Size),
+		Value:         tx.ValueOutSat.String(),
+		ValueIn:       tx.ValueInSat.String(),
+		Fees:          tx.FeesSat.String(),
+		Hex:           tx.Hex,
+		Rbf:           tx.Rbf,
+	}
+	for i := range tx.Vin {
+		vin := &tx.Vin[i]
+		p.Vin[i] = &ProtoTx_VinType{
+			Txid:      vin.Txid,
+			Vout:      vin.Vout,
+			Sequence:  vin.Sequence,
+			N:         int32(vin.N),
+			Addresses: vin.Addresses,
+			IsAddress: vin.IsAddress,
+			Value:     vin.ValueSat.String(),
+			Hex:       vin.Hex,
+			Coinbase:  vin.Coinbase,
+		}
+	}
+	for i := range tx.Vout {
+		vout := &tx.Vout[i]
+		p.Vout[i] = &ProtoTx_VoutType{
+			Value:       vout.ValueSat.String(),
+			N:           int32(vout.N),
+			Spent:       vout.Spent,
+			SpentTxid:   vout.SpentTxID,
+			SpentIndex:  int32(vout.SpentIndex),
+			SpentHeight: int32(vout.SpentHeight),
+			Hex:         vout.Hex,
+			Addresses:   vout.Addresses,
+			IsAddress:   vout.IsAddress,
+			Type:        vout.Type,
+		}
+	}
+	if len(tx.TokenTransfers) > 0 {
+		p.TokenTransfers = make([]*ProtoTx_TokenTransferType, len(tx.TokenTransfers))
+		for i := range tx.TokenTransfers {
+			t := &tx.TokenTransfers[i]
+			p.TokenTransfers[i] = &ProtoTx_TokenTransferType{
+				Type:     string(t.Type),
+				From:     t.From,
+				To:       t.To,
+				Token:    t.Token,
+				Name:     t.Name,
+				Symbol:   t.Symbol,
+				Decimals: int32(t.Decimals),
+				Value:    t.Value.String(),
+			}
+		}
+	}
+	if es := tx.EthereumSpecific; es != nil {
+		p.EthereumSpecific = &ProtoTx_EthereumSpecificType{
+			Status:   int32(es.Status),
+			Nonce:    es.Nonce,
+			GasPrice: es.GasPrice.String(),
+			Data:     es.Data,
+		}
+		if es.GasLimit != nil {
+			p.EthereumSpecific.GasLimit = es.GasLimit.String()
+		}
+		if es.GasUsed != nil {
+			p.EthereumSpecific.GasUsed = es.GasUsed.String()
+		}
+	}
+	return p
+}
+
+func addressToProto(a *api.Address) *ProtoAddress {
+	p := &ProtoAddress{
+		Page:               int32(a.Page),
+		TotalPages:         int32(a.TotalPages),
+		ItemsOnPage:        int32(a.ItemsOnPage),
+		Address:            a.AddrStr,
+		Balance:            a.BalanceSat.String(),
+		TotalReceived:      a.TotalReceivedSat.String(),
+		TotalSent:          a.TotalSentSat.String(),
+		UnconfirmedBalance: a.UnconfirmedBalanceSat.String(),
+		UnconfirmedTxs:     int32(a.UnconfirmedTxs),
+		Txs:                int32(a.Txs),
+		NonTokenTxs:        int32(a.NonTokenTxs),
+		Txids:              a.Txids,
+		Nonce:              a.Nonce,
+		UsedTokens:         int32(a.UsedTokens),
+	}
+	if len(a.Transactions) > 0 {
+		p.Transactions = make([]*ProtoTx, len(a.Transactions))
+		for i, tx := range a.Transactions {
+			p.Transactions[i] = txToProto(tx)
+		}
+	}
+	if len(a.Tokens) > 0 {
+		p.Tokens = make([]*ProtoAddress_TokenType, len(a.Tokens))
+		for i := range a.Tokens {
+			t := &a.Tokens[i]
+			p.Tokens[i] = &ProtoAddress_TokenType{
+				Type:          string(t.Type),
+				Name:          t.Name,
+				Path:          t.Path,
+				Contract:      t.Contract,
+				Transfers:     int32(t.Transfers),
+				Symbol:        t.Symbol,
+				Decimals:      int32(t.Decimals),
+				Balance:       t.BalanceSat.String(),
+				TotalReceived: t.TotalReceivedSat.String(),
+				TotalSent:     t.TotalSentSat.String(),
+			}
+		}
+	}
+	return p
+}
+
+func utxosToProto(utxos api.Utxos) *ProtoUtxos {
+	p := &ProtoUtxos{Utxos: make([]*ProtoUtxos_UtxoType, len(utxos))}
+	for i := range utxos {
+		u := &utxos[i]
+		p.Utxos[i] = &ProtoUtxos_UtxoType{
+			Txid:          u.Txid,
+			Vout:          u.Vout,
+			Value:         u.AmountSat.String(),
+			Height:        int32(u.Height),
+			Confirmations: int32(u.Confirmations),
+			Address:       u.Address,
+			Path:          u.Path,
+			LockTime:      u.Locktime,
+			Coinbase:      u.Coinbase,
+		}
+	}
+	return p
+}
+
+func blockToProto(b *api.Block) *ProtoBlock {
+	p := &ProtoBlock{
+		Page:          int32(b.Page),
+		TotalPages:    int32(b.TotalPages),
+		ItemsOnPage:   int32(b.ItemsOnPage),
+		Hash:          b.Hash,
+		Prev:          b.Prev,
+		Next:          b.Next,
+		Height:        b.Height,
+		Confirmations: int64(b.Confirmations),
+		Size:          int32(b.Size),
+		Time:          b.Time,
+		Version:       string(b.Version),
+		MerkleRoot:    b.MerkleRoot,
+		Nonce:         b.Nonce,
+		Bits:          b.Bits,
+		Difficulty:    b.Difficulty,
+		Txids:         b.Txids,
+		TxCount:       int32(b.TxCount),
+	}
+	if len(b.Transactions) > 0 {
+		p.Transactions = make([]*ProtoTx, len(b.Transactions))
+		for i, tx := range b.Transactions {
+			p.Transactions[i] = txToProto(tx)
+		}
+	}
+	return p
+}
@@ -0,0 +1,3153 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.25.0
+// 	protoc        (unknown)
+// source: grpc.proto
+
+package server
+
+import (
+	context "context"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// This is a compile-time assertion that a sufficiently up-to-date version
+// of the legacy proto package is being used.
+const _ = proto.ProtoPackageIsVersion4
+
+type ProtoGetAddressRequest_DetailsType int32
+
+const (
+	ProtoGetAddressRequest_DetailsBasic          ProtoGetAddressRequest_DetailsType = 0
+	ProtoGetAddressRequest_DetailsTokens         ProtoGetAddressRequest_DetailsType = 1
+	ProtoGetAddressRequest_DetailsTokenBalances  ProtoGetAddressRequest_DetailsType = 2
+	ProtoGetAddressRequest_DetailsTxidHistory    ProtoGetAddressRequest_DetailsType = 3
+	ProtoGetAddressRequest_DetailsTxHistoryLight ProtoGetAddressRequest_DetailsType = 4
+	ProtoGetAddressRequest_DetailsTxHistory      ProtoGetAddressRequest_DetailsType = 5
+)
+
+// Enum value maps for ProtoGetAddressRequest_DetailsType.
+var (
+	ProtoGetAddressRequest_DetailsType_name = map[int32]string{
+		0: "DetailsBasic",
+		1: "DetailsTokens",
+		2: "DetailsTokenBalances",
+		3: "DetailsTxidHistory",
+		4: "DetailsTxHistoryLight",
+		5: "DetailsTxHistory",
+	}
+	ProtoGetAddressRequest_DetailsType_value = map[string]int32{
+		"DetailsBasic":          0,
+		"DetailsTokens":         1,
+		"DetailsTokenBalances":  2,
+		"DetailsTxidHistory":    3,
+		"DetailsTxHistoryLight": 4,
+		"DetailsTxHistory":      5,
+	}
+)
+
+func (x ProtoGetAddressRequest_DetailsType) Enum() *ProtoGetAddressRequest_DetailsType {
+	p := new(ProtoGetAddressRequest_DetailsType)
+	*p = x
+	return p
+}
+
+func (x ProtoGetAddressRequest_DetailsType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProtoGetAddressRequest_DetailsType) Descriptor() protoreflect.EnumDescriptor {
+	return file_grpc_proto_enumTypes[0].Descriptor()
+}
+
+func (ProtoGetAddressRequest_DetailsType) Type() protoreflect.EnumType {
+	return &file_grpc_proto_enumTypes[0]
+}
+
+func (x ProtoGetAddressRequest_DetailsType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProtoGetAddressRequest_DetailsType.Descriptor instead.
+func (ProtoGetAddressRequest_DetailsType) EnumDescriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type ProtoGetAddressRequest_TokensType int32
+
+const (
+	ProtoGetAddressRequest_TokensNonzero ProtoGetAddressRequest_TokensType = 0
+	ProtoGetAddressRequest_TokensUsed    ProtoGetAddressRequest_TokensType = 1
+	ProtoGetAddressRequest_TokensDerived ProtoGetAddressRequest_TokensType = 2
+)
+
+// Enum value maps for ProtoGetAddressRequest_TokensType.
+var (
+	ProtoGetAddressRequest_TokensType_name = map[int32]string{
+		0: "TokensNonzero",
+		1: "TokensUsed",
+		2: "TokensDerived",
+	}
+	ProtoGetAddressRequest_TokensType_value = map[string]int32{
+		"TokensNonzero": 0,
+		"TokensUsed":    1,
+		"TokensDerived": 2,
+	}
+)
+
+func (x ProtoGetAddressRequest_TokensType) Enum() *ProtoGetAddressRequest_TokensType {
+	p := new(ProtoGetAddressRequest_TokensType)
+	*p = x
+	return p
+}
+
+func (x ProtoGetAddressRequest_TokensType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProtoGetAddressRequest_TokensType) Descriptor() protoreflect.EnumDescriptor {
+	return file_grpc_proto_enumTypes[1].Descriptor()
+}
+
+func (ProtoGetAddressRequest_TokensType) Type() protoreflect.EnumType {
+	return &file_grpc_proto_enumTypes[1]
+}
+
+func (x ProtoGetAddressRequest_TokensType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProtoGetAddressRequest_TokensType.Descriptor instead.
+func (ProtoGetAddressRequest_TokensType) EnumDescriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{0, 1}
+}
+
+type ProtoGetAddressRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address    string                             `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
+	Page       uint32                             `protobuf:"varint,2,opt,name=Page,proto3" json:"Page,omitempty"`
+	PageSize   uint32                             `protobuf:"varint,3,opt,name=PageSize,proto3" json:"PageSize,omitempty"`
+	Details    ProtoGetAddressRequest_DetailsType `protobuf:"varint,4,opt,name=Details,proto3,enum=server.ProtoGetAddressRequest_DetailsType" json:"Details,omitempty"`
+	Tokens     ProtoGetAddressRequest_TokensType  `protobuf:"varint,5,opt,name=Tokens,proto3,enum=server.ProtoGetAddressRequest_TokensType" json:"Tokens,omitempty"`
+	FromHeight uint32                             `protobuf:"varint,6,opt,name=FromHeight,proto3" json:"FromHeight,omitempty"`
+	ToHeight   uint32                             `protobuf:"varint,7,opt,name=ToHeight,proto3" json:"ToHeight,omitempty"`
+	Contract   string                             `protobuf:"bytes,8,opt,name=Contract,proto3" json:"Contract,omitempty"`
+	Gap        uint32                             `protobuf:"varint,9,opt,name=Gap,proto3" json:"Gap,omitempty"`
+}
+
+func (x *ProtoGetAddressRequest) Reset() {
+	*x = ProtoGetAddressRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoGetAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoGetAddressRequest) ProtoMessage() {}
+
+func (x *ProtoGetAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoGetAddressRequest.ProtoReflect.Descriptor instead.
+func (*ProtoGetAddressRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ProtoGetAddressRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ProtoGetAddressRequest) GetPage() uint32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ProtoGetAddressRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ProtoGetAddressRequest) GetDetails() ProtoGetAddressRequest_DetailsType {
+	if x != nil {
+		return x.Details
+	}
+	return ProtoGetAddressRequest_DetailsBasic
+}
+
+func (x *ProtoGetAddressRequest) GetTokens() ProtoGetAddressRequest_TokensType {
+	if x != nil {
+		return x.Tokens
+	}
+	return ProtoGetAddressRequest_TokensNonzero
+}
+
+func (x *ProtoGetAddressRequest) GetFromHeight() uint32 {
+	if x != nil {
+		return x.FromHeight
+	}
+	return 0
+}
+
+func (x *ProtoGetAddressRequest) GetToHeight() uint32 {
+	if x != nil {
+		return x.ToHeight
+	}
+	return 0
+}
+
+func (x *ProtoGetAddressRequest) GetContract() string {
+	if x != nil {
+		return x.Contract
+	}
+	return ""
+}
+
+func (x *ProtoGetAddressRequest) GetGap() uint32 {
+	if x != nil {
+		return x.Gap
+	}
+	return 0
+}
+
+type ProtoGetTransactionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Txid     string `protobuf:"bytes,1,opt,name=Txid,proto3" json:"Txid,omitempty"`
+	Spending bool   `protobuf:"varint,2,opt,name=Spending,proto3" json:"Spending,omitempty"`
+}
+
+func (x *ProtoGetTransactionRequest) Reset() {
+	*x = ProtoGetTransactionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoGetTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoGetTransactionRequest) ProtoMessage() {}
+
+func (x *ProtoGetTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoGetTransactionRequest.ProtoReflect.Descriptor instead.
+func (*ProtoGetTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ProtoGetTransactionRequest) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *ProtoGetTransactionRequest) GetSpending() bool {
+	if x != nil {
+		return x.Spending
+	}
+	return false
+}
+
+type ProtoGetAddressUtxoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address       string `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
+	OnlyConfirmed bool   `protobuf:"varint,2,opt,name=OnlyConfirmed,proto3" json:"OnlyConfirmed,omitempty"`
+	Gap           uint32 `protobuf:"varint,3,opt,name=Gap,proto3" json:"Gap,omitempty"`
+}
+
+func (x *ProtoGetAddressUtxoRequest) Reset() {
+	*x = ProtoGetAddressUtxoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoGetAddressUtxoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoGetAddressUtxoRequest) ProtoMessage() {}
+
+func (x *ProtoGetAddressUtxoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoGetAddressUtxoRequest.ProtoReflect.Descriptor instead.
+func (*ProtoGetAddressUtxoRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ProtoGetAddressUtxoRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ProtoGetAddressUtxoRequest) GetOnlyConfirmed() bool {
+	if x != nil {
+		return x.OnlyConfirmed
+	}
+	return false
+}
+
+func (x *ProtoGetAddressUtxoRequest) GetGap() uint32 {
+	if x != nil {
+		return x.Gap
+	}
+	return 0
+}
+
+type ProtoGetBlockRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Block string `protobuf:"bytes,1,opt,name=Block,proto3" json:"Block,omitempty"`
+	Page  uint32 `protobuf:"varint,2,opt,name=Page,proto3" json:"Page,omitempty"`
+}
+
+func (x *ProtoGetBlockRequest) Reset() {
+	*x = ProtoGetBlockRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoGetBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoGetBlockRequest) ProtoMessage() {}
+
+func (x *ProtoGetBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoGetBlockRequest.ProtoReflect.Descriptor instead.
+func (*ProtoGetBlockRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ProtoGetBlockRequest) GetBlock() string {
+	if x != nil {
+		return x.Block
+	}
+	return ""
+}
+
+func (x *ProtoGetBlockRequest) GetPage() uint32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+type ProtoSendTransactionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hex string `protobuf:"bytes,1,opt,name=Hex,proto3" json:"Hex,omitempty"`
+}
+
+func (x *ProtoSendTransactionRequest) Reset() {
+	*x = ProtoSendTransactionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoSendTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoSendTransactionRequest) ProtoMessage() {}
+
+func (x *ProtoSendTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoSendTransactionRequest.ProtoReflect.Descriptor instead.
+func (*ProtoSendTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ProtoSendTransactionRequest) GetHex() string {
+	if x != nil {
+		return x.Hex
+	}
+	return ""
+}
+
+type ProtoSendTransactionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Txid string `protobuf:"bytes,1,opt,name=Txid,proto3" json:"Txid,omitempty"`
+}
+
+func (x *ProtoSendTransactionResponse) Reset() {
+	*x = ProtoSendTransactionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoSendTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoSendTransactionResponse) ProtoMessage() {}
+
+func (x *ProtoSendTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoSendTransactionResponse.ProtoReflect.Descriptor instead.
+func (*ProtoSendTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ProtoSendTransactionResponse) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+type ProtoEstimateFeeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Blocks       []uint32 `protobuf:"varint,1,rep,packed,name=Blocks,proto3" json:"Blocks,omitempty"`
+	Conservative bool     `protobuf:"varint,2,opt,name=Conservative,proto3" json:"Conservative,omitempty"`
+}
+
+func (x *ProtoEstimateFeeRequest) Reset() {
+	*x = ProtoEstimateFeeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoEstimateFeeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoEstimateFeeRequest) ProtoMessage() {}
+
+func (x *ProtoEstimateFeeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoEstimateFeeRequest.ProtoReflect.Descriptor instead.
+func (*ProtoEstimateFeeRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ProtoEstimateFeeRequest) GetBlocks() []uint32 {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+func (x *ProtoEstimateFeeRequest) GetConservative() bool {
+	if x != nil {
+		return x.Conservative
+	}
+	return false
+}
+
+type ProtoEstimateFeeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	FeePerUnit []string `protobuf:"bytes,1,rep,name=FeePerUnit,proto3" json:"FeePerUnit,omitempty"`
+}
+
+func (x *ProtoEstimateFeeResponse) Reset() {
+	*x = ProtoEstimateFeeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoEstimateFeeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoEstimateFeeResponse) ProtoMessage() {}
+
+func (x *ProtoEstimateFeeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoEstimateFeeResponse.ProtoReflect.Descriptor instead.
+func (*ProtoEstimateFeeResponse) Descriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ProtoEstimateFeeResponse) GetFeePerUnit() []string {
+	if x != nil {
+		return x.FeePerUnit
+	}
+	return nil
+}
+
+type ProtoSubscribeNewBlockRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ProtoSubscribeNewBlockRequest) Reset() {
+	*x = ProtoSubscribeNewBlockRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoSubscribeNewBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoSubscribeNewBlockRequest) ProtoMessage() {}
+
+func (x *ProtoSubscribeNewBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoSubscribeNewBlockRequest.ProtoReflect.Descriptor instead.
+func (*ProtoSubscribeNewBlockRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{8}
+}
+
+type ProtoSubscribeAddressesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Addresses []string `protobuf:"bytes,1,rep,name=Addresses,proto3" json:"Addresses,omitempty"`
+}
+
+func (x *ProtoSubscribeAddressesRequest) Reset() {
+	*x = ProtoSubscribeAddressesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoSubscribeAddressesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoSubscribeAddressesRequest) ProtoMessage() {}
+
+func (x *ProtoSubscribeAddressesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoSubscribeAddressesRequest.ProtoReflect.Descriptor instead.
+func (*ProtoSubscribeAddressesRequest) Descriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ProtoSubscribeAddressesRequest) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type ProtoNewBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Height uint32 `protobuf:"varint,1,opt,name=Height,proto3" json:"Height,omitempty"`
+	Hash   string `protobuf:"bytes,2,opt,name=Hash,proto3" json:"Hash,omitempty"`
+}
+
+func (x *ProtoNewBlock) Reset() {
+	*x = ProtoNewBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoNewBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoNewBlock) ProtoMessage() {}
+
+func (x *ProtoNewBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoNewBlock.ProtoReflect.Descriptor instead.
+func (*ProtoNewBlock) Descriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ProtoNewBlock) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ProtoNewBlock) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+type ProtoAddressTx struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address string   `protobuf:"bytes,1,opt,name=Address,proto3" json:"Address,omitempty"`
+	Tx      *ProtoTx `protobuf:"bytes,2,opt,name=Tx,proto3" json:"Tx,omitempty"`
+}
+
+func (x *ProtoAddressTx) Reset() {
+	*x = ProtoAddressTx{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoAddressTx) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoAddressTx) ProtoMessage() {}
+
+func (x *ProtoAddressTx) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoAddressTx.ProtoReflect.Descriptor instead.
+func (*ProtoAddressTx) Descriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ProtoAddressTx) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ProtoAddressTx) GetTx() *ProtoTx {
+	if x != nil {
+		return x.Tx
+	}
+	return nil
+}
+
+type ProtoTx struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Txid             string                        `protobuf:"bytes,1,opt,name=Txid,proto3" json:"Txid,omitempty"`
+	Version          int32                         `protobuf:"varint,2,opt,name=Version,proto3" json:"Version,omitempty"`
+	LockTime         uint32                        `protobuf:"varint,3,opt,name=LockTime,proto3" json:"LockTime,omitempty"`
+	Vin              []*ProtoTx_VinType            `protobuf:"bytes,4,rep,name=Vin,proto3" json:"Vin,omitempty"`
+	Vout             []*ProtoTx_VoutType           `protobuf:"bytes,5,rep,name=Vout,proto3" json:"Vout,omitempty"`
+	BlockHash        string                        `protobuf:"bytes,6,opt,name=BlockHash,proto3" json:"BlockHash,omitempty"`
+	BlockHeight      int32                         `protobuf:"varint,7,opt,name=BlockHeight,proto3" json:"BlockHeight,omitempty"`
+	Confirmations    uint32                        `protobuf:"varint,8,opt,name=Confirmations,proto3" json:"Confirmations,omitempty"`
+	BlockTime        int64                         `protobuf:"varint,9,opt,name=BlockTime,proto3" json:"BlockTime,omitempty"`
+	Size             int32                         `protobuf:"varint,10,opt,name=Size,proto3" json:"Size,omitempty"`
+	Value            string                        `protobuf:"bytes,11,opt,name=Value,proto3" json:"Value,omitempty"`
+	ValueIn          string                        `protobuf:"bytes,12,opt,name=ValueIn,proto3" json:"ValueIn,omitempty"`
+	Fees             string                        `protobuf:"bytes,13,opt,name=Fees,proto3" json:"Fees,omitempty"`
+	Hex              string                        `protobuf:"bytes,14,opt,name=Hex,proto3" json:"Hex,omitempty"`
+	Rbf              bool                          `protobuf:"varint,15,opt,name=Rbf,proto3" json:"Rbf,omitempty"`
+	TokenTransfers   []*ProtoTx_TokenTransferType  `protobuf:"bytes,16,rep,name=TokenTransfers,proto3" json:"TokenTransfers,omitempty"`
+	EthereumSpecific *ProtoTx_EthereumSpecificType `protobuf:"bytes,17,opt,name=EthereumSpecific,proto3" json:"EthereumSpecific,omitempty"`
+}
+
+func (x *ProtoTx) Reset() {
+	*x = ProtoTx{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoTx) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoTx) ProtoMessage() {}
+
+func (x *ProtoTx) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoTx.ProtoReflect.Descriptor instead.
+func (*ProtoTx) Descriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ProtoTx) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *ProtoTx) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ProtoTx) GetLockTime() uint32 {
+	if x != nil {
+		return x.LockTime
+	}
+	return 0
+}
+
+func (x *ProtoTx) GetVin() []*ProtoTx_VinType {
+	if x != nil {
+		return x.Vin
+	}
+	return nil
+}
+
+func (x *ProtoTx) GetVout() []*ProtoTx_VoutType {
+	if x != nil {
+		return x.Vout
+	}
+	return nil
+}
+
+func (x *ProtoTx) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *ProtoTx) GetBlockHeight() int32 {
+	if x != nil {
+		return x.BlockHeight
+	}
+	return 0
+}
+
+func (x *ProtoTx) GetConfirmations() uint32 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *ProtoTx) GetBlockTime() int64 {
+	if x != nil {
+		return x.BlockTime
+	}
+	return 0
+}
+
+func (x *ProtoTx) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ProtoTx) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ProtoTx) GetValueIn() string {
+	if x != nil {
+		return x.ValueIn
+	}
+	return ""
+}
+
+func (x *ProtoTx) GetFees() string {
+	if x != nil {
+		return x.Fees
+	}
+	return ""
+}
+
+func (x *ProtoTx) GetHex() string {
+	if x != nil {
+		return x.Hex
+	}
+	return ""
+}
+
+func (x *ProtoTx) GetRbf() bool {
+	if x != nil {
+		return x.Rbf
+	}
+	return false
+}
+
+func (x *ProtoTx) GetTokenTransfers() []*ProtoTx_TokenTransferType {
+	if x != nil {
+		return x.TokenTransfers
+	}
+	return nil
+}
+
+func (x *ProtoTx) GetEthereumSpecific() *ProtoTx_EthereumSpecificType {
+	if x != nil {
+		return x.EthereumSpecific
+	}
+	return nil
+}
+
+type ProtoAddress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Page               int32                     `protobuf:"varint,1,opt,name=Page,proto3" json:"Page,omitempty"`
+	TotalPages         int32                     `protobuf:"varint,2,opt,name=TotalPages,proto3" json:"TotalPages,omitempty"`
+	ItemsOnPage        int32                     `protobuf:"varint,3,opt,name=ItemsOnPage,proto3" json:"ItemsOnPage,omitempty"`
+	Address            string                    `protobuf:"bytes,4,opt,name=Address,proto3" json:"Address,omitempty"`
+	Balance            string                    `protobuf:"bytes,5,opt,name=Balance,proto3" json:"Balance,omitempty"`
+	TotalReceived      string                    `protobuf:"bytes,6,opt,name=TotalReceived,proto3" json:"TotalReceived,omitempty"`
+	TotalSent          string                    `protobuf:"bytes,7,opt,name=TotalSent,proto3" json:"TotalSent,omitempty"`
+	UnconfirmedBalance string                    `protobuf:"bytes,8,opt,name=UnconfirmedBalance,proto3" json:"UnconfirmedBalance,omitempty"`
+	UnconfirmedTxs     int32                     `protobuf:"varint,9,opt,name=UnconfirmedTxs,proto3" json:"UnconfirmedTxs,omitempty"`
+	Txs                int32                     `protobuf:"varint,10,opt,name=Txs,proto3" json:"Txs,omitempty"`
+	NonTokenTxs        int32                     `protobuf:"varint,11,opt,name=NonTokenTxs,proto3" json:"NonTokenTxs,omitempty"`
+	Transactions       []*ProtoTx                `protobuf:"bytes,12,rep,name=Transactions,proto3" json:"Transactions,omitempty"`
+	Txids              []string                  `protobuf:"bytes,13,rep,name=Txids,proto3" json:"Txids,omitempty"`
+	Nonce              string                    `protobuf:"bytes,14,opt,name=Nonce,proto3" json:"Nonce,omitempty"`
+	UsedTokens         int32                     `protobuf:"varint,15,opt,name=UsedTokens,proto3" json:"UsedTokens,omitempty"`
+	Tokens             []*ProtoAddress_TokenType `protobuf:"bytes,16,rep,name=Tokens,proto3" json:"Tokens,omitempty"`
+}
+
+func (x *ProtoAddress) Reset() {
+	*x = ProtoAddress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoAddress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoAddress) ProtoMessage() {}
+
+func (x *ProtoAddress) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoAddress.ProtoReflect.Descriptor instead.
+func (*ProtoAddress) Descriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ProtoAddress) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ProtoAddress) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *ProtoAddress) GetItemsOnPage() int32 {
+	if x != nil {
+		return x.ItemsOnPage
+	}
+	return 0
+}
+
+func (x *ProtoAddress) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ProtoAddress) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *ProtoAddress) GetTotalReceived() string {
+	if x != nil {
+		return x.TotalReceived
+	}
+	return ""
+}
+
+func (x *ProtoAddress) GetTotalSent() string {
+	if x != nil {
+		return x.TotalSent
+	}
+	return ""
+}
+
+func (x *ProtoAddress) GetUnconfirmedBalance() string {
+	if x != nil {
+		return x.UnconfirmedBalance
+	}
+	return ""
+}
+
+func (x *ProtoAddress) GetUnconfirmedTxs() int32 {
+	if x != nil {
+		return x.UnconfirmedTxs
+	}
+	return 0
+}
+
+func (x *ProtoAddress) GetTxs() int32 {
+	if x != nil {
+		return x.Txs
+	}
+	return 0
+}
+
+func (x *ProtoAddress) GetNonTokenTxs() int32 {
+	if x != nil {
+		return x.NonTokenTxs
+	}
+	return 0
+}
+
+func (x *ProtoAddress) GetTransactions() []*ProtoTx {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ProtoAddress) GetTxids() []string {
+	if x != nil {
+		return x.Txids
+	}
+	return nil
+}
+
+func (x *ProtoAddress) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *ProtoAddress) GetUsedTokens() int32 {
+	if x != nil {
+		return x.UsedTokens
+	}
+	return 0
+}
+
+func (x *ProtoAddress) GetTokens() []*ProtoAddress_TokenType {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type ProtoUtxos struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Utxos []*ProtoUtxos_UtxoType `protobuf:"bytes,1,rep,name=Utxos,proto3" json:"Utxos,omitempty"`
+}
+
+func (x *ProtoUtxos) Reset() {
+	*x = ProtoUtxos{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoUtxos) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoUtxos) ProtoMessage() {}
+
+func (x *ProtoUtxos) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoUtxos.ProtoReflect.Descriptor instead.
+func (*ProtoUtxos) Descriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ProtoUtxos) GetUtxos() []*ProtoUtxos_UtxoType {
+	if x != nil {
+		return x.Utxos
+	}
+	return nil
+}
+
+type ProtoBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Page          int32      `protobuf:"varint,1,opt,name=Page,proto3" json:"Page,omitempty"`
+	TotalPages    int32      `protobuf:"varint,2,opt,name=TotalPages,proto3" json:"TotalPages,omitempty"`
+	ItemsOnPage   int32      `protobuf:"varint,3,opt,name=ItemsOnPage,proto3" json:"ItemsOnPage,omitempty"`
+	Hash          string     `protobuf:"bytes,4,opt,name=Hash,proto3" json:"Hash,omitempty"`
+	Prev          string     `protobuf:"bytes,5,opt,name=Prev,proto3" json:"Prev,omitempty"`
+	Next          string     `protobuf:"bytes,6,opt,name=Next,proto3" json:"Next,omitempty"`
+	Height        uint32     `protobuf:"varint,7,opt,name=Height,proto3" json:"Height,omitempty"`
+	Confirmations int64      `protobuf:"varint,8,opt,name=Confirmations,proto3" json:"Confirmations,omitempty"`
+	Size          int32      `protobuf:"varint,9,opt,name=Size,proto3" json:"Size,omitempty"`
+	Time          int64      `protobuf:"varint,10,opt,name=Time,proto3" json:"Time,omitempty"`
+	Version       string     `protobuf:"bytes,11,opt,name=Version,proto3" json:"Version,omitempty"`
+	MerkleRoot    string     `protobuf:"bytes,12,opt,name=MerkleRoot,proto3" json:"MerkleRoot,omitempty"`
+	Nonce         string     `protobuf:"bytes,13,opt,name=Nonce,proto3" json:"Nonce,omitempty"`
+	Bits          string     `protobuf:"bytes,14,opt,name=Bits,proto3" json:"Bits,omitempty"`
+	Difficulty    string     `protobuf:"bytes,15,opt,name=Difficulty,proto3" json:"Difficulty,omitempty"`
+	Txids         []string   `protobuf:"bytes,16,rep,name=Txids,proto3" json:"Txids,omitempty"`
+	TxCount       int32      `protobuf:"varint,17,opt,name=TxCount,proto3" json:"TxCount,omitempty"`
+	Transactions  []*ProtoTx `protobuf:"bytes,18,rep,name=Transactions,proto3" json:"Transactions,omitempty"`
+}
+
+func (x *ProtoBlock) Reset() {
+	*x = ProtoBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoBlock) ProtoMessage() {}
+
+func (x *ProtoBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoBlock.ProtoReflect.Descriptor instead.
+func (*ProtoBlock) Descriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ProtoBlock) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ProtoBlock) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+func (x *ProtoBlock) GetItemsOnPage() int32 {
+	if x != nil {
+		return x.ItemsOnPage
+	}
+	return 0
+}
+
+func (x *ProtoBlock) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *ProtoBlock) GetPrev() string {
+	if x != nil {
+		return x.Prev
+	}
+	return ""
+}
+
+func (x *ProtoBlock) GetNext() string {
+	if x != nil {
+		return x.Next
+	}
+	return ""
+}
+
+func (x *ProtoBlock) GetHeight() uint32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ProtoBlock) GetConfirmations() int64 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *ProtoBlock) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ProtoBlock) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *ProtoBlock) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ProtoBlock) GetMerkleRoot() string {
+	if x != nil {
+		return x.MerkleRoot
+	}
+	return ""
+}
+
+func (x *ProtoBlock) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *ProtoBlock) GetBits() string {
+	if x != nil {
+		return x.Bits
+	}
+	return ""
+}
+
+func (x *ProtoBlock) GetDifficulty() string {
+	if x != nil {
+		return x.Difficulty
+	}
+	return ""
+}
+
+func (x *ProtoBlock) GetTxids() []string {
+	if x != nil {
+		return x.Txids
+	}
+	return nil
+}
+
+func (x *ProtoBlock) GetTxCount() int32 {
+	if x != nil {
+		return x.TxCount
+	}
+	return 0
+}
+
+func (x *ProtoBlock) GetTransactions() []*ProtoTx {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type ProtoTx_VinType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Txid      string   `protobuf:"bytes,1,opt,name=Txid,proto3" json:"Txid,omitempty"`
+	Vout      uint32   `protobuf:"varint,2,opt,name=Vout,proto3" json:"Vout,omitempty"`
+	Sequence  int64    `protobuf:"varint,3,opt,name=Sequence,proto3" json:"Sequence,omitempty"`
+	N         int32    `protobuf:"varint,4,opt,name=N,proto3" json:"N,omitempty"`
+	Addresses []string `protobuf:"bytes,5,rep,name=Addresses,proto3" json:"Addresses,omitempty"`
+	IsAddress bool     `protobuf:"varint,6,opt,name=IsAddress,proto3" json:"IsAddress,omitempty"`
+	Value     string   `protobuf:"bytes,7,opt,name=Value,proto3" json:"Value,omitempty"`
+	Hex       string   `protobuf:"bytes,8,opt,name=Hex,proto3" json:"Hex,omitempty"`
+	Coinbase  string   `protobuf:"bytes,9,opt,name=Coinbase,proto3" json:"Coinbase,omitempty"`
+}
+
+func (x *ProtoTx_VinType) Reset() {
+	*x = ProtoTx_VinType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoTx_VinType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoTx_VinType) ProtoMessage() {}
+
+func (x *ProtoTx_VinType) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoTx_VinType.ProtoReflect.Descriptor instead.
+func (*ProtoTx_VinType) Descriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{12, 0}
+}
+
+func (x *ProtoTx_VinType) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *ProtoTx_VinType) GetVout() uint32 {
+	if x != nil {
+		return x.Vout
+	}
+	return 0
+}
+
+func (x *ProtoTx_VinType) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *ProtoTx_VinType) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *ProtoTx_VinType) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *ProtoTx_VinType) GetIsAddress() bool {
+	if x != nil {
+		return x.IsAddress
+	}
+	return false
+}
+
+func (x *ProtoTx_VinType) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ProtoTx_VinType) GetHex() string {
+	if x != nil {
+		return x.Hex
+	}
+	return ""
+}
+
+func (x *ProtoTx_VinType) GetCoinbase() string {
+	if x != nil {
+		return x.Coinbase
+	}
+	return ""
+}
+
+type ProtoTx_VoutType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value       string   `protobuf:"bytes,1,opt,name=Value,proto3" json:"Value,omitempty"`
+	N           int32    `protobuf:"varint,2,opt,name=N,proto3" json:"N,omitempty"`
+	Spent       bool     `protobuf:"varint,3,opt,name=Spent,proto3" json:"Spent,omitempty"`
+	SpentTxid   string   `protobuf:"bytes,4,opt,name=SpentTxid,proto3" json:"SpentTxid,omitempty"`
+	SpentIndex  int32    `protobuf:"varint,5,opt,name=SpentIndex,proto3" json:"SpentIndex,omitempty"`
+	SpentHeight int32    `protobuf:"varint,6,opt,name=SpentHeight,proto3" json:"SpentHeight,omitempty"`
+	Hex         string   `protobuf:"bytes,7,opt,name=Hex,proto3" json:"Hex,omitempty"`
+	Addresses   []string `protobuf:"bytes,8,rep,name=Addresses,proto3" json:"Addresses,omitempty"`
+	IsAddress   bool     `protobuf:"varint,9,opt,name=IsAddress,proto3" json:"IsAddress,omitempty"`
+	Type        string   `protobuf:"bytes,10,opt,name=Type,proto3" json:"Type,omitempty"`
+}
+
+func (x *ProtoTx_VoutType) Reset() {
+	*x = ProtoTx_VoutType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoTx_VoutType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoTx_VoutType) ProtoMessage() {}
+
+func (x *ProtoTx_VoutType) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoTx_VoutType.ProtoReflect.Descriptor instead.
+func (*ProtoTx_VoutType) Descriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{12, 1}
+}
+
+func (x *ProtoTx_VoutType) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ProtoTx_VoutType) GetN() int32 {
+	if x != nil {
+		return x.N
+	}
+	return 0
+}
+
+func (x *ProtoTx_VoutType) GetSpent() bool {
+	if x != nil {
+		return x.Spent
+	}
+	return false
+}
+
+func (x *ProtoTx_VoutType) GetSpentTxid() string {
+	if x != nil {
+		return x.SpentTxid
+	}
+	return ""
+}
+
+func (x *ProtoTx_VoutType) GetSpentIndex() int32 {
+	if x != nil {
+		return x.SpentIndex
+	}
+	return 0
+}
+
+func (x *ProtoTx_VoutType) GetSpentHeight() int32 {
+	if x != nil {
+		return x.SpentHeight
+	}
+	return 0
+}
+
+func (x *ProtoTx_VoutType) GetHex() string {
+	if x != nil {
+		return x.Hex
+	}
+	return ""
+}
+
+func (x *ProtoTx_VoutType) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *ProtoTx_VoutType) GetIsAddress() bool {
+	if x != nil {
+		return x.IsAddress
+	}
+	return false
+}
+
+func (x *ProtoTx_VoutType) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type ProtoTx_TokenTransferType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type     string `protobuf:"bytes,1,opt,name=Type,proto3" json:"Type,omitempty"`
+	From     string `protobuf:"bytes,2,opt,name=From,proto3" json:"From,omitempty"`
+	To       string `protobuf:"bytes,3,opt,name=To,proto3" json:"To,omitempty"`
+	Token    string `protobuf:"bytes,4,opt,name=Token,proto3" json:"Token,omitempty"`
+	Name     string `protobuf:"bytes,5,opt,name=Name,proto3" json:"Name,omitempty"`
+	Symbol   string `protobuf:"bytes,6,opt,name=Symbol,proto3" json:"Symbol,omitempty"`
+	Decimals int32  `protobuf:"varint,7,opt,name=Decimals,proto3" json:"Decimals,omitempty"`
+	Value    string `protobuf:"bytes,8,opt,name=Value,proto3" json:"Value,omitempty"`
+}
+
+func (x *ProtoTx_TokenTransferType) Reset() {
+	*x = ProtoTx_TokenTransferType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoTx_TokenTransferType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoTx_TokenTransferType) ProtoMessage() {}
+
+func (x *ProtoTx_TokenTransferType) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoTx_TokenTransferType.ProtoReflect.Descriptor instead.
+func (*ProtoTx_TokenTransferType) Descriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{12, 2}
+}
+
+func (x *ProtoTx_TokenTransferType) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ProtoTx_TokenTransferType) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ProtoTx_TokenTransferType) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ProtoTx_TokenTransferType) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ProtoTx_TokenTransferType) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProtoTx_TokenTransferType) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ProtoTx_TokenTransferType) GetDecimals() int32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *ProtoTx_TokenTransferType) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type ProtoTx_EthereumSpecificType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status   int32  `protobuf:"varint,1,opt,name=Status,proto3" json:"Status,omitempty"`
+	Nonce    uint64 `protobuf:"varint,2,opt,name=Nonce,proto3" json:"Nonce,omitempty"`
+	GasLimit string `protobuf:"bytes,3,opt,name=GasLimit,proto3" json:"GasLimit,omitempty"`
+	GasUsed  string `protobuf:"bytes,4,opt,name=GasUsed,proto3" json:"GasUsed,omitempty"`
+	GasPrice string `protobuf:"bytes,5,opt,name=GasPrice,proto3" json:"GasPrice,omitempty"`
+	Data     string `protobuf:"bytes,6,opt,name=Data,proto3" json:"Data,omitempty"`
+}
+
+func (x *ProtoTx_EthereumSpecificType) Reset() {
+	*x = ProtoTx_EthereumSpecificType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoTx_EthereumSpecificType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoTx_EthereumSpecificType) ProtoMessage() {}
+
+func (x *ProtoTx_EthereumSpecificType) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoTx_EthereumSpecificType.ProtoReflect.Descriptor instead.
+func (*ProtoTx_EthereumSpecificType) Descriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{12, 3}
+}
+
+func (x *ProtoTx_EthereumSpecificType) GetStatus() int32 {
+	if x != nil {
+		return x.Status
+	}
+	return 0
+}
+
+func (x *ProtoTx_EthereumSpecificType) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *ProtoTx_EthereumSpecificType) GetGasLimit() string {
+	if x != nil {
+		return x.GasLimit
+	}
+	return ""
+}
+
+func (x *ProtoTx_EthereumSpecificType) GetGasUsed() string {
+	if x != nil {
+		return x.GasUsed
+	}
+	return ""
+}
+
+func (x *ProtoTx_EthereumSpecificType) GetGasPrice() string {
+	if x != nil {
+		return x.GasPrice
+	}
+	return ""
+}
+
+func (x *ProtoTx_EthereumSpecificType) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+type ProtoAddress_TokenType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type          string `protobuf:"bytes,1,opt,name=Type,proto3" json:"Type,omitempty"`
+	Name          string `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Path          string `protobuf:"bytes,3,opt,name=Path,proto3" json:"Path,omitempty"`
+	Contract      string `protobuf:"bytes,4,opt,name=Contract,proto3" json:"Contract,omitempty"`
+	Transfers     int32  `protobuf:"varint,5,opt,name=Transfers,proto3" json:"Transfers,omitempty"`
+	Symbol        string `protobuf:"bytes,6,opt,name=Symbol,proto3" json:"Symbol,omitempty"`
+	Decimals      int32  `protobuf:"varint,7,opt,name=Decimals,proto3" json:"Decimals,omitempty"`
+	Balance       string `protobuf:"bytes,8,opt,name=Balance,proto3" json:"Balance,omitempty"`
+	TotalReceived string `protobuf:"bytes,9,opt,name=TotalReceived,proto3" json:"TotalReceived,omitempty"`
+	TotalSent     string `protobuf:"bytes,10,opt,name=TotalSent,proto3" json:"TotalSent,omitempty"`
+}
+
+func (x *ProtoAddress_TokenType) Reset() {
+	*x = ProtoAddress_TokenType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoAddress_TokenType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoAddress_TokenType) ProtoMessage() {}
+
+func (x *ProtoAddress_TokenType) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoAddress_TokenType.ProtoReflect.Descriptor instead.
+func (*ProtoAddress_TokenType) Descriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{13, 0}
+}
+
+func (x *ProtoAddress_TokenType) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ProtoAddress_TokenType) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProtoAddress_TokenType) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ProtoAddress_TokenType) GetContract() string {
+	if x != nil {
+		return x.Contract
+	}
+	return ""
+}
+
+func (x *ProtoAddress_TokenType) GetTransfers() int32 {
+	if x != nil {
+		return x.Transfers
+	}
+	return 0
+}
+
+func (x *ProtoAddress_TokenType) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ProtoAddress_TokenType) GetDecimals() int32 {
+	if x != nil {
+		return x.Decimals
+	}
+	return 0
+}
+
+func (x *ProtoAddress_TokenType) GetBalance() string {
+	if x != nil {
+		return x.Balance
+	}
+	return ""
+}
+
+func (x *ProtoAddress_TokenType) GetTotalReceived() string {
+	if x != nil {
+		return x.TotalReceived
+	}
+	return ""
+}
+
+func (x *ProtoAddress_TokenType) GetTotalSent() string {
+	if x != nil {
+		return x.TotalSent
+	}
+	return ""
+}
+
+type ProtoUtxos_UtxoType struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Txid          string `protobuf:"bytes,1,opt,name=Txid,proto3" json:"Txid,omitempty"`
+	Vout          int32  `protobuf:"varint,2,opt,name=Vout,proto3" json:"Vout,omitempty"`
+	Value         string `protobuf:"bytes,3,opt,name=Value,proto3" json:"Value,omitempty"`
+	Height        int32  `protobuf:"varint,4,opt,name=Height,proto3" json:"Height,omitempty"`
+	Confirmations int32  `protobuf:"varint,5,opt,name=Confirmations,proto3" json:"Confirmations,omitempty"`
+	Address       string `protobuf:"bytes,6,opt,name=Address,proto3" json:"Address,omitempty"`
+	Path          string `protobuf:"bytes,7,opt,name=Path,proto3" json:"Path,omitempty"`
+	LockTime      uint32 `protobuf:"varint,8,opt,name=LockTime,proto3" json:"LockTime,omitempty"`
+	Coinbase      bool   `protobuf:"varint,9,opt,name=Coinbase,proto3" json:"Coinbase,omitempty"`
+}
+
+func (x *ProtoUtxos_UtxoType) Reset() {
+	*x = ProtoUtxos_UtxoType{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_grpc_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProtoUtxos_UtxoType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProtoUtxos_UtxoType) ProtoMessage() {}
+
+func (x *ProtoUtxos_UtxoType) ProtoReflect() protoreflect.Message {
+	mi := &file_grpc_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProtoUtxos_UtxoType.ProtoReflect.Descriptor instead.
+func (*ProtoUtxos_UtxoType) Descriptor() ([]byte, []int) {
+	return file_grpc_proto_rawDescGZIP(), []int{14, 0}
+}
+
+func (x *ProtoUtxos_UtxoType) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *ProtoUtxos_UtxoType) GetVout() int32 {
+	if x != nil {
+		return x.Vout
+	}
+	return 0
+}
+
+func (x *ProtoUtxos_UtxoType) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ProtoUtxos_UtxoType) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ProtoUtxos_UtxoType) GetConfirmations() int32 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *ProtoUtxos_UtxoType) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ProtoUtxos_UtxoType) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ProtoUtxos_UtxoType) GetLockTime() uint32 {
+	if x != nil {
+		return x.LockTime
+	}
+	return 0
+}
+
+func (x *ProtoUtxos_UtxoType) GetCoinbase() bool {
+	if x != nil {
+		return x.Coinbase
+	}
+	return false
+}
+
+var File_grpc_proto protoreflect.FileDescriptor
+
+var file_grpc_proto_rawDesc = []byte{
+	0x0a, 0x0a, 0x67, 0x72, 0x70, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x22, 0xb1, 0x04, 0x0a, 0x16, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x47, 0x65,
+	0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x18, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x61, 0x67,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x50, 0x61, 0x67, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52,
+	0x08, 0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x44, 0x0a, 0x07, 0x44, 0x65, 0x74,
+	0x61, 0x69, 0x6c, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2a, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x47, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x69,
+	0x6c, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x07, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x12,
+	0x41, 0x0a, 0x06, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x29, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x47, 0x65,
+	0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x06, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x46, 0x72, 0x6f, 0x6d, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x46, 0x72, 0x6f, 0x6d, 0x48, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x54, 0x6f, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x54, 0x6f, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1a,
+	0x0a, 0x08, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x47, 0x61,
+	0x70, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x03, 0x47, 0x61, 0x70, 0x22, 0x95, 0x01, 0x0a,
+	0x0b, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x54, 0x79, 0x70, 0x65, 0x12, 0x10, 0x0a, 0x0c,
+	0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x42, 0x61, 0x73, 0x69, 0x63, 0x10, 0x00, 0x12, 0x11,
+	0x0a, 0x0d, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x10,
+	0x01, 0x12, 0x18, 0x0a, 0x14, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x54, 0x6f, 0x6b, 0x65,
+	0x6e, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x10, 0x02, 0x12, 0x16, 0x0a, 0x12, 0x44,
+	0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x54, 0x78, 0x69, 0x64, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72,
+	0x79, 0x10, 0x03, 0x12, 0x19, 0x0a, 0x15, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x54, 0x78,
+	0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x67, 0x68, 0x74, 0x10, 0x04, 0x12, 0x14,
+	0x0a, 0x10, 0x44, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x73, 0x54, 0x78, 0x48, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x10, 0x05, 0x22, 0x42, 0x0a, 0x0a, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x11, 0x0a, 0x0d, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x4e, 0x6f, 0x6e, 0x7a,
+	0x65, 0x72, 0x6f, 0x10, 0x00, 0x12, 0x0e, 0x0a, 0x0a, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x55,
+	0x73, 0x65, 0x64, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x44,
+	0x65, 0x72, 0x69, 0x76, 0x65, 0x64, 0x10, 0x02, 0x22, 0x4c, 0x0a, 0x1a, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x78, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x54, 0x78, 0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x53, 0x70,
+	0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x53, 0x70,
+	0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x22, 0x6e, 0x0a, 0x1a, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x47,
+	0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x55, 0x74, 0x78, 0x6f, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x24,
+	0x0a, 0x0d, 0x4f, 0x6e, 0x6c, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x4f, 0x6e, 0x6c, 0x79, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x72, 0x6d, 0x65, 0x64, 0x12, 0x10, 0x0a, 0x03, 0x47, 0x61, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x03, 0x47, 0x61, 0x70, 0x22, 0x40, 0x0a, 0x14, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x47,
+	0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x04, 0x50, 0x61, 0x67, 0x65, 0x22, 0x2f, 0x0a, 0x1b, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x48, 0x65, 0x78, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x48, 0x65, 0x78, 0x22, 0x32, 0x0a, 0x1c, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x78, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x54, 0x78, 0x69, 0x64, 0x22, 0x55, 0x0a,
+	0x17, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x46, 0x65,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x06, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73,
+	0x12, 0x22, 0x0a, 0x0c, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x76, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x43, 0x6f, 0x6e, 0x73, 0x65, 0x72, 0x76, 0x61,
+	0x74, 0x69, 0x76, 0x65, 0x22, 0x3a, 0x0a, 0x18, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x45, 0x73, 0x74,
+	0x69, 0x6d, 0x61, 0x74, 0x65, 0x46, 0x65, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x1e, 0x0a, 0x0a, 0x46, 0x65, 0x65, 0x50, 0x65, 0x72, 0x55, 0x6e, 0x69, 0x74, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x46, 0x65, 0x65, 0x50, 0x65, 0x72, 0x55, 0x6e, 0x69, 0x74,
+	0x22, 0x1f, 0x0a, 0x1d, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x4e, 0x65, 0x77, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x22, 0x3e, 0x0a, 0x1e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x22, 0x3b, 0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x4e, 0x65, 0x77, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x12, 0x16, 0x0a, 0x06, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x06, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x48, 0x61,
+	0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x48, 0x61, 0x73, 0x68, 0x22, 0x4b,
+	0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x54, 0x78,
+	0x12, 0x18, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1f, 0x0a, 0x02, 0x54, 0x78,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x54, 0x78, 0x52, 0x02, 0x54, 0x78, 0x22, 0x9f, 0x0b, 0x0a, 0x07,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x54, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x78, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x54, 0x78, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x56, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x29, 0x0a, 0x03, 0x56, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x54, 0x78, 0x2e,
+	0x56, 0x69, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x03, 0x56, 0x69, 0x6e, 0x12, 0x2c, 0x0a, 0x04,
+	0x56, 0x6f, 0x75, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73, 0x65, 0x72,
+	0x76, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x54, 0x78, 0x2e, 0x56, 0x6f, 0x75, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x56, 0x6f, 0x75, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x20, 0x0a, 0x0b, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x43, 0x6f,
+	0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x0d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x49, 0x6e, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x49, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x46, 0x65, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x46, 0x65, 0x65, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x48, 0x65, 0x78, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x48, 0x65, 0x78, 0x12, 0x10, 0x0a, 0x03, 0x52, 0x62, 0x66, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x52, 0x62, 0x66, 0x12, 0x49, 0x0a, 0x0e, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x18, 0x10, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x54, 0x78, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x0e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x73, 0x12, 0x50, 0x0a, 0x10, 0x45, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75,
+	0x6d, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x24, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x54, 0x78,
+	0x2e, 0x45, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x53, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69,
+	0x63, 0x54, 0x79, 0x70, 0x65, 0x52, 0x10, 0x45, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x53,
+	0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x63, 0x1a, 0xdb, 0x01, 0x0a, 0x07, 0x56, 0x69, 0x6e, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x78, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x54, 0x78, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x6f, 0x75, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x04, 0x56, 0x6f, 0x75, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x53,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x53,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x4e, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x01, 0x4e, 0x12, 0x1c, 0x0a, 0x09, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x49, 0x73, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x49, 0x73, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x10, 0x0a, 0x03, 0x48, 0x65, 0x78, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x48, 0x65, 0x78, 0x12, 0x1a, 0x0a, 0x08, 0x43, 0x6f, 0x69,
+	0x6e, 0x62, 0x61, 0x73, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x43, 0x6f, 0x69,
+	0x6e, 0x62, 0x61, 0x73, 0x65, 0x1a, 0x86, 0x02, 0x0a, 0x08, 0x56, 0x6f, 0x75, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x0c, 0x0a, 0x01, 0x4e, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x01, 0x4e, 0x12, 0x14, 0x0a, 0x05, 0x53, 0x70, 0x65, 0x6e, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x53, 0x70, 0x65, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09,
+	0x53, 0x70, 0x65, 0x6e, 0x74, 0x54, 0x78, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x53, 0x70, 0x65, 0x6e, 0x74, 0x54, 0x78, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x53, 0x70,
+	0x65, 0x6e, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x53, 0x70, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x20, 0x0a, 0x0b, 0x53, 0x70,
+	0x65, 0x6e, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0b, 0x53, 0x70, 0x65, 0x6e, 0x74, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x10, 0x0a, 0x03,
+	0x48, 0x65, 0x78, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x48, 0x65, 0x78, 0x12, 0x1c,
+	0x0a, 0x09, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x09, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09,
+	0x49, 0x73, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x09, 0x49, 0x73, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79,
+	0x70, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x1a, 0xbf,
+	0x01, 0x0a, 0x11, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x46, 0x72, 0x6f, 0x6d,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x0e, 0x0a, 0x02,
+	0x54, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x54, 0x6f, 0x12, 0x14, 0x0a, 0x05,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1a,
+	0x0a, 0x08, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x1a, 0xaa, 0x01, 0x0a, 0x14, 0x45, 0x74, 0x68, 0x65, 0x72, 0x65, 0x75, 0x6d, 0x53, 0x70, 0x65,
+	0x63, 0x69, 0x66, 0x69, 0x63, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x14, 0x0a, 0x05, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x47, 0x61, 0x73, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x47, 0x61, 0x73, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x47, 0x61, 0x73, 0x55, 0x73, 0x65, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x47, 0x61, 0x73, 0x55, 0x73, 0x65, 0x64, 0x12, 0x1a, 0x0a,
+	0x08, 0x47, 0x61, 0x73, 0x50, 0x72, 0x69, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x47, 0x61, 0x73, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x44, 0x61, 0x74,
+	0x61, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x44, 0x61, 0x74, 0x61, 0x22, 0xb7, 0x06,
+	0x0a, 0x0c, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x50, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x50, 0x61,
+	0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x50, 0x61, 0x67, 0x65, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x50, 0x61, 0x67,
+	0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x4f, 0x6e, 0x50, 0x61, 0x67,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x4f, 0x6e,
+	0x50, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x54, 0x6f, 0x74, 0x61,
+	0x6c, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0d, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x1c,
+	0x0a, 0x09, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x53, 0x65, 0x6e, 0x74, 0x12, 0x2e, 0x0a, 0x12,
+	0x55, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e,
+	0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x55, 0x6e, 0x63, 0x6f, 0x6e, 0x66,
+	0x69, 0x72, 0x6d, 0x65, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x26, 0x0a, 0x0e,
+	0x55, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65, 0x64, 0x54, 0x78, 0x73, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x55, 0x6e, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x65,
+	0x64, 0x54, 0x78, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x54, 0x78, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x03, 0x54, 0x78, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x4e, 0x6f, 0x6e, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x54, 0x78, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x4e, 0x6f, 0x6e,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x54, 0x78, 0x73, 0x12, 0x33, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x54, 0x78, 0x52,
+	0x0c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x54, 0x78, 0x69, 0x64, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x54, 0x78,
+	0x69, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x55, 0x73, 0x65,
+	0x64, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x55,
+	0x73, 0x65, 0x64, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x36, 0x0a, 0x06, 0x54, 0x6f, 0x6b,
+	0x65, 0x6e, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x2e,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x06, 0x54, 0x6f, 0x6b, 0x65, 0x6e,
+	0x73, 0x1a, 0x93, 0x02, 0x0a, 0x09, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x54, 0x79, 0x70, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x54, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x61, 0x74, 0x68, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1a, 0x0a, 0x08, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x66, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1a, 0x0a,
+	0x08, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x44, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x42, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x42, 0x61, 0x6c, 0x61,
+	0x6e, 0x63, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x52, 0x65, 0x63, 0x65,
+	0x69, 0x76, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x54, 0x6f, 0x74, 0x61,
+	0x6c, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x54, 0x6f, 0x74,
+	0x61, 0x6c, 0x53, 0x65, 0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x54, 0x6f,
+	0x74, 0x61, 0x6c, 0x53, 0x65, 0x6e, 0x74, 0x22, 0xae, 0x02, 0x0a, 0x0a, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x55, 0x74, 0x78, 0x6f, 0x73, 0x12, 0x31, 0x0a, 0x05, 0x55, 0x74, 0x78, 0x6f, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50,
+	0x72, 0x6f, 0x74, 0x6f, 0x55, 0x74, 0x78, 0x6f, 0x73, 0x2e, 0x55, 0x74, 0x78, 0x6f, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x05, 0x55, 0x74, 0x78, 0x6f, 0x73, 0x1a, 0xec, 0x01, 0x0a, 0x08, 0x55, 0x74,
+	0x78, 0x6f, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x54, 0x78, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x54, 0x78, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x56, 0x6f,
+	0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x56, 0x6f, 0x75, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x24, 0x0a, 0x0d,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x0d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x50, 0x61, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x50, 0x61, 0x74, 0x68,
+	0x12, 0x1a, 0x0a, 0x08, 0x4c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x08, 0x4c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x43, 0x6f, 0x69, 0x6e, 0x62, 0x61, 0x73, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08,
+	0x43, 0x6f, 0x69, 0x6e, 0x62, 0x61, 0x73, 0x65, 0x22, 0xed, 0x03, 0x0a, 0x0a, 0x50, 0x72, 0x6f,
+	0x74, 0x6f, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x61, 0x67, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x50, 0x61, 0x67, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x54,
+	0x6f, 0x74, 0x61, 0x6c, 0x50, 0x61, 0x67, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0a, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x50, 0x61, 0x67, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x49,
+	0x74, 0x65, 0x6d, 0x73, 0x4f, 0x6e, 0x50, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0b, 0x49, 0x74, 0x65, 0x6d, 0x73, 0x4f, 0x6e, 0x50, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a,
+	0x04, 0x48, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x48, 0x61, 0x73,
+	0x68, 0x12, 0x12, 0x0a, 0x04, 0x50, 0x72, 0x65, 0x76, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x50, 0x72, 0x65, 0x76, 0x12, 0x12, 0x0a, 0x04, 0x4e, 0x65, 0x78, 0x74, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x4e, 0x65, 0x78, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x48, 0x65, 0x69,
+	0x67, 0x68, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x48, 0x65, 0x69, 0x67, 0x68,
+	0x74, 0x12, 0x24, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x72,
+	0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x18,
+	0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x54,
+	0x69, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x54, 0x69, 0x6d, 0x65, 0x12,
+	0x18, 0x0a, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x4d, 0x65, 0x72,
+	0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x4d,
+	0x65, 0x72, 0x6b, 0x6c, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x4e, 0x6f, 0x6e,
+	0x63, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x42, 0x69, 0x74, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x42,
+	0x69, 0x74, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x44, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75, 0x6c, 0x74,
+	0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x44, 0x69, 0x66, 0x66, 0x69, 0x63, 0x75,
+	0x6c, 0x74, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x54, 0x78, 0x69, 0x64, 0x73, 0x18, 0x10, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x05, 0x54, 0x78, 0x69, 0x64, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x54, 0x78, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x54, 0x78, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x33, 0x0a, 0x0c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x54, 0x78, 0x52, 0x0c, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x32, 0xc3, 0x05, 0x0a, 0x09, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x62, 0x6f, 0x6f, 0x6b, 0x12, 0x42, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x12, 0x1e, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x47, 0x65, 0x74, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x46, 0x0a, 0x0e, 0x47, 0x65,
+	0x74, 0x58, 0x70, 0x75, 0x62, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1e, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x47, 0x65, 0x74, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x45, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x22, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x47, 0x65, 0x74, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x72, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x54, 0x78, 0x12, 0x48, 0x0a, 0x0e, 0x47, 0x65, 0x74,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x55, 0x74, 0x78, 0x6f, 0x12, 0x22, 0x2e, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x47, 0x65, 0x74, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x55, 0x74, 0x78, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x12, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x55, 0x74,
+	0x78, 0x6f, 0x73, 0x12, 0x3c, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12,
+	0x1c, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x47, 0x65,
+	0x74, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x12, 0x5c, 0x0a, 0x0f, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x53, 0x65, 0x6e, 0x64, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x50, 0x0a, 0x0b, 0x45, 0x73, 0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x46, 0x65, 0x65, 0x12, 0x1f,
+	0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x45, 0x73, 0x74,
+	0x69, 0x6d, 0x61, 0x74, 0x65, 0x46, 0x65, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x20, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x45, 0x73,
+	0x74, 0x69, 0x6d, 0x61, 0x74, 0x65, 0x46, 0x65, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x53, 0x0a, 0x11, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4e, 0x65,
+	0x77, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x25, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4e, 0x65,
+	0x77, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x4e, 0x65, 0x77, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x30, 0x01, 0x12, 0x56, 0x0a, 0x12, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
+	0x69, 0x62, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x26, 0x2e, 0x73,
+	0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x53, 0x75, 0x62, 0x73, 0x63,
+	0x72, 0x69, 0x62, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x73, 0x65, 0x72, 0x76, 0x65, 0x72, 0x2e, 0x50, 0x72,
+	0x6f, 0x74, 0x6f, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x54, 0x78, 0x30, 0x01, 0x42, 0x24,
+	0x5a, 0x22, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x74, 0x72, 0x65,
+	0x7a, 0x6f, 0x72, 0x2f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x62, 0x6f, 0x6f, 0x6b, 0x2f, 0x73, 0x65,
+	0x72, 0x76, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_grpc_proto_rawDescOnce sync.Once
+	file_grpc_proto_rawDescData = file_grpc_proto_rawDesc
+)
+
+func file_grpc_proto_rawDescGZIP() []byte {
+	file_grpc_proto_rawDescOnce.Do(func() {
+		file_grpc_proto_rawDescData = protoimpl.X.CompressGZIP(file_grpc_proto_rawDescData)
+	})
+	return file_grpc_proto_rawDescData
+}
+
+var file_grpc_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_grpc_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_grpc_proto_goTypes = []interface{}{
+	(ProtoGetAddressRequest_DetailsType)(0), // 0: server.ProtoGetAddressRequest.DetailsType
+	(ProtoGetAddressRequest_TokensType)(0),  // 1: server.ProtoGetAddressRequest.TokensType
+	(*ProtoGetAddressRequest)(nil),          // 2: server.ProtoGetAddressRequest
+	(*ProtoGetTransactionRequest)(nil),      // 3: server.ProtoGetTransactionRequest
+	(*ProtoGetAddressUtxoRequest)(nil),      // 4: server.ProtoGetAddressUtxoRequest
+	(*ProtoGetBlockRequest)(nil),            // 5: server.ProtoGetBlockRequest
+	(*ProtoSendTransactionRequest)(nil),     // 6: server.ProtoSendTransactionRequest
+	(*ProtoSendTransactionResponse)(nil),    // 7: server.ProtoSendTransactionResponse
+	(*ProtoEstimateFeeRequest)(nil),         // 8: server.ProtoEstimateFeeRequest
+	(*ProtoEstimateFeeResponse)(nil),        // 9: server.ProtoEstimateFeeResponse
+	(*ProtoSubscribeNewBlockRequest)(nil),   // 10: server.ProtoSubscribeNewBlockRequest
+	(*ProtoSubscribeAddressesRequest)(nil),  // 11: server.ProtoSubscribeAddressesRequest
+	(*ProtoNewBlock)(nil),                   // 12: server.ProtoNewBlock
+	(*ProtoAddressTx)(nil),                  // 13: server.ProtoAddressTx
+	(*ProtoTx)(nil),                         // 14: server.ProtoTx
+	(*ProtoAddress)(nil),                    // 15: server.ProtoAddress
+	(*ProtoUtxos)(nil),                      // 16: server.ProtoUtxos
+	(*ProtoBlock)(nil),                      // 17: server.ProtoBlock
+	(*ProtoTx_VinType)(nil),                 // 18: server.ProtoTx.VinType
+	(*ProtoTx_VoutType)(nil),                // 19: server.ProtoTx.VoutType
+	(*ProtoTx_TokenTransferType)(nil),       // 20: server.ProtoTx.TokenTransferType
+	(*ProtoTx_EthereumSpecificType)(nil),    // 21: server.ProtoTx.EthereumSpecificType
+	(*ProtoAddress_TokenType)(nil),          // 22: server.ProtoAddress.TokenType
+	(*ProtoUtxos_UtxoType)(nil),             // 23: server.ProtoUtxos.UtxoType
+}
+var file_grpc_proto_depIdxs = []int32{
+	0,  // 0: server.ProtoGetAddressRequest.Details:type_name -> server.ProtoGetAddressRequest.DetailsType
+	1,  // 1: server.ProtoGetAddressRequest.Tokens:type_name -> server.ProtoGetAddressRequest.TokensType
+	14, // 2: server.ProtoAddressTx.Tx:type_name -> server.ProtoTx
+	18, // 3: server.ProtoTx.Vin:type_name -> server.ProtoTx.VinType
+	19, // 4: server.ProtoTx.Vout:type_name -> server.ProtoTx.VoutType
+	20, // 5: server.ProtoTx.TokenTransfers:type_name -> server.ProtoTx.TokenTransferType
+	21, // 6: server.ProtoTx.EthereumSpecific:type_name -> server.ProtoTx.EthereumSpecificType
+	14, // 7: server.ProtoAddress.Transactions:type_name -> server.ProtoTx
+	22, // 8: server.ProtoAddress.Tokens:type_name -> server.ProtoAddress.TokenType
+	23, // 9: server.ProtoUtxos.Utxos:type_name -> server.ProtoUtxos.UtxoType
+	14, // 10: server.ProtoBlock.Transactions:type_name -> server.ProtoTx
+	2,  // 11: server.Blockbook.GetAddress:input_type -> server.ProtoGetAddressRequest
+	2,  // 12: server.Blockbook.GetXpubAddress:input_type -> server.ProtoGetAddressRequest
+	3,  // 13: server.Blockbook.GetTransaction:input_type -> server.ProtoGetTransactionRequest
+	4,  // 14: server.Blockbook.GetAddressUtxo:input_type -> server.ProtoGetAddressUtxoRequest
+	5,  // 15: server.Blockbook.GetBlock:input_type -> server.ProtoGetBlockRequest
+	6,  // 16: server.Blockbook.SendTransaction:input_type -> server.ProtoSendTransactionRequest
+	8,  // 17: server.Blockbook.EstimateFee:input_type -> server.ProtoEstimateFeeRequest
+	10, // 18: server.Blockbook.SubscribeNewBlock:input_type -> server.ProtoSubscribeNewBlockRequest
+	11, // 19: server.Blockbook.SubscribeAddresses:input_type -> server.ProtoSubscribeAddressesRequest
+	15, // 20: server.Blockbook.GetAddress:output_type -> server.ProtoAddress
+	15, // 21: server.Blockbook.GetXpubAddress:output_type -> server.ProtoAddress
+	14, // 22: server.Blockbook.GetTransaction:output_type -> server.ProtoTx
+	16, // 23: server.Blockbook.GetAddressUtxo:output_type -> server.ProtoUtxos
+	17, // 24: server.Blockbook.GetBlock:output_type -> server.ProtoBlock
+	7,  // 25: server.Blockbook.SendTransaction:output_type -> server.ProtoSendTransactionResponse
+	9,  // 26: server.Blockbook.EstimateFee:output_type -> server.ProtoEstimateFeeResponse
+	12, // 27: server.Blockbook.SubscribeNewBlock:output_type -> server.ProtoNewBlock
+	13, // 28: server.Blockbook.SubscribeAddresses:output_type -> server.ProtoAddressTx
+	20, // [20:29] is the sub-list for method output_type
+	11, // [11:20] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_grpc_proto_init() }
+func file_grpc_proto_init() {
+	if File_grpc_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_grpc_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoGetAddressRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoGetTransactionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoGetAddressUtxoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoGetBlockRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoSendTransactionRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoSendTransactionResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoEstimateFeeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoEstimateFeeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoSubscribeNewBlockRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoSubscribeAddressesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoNewBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoAddressTx); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoTx); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoAddress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoUtxos); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoTx_VinType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoTx_VoutType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoTx_TokenTransferType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoTx_EthereumSpecificType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoAddress_TokenType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_grpc_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProtoUtxos_UtxoType); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_grpc_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   22,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_grpc_proto_goTypes,
+		DependencyIndexes: file_grpc_proto_depIdxs,
+		EnumInfos:         file_grpc_proto_enumTypes,
+		MessageInfos:      file_grpc_proto_msgTypes,
+	}.Build()
+	File_grpc_proto = out.File
+	file_grpc_proto_rawDesc = nil
+	file_grpc_proto_goTypes = nil
+	file_grpc_proto_depIdxs = nil
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// BlockbookClient is the client API for Blockbook service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type BlockbookClient interface {
+	GetAddress(ctx context.Context, in *ProtoGetAddressRequest, opts ...grpc.CallOption) (*ProtoAddress, error)
+	GetXpubAddress(ctx context.Context, in *ProtoGetAddressRequest, opts ...grpc.CallOption) (*ProtoAddress, error)
+	GetTransaction(ctx context.Context, in *ProtoGetTransactionRequest, opts ...grpc.CallOption) (*ProtoTx, error)
+	GetAddressUtxo(ctx context.Context, in *ProtoGetAddressUtxoRequest, opts ...grpc.CallOption) (*ProtoUtxos, error)
+	GetBlock(ctx context.Context, in *ProtoGetBlockRequest, opts ...grpc.CallOption) (*ProtoBlock, error)
+	SendTransaction(ctx context.Context, in *ProtoSendTransactionRequest, opts ...grpc.CallOption) (*ProtoSendTransactionResponse, error)
+	EstimateFee(ctx context.Context, in *ProtoEstimateFeeRequest, opts ...grpc.CallOption) (*ProtoEstimateFeeResponse, error)
+	SubscribeNewBlock(ctx context.Context, in *ProtoSubscribeNewBlockRequest, opts ...grpc.CallOption) (Blockbook_SubscribeNewBlockClient, error)
+	SubscribeAddresses(ctx context.Context, in *ProtoSubscribeAddressesRequest, opts ...grpc.CallOption) (Blockbook_SubscribeAddressesClient, error)
+}
+
+type blockbookClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBlockbookClient(cc grpc.ClientConnInterface) BlockbookClient {
+	return &blockbookClient{cc}
+}
+
+func (c *blockbookClient) GetAddress(ctx context.Context, in *ProtoGetAddressRequest, opts ...grpc.CallOption) (*ProtoAddress, error) {
+	out := new(ProtoAddress)
+	err := c.cc.Invoke(ctx, "/server.Blockbook/GetAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockbookClient) GetXpubAddress(ctx context.Context, in *ProtoGetAddressRequest, opts ...grpc.CallOption) (*ProtoAddress, error) {
+	out := new(ProtoAddress)
+	err := c.cc.Invoke(ctx, "/server.Blockbook/GetXpubAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockbookClient) GetTransaction(ctx context.Context, in *ProtoGetTransactionRequest, opts ...grpc.CallOption) (*ProtoTx, error) {
+	out := new(ProtoTx)
+	err := c.cc.Invoke(ctx, "/server.Blockbook/GetTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockbookClient) GetAddressUtxo(ctx context.Context, in *ProtoGetAddressUtxoRequest, opts ...grpc.CallOption) (*ProtoUtxos, error) {
+	out := new(ProtoUtxos)
+	err := c.cc.Invoke(ctx, "/server.Blockbook/GetAddressUtxo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockbookClient) GetBlock(ctx context.Context, in *ProtoGetBlockRequest, opts ...grpc.CallOption) (*ProtoBlock, error) {
+	out := new(ProtoBlock)
+	err := c.cc.Invoke(ctx, "/server.Blockbook/GetBlock", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockbookClient) SendTransaction(ctx context.Context, in *ProtoSendTransactionRequest, opts ...grpc.CallOption) (*ProtoSendTransactionResponse, error) {
+	out := new(ProtoSendTransactionResponse)
+	err := c.cc.Invoke(ctx, "/server.Blockbook/SendTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockbookClient) EstimateFee(ctx context.Context, in *ProtoEstimateFeeRequest, opts ...grpc.CallOption) (*ProtoEstimateFeeResponse, error) {
+	out := new(ProtoEstimateFeeResponse)
+	err := c.cc.Invoke(ctx, "/server.Blockbook/EstimateFee", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *blockbookClient) SubscribeNewBlock(ctx context.Context, in *ProtoSubscribeNewBlockRequest, opts ...grpc.CallOption) (Blockbook_SubscribeNewBlockClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Blockbook_serviceDesc.Streams[0], "/server.Blockbook/SubscribeNewBlock", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blockbookSubscribeNewBlockClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Blockbook_SubscribeNewBlockClient interface {
+	Recv() (*ProtoNewBlock, error)
+	grpc.ClientStream
+}
+
+type blockbookSubscribeNewBlockClient struct {
+	grpc.ClientStream
+}
+
+func (x *blockbookSubscribeNewBlockClient) Recv() (*ProtoNewBlock, error) {
+	m := new(ProtoNewBlock)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *blockbookClient) SubscribeAddresses(ctx context.Context, in *ProtoSubscribeAddressesRequest, opts ...grpc.CallOption) (Blockbook_SubscribeAddressesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Blockbook_serviceDesc.Streams[1], "/server.Blockbook/SubscribeAddresses", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &blockbookSubscribeAddressesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Blockbook_SubscribeAddressesClient interface {
+	Recv() (*ProtoAddressTx, error)
+	grpc.ClientStream
+}
+
+type blockbookSubscribeAddressesClient struct {
+	grpc.ClientStream
+}
+
+func (x *blockbookSubscribeAddressesClient) Recv() (*ProtoAddressTx, error) {
+	m := new(ProtoAddressTx)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// BlockbookServer is the server API for Blockbook service.
+type BlockbookServer interface {
+	GetAddress(context.Context, *ProtoGetAddressRequest) (*ProtoAddress, error)
+	GetXpubAddress(context.Context, *ProtoGetAddressRequest) (*ProtoAddress, error)
+	GetTransaction(context.Context, *ProtoGetTransactionRequest) (*ProtoTx, error)
+	GetAddressUtxo(context.Context, *ProtoGetAddressUtxoRequest) (*ProtoUtxos, error)
+	GetBlock(context.Context, *ProtoGetBlockRequest) (*ProtoBlock, error)
+	SendTransaction(context.Context, *ProtoSendTransactionRequest) (*ProtoSendTransactionResponse, error)
+	EstimateFee(context.Context, *ProtoEstimateFeeRequest) (*ProtoEstimateFeeResponse, error)
+	SubscribeNewBlock(*ProtoSubscribeNewBlockRequest, Blockbook_SubscribeNewBlockServer) error
+	SubscribeAddresses(*ProtoSubscribeAddressesRequest, Blockbook_SubscribeAddressesServer) error
+}
+
+// UnimplementedBlockbookServer can be embedded to have forward compatible implementations.
+type UnimplementedBlockbookServer struct {
+}
+
+func (*UnimplementedBlockbookServer) GetAddress(context.Context, *ProtoGetAddressRequest) (*ProtoAddress, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAddress not implemented")
+}
+func (*UnimplementedBlockbookServer) GetXpubAddress(context.Context, *ProtoGetAddressRequest) (*ProtoAddress, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetXpubAddress not implemented")
+}
+func (*UnimplementedBlockbookServer) GetTransaction(context.Context, *ProtoGetTransactionRequest) (*ProtoTx, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransaction not implemented")
+}
+func (*UnimplementedBlockbookServer) GetAddressUtxo(context.Context, *ProtoGetAddressUtxoRequest) (*ProtoUtxos, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAddressUtxo not implemented")
+}
+func (*UnimplementedBlockbookServer) GetBlock(context.Context, *ProtoGetBlockRequest) (*ProtoBlock, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlock not implemented")
+}
+func (*UnimplementedBlockbookServer) SendTransaction(context.Context, *ProtoSendTransactionRequest) (*ProtoSendTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendTransaction not implemented")
+}
+func (*UnimplementedBlockbookServer) EstimateFee(context.Context, *ProtoEstimateFeeRequest) (*ProtoEstimateFeeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateFee not implemented")
+}
+func (*UnimplementedBlockbookServer) SubscribeNewBlock(*ProtoSubscribeNewBlockRequest, Blockbook_SubscribeNewBlockServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeNewBlock not implemented")
+}
+func (*UnimplementedBlockbookServer) SubscribeAddresses(*ProtoSubscribeAddressesRequest, Blockbook_SubscribeAddressesServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeAddresses not implemented")
+}
+
+func RegisterBlockbookServer(s *grpc.Server, srv BlockbookServer) {
+	s.RegisterService(&_Blockbook_serviceDesc, srv)
+}
+
+func _Blockbook_GetAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProtoGetAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockbookServer).GetAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/server.Blockbook/GetAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockbookServer).GetAddress(ctx, req.(*ProtoGetAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blockbook_GetXpubAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProtoGetAddressRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockbookServer).GetXpubAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/server.Blockbook/GetXpubAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockbookServer).GetXpubAddress(ctx, req.(*ProtoGetAddressRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blockbook_GetTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProtoGetTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockbookServer).GetTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/server.Blockbook/GetTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockbookServer).GetTransaction(ctx, req.(*ProtoGetTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blockbook_GetAddressUtxo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProtoGetAddressUtxoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockbookServer).GetAddressUtxo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/server.Blockbook/GetAddressUtxo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockbookServer).GetAddressUtxo(ctx, req.(*ProtoGetAddressUtxoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blockbook_GetBlock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProtoGetBlockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockbookServer).GetBlock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/server.Blockbook/GetBlock",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockbookServer).GetBlock(ctx, req.(*ProtoGetBlockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blockbook_SendTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProtoSendTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockbookServer).SendTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/server.Blockbook/SendTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockbookServer).SendTransaction(ctx, req.(*ProtoSendTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blockbook_EstimateFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProtoEstimateFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BlockbookServer).EstimateFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/server.Blockbook/EstimateFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BlockbookServer).EstimateFee(ctx, req.(*ProtoEstimateFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Blockbook_SubscribeNewBlock_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ProtoSubscribeNewBlockRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockbookServer).SubscribeNewBlock(m, &blockbookSubscribeNewBlockServer{stream})
+}
+
+type Blockbook_SubscribeNewBlockServer interface {
+	Send(*ProtoNewBlock) error
+	grpc.ServerStream
+}
+
+type blockbookSubscribeNewBlockServer struct {
+	grpc.ServerStream
+}
+
+func (x *blockbookSubscribeNewBlockServer) Send(m *ProtoNewBlock) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Blockbook_SubscribeAddresses_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ProtoSubscribeAddressesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BlockbookServer).SubscribeAddresses(m, &blockbookSubscribeAddressesServer{stream})
+}
+
+type Blockbook_SubscribeAddressesServer interface {
+	Send(*ProtoAddressTx) error
+	grpc.ServerStream
+}
+
+type blockbookSubscribeAddressesServer struct {
+	grpc.ServerStream
+}
+
+func (x *blockbookSubscribeAddressesServer) Send(m *ProtoAddressTx) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _Blockbook_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "server.Blockbook",
+	HandlerType: (*BlockbookServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAddress",
+			Handler:    _Blockbook_GetAddress_Handler,
+		},
+		{
+			MethodName: "GetXpubAddress",
+			Handler:    _Blockbook_GetXpubAddress_Handler,
+		},
+		{
+			MethodName: "GetTransaction",
+			Handler:    _Blockbook_GetTransaction_Handler,
+		},
+		{
+			MethodName: "GetAddressUtxo",
+			Handler:    _Blockbook_GetAddressUtxo_Handler,
+		},
+		{
+			MethodName: "GetBlock",
+			Handler:    _Blockbook_GetBlock_Handler,
+		},
+		{
+			MethodName: "SendTransaction",
+			Handler:    _Blockbook_SendTransaction_Handler,
+		},
+		{
+			MethodName: "EstimateFee",
+			Handler:    _Blockbook_EstimateFee_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeNewBlock",
+			Handler:       _Blockbook_SubscribeNewBlock_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SubscribeAddresses",
+			Handler:       _Blockbook_SubscribeAddresses_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "grpc.proto",
+}
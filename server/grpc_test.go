@@ -0,0 +1,107 @@
+// +build unittest
+
+package server
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/trezor/blockbook/api"
+)
+
+func Test_txToProto(t *testing.T) {
+	tests := []struct {
+		name string
+		tx   api.Tx
+		want *ProtoTx
+	}{
+		{
+			name: "bitcoin type",
+			tx: api.Tx{
+				Txid:          "0cc5d6f4a6eb4ed87d6a3b2a7c25dc5e3d3b4e35e8e68a1b3c2d4f5a6b7c8d9e",
+				Version:       2,
+				Locktime:      700000,
+				Blockhash:     "00000000000000000001",
+				Blockheight:   700001,
+				Confirmations: 10,
+				Blocktime:     1639000000,
+				Size:          225,
+				ValueOutSat:   (*api.Amount)(big.NewInt(90000)),
+				ValueInSat:    (*api.Amount)(big.NewInt(100000)),
+				FeesSat:       (*api.Amount)(big.NewInt(10000)),
+				Rbf:           true,
+				Vin: []api.Vin{
+					{
+						Txid:      "a1",
+						Vout:      1,
+						Sequence:  4294967293,
+						N:         0,
+						Addresses: []string{"bc1qaddress1"},
+						IsAddress: true,
+						ValueSat:  (*api.Amount)(big.NewInt(100000)),
+					},
+				},
+				Vout: []api.Vout{
+					{
+						ValueSat:  (*api.Amount)(big.NewInt(90000)),
+						N:         0,
+						Hex:       "0014abcd",
+						Addresses: []string{"bc1qaddress2"},
+						IsAddress: true,
+					},
+					{
+						N:         1,
+						Hex:       "6a0461626364",
+						Addresses: []string{"OP_RETURN 61626364"},
+					},
+				},
+			},
+			want: &ProtoTx{
+				Txid:          "0cc5d6f4a6eb4ed87d6a3b2a7c25dc5e3d3b4e35e8e68a1b3c2d4f5a6b7c8d9e",
+				Version:       2,
+				LockTime:      700000,
+				BlockHash:     "00000000000000000001",
+				BlockHeight:   700001,
+				Confirmations: 10,
+				BlockTime:     1639000000,
+				Size:          225,
+				Value:         "90000",
+				ValueIn:       "100000",
+				Fees:          "10000",
+				Rbf:           true,
+				Vin: []*ProtoTx_VinType{
+					{
+						Txid:      "a1",
+						Vout:      1,
+						Sequence:  4294967293,
+						Addresses: []string{"bc1qaddress1"},
+						IsAddress: true,
+						Value:     "100000",
+					},
+				},
+				Vout: []*ProtoTx_VoutType{
+					{
+						Value:     "90000",
+						Hex:       "0014abcd",
+						Addresses: []string{"bc1qaddress2"},
+						IsAddress: true,
+					},
+					{
+						N:         1,
+						Hex:       "6a0461626364",
+						Addresses: []string{"OP_RETURN 61626364"},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := txToProto(&tt.tx)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("txToProto() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// cacheControl is the Cache-Control header of the cached API responses
+// the responses contain the number of confirmations, spent outputs etc., which change even for old data,
+// they are cached only for a short time and revalidated using the ETag
+const cacheControl = "public, max-age=10"
+
+// cachedResponse is returned by the API handlers for the data which can be cached by the clients and proxies
+// confirmations and blockTime of the returned object determine the Last-Modified header
+type cachedResponse struct {
+	data          interface{}
+	confirmations int
+	blockTime     int64
+}
+
+func newCachedResponse(data interface{}, confirmations int, blockTime int64) *cachedResponse {
+	return &cachedResponse{
+		data:          data,
+		confirmations: confirmations,
+		blockTime:     blockTime,
+	}
+}
+
+// confirmationsRegex matches the number of confirmations in the JSON encoded responses
+var confirmationsRegex = regexp.MustCompile(`"confirmations":[0-9]+`)
+
+// computeETag returns strong entity tag of the response body
+func computeETag(body []byte) string {
+	h := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(h[:16]) + `"`
+}
+
+// computeStableETag returns weak entity tag of the JSON encoded response body, which ignores the number of confirmations
+// the confirmations change with every block, if they were included, the ETag would change with every block
+// any other change of the data (for example spent outputs, reorg of the block, confirmation of a mempool transaction) changes the ETag
+func computeStableETag(body []byte) string {
+	return "W/" + computeETag(confirmationsRegex.ReplaceAll(body, []byte(`"confirmations":0`)))
+}
+
+// etagMatches checks if the If-None-Match header value matches the etag, weak comparison is used as required by RFC 7232
+func etagMatches(ifNoneMatch string, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, t := range strings.Split(ifNoneMatch, ",") {
+		t = strings.TrimSpace(t)
+		if t == "*" || strings.TrimPrefix(t, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// writeWithETag sets the ETag header and writes the body or, if the client already has the same content, status 304 Not Modified
+func writeWithETag(w http.ResponseWriter, r *http.Request, body []byte, etag string) {
+	w.Header().Set("ETag", etag)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Write(body)
+}
+
+// writeCachedJSON writes the JSON encoded data with the caching headers
+func writeCachedJSON(w http.ResponseWriter, r *http.Request, c *cachedResponse) error {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(c.data); err != nil {
+		return err
+	}
+	w.Header().Set("Cache-Control", cacheControl)
+	if c.confirmations > 0 && c.blockTime > 0 {
+		w.Header().Set("Last-Modified", time.Unix(c.blockTime, 0).UTC().Format(http.TimeFormat))
+	}
+	writeWithETag(w, r, buf.Bytes(), computeStableETag(buf.Bytes()))
+	return nil
+}
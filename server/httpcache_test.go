@@ -0,0 +1,125 @@
+// +build unittest
+
+package server
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_etagMatches(t *testing.T) {
+	etag := `"0123456789abcdef"`
+	tests := []struct {
+		ifNoneMatch string
+		want        bool
+	}{
+		{"", false},
+		{`"0123456789abcdef"`, true},
+		{`W/"0123456789abcdef"`, true},
+		{`"other", "0123456789abcdef"`, true},
+		{`"other"`, false},
+		{`*`, true},
+	}
+	for _, tt := range tests {
+		if got := etagMatches(tt.ifNoneMatch, etag); got != tt.want {
+			t.Errorf("etagMatches(%v) = %v, want %v", tt.ifNoneMatch, got, tt.want)
+		}
+	}
+}
+
+func Test_computeStableETag(t *testing.T) {
+	etag := computeStableETag([]byte(`{"txid":"a","confirmations":1,"vout":[{"spent":false}]}`))
+	if !strings.HasPrefix(etag, `W/"`) {
+		t.Errorf("computeStableETag() = %v, want weak ETag", etag)
+	}
+	// the number of confirmations does not change the ETag
+	if got := computeStableETag([]byte(`{"txid":"a","confirmations":12345,"vout":[{"spent":false}]}`)); got != etag {
+		t.Errorf("computeStableETag() with other confirmations = %v, want %v", got, etag)
+	}
+	// other changes do
+	if got := computeStableETag([]byte(`{"txid":"a","confirmations":1,"vout":[{"spent":true}]}`)); got == etag {
+		t.Errorf("computeStableETag() with spent output = %v, want other than %v", got, etag)
+	}
+	if !etagMatches(`W/"0123"`, `W/"0123"`) || !etagMatches(`"0123"`, `W/"0123"`) {
+		t.Error("etagMatches() does not match weak ETag")
+	}
+}
+
+func httpCacheTestsBitcoinType(t *testing.T, ts *httptest.Server) {
+	tests := []struct {
+		name         string
+		url          string
+		cacheControl string
+		lastModified string
+	}{
+		{
+			name:         "apiTx v2",
+			url:          ts.URL + "/api/v2/tx/05e2e48aeabdd9b75def7b48d756ba304713c2aba7b522bf9dbc893fc4231b07",
+			cacheControl: "public, max-age=10",
+			lastModified: "Wed, 21 Mar 2018 01:27:58 GMT",
+		},
+		{
+			name:         "apiTx v2 spending",
+			url:          ts.URL + "/api/v2/tx/05e2e48aeabdd9b75def7b48d756ba304713c2aba7b522bf9dbc893fc4231b07?spending=true",
+			cacheControl: "public, max-age=10",
+		},
+		{
+			name:         "apiTxSpecific",
+			url:          ts.URL + "/api/tx-specific/00b2c06055e5e90e9c82bd4181fde310104391a7fa4f289b1704e5d90caa3840",
+			cacheControl: "public, max-age=10",
+			lastModified: "Tue, 20 Mar 2018 03:03:46 GMT",
+		},
+		{
+			name:         "apiBlock v2",
+			url:          ts.URL + "/api/v2/block/225493",
+			cacheControl: "public, max-age=10",
+			lastModified: "Tue, 20 Mar 2018 03:03:46 GMT",
+		},
+		{
+			name: "explorerTx",
+			url:  ts.URL + "/tx/fdd824a780cbb718eeb766eb05d83fdefc793a27082cd5e67f856d69798cf7db",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := http.DefaultClient.Do(newGetRequest(tt.url))
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("StatusCode = %v, want %v", resp.StatusCode, http.StatusOK)
+			}
+			if got := resp.Header.Get("Cache-Control"); got != tt.cacheControl {
+				t.Errorf("Cache-Control = %v, want %v", got, tt.cacheControl)
+			}
+			if got := resp.Header.Get("Last-Modified"); got != tt.lastModified {
+				t.Errorf("Last-Modified = %v, want %v", got, tt.lastModified)
+			}
+			etag := resp.Header.Get("ETag")
+			if etag == "" {
+				t.Fatal("missing ETag")
+			}
+			r := newGetRequest(tt.url)
+			r.Header.Set("If-None-Match", etag)
+			resp, err = http.DefaultClient.Do(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != http.StatusNotModified {
+				t.Errorf("StatusCode with If-None-Match = %v, want %v", resp.StatusCode, http.StatusNotModified)
+			}
+			b, err := ioutil.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(b) != 0 {
+				t.Errorf("body with If-None-Match = %v, want empty", string(b))
+			}
+		})
+	}
+}
@@ -25,10 +25,12 @@ type InternalServer struct {
 	mempool     bchain.Mempool
 	is          *common.InternalState
 	api         *api.Worker
+	webhooks    *Webhooks
 }
 
 // NewInternalServer creates new internal http interface to blockbook and returns its handle
-func NewInternalServer(binding, certFiles string, db *db.RocksDB, chain bchain.BlockChain, mempool bchain.Mempool, txCache *db.TxCache, metrics *common.Metrics, is *common.InternalState) (*InternalServer, error) {
+// if webhooks is not nil, the interface provides the management of the webhook watchlist
+func NewInternalServer(binding, certFiles string, db *db.RocksDB, chain bchain.BlockChain, mempool bchain.Mempool, txCache *db.TxCache, metrics *common.Metrics, is *common.InternalState, webhooks *Webhooks) (*InternalServer, error) {
 	api, err := api.NewWorker(db, chain, mempool, txCache, metrics, is)
 	if err != nil {
 		return nil, err
@@ -50,11 +52,20 @@ func NewInternalServer(binding, certFiles string, db *db.RocksDB, chain bchain.B
 		mempool:     mempool,
 		is:          is,
 		api:         api,
+		webhooks:    webhooks,
 	}
 
 	serveMux.Handle(path+"favicon.ico", http.FileServer(http.Dir("./static/")))
 	serveMux.HandleFunc(path+"metrics", promhttp.Handler().ServeHTTP)
 	serveMux.HandleFunc(path, s.index)
+	if webhooks != nil {
+		serveMux.HandleFunc(path+"webhooks/watches", s.jsonHandler(s.webhookWatches, http.MethodGet))
+		serveMux.HandleFunc(path+"webhooks/watch", s.jsonHandler(s.webhookWatch, http.MethodPost))
+		serveMux.HandleFunc(path+"webhooks/unwatch", s.jsonHandler(s.webhookUnwatch, http.MethodPost))
+		serveMux.HandleFunc(path+"webhooks/queue", s.jsonHandler(s.webhookQueue, http.MethodGet))
+		serveMux.HandleFunc(path+"webhooks/deadletter", s.jsonHandler(s.webhookDeadLetter, http.MethodGet))
+		serveMux.HandleFunc(path+"webhooks/deadletter/retry", s.jsonHandler(s.webhookRetryDeadLetter, http.MethodPost))
+	}
 
 	return s, nil
 }
@@ -97,3 +108,78 @@ func (s *InternalServer) index(w http.ResponseWriter, r *http.Request) {
 
 	w.Write(buf)
 }
+
+func (s *InternalServer) jsonHandler(handler func(r *http.Request) (interface{}, error), method string) func(w http.ResponseWriter, r *http.Request) {
+	type jsonError struct {
+		Text string `json:"error"`
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		var data interface{}
+		var err error
+		if r.Method != method {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			data = jsonError{"Method not allowed"}
+		} else if data, err = handler(r); err != nil {
+			if apiErr, ok := err.(*api.APIError); ok && apiErr.Public {
+				w.WriteHeader(http.StatusBadRequest)
+				data = jsonError{apiErr.Error()}
+			} else {
+				glog.Error(r.URL.Path, " error: ", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				data = jsonError{err.Error()}
+			}
+		}
+		if err = json.NewEncoder(w).Encode(data); err != nil {
+			glog.Warning("json encode ", err)
+		}
+	}
+}
+
+func (s *InternalServer) webhookWatches(r *http.Request) (interface{}, error) {
+	return s.webhooks.GetWatches(), nil
+}
+
+func (s *InternalServer) webhookWatch(r *http.Request) (interface{}, error) {
+	var watch db.WebhookWatch
+	if err := json.NewDecoder(r.Body).Decode(&watch); err != nil {
+		return nil, api.NewAPIError("Invalid request body: "+err.Error(), true)
+	}
+	if err := s.webhooks.Watch(&watch); err != nil {
+		return nil, err
+	}
+	// the secret is never returned
+	watch.Secret = ""
+	return watch, nil
+}
+
+func (s *InternalServer) webhookUnwatch(r *http.Request) (interface{}, error) {
+	var watch db.WebhookWatch
+	if err := json.NewDecoder(r.Body).Decode(&watch); err != nil {
+		return nil, api.NewAPIError("Invalid request body: "+err.Error(), true)
+	}
+	if err := s.webhooks.Unwatch(watch.Address, watch.URL); err != nil {
+		return nil, err
+	}
+	watch.Secret = ""
+	return watch, nil
+}
+
+func (s *InternalServer) webhookQueue(r *http.Request) (interface{}, error) {
+	return s.webhooks.GetQueue()
+}
+
+func (s *InternalServer) webhookDeadLetter(r *http.Request) (interface{}, error) {
+	return s.webhooks.GetDeadLetters()
+}
+
+func (s *InternalServer) webhookRetryDeadLetter(r *http.Request) (interface{}, error) {
+	type resultRetry struct {
+		Retried int `json:"retried"`
+	}
+	n, err := s.webhooks.RetryDeadLetters()
+	if err != nil {
+		return nil, err
+	}
+	return resultRetry{n}, nil
+}
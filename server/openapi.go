@@ -0,0 +1,549 @@
+package server
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/trezor/blockbook/api"
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/common"
+	"github.com/trezor/blockbook/db"
+)
+
+// apiRoute is a json API handler registered by apiHandleFunc, the list of routes is the source of the OpenAPI specification
+type apiRoute struct {
+	route      string
+	handler    string
+	apiVersion int
+}
+
+type openAPIParam struct {
+	name        string
+	in          string
+	typ         string
+	description string
+}
+
+// openAPIOperation documents a json API handler
+// result and resultV1 are values of the types returned by the handler for the v2 and v1 api version
+// pathSuffix is the part of the route following the path parameter
+// accountBased operations are registered only for EthereumType and TronType coins
+// resources are other operations of the same handler, distinguished by their pathSuffix
+// post operations take a plain text body, unless postJSON is set
+type openAPIOperation struct {
+	summary       string
+	pathParam     *openAPIParam
+	pathParamOpt  bool
+	pathSuffix    string
+	accountBased  bool
+	query         []openAPIParam
+	post          bool
+	postJSON      bool
+	result        interface{}
+	resultV1      interface{}
+	resultIsArray bool
+	resources     []openAPIOperation
+}
+
+type resultOpenAPIError struct {
+	Error string `json:"error"`
+}
+
+var addressQueryParams = []openAPIParam{
+	{"page", "query", "integer", "page of the returned transactions, starting from 1"},
+	{"pageSize", "query", "integer", "number of transactions on page"},
+	{"from", "query", "integer", "filter transactions from block height"},
+	{"to", "query", "integer", "filter transactions to block height"},
+	{"details", "query", "string", "level of details: basic, tokens, tokenBalances, txids, txslight, txs"},
+	{"contract", "query", "string", "filter transactions by contract"},
+}
+
+// openAPIOperations documents the json API handlers by their name
+// every handler registered by apiHandleFunc must be present in this map
+var openAPIOperations = map[string]openAPIOperation{
+	"apiIndex": {
+		summary: "Status of blockbook and backend",
+		result:  api.SystemInfo{},
+	},
+	"apiBlockIndex": {
+		summary:      "Hash of the block at given height or of the best block",
+		pathParam:    &openAPIParam{"height", "path", "integer", "block height"},
+		pathParamOpt: true,
+		result:       resultBlockIndex{},
+	},
+	"apiTx": {
+		summary:   "Transaction",
+		pathParam: &openAPIParam{"txid", "path", "string", "transaction id"},
+		query: []openAPIParam{
+			{"spending", "query", "boolean", "return spending transactions of outputs"},
+		},
+		result:   api.Tx{},
+		resultV1: api.TxV1{},
+	},
+	"apiTxSpecific": {
+		summary:   "Transaction in the format returned by the backend",
+		pathParam: &openAPIParam{"txid", "path", "string", "transaction id"},
+		result:    json.RawMessage{},
+	},
+	"apiAddress": {
+		summary:   "Balances and transactions of an address",
+		pathParam: &openAPIParam{"address", "path", "string", "address"},
+		query:     addressQueryParams,
+		result:    api.Address{},
+		resultV1:  api.AddressV1{},
+		resources: []openAPIOperation{
+			{
+				summary:      "ERC721 and ERC1155 tokens held by an address (Ethereum type coins only)",
+				pathSuffix:   "/nfts",
+				accountBased: true,
+				result:       api.AddressNfts{},
+			},
+			{
+				summary:      "Current non-zero ERC20/TRC20 allowances of an address as the owner or the spender and the history of its approvals",
+				pathSuffix:   "/allowances",
+				accountBased: true,
+				query: []openAPIParam{
+					{"page", "query", "integer", "page of the returned approval history, starting from 1"},
+					{"pageSize", "query", "integer", "number of approvals on page"},
+				},
+				result: api.AddressAllowances{},
+			},
+		},
+	},
+	"apiXpub": {
+		summary:   "Balances and transactions of an xpub or output descriptor",
+		pathParam: &openAPIParam{"xpub", "path", "string", "xpub or output descriptor"},
+		query: append(addressQueryParams,
+			openAPIParam{"tokens", "query", "string", "derived addresses to return: nonzero, used, derived"},
+			openAPIParam{"gap", "query", "integer", "gap limit of the derived addresses"},
+		),
+		result:   api.Address{},
+		resultV1: api.AddressV1{},
+	},
+	"apiUtxo": {
+		summary:   "Unspent outputs of an address or xpub",
+		pathParam: &openAPIParam{"descriptor", "path", "string", "address, xpub or output descriptor"},
+		query: []openAPIParam{
+			{"confirmed", "query", "boolean", "return only confirmed outputs"},
+			{"gap", "query", "integer", "gap limit of the derived addresses"},
+		},
+		result:        api.Utxo{},
+		resultV1:      api.AddressUtxoV1{},
+		resultIsArray: true,
+	},
+	"apiBalanceHistory": {
+		summary:   "Balance history of an address or xpub",
+		pathParam: &openAPIParam{"descriptor", "path", "string", "address, xpub or output descriptor"},
+		query: []openAPIParam{
+			{"from", "query", "integer", "unix timestamp of the start of the history"},
+			{"to", "query", "integer", "unix timestamp of the end of the history"},
+			{"fiatcurrency", "query", "string", "return the fiat rate of the currency for each item"},
+			{"groupBy", "query", "integer", "interval in seconds to group the history by"},
+			{"gap", "query", "integer", "gap limit of the derived addresses"},
+		},
+		result:        api.BalanceHistory{},
+		resultIsArray: true,
+	},
+	"apiBlock": {
+		summary:   "Block with transactions",
+		pathParam: &openAPIParam{"block", "path", "string", "block height or hash"},
+		query: []openAPIParam{
+			{"page", "query", "integer", "page of the returned transactions, starting from 1"},
+		},
+		result:   api.Block{},
+		resultV1: api.BlockV1{},
+	},
+	"apiFeeStats": {
+		summary:   "Fee statistics of a block",
+		pathParam: &openAPIParam{"block", "path", "string", "block height or hash"},
+		result:    api.FeeStats{},
+	},
+	"apiMempoolStats": {
+		summary: "Fee rate histogram and projected blocks of the mempool (Bitcoin type coins only)",
+		query: []openAPIParam{
+			{"blocks", "query", "integer", "number of the returned projected blocks, default 8, maximum 50"},
+		},
+		result: api.MempoolStats{},
+	},
+	"apiSendTx": {
+		summary:      "Send transaction, the hex encoded transaction is passed in the path or as POST body",
+		pathParam:    &openAPIParam{"hex", "path", "string", "hex encoded transaction"},
+		pathParamOpt: true,
+		post:         true,
+		result:       resultSendTransaction{},
+	},
+	"apiEstimateFee": {
+		summary:   "Estimated fee per unit of size to get transaction confirmed in given number of blocks",
+		pathParam: &openAPIParam{"blocks", "path", "integer", "number of blocks"},
+		query: []openAPIParam{
+			{"conservative", "query", "boolean", "use conservative estimate mode"},
+		},
+		result: resultEstimateFeeAsString{},
+	},
+	"apiTickers": {
+		summary: "Fiat rates for given block, timestamp or the latest rates",
+		query: []openAPIParam{
+			{"currency", "query", "string", "return only rate for this currency"},
+			{"block", "query", "string", "block height or hash"},
+			{"timestamp", "query", "integer", "unix timestamp"},
+		},
+		result: db.ResultTickerAsString{},
+	},
+	"apiTickersList": {
+		summary: "List of currencies with fiat rates available at given timestamp",
+		query: []openAPIParam{
+			{"timestamp", "query", "integer", "unix timestamp"},
+		},
+		result: db.ResultTickerListAsString{},
+	},
+	"apiContract": {
+		summary:      "Creation info, token metadata and holder and transfer counts of a contract",
+		pathParam:    &openAPIParam{"contract", "path", "string", "contract address"},
+		accountBased: true,
+		result:       api.Contract{},
+		resources: []openAPIOperation{
+			{
+				summary:      "Token transfers of a contract",
+				pathSuffix:   "/transfers",
+				accountBased: true,
+				query: []openAPIParam{
+					{"page", "query", "integer", "page of the returned transfers, starting from 1"},
+					{"pageSize", "query", "integer", "number of transfers on page"},
+					{"from", "query", "integer", "filter transfers from block height"},
+					{"to", "query", "integer", "filter transfers to block height"},
+				},
+				result: api.ContractTransfers{},
+			},
+			{
+				summary:      "Holders of the ERC20/TRC20 token of a contract sorted by balance",
+				pathSuffix:   "/holders",
+				accountBased: true,
+				query: []openAPIParam{
+					{"page", "query", "integer", "page of the returned holders, starting from 1"},
+					{"pageSize", "query", "integer", "number of holders on page"},
+					{"height", "query", "integer", "return the holders after the block at height instead of the current holders"},
+				},
+				result: api.ContractHolders{},
+			},
+		},
+	},
+	"apiSimulate": {
+		summary:      "Expected token and value transfers, revert reason and gas used of an unsigned transaction executed against the latest block, the parameters can be passed also as a json object in the POST body",
+		accountBased: true,
+		query: []openAPIParam{
+			{"from", "query", "string", "sender of the transaction"},
+			{"to", "query", "string", "recipient or called contract"},
+			{"data", "query", "string", "hex encoded call data"},
+			{"value", "query", "string", "transferred value in the base units, hex encoded with 0x prefix for Ethereum type coins"},
+			{"gas", "query", "string", "gas limit, hex encoded with 0x prefix (Ethereum type coins only)"},
+			{"gasPrice", "query", "string", "gas price, hex encoded with 0x prefix (Ethereum type coins only)"},
+		},
+		post:     true,
+		postJSON: true,
+		result:   api.TransactionSimulation{},
+	},
+	"apiOpenAPI": {
+		summary: "OpenAPI specification of this API",
+		result:  map[string]interface{}{},
+	},
+}
+
+// apiHandleFunc registers json API handler and records its route for the OpenAPI specification
+func (s *PublicServer) apiHandleFunc(serveMux *http.ServeMux, path, route string, handler func(r *http.Request, apiVersion int) (interface{}, error), apiVersion int) {
+	serveMux.HandleFunc(path+route, s.jsonHandler(handler, apiVersion))
+	s.apiRoutes = append(s.apiRoutes, apiRoute{
+		route:      route,
+		handler:    getFunctionName(handler),
+		apiVersion: apiVersion,
+	})
+}
+
+func (s *PublicServer) apiOpenAPI(r *http.Request, apiVersion int) (interface{}, error) {
+	s.metrics.ExplorerViews.With(common.Labels{"action": "api-openapi"}).Inc()
+	return s.getOpenAPISpec()
+}
+
+// getOpenAPISpec generates OpenAPI 3 specification from the registered json API routes
+func (s *PublicServer) getOpenAPISpec() (map[string]interface{}, error) {
+	g := openAPIGenerator{
+		schemas: make(map[string]interface{}),
+		names:   make(map[reflect.Type]string),
+	}
+	paths := make(map[string]interface{})
+	ct := s.chainParser.GetChainType()
+	accountBased := ct == bchain.ChainEthereumType || ct == bchain.ChainTronType
+	for _, r := range s.apiRoutes {
+		op, found := openAPIOperations[r.handler]
+		if !found {
+			return nil, errors.Errorf("OpenAPI: handler %v of route %v is not documented", r.handler, r.route)
+		}
+		result := op.result
+		if r.apiVersion == apiV1 && op.resultV1 != nil {
+			result = op.resultV1
+		}
+		schema := g.schema(reflect.TypeOf(result))
+		if op.resultIsArray {
+			schema = map[string]interface{}{"type": "array", "items": schema}
+		}
+		route := "/" + r.route
+		if op.pathParam == nil {
+			paths[route] = g.pathItem(r, &op, schema, false)
+		} else {
+			paths[route+"{"+op.pathParam.name+"}"+op.pathSuffix] = g.pathItem(r, &op, schema, true)
+			if op.pathParamOpt {
+				paths[route] = g.pathItem(r, &op, schema, false)
+			}
+			// resources are documented only for api v2
+			if r.apiVersion != apiV2 {
+				continue
+			}
+			for i := range op.resources {
+				res := op.resources[i]
+				if res.accountBased && !accountBased {
+					continue
+				}
+				res.pathParam = op.pathParam
+				paths[route+"{"+op.pathParam.name+"}"+res.pathSuffix] = g.pathItem(r, &res, g.schema(reflect.TypeOf(res.result)), true)
+			}
+		}
+	}
+	g.schemas["Error"] = g.schema(reflect.TypeOf(resultOpenAPIError{}))
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "Blockbook " + s.is.Coin + " API",
+			"version": "2",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": g.schemas,
+		},
+	}, nil
+}
+
+type openAPIGenerator struct {
+	schemas map[string]interface{}
+	names   map[reflect.Type]string
+}
+
+func operationID(route string, withParam bool) string {
+	var b strings.Builder
+	for _, f := range strings.FieldsFunc(route, func(c rune) bool { return c == '/' || c == '-' || c == '.' }) {
+		if b.Len() == 0 {
+			b.WriteString(f)
+		} else {
+			b.WriteString(strings.ToUpper(f[:1]) + f[1:])
+		}
+	}
+	if withParam {
+		b.WriteString("WithParam")
+	}
+	return b.String()
+}
+
+func (g *openAPIGenerator) pathItem(r apiRoute, op *openAPIOperation, schema interface{}, withParam bool) map[string]interface{} {
+	params := make([]interface{}, 0, len(op.query)+1)
+	if withParam {
+		params = append(params, map[string]interface{}{
+			"name":        op.pathParam.name,
+			"in":          "path",
+			"required":    true,
+			"description": op.pathParam.description,
+			"schema":      map[string]interface{}{"type": op.pathParam.typ},
+		})
+	}
+	for _, q := range op.query {
+		params = append(params, map[string]interface{}{
+			"name":        q.name,
+			"in":          q.in,
+			"description": q.description,
+			"schema":      map[string]interface{}{"type": q.typ},
+		})
+	}
+	errorResponse := func(description string) map[string]interface{} {
+		return map[string]interface{}{
+			"description": description,
+			"content": map[string]interface{}{
+				"application/json": map[string]interface{}{
+					"schema": map[string]interface{}{"$ref": "#/components/schemas/Error"},
+				},
+			},
+		}
+	}
+	tag := "default"
+	if strings.HasPrefix(r.route, "api/v1/") {
+		tag = "v1"
+	} else if strings.HasPrefix(r.route, "api/v2/") {
+		tag = "v2"
+	}
+	operation := func(id string) map[string]interface{} {
+		return map[string]interface{}{
+			"summary":     op.summary,
+			"operationId": id,
+			"tags":        []string{tag},
+			"parameters":  params,
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{
+					"description": "OK",
+					"content": map[string]interface{}{
+						"application/json": map[string]interface{}{"schema": schema},
+					},
+				},
+				"400": errorResponse("Invalid request"),
+				"500": errorResponse("Internal server error"),
+			},
+		}
+	}
+	id := operationID(r.route+op.pathSuffix, withParam)
+	item := map[string]interface{}{"get": operation(id)}
+	if op.post && !withParam {
+		post := operation(id + "Post")
+		content := map[string]interface{}{
+			"text/plain": map[string]interface{}{"schema": map[string]interface{}{"type": "string"}},
+		}
+		if op.postJSON {
+			content = map[string]interface{}{
+				"application/json": map[string]interface{}{"schema": map[string]interface{}{"type": "object"}},
+			}
+		}
+		post["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content":  content,
+		}
+		item["post"] = post
+	}
+	return item
+}
+
+var (
+	jsonMarshalerType  = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	jsonRawMessageType = reflect.TypeOf(json.RawMessage{})
+	amountType         = reflect.TypeOf(api.Amount{})
+	bigIntType         = reflect.TypeOf(big.Int{})
+	jsonNumberType     = reflect.TypeOf(common.JSONNumber(""))
+	timeType           = reflect.TypeOf(time.Time{})
+)
+
+// schema returns the schema of the type, named struct types are stored in components and referenced
+func (g *openAPIGenerator) schema(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t {
+	case amountType:
+		return map[string]interface{}{"type": "string", "description": "amount in the base units"}
+	case bigIntType:
+		return map[string]interface{}{"type": "integer"}
+	case jsonNumberType:
+		return map[string]interface{}{"type": "number"}
+	case timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case jsonRawMessageType:
+		return map[string]interface{}{}
+	}
+	if t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType) {
+		return map[string]interface{}{}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case reflect.Int64, reflect.Uint, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": g.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		name, found := g.names[t]
+		if !found {
+			name = g.schemaName(t)
+			g.names[t] = name
+			// store placeholder to stop recursion of self referencing types
+			g.schemas[name] = nil
+			g.schemas[name] = g.structSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	}
+	return map[string]interface{}{}
+}
+
+// schemaName returns the type name, types with the same name from different packages are prefixed by the package name
+func (g *openAPIGenerator) schemaName(t reflect.Type) string {
+	name := t.Name()
+	if _, used := g.schemas[name]; used {
+		pkg := t.PkgPath()
+		pkg = pkg[strings.LastIndexByte(pkg, '/')+1:]
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+	return name
+}
+
+func (g *openAPIGenerator) structSchema(t reflect.Type) map[string]interface{} {
+	properties := make(map[string]interface{})
+	var required []string
+	g.addStructFields(t, properties, &required)
+	s := map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		sort.Strings(required)
+		s["required"] = required
+	}
+	return s
+}
+
+// addStructFields adds fields of the struct to the schema properties, following the rules of encoding/json
+func (g *openAPIGenerator) addStructFields(t reflect.Type, properties map[string]interface{}, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name := tag
+		omitempty := false
+		if c := strings.IndexByte(tag, ','); c >= 0 {
+			name = tag[:c]
+			omitempty = strings.Contains(tag[c:], ",omitempty")
+		}
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				g.addStructFields(ft, properties, required)
+				continue
+			}
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		properties[name] = g.schema(f.Type)
+		if !omitempty {
+			*required = append(*required, name)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// +build unittest
+
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const openAPISpecFile = "docs/openapi.json"
+
+var updateOpenAPISpec = flag.Bool("update-openapi", false, "write the generated OpenAPI specification to "+openAPISpecFile)
+
+func openAPITestsBitcoinType(t *testing.T, s *PublicServer, ts *httptest.Server) {
+	handlers := make(map[string]struct{})
+	for _, r := range s.apiRoutes {
+		handlers[r.handler] = struct{}{}
+	}
+	for name, op := range openAPIOperations {
+		if _, found := handlers[name]; !found && !op.accountBased {
+			t.Errorf("openAPIOperations contains %v which is not registered as API handler", name)
+		}
+	}
+
+	resp, err := http.Get(ts.URL + "/api/v2/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("openapi.json StatusCode = %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got bytes.Buffer
+	if err := json.Indent(&got, b, "", "  "); err != nil {
+		t.Fatal(err)
+	}
+	if *updateOpenAPISpec {
+		if err := ioutil.WriteFile(openAPISpecFile, got.Bytes(), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := ioutil.ReadFile(openAPISpecFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got.Bytes(), want) {
+		t.Errorf("generated OpenAPI specification differs from %v, if the change of the API is intended, run the test with -update-openapi flag and commit the file", openAPISpecFile)
+	}
+}
@@ -1,11 +1,13 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"html/template"
 	"io/ioutil"
+	"math"
 	"math/big"
 	"net/http"
 	"path/filepath"
@@ -54,11 +56,15 @@ type PublicServer struct {
 	is               *common.InternalState
 	templates        []*template.Template
 	debug            bool
+	apiRoutes        []apiRoute
+	rateLimiter      *RateLimiter
 }
 
 // NewPublicServer creates new public server http interface to blockbook and returns its handle
 // only basic functionality is mapped, to map all functions, call
-func NewPublicServer(binding string, certFiles string, db *db.RocksDB, chain bchain.BlockChain, mempool bchain.Mempool, txCache *db.TxCache, explorerURL string, metrics *common.Metrics, is *common.InternalState, debugMode bool, enableSubNewTx bool) (*PublicServer, error) {
+// if rateLimiter is not nil, the API requests are subject to API key checks and rate limits
+// if wsSessionTTL is positive, the websocket clients can resume their sessions within wsSessionTTL after disconnect
+func NewPublicServer(binding string, certFiles string, db *db.RocksDB, chain bchain.BlockChain, mempool bchain.Mempool, txCache *db.TxCache, explorerURL string, metrics *common.Metrics, is *common.InternalState, debugMode bool, enableSubNewTx bool, rateLimiter *RateLimiter, wsSessionTTL time.Duration) (*PublicServer, error) {
 
 	api, err := api.NewWorker(db, chain, mempool, txCache, metrics, is)
 	if err != nil {
@@ -70,7 +76,7 @@ func NewPublicServer(binding string, certFiles string, db *db.RocksDB, chain bch
 		return nil, err
 	}
 
-	websocket, err := NewWebsocketServer(db, chain, mempool, txCache, metrics, is, enableSubNewTx)
+	websocket, err := NewWebsocketServer(db, chain, mempool, txCache, metrics, is, enableSubNewTx, rateLimiter, wsSessionTTL)
 	if err != nil {
 		return nil, err
 	}
@@ -99,6 +105,7 @@ func NewPublicServer(binding string, certFiles string, db *db.RocksDB, chain bch
 		metrics:          metrics,
 		is:               is,
 		debug:            debugMode,
+		rateLimiter:      rateLimiter,
 	}
 	s.templates = s.parseTemplates()
 
@@ -108,7 +115,7 @@ func NewPublicServer(binding string, certFiles string, db *db.RocksDB, chain bch
 	// default handler
 	serveMux.HandleFunc(path, s.htmlTemplateHandler(s.explorerIndex))
 	// default API handler
-	serveMux.HandleFunc(path+"api/", s.jsonHandler(s.apiIndex, apiV2))
+	s.apiHandleFunc(serveMux, path, "api/", s.apiIndex, apiV2)
 
 	return s, nil
 }
@@ -141,6 +148,9 @@ func (s *PublicServer) ConnectFullPublicInterface() {
 		serveMux.HandleFunc(path+"spending/", s.htmlTemplateHandler(s.explorerSpendingTx))
 		serveMux.HandleFunc(path+"sendtx", s.htmlTemplateHandler(s.explorerSendTx))
 		serveMux.HandleFunc(path+"mempool", s.htmlTemplateHandler(s.explorerMempool))
+		if ct := s.chainParser.GetChainType(); ct == bchain.ChainEthereumType || ct == bchain.ChainTronType {
+			serveMux.HandleFunc(path+"contract/", s.htmlTemplateHandler(s.explorerContract))
+		}
 	} else {
 		// redirect to wallet requests for tx and address, possibly to external site
 		serveMux.HandleFunc(path+"tx/", s.txRedirect)
@@ -157,39 +167,45 @@ func (s *PublicServer) ConnectFullPublicInterface() {
 	} else {
 		apiDefault = apiV1
 		// legacy v1 format
-		serveMux.HandleFunc(path+"api/v1/block-index/", s.jsonHandler(s.apiBlockIndex, apiV1))
-		serveMux.HandleFunc(path+"api/v1/tx-specific/", s.jsonHandler(s.apiTxSpecific, apiV1))
-		serveMux.HandleFunc(path+"api/v1/tx/", s.jsonHandler(s.apiTx, apiV1))
-		serveMux.HandleFunc(path+"api/v1/address/", s.jsonHandler(s.apiAddress, apiV1))
-		serveMux.HandleFunc(path+"api/v1/utxo/", s.jsonHandler(s.apiUtxo, apiV1))
-		serveMux.HandleFunc(path+"api/v1/block/", s.jsonHandler(s.apiBlock, apiV1))
-		serveMux.HandleFunc(path+"api/v1/sendtx/", s.jsonHandler(s.apiSendTx, apiV1))
-		serveMux.HandleFunc(path+"api/v1/estimatefee/", s.jsonHandler(s.apiEstimateFee, apiV1))
-	}
-	serveMux.HandleFunc(path+"api/block-index/", s.jsonHandler(s.apiBlockIndex, apiDefault))
-	serveMux.HandleFunc(path+"api/tx-specific/", s.jsonHandler(s.apiTxSpecific, apiDefault))
-	serveMux.HandleFunc(path+"api/tx/", s.jsonHandler(s.apiTx, apiDefault))
-	serveMux.HandleFunc(path+"api/address/", s.jsonHandler(s.apiAddress, apiDefault))
-	serveMux.HandleFunc(path+"api/xpub/", s.jsonHandler(s.apiXpub, apiDefault))
-	serveMux.HandleFunc(path+"api/utxo/", s.jsonHandler(s.apiUtxo, apiDefault))
-	serveMux.HandleFunc(path+"api/block/", s.jsonHandler(s.apiBlock, apiDefault))
-	serveMux.HandleFunc(path+"api/sendtx/", s.jsonHandler(s.apiSendTx, apiDefault))
-	serveMux.HandleFunc(path+"api/estimatefee/", s.jsonHandler(s.apiEstimateFee, apiDefault))
-	serveMux.HandleFunc(path+"api/balancehistory/", s.jsonHandler(s.apiBalanceHistory, apiDefault))
+		s.apiHandleFunc(serveMux, path, "api/v1/block-index/", s.apiBlockIndex, apiV1)
+		s.apiHandleFunc(serveMux, path, "api/v1/tx-specific/", s.apiTxSpecific, apiV1)
+		s.apiHandleFunc(serveMux, path, "api/v1/tx/", s.apiTx, apiV1)
+		s.apiHandleFunc(serveMux, path, "api/v1/address/", s.apiAddress, apiV1)
+		s.apiHandleFunc(serveMux, path, "api/v1/utxo/", s.apiUtxo, apiV1)
+		s.apiHandleFunc(serveMux, path, "api/v1/block/", s.apiBlock, apiV1)
+		s.apiHandleFunc(serveMux, path, "api/v1/sendtx/", s.apiSendTx, apiV1)
+		s.apiHandleFunc(serveMux, path, "api/v1/estimatefee/", s.apiEstimateFee, apiV1)
+	}
+	s.apiHandleFunc(serveMux, path, "api/block-index/", s.apiBlockIndex, apiDefault)
+	s.apiHandleFunc(serveMux, path, "api/tx-specific/", s.apiTxSpecific, apiDefault)
+	s.apiHandleFunc(serveMux, path, "api/tx/", s.apiTx, apiDefault)
+	s.apiHandleFunc(serveMux, path, "api/address/", s.apiAddress, apiDefault)
+	s.apiHandleFunc(serveMux, path, "api/xpub/", s.apiXpub, apiDefault)
+	s.apiHandleFunc(serveMux, path, "api/utxo/", s.apiUtxo, apiDefault)
+	s.apiHandleFunc(serveMux, path, "api/block/", s.apiBlock, apiDefault)
+	s.apiHandleFunc(serveMux, path, "api/sendtx/", s.apiSendTx, apiDefault)
+	s.apiHandleFunc(serveMux, path, "api/estimatefee/", s.apiEstimateFee, apiDefault)
+	s.apiHandleFunc(serveMux, path, "api/balancehistory/", s.apiBalanceHistory, apiDefault)
 	// v2 format
-	serveMux.HandleFunc(path+"api/v2/block-index/", s.jsonHandler(s.apiBlockIndex, apiV2))
-	serveMux.HandleFunc(path+"api/v2/tx-specific/", s.jsonHandler(s.apiTxSpecific, apiV2))
-	serveMux.HandleFunc(path+"api/v2/tx/", s.jsonHandler(s.apiTx, apiV2))
-	serveMux.HandleFunc(path+"api/v2/address/", s.jsonHandler(s.apiAddress, apiV2))
-	serveMux.HandleFunc(path+"api/v2/xpub/", s.jsonHandler(s.apiXpub, apiV2))
-	serveMux.HandleFunc(path+"api/v2/utxo/", s.jsonHandler(s.apiUtxo, apiV2))
-	serveMux.HandleFunc(path+"api/v2/block/", s.jsonHandler(s.apiBlock, apiV2))
-	serveMux.HandleFunc(path+"api/v2/sendtx/", s.jsonHandler(s.apiSendTx, apiV2))
-	serveMux.HandleFunc(path+"api/v2/estimatefee/", s.jsonHandler(s.apiEstimateFee, apiV2))
-	serveMux.HandleFunc(path+"api/v2/feestats/", s.jsonHandler(s.apiFeeStats, apiV2))
-	serveMux.HandleFunc(path+"api/v2/balancehistory/", s.jsonHandler(s.apiBalanceHistory, apiDefault))
-	serveMux.HandleFunc(path+"api/v2/tickers/", s.jsonHandler(s.apiTickers, apiV2))
-	serveMux.HandleFunc(path+"api/v2/tickers-list/", s.jsonHandler(s.apiTickersList, apiV2))
+	s.apiHandleFunc(serveMux, path, "api/v2/block-index/", s.apiBlockIndex, apiV2)
+	s.apiHandleFunc(serveMux, path, "api/v2/tx-specific/", s.apiTxSpecific, apiV2)
+	s.apiHandleFunc(serveMux, path, "api/v2/tx/", s.apiTx, apiV2)
+	s.apiHandleFunc(serveMux, path, "api/v2/address/", s.apiAddress, apiV2)
+	s.apiHandleFunc(serveMux, path, "api/v2/xpub/", s.apiXpub, apiV2)
+	s.apiHandleFunc(serveMux, path, "api/v2/utxo/", s.apiUtxo, apiV2)
+	s.apiHandleFunc(serveMux, path, "api/v2/block/", s.apiBlock, apiV2)
+	s.apiHandleFunc(serveMux, path, "api/v2/sendtx/", s.apiSendTx, apiV2)
+	s.apiHandleFunc(serveMux, path, "api/v2/estimatefee/", s.apiEstimateFee, apiV2)
+	s.apiHandleFunc(serveMux, path, "api/v2/feestats/", s.apiFeeStats, apiV2)
+	s.apiHandleFunc(serveMux, path, "api/v2/mempool/stats", s.apiMempoolStats, apiV2)
+	s.apiHandleFunc(serveMux, path, "api/v2/balancehistory/", s.apiBalanceHistory, apiDefault)
+	s.apiHandleFunc(serveMux, path, "api/v2/tickers/", s.apiTickers, apiV2)
+	s.apiHandleFunc(serveMux, path, "api/v2/tickers-list/", s.apiTickersList, apiV2)
+	s.apiHandleFunc(serveMux, path, "api/v2/openapi.json", s.apiOpenAPI, apiV2)
+	if ct := s.chainParser.GetChainType(); ct == bchain.ChainEthereumType || ct == bchain.ChainTronType {
+		s.apiHandleFunc(serveMux, path, "api/v2/contract/", s.apiContract, apiV2)
+		s.apiHandleFunc(serveMux, path, "api/v2/simulate", s.apiSimulate, apiV2)
+	}
 	// socket.io interface
 	serveMux.Handle(path+"socket.io/", s.socketio.GetHandler())
 	// websocket interface
@@ -214,6 +230,16 @@ func (s *PublicServer) OnNewBlock(hash string, height uint32) {
 	s.websocket.OnNewBlock(hash, height)
 }
 
+// OnDisconnectBlocks notifies users about the transactions reverted by a reorg
+func (s *PublicServer) OnDisconnectBlocks(lower uint32, higher uint32) {
+	s.websocket.OnDisconnectBlocks(lower, higher)
+}
+
+// OnMempoolResync notifies users subscribed to the mempool stats
+func (s *PublicServer) OnMempoolResync() {
+	s.websocket.OnMempoolResync()
+}
+
 // OnNewFiatRatesTicker notifies users subscribed to bitcoind/fiatrates about new ticker
 func (s *PublicServer) OnNewFiatRatesTicker(ticker *db.CurrencyRatesTicker) {
 	s.websocket.OnNewFiatRatesTicker(ticker)
@@ -229,6 +255,11 @@ func (s *PublicServer) OnNewTx(tx *bchain.MempoolTx) {
 	s.websocket.OnNewTx(tx)
 }
 
+// OnReplacedTx notifies users subscribed to the addresses of a mempool tx replaced by a conflicting tx or dropped from mempool
+func (s *PublicServer) OnReplacedTx(replacement *bchain.MempoolReplacement, addrDescs []bchain.AddressDescriptor) {
+	s.websocket.OnReplacedTx(replacement, addrDescs)
+}
+
 func (s *PublicServer) txRedirect(w http.ResponseWriter, r *http.Request) {
 	http.Redirect(w, r, joinURL(s.explorerURL, r.URL.Path), 302)
 	s.metrics.ExplorerViews.With(common.Labels{"action": "tx-redirect"}).Inc()
@@ -289,14 +320,30 @@ func (s *PublicServer) jsonHandler(handler func(r *http.Request, apiVersion int)
 			w.Header().Set("Content-Type", "application/json; charset=utf-8")
 			if e, isError := data.(jsonError); isError {
 				w.WriteHeader(e.HTTPStatus)
+				s.metrics.ErrorResponses.With(common.Labels{"interface": "api", "code": strconv.Itoa(e.HTTPStatus)}).Inc()
+			}
+			if c, isCached := data.(*cachedResponse); isCached {
+				err = writeCachedJSON(w, r, c)
+			} else {
+				err = json.NewEncoder(w).Encode(data)
 			}
-			err = json.NewEncoder(w).Encode(data)
 			if err != nil {
 				glog.Warning("json encode ", err)
 			}
 			s.metrics.ExplorerPendingRequests.With((common.Labels{"method": handlerName})).Dec()
 		}()
 		s.metrics.ExplorerPendingRequests.With((common.Labels{"method": handlerName})).Inc()
+		if s.rateLimiter != nil {
+			wait, err := s.rateLimiter.Allow(getAPIKey(r), getIP(r), handlerName, "api")
+			if err == errInvalidAPIKey {
+				data = jsonError{err.Error(), http.StatusUnauthorized}
+				return
+			} else if err != nil {
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+				data = jsonError{err.Error(), http.StatusTooManyRequests}
+				return
+			}
+		}
 		start := time.Now()
 		data, err = handler(r, apiVersion)
 		glog.Info(r.RequestURI, ", ", time.Since(start))
@@ -366,9 +413,16 @@ func (s *PublicServer) htmlTemplateHandler(handler func(w http.ResponseWriter, r
 				if t == errorInternalTpl {
 					w.WriteHeader(http.StatusInternalServerError)
 				}
-				if err := s.templates[t].ExecuteTemplate(w, "base.html", data); err != nil {
+				var buf bytes.Buffer
+				if err := s.templates[t].ExecuteTemplate(&buf, "base.html", data); err != nil {
 					glog.Error(err)
 				}
+				// error pages are not subject to caching
+				if t == errorTpl || t == errorInternalTpl {
+					w.Write(buf.Bytes())
+				} else {
+					writeWithETag(w, r, buf.Bytes(), computeETag(buf.Bytes()))
+				}
 			}
 			s.metrics.ExplorerPendingRequests.With((common.Labels{"method": handlerName})).Dec()
 		}()
@@ -415,6 +469,7 @@ const (
 	blockTpl
 	sendTransactionTpl
 	mempoolTpl
+	contractTpl
 
 	tplCount
 )
@@ -434,6 +489,8 @@ type TemplateData struct {
 	Block                *api.Block
 	Info                 *api.SystemInfo
 	MempoolTxids         *api.MempoolTxids
+	Contract             *api.Contract
+	ContractTransfers    *api.ContractTransfers
 	Page                 int
 	PrevPage             int
 	NextPage             int
@@ -511,6 +568,7 @@ func (s *PublicServer) parseTemplates() []*template.Template {
 	}
 	t[xpubTpl] = createTemplate("./static/templates/xpub.html", "./static/templates/txdetail.html", "./static/templates/paging.html", "./static/templates/base.html")
 	t[mempoolTpl] = createTemplate("./static/templates/mempool.html", "./static/templates/paging.html", "./static/templates/base.html")
+	t[contractTpl] = createTemplate("./static/templates/contract.html", "./static/templates/paging.html", "./static/templates/base.html")
 	return t
 }
 
@@ -711,6 +769,37 @@ func (s *PublicServer) explorerAddress(w http.ResponseWriter, r *http.Request) (
 	return addressTpl, data, nil
 }
 
+func (s *PublicServer) explorerContract(w http.ResponseWriter, r *http.Request) (tpl, *TemplateData, error) {
+	var contractParam string
+	i := strings.LastIndexByte(r.URL.Path, '/')
+	if i > 0 {
+		contractParam = r.URL.Path[i+1:]
+	}
+	if len(contractParam) == 0 {
+		return errorTpl, nil, api.NewAPIError("Missing contract", true)
+	}
+	s.metrics.ExplorerViews.With(common.Labels{"action": "contract"}).Inc()
+	contract, err := s.api.GetContract(contractParam)
+	if err != nil {
+		return errorTpl, nil, err
+	}
+	page, ec := strconv.Atoi(r.URL.Query().Get("page"))
+	if ec != nil {
+		page = 0
+	}
+	transfers, err := s.api.GetContractTransfers(contract.Contract, page, txsOnPage, 0, 0)
+	if err != nil {
+		return errorTpl, nil, err
+	}
+	data := s.newTemplateData()
+	data.AddrStr = contract.Contract
+	data.Contract = contract
+	data.ContractTransfers = transfers
+	data.Page = transfers.Page
+	data.PagingRange, data.PrevPage, data.NextPage = getPagingRange(transfers.Page, transfers.TotalPages)
+	return contractTpl, data, nil
+}
+
 func (s *PublicServer) explorerXpub(w http.ResponseWriter, r *http.Request) (tpl, *TemplateData, error) {
 	var xpub string
 	i := strings.LastIndexByte(r.URL.Path, '/')
@@ -932,10 +1021,11 @@ func (s *PublicServer) apiIndex(r *http.Request, apiVersion int) (interface{}, e
 	return s.api.GetSystemInfo(false)
 }
 
+type resultBlockIndex struct {
+	BlockHash string `json:"blockHash"`
+}
+
 func (s *PublicServer) apiBlockIndex(r *http.Request, apiVersion int) (interface{}, error) {
-	type resBlockIndex struct {
-		BlockHash string `json:"blockHash"`
-	}
 	var err error
 	var hash string
 	height := -1
@@ -953,7 +1043,7 @@ func (s *PublicServer) apiBlockIndex(r *http.Request, apiVersion int) (interface
 		glog.Error(err)
 		return nil, err
 	}
-	return resBlockIndex{
+	return resultBlockIndex{
 		BlockHash: hash,
 	}, nil
 }
@@ -979,10 +1069,18 @@ func (s *PublicServer) apiTx(r *http.Request, apiVersion int) (interface{}, erro
 		}
 	}
 	tx, err = s.api.GetTransaction(txid, spendingTxs, false)
-	if err == nil && apiVersion == apiV1 {
-		return s.api.TxToV1(tx), nil
+	if err != nil {
+		return nil, err
+	}
+	// the spending transactions may change at any time, Last-Modified is not returned for them
+	confirmations := tx.Confirmations
+	if spendingTxs {
+		confirmations = 0
 	}
-	return tx, err
+	if apiVersion == apiV1 {
+		return newCachedResponse(s.api.TxToV1(tx), int(confirmations), tx.Blocktime), nil
+	}
+	return newCachedResponse(tx, int(confirmations), tx.Blocktime), nil
 }
 
 func (s *PublicServer) apiTxSpecific(r *http.Request, apiVersion int) (interface{}, error) {
@@ -994,25 +1092,43 @@ func (s *PublicServer) apiTxSpecific(r *http.Request, apiVersion int) (interface
 	if len(txid) == 0 {
 		return nil, api.NewAPIError("Missing txid", true)
 	}
-	var tx json.RawMessage
-	var err error
 	s.metrics.ExplorerViews.With(common.Labels{"action": "api-tx-specific"}).Inc()
-	tx, err = s.txCache.GetTransactionSpecific(txid)
+	tx, btx, err := s.txCache.GetTransactionSpecific(txid)
 	if err == bchain.ErrTxNotFound {
 		return nil, api.NewAPIError(fmt.Sprintf("Transaction '%v' not found", txid), true)
 	}
-	return tx, err
+	if err != nil {
+		return nil, err
+	}
+	return newCachedResponse(tx, int(btx.Confirmations), btx.Blocktime), nil
 }
 
+// apiAddress handles the requests /api/v2/address/<address>[/<resource>]
 func (s *PublicServer) apiAddress(r *http.Request, apiVersion int) (interface{}, error) {
-	var addressParam string
-	i := strings.LastIndexByte(r.URL.Path, '/')
-	if i > 0 {
-		addressParam = r.URL.Path[i+1:]
+	var addressParam, resource string
+	i := strings.Index(r.URL.Path, "address/")
+	if i >= 0 {
+		p := strings.SplitN(r.URL.Path[i+len("address/"):], "/", 2)
+		addressParam = p[0]
+		if len(p) > 1 {
+			resource = p[1]
+		}
 	}
 	if len(addressParam) == 0 {
 		return nil, api.NewAPIError("Missing address", true)
 	}
+	switch resource {
+	case "":
+	case "nfts":
+		s.metrics.ExplorerViews.With(common.Labels{"action": "api-address-nfts"}).Inc()
+		return s.api.GetAddressNfts(addressParam)
+	case "allowances":
+		s.metrics.ExplorerViews.With(common.Labels{"action": "api-address-allowances"}).Inc()
+		page, pageSize, _, _, _, _ := s.getAddressQueryParams(r, api.AccountDetailsTxidHistory, txsInAPI)
+		return s.api.GetAddressAllowances(addressParam, page, pageSize)
+	default:
+		return nil, api.NewAPIError("Unknown address resource '"+resource+"'", true)
+	}
 	var address *api.Address
 	var err error
 	s.metrics.ExplorerViews.With(common.Labels{"action": "api-address"}).Inc()
@@ -1024,6 +1140,44 @@ func (s *PublicServer) apiAddress(r *http.Request, apiVersion int) (interface{},
 	return address, err
 }
 
+// apiContract handles the requests /api/v2/contract/<contract>/<resource>
+func (s *PublicServer) apiContract(r *http.Request, apiVersion int) (interface{}, error) {
+	var contract, resource string
+	i := strings.Index(r.URL.Path, "contract/")
+	if i >= 0 {
+		p := strings.SplitN(r.URL.Path[i+len("contract/"):], "/", 2)
+		contract = p[0]
+		if len(p) > 1 {
+			resource = p[1]
+		}
+	}
+	if len(contract) == 0 {
+		return nil, api.NewAPIError("Missing contract", true)
+	}
+	switch resource {
+	case "":
+		s.metrics.ExplorerViews.With(common.Labels{"action": "api-contract"}).Inc()
+		return s.api.GetContract(contract)
+	case "transfers":
+		s.metrics.ExplorerViews.With(common.Labels{"action": "api-contract-transfers"}).Inc()
+		page, pageSize, _, filter, _, _ := s.getAddressQueryParams(r, api.AccountDetailsBasic, txsInAPI)
+		return s.api.GetContractTransfers(contract, page, pageSize, filter.FromHeight, filter.ToHeight)
+	case "holders":
+		s.metrics.ExplorerViews.With(common.Labels{"action": "api-contract-holders"}).Inc()
+		page, pageSize, _, _, _, _ := s.getAddressQueryParams(r, api.AccountDetailsBasic, txsInAPI)
+		var height uint64
+		if h := r.URL.Query().Get("height"); h != "" {
+			var err error
+			height, err = strconv.ParseUint(h, 10, 32)
+			if err != nil {
+				return nil, api.NewAPIError("Parameter 'height' is not a valid block height", true)
+			}
+		}
+		return s.api.GetContractHolders(contract, page, pageSize, uint32(height))
+	}
+	return nil, api.NewAPIError("Unknown contract resource '"+resource+"'", true)
+}
+
 func (s *PublicServer) apiXpub(r *http.Request, apiVersion int) (interface{}, error) {
 	var xpub string
 	i := strings.LastIndexByte(r.URL.Path, '/')
@@ -1131,9 +1285,13 @@ func (s *PublicServer) apiBlock(r *http.Request, apiVersion int) (interface{}, e
 			page = 0
 		}
 		block, err = s.api.GetBlock(r.URL.Path[i+1:], page, txsInAPI)
-		if err == nil && apiVersion == apiV1 {
-			return s.api.BlockToV1(block), nil
+		if err != nil {
+			return nil, err
+		}
+		if apiVersion == apiV1 {
+			return newCachedResponse(s.api.BlockToV1(block), block.Confirmations, block.Time), nil
 		}
+		return newCachedResponse(block, block.Confirmations, block.Time), nil
 	}
 	return block, err
 }
@@ -1148,6 +1306,19 @@ func (s *PublicServer) apiFeeStats(r *http.Request, apiVersion int) (interface{}
 	return feeStats, err
 }
 
+func (s *PublicServer) apiMempoolStats(r *http.Request, apiVersion int) (interface{}, error) {
+	s.metrics.ExplorerViews.With(common.Labels{"action": "api-mempool-stats"}).Inc()
+	var blocks int
+	if b := r.URL.Query().Get("blocks"); b != "" {
+		var err error
+		blocks, err = strconv.Atoi(b)
+		if err != nil {
+			return nil, api.NewAPIError("Parameter 'blocks' is not a number", true)
+		}
+	}
+	return s.api.GetMempoolStats(blocks, false)
+}
+
 type resultSendTransaction struct {
 	Result string `json:"result"`
 }
@@ -1230,6 +1401,28 @@ func (s *PublicServer) apiTickers(r *http.Request, apiVersion int) (interface{},
 	return result, nil
 }
 
+// apiSimulate executes an unsigned transaction against the latest block, the parameters of the transaction
+// are passed as a json object in the POST body or as query parameters
+func (s *PublicServer) apiSimulate(r *http.Request, apiVersion int) (interface{}, error) {
+	s.metrics.ExplorerViews.With(common.Labels{"action": "api-simulate"}).Inc()
+	params := make(map[string]interface{})
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			return nil, api.NewAPIError("Invalid transaction parameters", true)
+		}
+	} else {
+		for k, v := range r.URL.Query() {
+			if len(v) > 0 {
+				params[k] = v[0]
+			}
+		}
+	}
+	if len(params) == 0 {
+		return nil, api.NewAPIError("Missing transaction parameters", true)
+	}
+	return s.api.SimulateTransaction(params)
+}
+
 type resultEstimateFeeAsString struct {
 	Result string `json:"result"`
 }
@@ -1253,7 +1446,11 @@ func (s *PublicServer) apiEstimateFee(r *http.Request, apiVersion int) (interfac
 				}
 			}
 			var fee big.Int
-			fee, err = s.chain.EstimateSmartFee(blocks, conservative)
+			if api.IsInternalFeeEstimatorEnabled() {
+				fee, err = s.api.BitcoinTypeEstimateFee(blocks, conservative)
+			} else {
+				fee, err = s.chain.EstimateSmartFee(blocks, conservative)
+			}
 			if err != nil {
 				fee, err = s.chain.EstimateFee(blocks)
 				if err != nil {
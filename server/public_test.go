@@ -19,6 +19,8 @@ import (
 	"github.com/martinboehm/btcutil/chaincfg"
 	gosocketio "github.com/martinboehm/golang-socketio"
 	"github.com/martinboehm/golang-socketio/transport"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/trezor/blockbook/api"
 	"github.com/trezor/blockbook/bchain"
 	"github.com/trezor/blockbook/bchain/coins/btc"
 	"github.com/trezor/blockbook/common"
@@ -41,7 +43,7 @@ func setupRocksDB(t *testing.T, parser bchain.BlockChainParser) (*db.RocksDB, *c
 	if err != nil {
 		t.Fatal(err)
 	}
-	d, err := db.NewRocksDB(tmp, 100000, -1, parser, nil)
+	d, err := db.NewRocksDB(tmp, 100000, -1, parser, nil, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -109,7 +111,7 @@ func setupPublicHTTPServer(t *testing.T) (*PublicServer, string) {
 	}
 
 	// s.Run is never called, binding can be to any port
-	s, err := NewPublicServer("localhost:12345", "", d, chain, mempool, txCache, "", metrics, is, false, false)
+	s, err := NewPublicServer("localhost:12345", "", d, chain, mempool, txCache, "", metrics, is, false, false, nil, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -518,6 +520,25 @@ func httpTestsBitcoinType(t *testing.T, ts *httptest.Server) {
 				`{"txCount":3,"totalFeesSat":"1284","averageFeePerKb":1398,"decilesFeePerKb":[155,155,155,155,1679,1679,1679,2361,2361,2361,2361]}`,
 			},
 		},
+		{
+			name:        "apiMempoolStats",
+			r:           newGetRequest(ts.URL + "/api/v2/mempool/stats"),
+			status:      http.StatusOK,
+			contentType: "application/json; charset=utf-8",
+			body: []string{
+				`"count":0,"vsize":0,"totalFees":"0","histogram":[{"feeRate":0,"count":0,"vsize":0},{"feeRate":1,"count":0,"vsize":0},`,
+				`{"feeRate":2000,"count":0,"vsize":0}],"blocks":[]}`,
+			},
+		},
+		{
+			name:        "apiMempoolStats invalid blocks",
+			r:           newGetRequest(ts.URL + "/api/v2/mempool/stats?blocks=x"),
+			status:      http.StatusBadRequest,
+			contentType: "application/json; charset=utf-8",
+			body: []string{
+				`{"error":"Parameter 'blocks' is not a number"}`,
+			},
+		},
 		{
 			name:        "apiFiatRates missing currency",
 			r:           newGetRequest(ts.URL + "/api/v2/tickers"),
@@ -1449,6 +1470,72 @@ func websocketTestsBitcoinType(t *testing.T, ts *httptest.Server) {
 			},
 			want: `{"id":"39","data":{"subscribed":false,"message":"unsubscribeNewTransaction not enabled, use -enablesubnewtx flag to enable."}}`,
 		},
+		{
+			name: "websocket subscribeAccounts",
+			req: websocketReq{
+				Method: "subscribeAccounts",
+				Params: map[string]interface{}{
+					"descriptors": []string{dbtestdata.Xpub},
+				},
+			},
+			want: `{"id":"40","data":{"subscribed":true}}`,
+		},
+		{
+			name: "websocket subscribeAccounts invalid descriptor",
+			req: websocketReq{
+				Method: "subscribeAccounts",
+				Params: map[string]interface{}{
+					"descriptors": []string{"invalid"},
+				},
+			},
+			want: `{"id":"41","data":{"error":{"message":"Invalid descriptor invalid"}}}`,
+		},
+		{
+			name: "websocket unsubscribeAccounts",
+			req: websocketReq{
+				Method: "unsubscribeAccounts",
+			},
+			want: `{"id":"42","data":{"subscribed":false}}`,
+		},
+		{
+			name: "websocket subscribeContracts",
+			req: websocketReq{
+				Method: "subscribeContracts",
+				Params: map[string]interface{}{
+					"contracts": []string{dbtestdata.Addr1},
+				},
+			},
+			want: `{"id":"43","data":{"error":{"message":"Contracts are not supported"}}}`,
+		},
+		{
+			name: "websocket subscribeMempoolStats",
+			req: websocketReq{
+				Method: "subscribeMempoolStats",
+				Params: map[string]interface{}{
+					"blocks": 4,
+				},
+			},
+			want: `{"id":"44","data":{"subscribed":true}}`,
+		},
+		{
+			name: "websocket unsubscribeMempoolStats",
+			req: websocketReq{
+				Method: "unsubscribeMempoolStats",
+			},
+			want: `{"id":"45","data":{"subscribed":false}}`,
+		},
+		{
+			name: "websocket simulateTransaction not supported",
+			req: websocketReq{
+				Method: "simulateTransaction",
+				Params: map[string]interface{}{
+					"specific": map[string]interface{}{
+						"from": "mtGXQvBowMkBpnhLckhxhbwYK44Gs9eEtz",
+					},
+				},
+			},
+			want: `{"id":"46","data":{"error":{"message":"Transaction simulation is not supported"}}}`,
+		},
 	}
 
 	// send all requests at once
@@ -1498,6 +1585,70 @@ func websocketTestsBitcoinType(t *testing.T, ts *httptest.Server) {
 	}
 }
 
+func websocketAccountsTestsBitcoinType(t *testing.T, s *PublicServer, ts *httptest.Server) {
+	url := strings.Replace(ts.URL, "http://", "ws://", 1) + "/websocket"
+	c, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	c.SetReadDeadline(time.Now().Add(10 * time.Second))
+	readMessage := func() string {
+		_, message, err := c.ReadMessage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.TrimSpace(string(message))
+	}
+	err = c.WriteJSON(map[string]interface{}{
+		"id":     "acc",
+		"method": "subscribeAccounts",
+		"params": map[string]interface{}{
+			"descriptors": []string{dbtestdata.Xpub},
+			"gap":         2,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := readMessage(), `{"id":"acc","data":{"subscribed":true}}`; got != want {
+		t.Fatalf("subscribeAccounts: got %v, want %v", got, want)
+	}
+	tests := []struct {
+		name    string
+		address string
+		want    string
+	}{
+		{
+			name:    "derived address",
+			address: "2MuAZNAjLSo6RLFad2fvHSfgqBD7BoEVy4T",
+			want:    `{"id":"acc","data":{"descriptor":"` + dbtestdata.Xpub + `","addresses":[{"address":"2MuAZNAjLSo6RLFad2fvHSfgqBD7BoEVy4T","path":"m/49'/1'/33'/0/2"}],"tx":{"txid":"tx1","vin":null,"vout":null,"blockHeight":0,"confirmations":0,"blockTime":0,"value":null}}}`,
+		},
+		{
+			// address is beyond the initial gap, it is derived after the previous address was used
+			name:    "extended address",
+			address: "2Mw7vJNC8zUK6VNN4CEjtoTYmuNPLewxZzV",
+			want:    `{"id":"acc","data":{"descriptor":"` + dbtestdata.Xpub + `","addresses":[{"address":"2Mw7vJNC8zUK6VNN4CEjtoTYmuNPLewxZzV","path":"m/49'/1'/33'/0/4"}],"tx":{"txid":"tx1","vin":null,"vout":null,"blockHeight":0,"confirmations":0,"blockTime":0,"value":null}}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ad, err := s.chainParser.GetAddrDescFromAddress(tt.address)
+			if err != nil {
+				t.Fatal(err)
+			}
+			subscribed := s.websocket.getNewTxSubscriptions(&bchain.MempoolTx{Vin: []bchain.MempoolVin{{AddrDesc: ad}}}).accounts
+			if len(subscribed) != 1 {
+				t.Fatalf("address %v not subscribed", tt.address)
+			}
+			s.websocket.sendOnNewTxAccounts(subscribed, &api.Tx{Txid: "tx1"})
+			if got := readMessage(); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_PublicServer_BitcoinType(t *testing.T) {
 	s, dbpath := setupPublicHTTPServer(t)
 	defer closeAndDestroyPublicServer(t, s, dbpath)
@@ -1509,4 +1660,24 @@ func Test_PublicServer_BitcoinType(t *testing.T) {
 	httpTestsBitcoinType(t, ts)
 	socketioTestsBitcoinType(t, ts)
 	websocketTestsBitcoinType(t, ts)
+	websocketAccountsTestsBitcoinType(t, s, ts)
+	websocketSessionTestsBitcoinType(t, s, ts)
+	websocketCompressionTestsBitcoinType(t, ts)
+	openAPITestsBitcoinType(t, s, ts)
+	httpCacheTestsBitcoinType(t, ts)
+}
+
+func Test_PublicServer_apiContractHoldersHeight(t *testing.T) {
+	s := &PublicServer{
+		metrics: &common.Metrics{
+			ExplorerViews: prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_explorer_views"}, []string{"action"}),
+		},
+	}
+	for _, height := range []string{"-1", "x", "4294967296"} {
+		r := httptest.NewRequest(http.MethodGet, "/api/v2/contract/0x4af4114f73d1c1c903ac9e0361b379d1291808a2/holders?height="+height, nil)
+		_, err := s.apiContract(r, apiV2)
+		if apiErr, ok := err.(*api.APIError); !ok || !apiErr.Public || apiErr.Text != "Parameter 'height' is not a valid block height" {
+			t.Errorf("apiContract(height=%v) error = %v, want invalid block height", height, err)
+		}
+	}
 }
@@ -0,0 +1,220 @@
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/juju/errors"
+	"github.com/trezor/blockbook/common"
+)
+
+const (
+	apiKeyHeader = "X-Api-Key"
+	apiKeyParam  = "apikey"
+
+	// buckets of IP addresses not used for this time are removed
+	rateLimitIdleTime = 10 * time.Minute
+)
+
+var (
+	// errRateLimited is returned when the client exceeded its rate limit
+	errRateLimited = errors.New("Too many requests")
+	// errInvalidAPIKey is returned when the client used unknown API key or did not specify it while it is required
+	errInvalidAPIKey = errors.New("Invalid or missing API key")
+)
+
+// defaultMethodCosts are the costs of the methods not specified in the config, other methods cost 1
+// the names are the names of the REST API handlers and of the websocket methods
+var defaultMethodCosts = map[string]float64{
+	"apiXpub":             10,
+	"subscribeAccounts":   10,
+	"apiSimulate":         10,
+	"simulateTransaction": 10,
+	"apiBalanceHistory":   5,
+	"apiContract":         5,
+	"getAccountInfo":      5,
+	"getAccountUtxo":      5,
+	"getBalanceHistory":   5,
+}
+
+// RateLimit is a token bucket definition, Rate tokens per second are added to the bucket up to Burst tokens
+type RateLimit struct {
+	Rate  float64 `json:"rate"`
+	Burst float64 `json:"burst"`
+}
+
+// APIKey is a definition of an API key
+type APIKey struct {
+	Name string `json:"name"`
+	RateLimit
+}
+
+// RateLimitConfig is the content of the API keys and rate limits config file
+type RateLimitConfig struct {
+	// RequireAPIKey rejects the requests without a valid API key
+	RequireAPIKey bool `json:"require_api_key"`
+	// IPLimit is the limit of the requests without an API key, applied per IP address, zero rate means no limit
+	IPLimit RateLimit `json:"ip_limit"`
+	// Keys are the API keys indexed by the key
+	Keys map[string]APIKey `json:"keys"`
+	// MethodCosts overrides the default costs of the methods
+	MethodCosts map[string]float64 `json:"method_costs"`
+}
+
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// take removes the cost from the bucket if there are enough tokens
+// otherwise it returns the time after which there will be enough tokens
+// the cost higher than the burst could never be satisfied, such a method takes the full bucket
+func (b *tokenBucket) take(limit *RateLimit, cost float64, now time.Time) (bool, time.Duration) {
+	cost = math.Min(cost, limit.Burst)
+	b.tokens = math.Min(limit.Burst, b.tokens+now.Sub(b.last).Seconds()*limit.Rate)
+	b.last = now
+	if b.tokens >= cost {
+		b.tokens -= cost
+		return true, 0
+	}
+	return false, time.Duration((cost - b.tokens) / limit.Rate * float64(time.Second))
+}
+
+// RateLimiter limits the rate of the requests per API key and per IP address
+type RateLimiter struct {
+	config    RateLimitConfig
+	metrics   *common.Metrics
+	buckets   map[string]*tokenBucket
+	lock      sync.Mutex
+	lastPrune time.Time
+	now       func() time.Time
+}
+
+// NewRateLimiter creates a rate limiter from the config file
+func NewRateLimiter(configFile string, metrics *common.Metrics) (*RateLimiter, error) {
+	data, err := ioutil.ReadFile(configFile)
+	if err != nil {
+		return nil, errors.Annotatef(err, "read %v", configFile)
+	}
+	var config RateLimitConfig
+	if err = json.Unmarshal(data, &config); err != nil {
+		return nil, errors.Annotatef(err, "parse %v", configFile)
+	}
+	if config.IPLimit.Rate < 0 || (config.IPLimit.Rate > 0 && config.IPLimit.Burst <= 0) {
+		return nil, errors.New("ip_limit: rate must not be negative and burst must be positive if rate is set")
+	}
+	for m, c := range config.MethodCosts {
+		if c < 0 {
+			return nil, errors.Errorf("method %v: cost must not be negative", m)
+		}
+	}
+	for k, v := range config.Keys {
+		if v.Rate <= 0 || v.Burst <= 0 {
+			return nil, errors.Errorf("API key %v: rate and burst must be positive", v.Name)
+		}
+		if v.Name == "" {
+			v.Name = k
+			config.Keys[k] = v
+		}
+	}
+	glog.Info("rate limiter: ", len(config.Keys), " API keys, API key required ", config.RequireAPIKey, ", IP limit ", config.IPLimit)
+	return newRateLimiter(&config, metrics), nil
+}
+
+func newRateLimiter(config *RateLimitConfig, metrics *common.Metrics) *RateLimiter {
+	return &RateLimiter{
+		config:  *config,
+		metrics: metrics,
+		buckets: make(map[string]*tokenBucket),
+		now:     time.Now,
+	}
+}
+
+// getAPIKey returns the API key of the request, taken from the header or from the query parameter
+func getAPIKey(r *http.Request) string {
+	if k := r.Header.Get(apiKeyHeader); k != "" {
+		return k
+	}
+	return r.URL.Query().Get(apiKeyParam)
+}
+
+// CheckAPIKey returns an error if the API key is invalid or missing while it is required
+func (rl *RateLimiter) CheckAPIKey(apiKey string) error {
+	if apiKey == "" {
+		if rl.config.RequireAPIKey {
+			return errInvalidAPIKey
+		}
+		return nil
+	}
+	if _, ok := rl.config.Keys[apiKey]; !ok {
+		return errInvalidAPIKey
+	}
+	return nil
+}
+
+func (rl *RateLimiter) methodCost(method string) float64 {
+	if c, ok := rl.config.MethodCosts[method]; ok {
+		return c
+	}
+	if c, ok := defaultMethodCosts[method]; ok {
+		return c
+	}
+	return 1
+}
+
+// Allow takes the cost of the method from the bucket of the API key or, if the key is not specified, of the IP address
+// it returns errRateLimited and the time to wait if there are not enough tokens
+func (rl *RateLimiter) Allow(apiKey, ip, method, iface string) (time.Duration, error) {
+	if err := rl.CheckAPIKey(apiKey); err != nil {
+		rl.metrics.ThrottledRequests.With(common.Labels{"interface": iface, "method": method, "reason": "apikey"}).Inc()
+		return 0, err
+	}
+	var limit *RateLimit
+	var bucketKey string
+	if apiKey != "" {
+		k := rl.config.Keys[apiKey]
+		limit = &k.RateLimit
+		bucketKey = "k" + apiKey
+	} else {
+		if rl.config.IPLimit.Rate <= 0 {
+			return 0, nil
+		}
+		limit = &rl.config.IPLimit
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+		bucketKey = "i" + ip
+	}
+	now := rl.now()
+	rl.lock.Lock()
+	defer rl.lock.Unlock()
+	if now.Sub(rl.lastPrune) > rateLimitIdleTime {
+		rl.prune(now)
+	}
+	b, ok := rl.buckets[bucketKey]
+	if !ok {
+		b = &tokenBucket{tokens: limit.Burst, last: now}
+		rl.buckets[bucketKey] = b
+	}
+	if ok, wait := b.take(limit, rl.methodCost(method), now); !ok {
+		rl.metrics.ThrottledRequests.With(common.Labels{"interface": iface, "method": method, "reason": "ratelimit"}).Inc()
+		return wait, errRateLimited
+	}
+	return 0, nil
+}
+
+// prune removes the buckets which were not used for some time, they would be full anyway
+func (rl *RateLimiter) prune(now time.Time) {
+	for k, b := range rl.buckets {
+		if now.Sub(b.last) > rateLimitIdleTime {
+			delete(rl.buckets, k)
+		}
+	}
+	rl.lastPrune = now
+}
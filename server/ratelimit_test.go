@@ -0,0 +1,136 @@
+// +build unittest
+
+package server
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/trezor/blockbook/common"
+)
+
+func TestRateLimiter_Allow(t *testing.T) {
+	metrics := &common.Metrics{
+		ThrottledRequests: prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_throttled_requests"}, []string{"interface", "method", "reason"}),
+	}
+	rl := newRateLimiter(&RateLimitConfig{
+		IPLimit: RateLimit{Rate: 1, Burst: 10},
+		Keys: map[string]APIKey{
+			"key1": {Name: "partner", RateLimit: RateLimit{Rate: 10, Burst: 20}},
+		},
+		MethodCosts: map[string]float64{"apiTx": 2},
+	}, metrics)
+	now := time.Unix(1600000000, 0)
+	rl.now = func() time.Time { return now }
+
+	type call struct {
+		apiKey, ip, method string
+		wantWait           time.Duration
+		wantErr            error
+	}
+	calls := []call{
+		// xpub costs 10 by default, exhausts the IP bucket
+		{"", "1.2.3.4:1111", "apiXpub", 0, nil},
+		{"", "1.2.3.4:2222", "apiBlock", time.Second, errRateLimited},
+		// another IP has its own bucket
+		{"", "5.6.7.8:1111", "apiBlock", 0, nil},
+		// API key has its own bucket, configured cost 2
+		{"key1", "1.2.3.4:1111", "apiXpub", 0, nil},
+		{"key1", "1.2.3.4:1111", "apiTx", 0, nil},
+		{"key1", "1.2.3.4:1111", "apiXpub", 200 * time.Millisecond, errRateLimited},
+		{"unknown", "1.2.3.4:1111", "apiTx", 0, errInvalidAPIKey},
+	}
+	for i, c := range calls {
+		wait, err := rl.Allow(c.apiKey, c.ip, c.method, "api")
+		if err != c.wantErr || wait != c.wantWait {
+			t.Errorf("call %d: Allow() = %v, %v, want %v, %v", i, wait, err, c.wantWait, c.wantErr)
+		}
+	}
+	// after 2 seconds the IP bucket has 2 tokens
+	now = now.Add(2 * time.Second)
+	if _, err := rl.Allow("", "1.2.3.4", "apiBlock", "api"); err != nil {
+		t.Errorf("Allow() after refill = %v, want nil", err)
+	}
+	if _, err := rl.Allow("", "1.2.3.4", "apiTx", "api"); err != errRateLimited {
+		t.Errorf("Allow() after refill = %v, want %v", err, errRateLimited)
+	}
+	// idle buckets are pruned and start full
+	now = now.Add(2 * rateLimitIdleTime)
+	if _, err := rl.Allow("", "1.2.3.4", "apiXpub", "api"); err != nil {
+		t.Errorf("Allow() after idle time = %v, want nil", err)
+	}
+	if len(rl.buckets) != 1 {
+		t.Errorf("len(buckets) = %d, want 1", len(rl.buckets))
+	}
+}
+
+func TestRateLimiter_CheckAPIKey(t *testing.T) {
+	rl := newRateLimiter(&RateLimitConfig{
+		RequireAPIKey: true,
+		Keys: map[string]APIKey{
+			"key1": {Name: "partner", RateLimit: RateLimit{Rate: 10, Burst: 20}},
+		},
+	}, nil)
+	if err := rl.CheckAPIKey(""); err != errInvalidAPIKey {
+		t.Errorf("CheckAPIKey(\"\") = %v, want %v", err, errInvalidAPIKey)
+	}
+	if err := rl.CheckAPIKey("key1"); err != nil {
+		t.Errorf("CheckAPIKey(\"key1\") = %v, want nil", err)
+	}
+}
+
+func TestRateLimiter_CostOverBurst(t *testing.T) {
+	metrics := &common.Metrics{
+		ThrottledRequests: prometheus.NewCounterVec(prometheus.CounterOpts{Name: "test_throttled_requests_burst"}, []string{"interface", "method", "reason"}),
+	}
+	rl := newRateLimiter(&RateLimitConfig{IPLimit: RateLimit{Rate: 1, Burst: 3}}, metrics)
+	now := time.Unix(1600000000, 0)
+	rl.now = func() time.Time { return now }
+	// xpub costs 10 by default, more than the burst, it takes the full bucket
+	if _, err := rl.Allow("", "1.2.3.4", "apiXpub", "api"); err != nil {
+		t.Errorf("Allow() = %v, want nil", err)
+	}
+	if wait, err := rl.Allow("", "1.2.3.4", "apiXpub", "api"); err != errRateLimited || wait != 3*time.Second {
+		t.Errorf("Allow() = %v, %v, want %v, %v", wait, err, 3*time.Second, errRateLimited)
+	}
+	now = now.Add(3 * time.Second)
+	if _, err := rl.Allow("", "1.2.3.4", "apiXpub", "api"); err != nil {
+		t.Errorf("Allow() after refill = %v, want nil", err)
+	}
+}
+
+func TestNewRateLimiter_Validation(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ratelimit")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tests := []struct {
+		name    string
+		config  string
+		wantErr bool
+	}{
+		{"valid", `{"ip_limit":{"rate":1,"burst":5},"keys":{"k":{"rate":10,"burst":20}},"method_costs":{"apiTx":2}}`, false},
+		{"no ip limit", `{"require_api_key":true,"keys":{"k":{"rate":10,"burst":20}}}`, false},
+		{"ip limit without burst", `{"ip_limit":{"rate":1}}`, true},
+		{"negative ip rate", `{"ip_limit":{"rate":-1,"burst":5}}`, true},
+		{"key without burst", `{"keys":{"k":{"rate":10}}}`, true},
+		{"negative cost", `{"method_costs":{"apiTx":-1}}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := filepath.Join(dir, "config.json")
+			if err := ioutil.WriteFile(f, []byte(tt.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := NewRateLimiter(f, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewRateLimiter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
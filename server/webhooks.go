@@ -0,0 +1,625 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/juju/errors"
+	"github.com/trezor/blockbook/api"
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/common"
+	"github.com/trezor/blockbook/db"
+)
+
+const (
+	webhookEventMempool       = "mempool"
+	webhookEventConfirmed     = "confirmed"
+	webhookEventConfirmations = "confirmations"
+
+	webhookSignatureHeader = "X-Blockbook-Signature"
+	webhookEventHeader     = "X-Blockbook-Event"
+	webhookDeliveryHeader  = "X-Blockbook-Delivery"
+
+	webhookRequestTimeout  = 10 * time.Second
+	webhookRetryBase       = 5 * time.Second
+	webhookRetryMax        = time.Hour
+	webhookMaxAttempts     = 12
+	webhookMaxParallel     = 8
+	webhookMempoolSentTime = 24 * time.Hour
+)
+
+// WebhookPayload is the JSON body of a webhook notification
+type WebhookPayload struct {
+	Event         string  `json:"event"`
+	Address       string  `json:"address"`
+	Txid          string  `json:"txid"`
+	Confirmations uint32  `json:"confirmations"`
+	Tx            *api.Tx `json:"tx,omitempty"`
+}
+
+// Webhooks sends notifications about transactions of the watched addresses to the registered URLs
+type Webhooks struct {
+	db           *db.RocksDB
+	chainParser  bchain.BlockChainParser
+	metrics      *common.Metrics
+	is           *common.InternalState
+	api          *api.Worker
+	client       *http.Client
+	watches      map[string][]db.WebhookWatch
+	watchesLock  sync.Mutex
+	queue        map[uint64]*db.WebhookDelivery
+	queueLock    sync.Mutex
+	mempoolSent  map[string]time.Time
+	mempoolLock  sync.Mutex
+	lastID       uint64
+	idLock       sync.Mutex
+	chanNewBlock chan uint32
+	chanDeliver  chan struct{}
+	chanStop     chan struct{}
+	chanStopped  chan struct{}
+}
+
+// NewWebhooks creates a new webhook dispatcher and loads the watchlist and the pending deliveries from the db
+func NewWebhooks(d *db.RocksDB, chain bchain.BlockChain, mempool bchain.Mempool, txCache *db.TxCache, metrics *common.Metrics, is *common.InternalState) (*Webhooks, error) {
+	api, err := api.NewWorker(d, chain, mempool, txCache, metrics, is)
+	if err != nil {
+		return nil, err
+	}
+	w := &Webhooks{
+		db:           d,
+		chainParser:  chain.GetChainParser(),
+		metrics:      metrics,
+		is:           is,
+		api:          api,
+		client:       &http.Client{Timeout: webhookRequestTimeout},
+		queue:        make(map[uint64]*db.WebhookDelivery),
+		mempoolSent:  make(map[string]time.Time),
+		lastID:       uint64(time.Now().UnixNano()),
+		chanNewBlock: make(chan uint32, 64),
+		chanDeliver:  make(chan struct{}, 1),
+		chanStop:     make(chan struct{}),
+		chanStopped:  make(chan struct{}, 2),
+	}
+	if w.watches, err = d.GetWebhookWatches(); err != nil {
+		return nil, err
+	}
+	queue, err := d.GetWebhookDeliveries(false)
+	if err != nil {
+		return nil, err
+	}
+	for _, wd := range queue {
+		w.queue[wd.ID] = wd
+		if wd.ID > w.lastID {
+			w.lastID = wd.ID
+		}
+	}
+	glog.Info("webhooks: ", len(w.watches), " watched addresses, ", len(w.queue), " deliveries to replay")
+	w.updateQueueMetrics()
+	return w, nil
+}
+
+func (w *Webhooks) nextID() uint64 {
+	w.idLock.Lock()
+	defer w.idLock.Unlock()
+	w.lastID++
+	return w.lastID
+}
+
+func (w *Webhooks) updateQueueMetrics() {
+	w.metrics.WebhookQueueSize.Set(float64(len(w.queue)))
+}
+
+// Run starts the processing of new blocks and the delivery of the notifications, the deliveries stored in db are replayed
+func (w *Webhooks) Run() {
+	go w.blocksLoop()
+	go w.deliveryLoop()
+	w.OnNewBlock("", 0)
+}
+
+// Close stops the dispatcher, the undelivered notifications are kept in db and delivered after restart
+// it waits for the running deliveries to finish until the context is done
+func (w *Webhooks) Close(ctx context.Context) error {
+	glog.Info("webhooks: closing")
+	close(w.chanStop)
+	// wait for both blocksLoop and deliveryLoop
+	for i := 0; i < 2; i++ {
+		select {
+		case <-w.chanStopped:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// GetWatches returns the list of all watches, without the secrets
+func (w *Webhooks) GetWatches() []db.WebhookWatch {
+	w.watchesLock.Lock()
+	defer w.watchesLock.Unlock()
+	r := make([]db.WebhookWatch, 0, len(w.watches))
+	for _, ws := range w.watches {
+		r = append(r, ws...)
+	}
+	for i := range r {
+		r[i].Secret = ""
+	}
+	return r
+}
+
+// Watch adds the address to the watchlist or updates the existing watch of the address with the same URL
+func (w *Webhooks) Watch(watch *db.WebhookWatch) error {
+	addrDesc, err := w.chainParser.GetAddrDescFromAddress(watch.Address)
+	if err != nil || len(addrDesc) == 0 {
+		return api.NewAPIError(fmt.Sprintf("Invalid address %v", watch.Address), true)
+	}
+	if !strings.HasPrefix(watch.URL, "http://") && !strings.HasPrefix(watch.URL, "https://") {
+		return api.NewAPIError(fmt.Sprintf("Invalid URL %v", watch.URL), true)
+	}
+	w.watchesLock.Lock()
+	defer w.watchesLock.Unlock()
+	ws := w.watches[string(addrDesc)]
+	updated := make([]db.WebhookWatch, 0, len(ws)+1)
+	for i := range ws {
+		if ws[i].URL != watch.URL {
+			updated = append(updated, ws[i])
+		}
+	}
+	updated = append(updated, *watch)
+	if err := w.db.StoreWebhookWatches(addrDesc, updated); err != nil {
+		return err
+	}
+	w.watches[string(addrDesc)] = updated
+	return nil
+}
+
+// Unwatch removes the watch of the address with the URL, empty URL removes all watches of the address
+func (w *Webhooks) Unwatch(address, url string) error {
+	addrDesc, err := w.chainParser.GetAddrDescFromAddress(address)
+	if err != nil || len(addrDesc) == 0 {
+		return api.NewAPIError(fmt.Sprintf("Invalid address %v", address), true)
+	}
+	w.watchesLock.Lock()
+	defer w.watchesLock.Unlock()
+	ws, ok := w.watches[string(addrDesc)]
+	if !ok {
+		return api.NewAPIError(fmt.Sprintf("Address %v is not watched", address), true)
+	}
+	updated := make([]db.WebhookWatch, 0, len(ws))
+	if url != "" {
+		for i := range ws {
+			if ws[i].URL != url {
+				updated = append(updated, ws[i])
+			}
+		}
+	}
+	if err := w.db.StoreWebhookWatches(addrDesc, updated); err != nil {
+		return err
+	}
+	if len(updated) == 0 {
+		delete(w.watches, string(addrDesc))
+	} else {
+		w.watches[string(addrDesc)] = updated
+	}
+	return nil
+}
+
+// GetQueue returns the deliveries waiting in the queue, ordered by id, without the secrets
+func (w *Webhooks) GetQueue() ([]*db.WebhookDelivery, error) {
+	return redactDeliveries(w.db.GetWebhookDeliveries(false))
+}
+
+// GetDeadLetters returns the deliveries which failed all delivery attempts, without the secrets
+func (w *Webhooks) GetDeadLetters() ([]*db.WebhookDelivery, error) {
+	return redactDeliveries(w.db.GetWebhookDeliveries(true))
+}
+
+// redactDeliveries removes the secrets from the deliveries read from db
+func redactDeliveries(wds []*db.WebhookDelivery, err error) ([]*db.WebhookDelivery, error) {
+	for _, wd := range wds {
+		wd.Secret = ""
+	}
+	return wds, err
+}
+
+// RetryDeadLetters moves all deliveries from the dead-letter list back to the queue, returns the number of moved deliveries
+func (w *Webhooks) RetryDeadLetters() (int, error) {
+	dead, err := w.db.GetWebhookDeliveries(true)
+	if err != nil {
+		return 0, err
+	}
+	for _, wd := range dead {
+		wd.Attempts = 0
+		wd.NextAttempt = time.Now()
+		if err := w.enqueue(wd); err != nil {
+			return 0, err
+		}
+		if err := w.db.DeleteWebhookDelivery(wd.ID, true); err != nil {
+			return 0, err
+		}
+	}
+	return len(dead), nil
+}
+
+func (w *Webhooks) getWatches(addrDesc bchain.AddressDescriptor) []db.WebhookWatch {
+	w.watchesLock.Lock()
+	defer w.watchesLock.Unlock()
+	return w.watches[string(addrDesc)]
+}
+
+func (w *Webhooks) enqueue(wd *db.WebhookDelivery) error {
+	if err := w.db.StoreWebhookDelivery(wd, false); err != nil {
+		return err
+	}
+	w.queueLock.Lock()
+	w.queue[wd.ID] = wd
+	w.updateQueueMetrics()
+	w.queueLock.Unlock()
+	select {
+	case w.chanDeliver <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (w *Webhooks) notify(watch *db.WebhookWatch, event string, tx *api.Tx, confirmations uint32) {
+	payload, err := json.Marshal(&WebhookPayload{
+		Event:         event,
+		Address:       watch.Address,
+		Txid:          tx.Txid,
+		Confirmations: confirmations,
+		Tx:            tx,
+	})
+	if err != nil {
+		glog.Error("webhooks: marshal payload of ", tx.Txid, " error ", err)
+		return
+	}
+	wd := &db.WebhookDelivery{
+		ID:          w.nextID(),
+		URL:         watch.URL,
+		Secret:      watch.Secret,
+		Event:       event,
+		Payload:     payload,
+		NextAttempt: time.Now(),
+	}
+	if err := w.enqueue(wd); err != nil {
+		glog.Error("webhooks: enqueue delivery of ", tx.Txid, " error ", err)
+	}
+}
+
+// OnNewTxAddr is a callback that sends the mempool notification about a new transaction of a watched address
+func (w *Webhooks) OnNewTxAddr(tx *bchain.Tx, addrDesc bchain.AddressDescriptor) {
+	watches := w.getWatches(addrDesc)
+	if len(watches) == 0 {
+		return
+	}
+	// the callback can be called several times for the same transaction and address
+	key := tx.Txid + string(addrDesc)
+	w.mempoolLock.Lock()
+	_, sent := w.mempoolSent[key]
+	if !sent {
+		w.mempoolSent[key] = time.Now()
+	}
+	w.mempoolLock.Unlock()
+	if sent {
+		return
+	}
+	atx, err := w.api.GetTransactionFromBchainTx(tx, 0, false, false)
+	if err != nil {
+		glog.Error("webhooks: GetTransactionFromBchainTx error ", err, " for ", tx.Txid)
+		return
+	}
+	for i := range watches {
+		w.notify(&watches[i], webhookEventMempool, atx, 0)
+	}
+}
+
+// OnNewBlock is a callback that sends the notifications about the confirmations of the transactions of the watched addresses
+func (w *Webhooks) OnNewBlock(hash string, height uint32) {
+	select {
+	case w.chanNewBlock <- height:
+	default:
+		glog.Warning("webhooks: new block channel full, block ", height, " will be processed with the next block")
+	}
+}
+
+func (w *Webhooks) blocksLoop() {
+	defer func() { w.chanStopped <- struct{}{} }()
+	for {
+		select {
+		case <-w.chanStop:
+			return
+		case height := <-w.chanNewBlock:
+			if err := w.processBlocks(height); err != nil {
+				glog.Error("webhooks: processBlocks error ", err)
+			}
+			w.pruneMempoolSent()
+		}
+	}
+}
+
+// processBlocks processes the blocks from the last processed block up to the best block,
+// the block at the given height is processed even if it was already processed before (in case of a fork)
+func (w *Webhooks) processBlocks(height uint32) error {
+	bestHeight, _, err := w.db.GetBestBlock()
+	if err != nil {
+		return err
+	}
+	last, err := w.db.GetWebhookHeight()
+	if err != nil {
+		return err
+	}
+	if last == 0 {
+		// first run, do not notify about the historical blocks
+		return w.db.StoreWebhookHeight(bestHeight)
+	}
+	from := last + 1
+	if height > 0 && height < from {
+		from = height
+	}
+	for h := from; h <= bestHeight; h++ {
+		select {
+		case <-w.chanStop:
+			return nil
+		default:
+		}
+		if err := w.processBlock(h); err != nil {
+			return err
+		}
+		if err := w.db.StoreWebhookHeight(h); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type webhookBlockTx struct {
+	txid    string
+	watches []db.WebhookWatch
+}
+
+// watchedBlockTxs returns the transactions of the block at given height touching the watched addresses, with the watches of these addresses
+func (w *Webhooks) watchedBlockTxs(height uint32) ([]webhookBlockTx, error) {
+	btas, err := w.db.GetBlockTxAddresses(height)
+	if err != nil {
+		return nil, err
+	}
+	var txs []webhookBlockTx
+	if btas != nil {
+		for i := range btas {
+			var watches []db.WebhookWatch
+			seen := make(map[string]struct{}, len(btas[i].AddrDescs))
+			for _, ad := range btas[i].AddrDescs {
+				if _, found := seen[string(ad)]; found {
+					continue
+				}
+				seen[string(ad)] = struct{}{}
+				watches = append(watches, w.getWatches(ad)...)
+			}
+			if len(watches) > 0 {
+				txs = append(txs, webhookBlockTx{txid: btas[i].Txid, watches: watches})
+			}
+		}
+		return txs, nil
+	}
+	// the transactions of the block are not kept in db, look up the transactions of each watched address
+	w.watchesLock.Lock()
+	watched := make(map[string][]db.WebhookWatch, len(w.watches))
+	for ad, ws := range w.watches {
+		watched[ad] = ws
+	}
+	w.watchesLock.Unlock()
+	for ad, ws := range watched {
+		txids := make(map[string]struct{})
+		err := w.db.GetAddrDescTransactions(bchain.AddressDescriptor(ad), height, height, func(txid string, height uint32, indexes []int32) error {
+			if _, found := txids[txid]; !found {
+				txids[txid] = struct{}{}
+				txs = append(txs, webhookBlockTx{txid: txid, watches: ws})
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return txs, nil
+}
+
+func (w *Webhooks) processBlock(height uint32) error {
+	txs, err := w.watchedBlockTxs(height)
+	if err != nil {
+		return err
+	}
+	for _, btx := range txs {
+		tx, err := w.api.GetTransaction(btx.txid, false, false)
+		if err != nil {
+			glog.Error("webhooks: GetTransaction error ", err, " for ", btx.txid)
+			continue
+		}
+		for i := range btx.watches {
+			watch := &btx.watches[i]
+			w.notify(watch, webhookEventConfirmed, tx, 1)
+			if watch.Confirmations > 1 {
+				wc := db.WebhookConfirmation{
+					ID:     w.nextID(),
+					Height: height + watch.Confirmations - 1,
+					Txid:   btx.txid,
+					Watch:  *watch,
+				}
+				if err := w.db.StoreWebhookConfirmation(&wc); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	wcs, err := w.db.GetWebhookConfirmations(height)
+	if err != nil {
+		return err
+	}
+	for _, wc := range wcs {
+		tx, err := w.api.GetTransaction(wc.Txid, false, false)
+		if err != nil {
+			glog.Error("webhooks: GetTransaction error ", err, " for ", wc.Txid)
+		} else if tx.Confirmations == 0 {
+			// the transaction was removed from the block by a fork, it will be notified again when it is confirmed
+			glog.Warning("webhooks: transaction ", wc.Txid, " is not confirmed anymore")
+		} else {
+			w.notify(&wc.Watch, webhookEventConfirmations, tx, tx.Confirmations)
+		}
+		if err := w.db.DeleteWebhookConfirmation(wc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *Webhooks) pruneMempoolSent() {
+	w.mempoolLock.Lock()
+	defer w.mempoolLock.Unlock()
+	for k, t := range w.mempoolSent {
+		if time.Since(t) > webhookMempoolSentTime {
+			delete(w.mempoolSent, k)
+		}
+	}
+}
+
+func (w *Webhooks) deliveryLoop() {
+	defer func() { w.chanStopped <- struct{}{} }()
+	tick := time.NewTicker(time.Second)
+	defer tick.Stop()
+	for {
+		select {
+		case <-w.chanStop:
+			return
+		case <-tick.C:
+		case <-w.chanDeliver:
+		}
+		w.deliverDue()
+	}
+}
+
+// deliverDue sends the deliveries whose time has come, in the order of their creation
+func (w *Webhooks) deliverDue() {
+	now := time.Now()
+	w.queueLock.Lock()
+	due := make([]*db.WebhookDelivery, 0)
+	for _, wd := range w.queue {
+		if !wd.NextAttempt.After(now) {
+			due = append(due, wd)
+		}
+	}
+	w.queueLock.Unlock()
+	sort.Slice(due, func(i, j int) bool { return due[i].ID < due[j].ID })
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, webhookMaxParallel)
+loop:
+	for _, wd := range due {
+		// do not start new deliveries on shutdown, they stay in the queue
+		select {
+		case <-w.chanStop:
+			break loop
+		default:
+		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(wd *db.WebhookDelivery) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			w.deliver(wd)
+		}(wd)
+	}
+	wg.Wait()
+}
+
+// webhookSignature returns hex encoded HMAC-SHA256 of the body
+func webhookSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (w *Webhooks) post(wd *db.WebhookDelivery) error {
+	req, err := http.NewRequest(http.MethodPost, wd.URL, bytes.NewReader(wd.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookEventHeader, wd.Event)
+	req.Header.Set(webhookDeliveryHeader, strconv.FormatUint(wd.ID, 10))
+	if wd.Secret != "" {
+		req.Header.Set(webhookSignatureHeader, webhookSignature(wd.Secret, wd.Payload))
+	}
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("HTTP status %v", resp.StatusCode)
+	}
+	return nil
+}
+
+func (w *Webhooks) deliver(wd *db.WebhookDelivery) {
+	err := w.post(wd)
+	if err == nil {
+		w.metrics.WebhookDeliveries.With(common.Labels{"event": wd.Event, "status": "success"}).Inc()
+		if err := w.db.DeleteWebhookDelivery(wd.ID, false); err != nil {
+			glog.Error("webhooks: delete delivery ", wd.ID, " error ", err)
+		}
+		w.removeFromQueue(wd.ID)
+		return
+	}
+	wd.Attempts++
+	wd.LastError = err.Error()
+	if wd.Attempts >= webhookMaxAttempts {
+		glog.Warning("webhooks: delivery ", wd.ID, " to ", wd.URL, " failed ", wd.Attempts, " times, moved to dead-letter list, last error ", err)
+		w.metrics.WebhookDeliveries.With(common.Labels{"event": wd.Event, "status": "deadletter"}).Inc()
+		if err := w.db.StoreWebhookDelivery(wd, true); err != nil {
+			glog.Error("webhooks: store dead-letter ", wd.ID, " error ", err)
+		}
+		w.removeFromQueue(wd.ID)
+		return
+	}
+	glog.V(1).Info("webhooks: delivery ", wd.ID, " to ", wd.URL, " failed, attempt ", wd.Attempts, ", error ", err)
+	w.metrics.WebhookDeliveries.With(common.Labels{"event": wd.Event, "status": "retry"}).Inc()
+	wd.NextAttempt = time.Now().Add(webhookRetryDelay(wd.Attempts))
+	if err := w.db.StoreWebhookDelivery(wd, false); err != nil {
+		glog.Error("webhooks: store delivery ", wd.ID, " error ", err)
+	}
+}
+
+func (w *Webhooks) removeFromQueue(id uint64) {
+	w.queueLock.Lock()
+	delete(w.queue, id)
+	w.updateQueueMetrics()
+	w.queueLock.Unlock()
+}
+
+// webhookRetryDelay returns the exponential backoff delay after the given number of failed attempts
+func webhookRetryDelay(attempts int) time.Duration {
+	d := webhookRetryBase
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= webhookRetryMax {
+			return webhookRetryMax
+		}
+	}
+	return d
+}
@@ -0,0 +1,66 @@
+// +build unittest
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/trezor/blockbook/db"
+)
+
+func Test_webhookSignature(t *testing.T) {
+	// expected value computed by `echo -n '{"event":"mempool"}' | openssl dgst -sha256 -hmac secret`
+	got := webhookSignature("secret", []byte(`{"event":"mempool"}`))
+	want := "sha256=25e4e44f3c778c09ec54b5bce659906ba369960d7aded6d3431a89b70d88cdfc"
+	if got != want {
+		t.Errorf("webhookSignature() = %v, want %v", got, want)
+	}
+}
+
+func Test_webhookRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, 5 * time.Second},
+		{2, 10 * time.Second},
+		{3, 20 * time.Second},
+		{10, 2560 * time.Second},
+		{11, time.Hour},
+		{100, time.Hour},
+	}
+	for _, tt := range tests {
+		if got := webhookRetryDelay(tt.attempts); got != tt.want {
+			t.Errorf("webhookRetryDelay(%v) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func Test_Webhooks_GetWatchesRedactsSecret(t *testing.T) {
+	w := &Webhooks{watches: map[string][]db.WebhookWatch{
+		"ad": {{Address: "addr1", URL: "https://example.com/hook", Secret: "secret"}},
+	}}
+	got := w.GetWatches()
+	if len(got) != 1 || got[0].Secret != "" || got[0].URL != "https://example.com/hook" {
+		t.Errorf("GetWatches() = %+v, want the watch without secret", got)
+	}
+	if w.watches["ad"][0].Secret != "secret" {
+		t.Error("GetWatches() modified the stored watch")
+	}
+}
+
+func Test_Webhooks_CloseTimeout(t *testing.T) {
+	w := &Webhooks{
+		chanStop:    make(chan struct{}),
+		chanStopped: make(chan struct{}, 2),
+	}
+	// only one of the loops stops, Close must give up when the context ends
+	w.chanStopped <- struct{}{}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := w.Close(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Close() = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
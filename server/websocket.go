@@ -2,9 +2,11 @@ package server
 
 import (
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"net/http"
 	"runtime/debug"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -24,9 +26,28 @@ const upgradeFailed = "Upgrade failed: "
 const outChannelSize = 500
 const defaultTimeout = 60 * time.Second
 
+// when the output channel of a connection is filled above coalesceThreshold, only the latest of the coalescable notifications
+// (new block, fiat rates) of each subscription is kept and it is sent when the client catches up
+const coalesceThreshold = outChannelSize / 4
+
+// when the output channel of a connection is filled above slowConsumerThreshold, the client is warned that it will be disconnected
+const slowConsumerThreshold = outChannelSize * 3 / 4
+
+// slowConsumerMessageID is the id of the warning sent to a slow client
+const slowConsumerMessageID = "slowConsumer"
+
 // allRates is a special "currency" parameter that means all available currencies
 const allFiatRates = "!ALL!"
 
+// maxTrackedTxs is the maximum number of txs tracked for the confirmations notifications per connection
+const maxTrackedTxs = 1000
+
+// trackedTxExpiration is the time after which a tracked tx which was not included in a block is no longer tracked
+const trackedTxExpiration = 24 * time.Hour
+
+// maxTrackedBlocksScan is the maximum number of the new blocks searched for the tracked txs at once
+const maxTrackedBlocksScan = 10
+
 var (
 	// ErrorMethodNotAllowed is returned when client tries to upgrade method other than GET
 	ErrorMethodNotAllowed = errors.New("Method not allowed")
@@ -46,14 +67,93 @@ type websocketRes struct {
 }
 
 type websocketChannel struct {
-	id            uint64
-	conn          *websocket.Conn
-	out           chan *websocketRes
-	ip            string
-	requestHeader http.Header
-	alive         bool
-	aliveLock     sync.Mutex
-	addrDescs     []string // subscribed address descriptors as strings
+	id                uint64
+	conn              *websocket.Conn
+	out               chan *websocketRes
+	ip                string
+	requestHeader     http.Header
+	apiKey            string
+	alive             bool
+	aliveLock         sync.Mutex
+	addrDescs         []string              // subscribed address descriptors as strings
+	addrConfirmations uint32                // confirmations target of the subscribed addresses, values 0 and 1 mean no confirmations notifications
+	trackedTxs        map[string]*trackedTx // txs of the subscribed addresses waiting for the confirmations target, guarded by addressSubscriptionsLock
+	accountDescs      []string              // address descriptors of the subscribed accounts as strings
+	contractDescs     []string              // subscribed contract descriptors as strings
+	session           *websocketSession
+	replaying         bool // the session is being restored, the messages are postponed
+	pending           []*websocketRes
+	metrics           *common.Metrics
+	coalesced         map[string]*websocketRes // the latest coalescable notifications waiting until the client catches up
+	coalescedIDs      []string                 // the order of the coalesced notifications
+	slowConsumer      bool                     // the client was warned that it does not read the messages fast enough
+	overflow          bool                     // the connection is being closed because of the overflow
+}
+
+// mempoolStatsSubscription is a subscribeMempoolStats subscription with the number of the sent projected blocks
+type mempoolStatsSubscription struct {
+	id     string
+	blocks int
+}
+
+// newTxSubscription is a subscribeNewTransaction subscription, nil filter means all transactions
+type newTxSubscription struct {
+	id     string
+	filter *newTxFilter
+}
+
+type slowConsumerWarning struct {
+	Warning string `json:"warning"`
+	Pending int    `json:"pending"`
+}
+
+// trackedTx is a tx of the subscribed addresses waiting until it reaches the confirmations target of the subscription
+type trackedTx struct {
+	id        string
+	addresses map[string]string // address descriptor -> address
+	height    uint32            // 0 if the tx is not in a block
+	added     time.Time
+}
+
+// txConfirmationsEvent is a notification about a tracked tx which reached the confirmations target or which was reverted by a reorg
+type txConfirmationsEvent struct {
+	Address       string `json:"address"`
+	Txid          string `json:"txid"`
+	Event         string `json:"event"`
+	BlockHeight   uint32 `json:"blockHeight"`
+	Confirmations uint32 `json:"confirmations"`
+}
+
+// txReplacedEvent is a notification about a mempool tx of a subscribed address replaced by a conflicting tx or dropped from mempool
+type txReplacedEvent struct {
+	Address    string `json:"address"`
+	Txid       string `json:"txid"`
+	Event      string `json:"event"`
+	ReplacedBy string `json:"replacedBy,omitempty"`
+}
+
+const (
+	txEventConfirmed = "confirmed"
+	txEventReverted  = "reverted"
+	txEventReplaced  = "replaced"
+	txEventDropped   = "dropped"
+)
+
+// accountSubscription is an xpub subscribed by subscribeAccounts, its watched addresses are extended as they get used
+type accountSubscription struct {
+	c          *websocketChannel
+	id         string
+	descriptor string
+	basePath   string
+	gap        int
+	derived    [2]int // number of derived addresses in the receive and change chain
+}
+
+// accountAddress is a watched address of a subscribed account
+type accountAddress struct {
+	account *accountSubscription
+	change  int
+	index   int
 }
 
 // WebsocketServer is a handle to websocket server
@@ -72,16 +172,30 @@ type WebsocketServer struct {
 	newBlockSubscriptions           map[*websocketChannel]string
 	newBlockSubscriptionsLock       sync.Mutex
 	newTransactionEnabled           bool
-	newTransactionSubscriptions     map[*websocketChannel]string
+	newTransactionSubscriptions     map[*websocketChannel]*newTxSubscription
 	newTransactionSubscriptionsLock sync.Mutex
 	addressSubscriptions            map[string]map[*websocketChannel]string
 	addressSubscriptionsLock        sync.Mutex
+	trackingChannels                map[*websocketChannel]struct{}
+	trackedHeight                   uint32                                // the last block searched for the tracked txs, guarded by addressSubscriptionsLock
+	blockTxids                      func(height uint32) ([]string, error) // returns the txids of the block, nil if not available
+	accountSubscriptions            map[string]map[*websocketChannel]*accountAddress
+	accountSubscriptionsLock        sync.Mutex
+	contractSubscriptions           map[string]map[*websocketChannel]string
+	contractSubscriptionsLock       sync.Mutex
 	fiatRatesSubscriptions          map[string]map[*websocketChannel]string
 	fiatRatesSubscriptionsLock      sync.Mutex
+	mempoolStatsSubscriptions       map[*websocketChannel]*mempoolStatsSubscription
+	mempoolStatsSubscriptionsLock   sync.Mutex
+	rateLimiter                     *RateLimiter
+	sessionTTL                      time.Duration
+	sessions                        map[string]*websocketSession
+	sessionsLock                    sync.Mutex
 }
 
 // NewWebsocketServer creates new websocket interface to blockbook and returns its handle
-func NewWebsocketServer(db *db.RocksDB, chain bchain.BlockChain, mempool bchain.Mempool, txCache *db.TxCache, metrics *common.Metrics, is *common.InternalState, enableSubNewTx bool) (*WebsocketServer, error) {
+// if sessionTTL is positive, the clients can request resumable sessions, which are kept for sessionTTL after disconnect
+func NewWebsocketServer(db *db.RocksDB, chain bchain.BlockChain, mempool bchain.Mempool, txCache *db.TxCache, metrics *common.Metrics, is *common.InternalState, enableSubNewTx bool, rateLimiter *RateLimiter, sessionTTL time.Duration) (*WebsocketServer, error) {
 	api, err := api.NewWorker(db, chain, mempool, txCache, metrics, is)
 	if err != nil {
 		return nil, err
@@ -92,9 +206,10 @@ func NewWebsocketServer(db *db.RocksDB, chain bchain.BlockChain, mempool bchain.
 	}
 	s := &WebsocketServer{
 		upgrader: &websocket.Upgrader{
-			ReadBufferSize:  1024 * 32,
-			WriteBufferSize: 1024 * 32,
-			CheckOrigin:     checkOrigin,
+			ReadBufferSize:    1024 * 32,
+			WriteBufferSize:   1024 * 32,
+			CheckOrigin:       checkOrigin,
+			EnableCompression: true,
 		},
 		db:                          db,
 		txCache:                     txCache,
@@ -107,10 +222,18 @@ func NewWebsocketServer(db *db.RocksDB, chain bchain.BlockChain, mempool bchain.
 		block0hash:                  b0,
 		newBlockSubscriptions:       make(map[*websocketChannel]string),
 		newTransactionEnabled:       enableSubNewTx,
-		newTransactionSubscriptions: make(map[*websocketChannel]string),
+		newTransactionSubscriptions: make(map[*websocketChannel]*newTxSubscription),
 		addressSubscriptions:        make(map[string]map[*websocketChannel]string),
+		trackingChannels:            make(map[*websocketChannel]struct{}),
+		accountSubscriptions:        make(map[string]map[*websocketChannel]*accountAddress),
+		contractSubscriptions:       make(map[string]map[*websocketChannel]string),
 		fiatRatesSubscriptions:      make(map[string]map[*websocketChannel]string),
+		mempoolStatsSubscriptions:   make(map[*websocketChannel]*mempoolStatsSubscription),
+		rateLimiter:                 rateLimiter,
+		sessionTTL:                  sessionTTL,
+		sessions:                    make(map[string]*websocketSession),
 	}
+	s.blockTxids = s.getBlockTxids
 	return s, nil
 }
 
@@ -133,6 +256,25 @@ func (s *WebsocketServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, upgradeFailed+ErrorMethodNotAllowed.Error(), 503)
 		return
 	}
+	apiKey := getAPIKey(r)
+	if s.rateLimiter != nil {
+		if err := s.rateLimiter.CheckAPIKey(apiKey); err != nil {
+			s.metrics.ErrorResponses.With(common.Labels{"interface": "websocket", "code": strconv.Itoa(http.StatusUnauthorized)}).Inc()
+			http.Error(w, upgradeFailed+err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+	var session *websocketSession
+	var resumed bool
+	if s.sessionTTL > 0 {
+		if token, ok := r.URL.Query()[sessionParam]; ok {
+			var err error
+			if session, resumed, err = s.getSession(token[0]); err != nil {
+				http.Error(w, upgradeFailed+err.Error(), 503)
+				return
+			}
+		}
+	}
 	conn, err := s.upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		http.Error(w, upgradeFailed+err.Error(), 503)
@@ -144,11 +286,18 @@ func (s *WebsocketServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		out:           make(chan *websocketRes, outChannelSize),
 		ip:            getIP(r),
 		requestHeader: r.Header,
+		apiKey:        apiKey,
 		alive:         true,
+		session:       session,
+		replaying:     session != nil,
+		metrics:       s.metrics,
 	}
 	go s.inputLoop(c)
 	go s.outputLoop(c)
 	s.onConnect(c)
+	if session != nil {
+		go s.attachSession(c, session, resumed)
+	}
 }
 
 // GetHandler returns http handler
@@ -157,22 +306,41 @@ func (s *WebsocketServer) GetHandler() http.Handler {
 }
 
 func (s *WebsocketServer) closeChannel(c *websocketChannel) {
-	if c.CloseOut() {
+	detached := false
+	if c.CloseOut(func() {
+		// the session must be detached before the channel stops accepting the messages, otherwise the notifications sent in between would be lost
+		detached = c.session != nil && s.detachSession(c)
+	}) {
 		c.conn.Close()
-		s.onDisconnect(c)
+		s.onDisconnect(c, detached)
 	}
 }
 
-func (c *websocketChannel) CloseOut() bool {
+// CloseOut closes the output of the channel, detach is called before the channel is closed, with aliveLock
+// the unsent notifications of the detached session are kept in the session buffer
+func (c *websocketChannel) CloseOut(detach func()) bool {
 	c.aliveLock.Lock()
 	defer c.aliveLock.Unlock()
 	if c.alive {
+		if detach != nil {
+			detach()
+		}
 		c.alive = false
 		//clean out
 		close(c.out)
 		for len(c.out) > 0 {
-			<-c.out
+			data := <-c.out
+			if c.session != nil {
+				c.session.store(data)
+			}
+		}
+		if c.session != nil {
+			for _, id := range c.coalescedIDs {
+				c.session.store(c.coalesced[id])
+			}
 		}
+		c.coalesced = nil
+		c.coalescedIDs = nil
 		return true
 	}
 	return false
@@ -181,16 +349,119 @@ func (c *websocketChannel) CloseOut() bool {
 func (c *websocketChannel) DataOut(data *websocketRes) {
 	c.aliveLock.Lock()
 	defer c.aliveLock.Unlock()
+	c.dataOut(data)
+}
+
+// DataOutCoalesce sends a notification which is replaced by a newer notification of the same subscription
+// if the client does not read the messages fast enough
+func (c *websocketChannel) DataOutCoalesce(data *websocketRes) {
+	c.aliveLock.Lock()
+	defer c.aliveLock.Unlock()
+	// once there is a coalesced notification, the following ones must be coalesced too to keep their order
+	if c.alive && !c.replaying && !c.overflow && (len(c.out) >= coalesceThreshold || len(c.coalesced) > 0) {
+		if c.coalesced == nil {
+			c.coalesced = make(map[string]*websocketRes)
+		}
+		if _, ok := c.coalesced[data.ID]; ok {
+			c.metrics.WebsocketCoalesced.Inc()
+		} else {
+			c.coalescedIDs = append(c.coalescedIDs, data.ID)
+		}
+		c.coalesced[data.ID] = data
+		return
+	}
+	c.dataOut(data)
+}
+
+// dataOut can be called only with aliveLock
+func (c *websocketChannel) dataOut(data *websocketRes) {
 	if c.alive {
-		if len(c.out) < outChannelSize-1 {
-			c.out <- data
+		if c.replaying {
+			if len(c.pending) < maxPendingMessages {
+				c.pending = append(c.pending, data)
+			} else {
+				glog.Warning("Channel ", c.id, " overflow during session restore, closing")
+				c.conn.Close()
+			}
 		} else {
-			glog.Warning("Channel ", c.id, " overflow, closing")
-			// close the connection but do not call CloseOut - would call duplicate c.aliveLock.Lock
-			// CloseOut will be called because the closed connection will cause break in the inputLoop
-			c.conn.Close()
+			c.sendOut(data)
 		}
+	} else if c.session != nil {
+		// keep the notifications of the detached session until it is resumed
+		c.session.store(data)
+	}
+}
+
+// sendOut writes the message to the output channel, can be called only with aliveLock and alive channel
+// a slow client is first warned and disconnected only if the output channel gets full
+func (c *websocketChannel) sendOut(data *websocketRes) bool {
+	if c.overflow {
+		c.metrics.WebsocketDropped.Inc()
+		return false
+	}
+	l := len(c.out)
+	if l < outChannelSize-2 {
+		if l >= slowConsumerThreshold && !c.slowConsumer {
+			c.slowConsumer = true
+			c.metrics.WebsocketSlowConsumers.Inc()
+			glog.Warning("Channel ", c.id, " slow consumer, ", l, " messages pending")
+			c.out <- &websocketRes{
+				ID: slowConsumerMessageID,
+				Data: &slowConsumerWarning{
+					Warning: "Messages are not read fast enough, the connection will be closed if the client does not catch up",
+					Pending: l,
+				},
+			}
+		}
+		c.out <- data
+		return true
 	}
+	c.overflow = true
+	dropped := l + len(c.coalesced) + 1
+	c.metrics.WebsocketDropped.Add(float64(dropped))
+	glog.Warning("Channel ", c.id, " overflow, closing, ", dropped, " messages dropped")
+	// close the connection but do not call CloseOut - would call duplicate c.aliveLock.Lock
+	// CloseOut will be called because the closed connection will cause break in the inputLoop
+	c.conn.Close()
+	return false
+}
+
+// takeCoalesced returns the coalesced notifications if the client caught up with the other messages
+func (c *websocketChannel) takeCoalesced() []*websocketRes {
+	if len(c.out) >= coalesceThreshold {
+		return nil
+	}
+	c.aliveLock.Lock()
+	defer c.aliveLock.Unlock()
+	c.slowConsumer = false
+	if len(c.coalesced) == 0 {
+		return nil
+	}
+	r := make([]*websocketRes, len(c.coalescedIDs))
+	for i, id := range c.coalescedIDs {
+		r[i] = c.coalesced[id]
+	}
+	c.coalesced = nil
+	c.coalescedIDs = nil
+	return r
+}
+
+// flushPending sends the messages followed by the messages postponed during the session restore and ends the restore
+func (c *websocketChannel) flushPending(msgs []*websocketRes) bool {
+	c.aliveLock.Lock()
+	defer c.aliveLock.Unlock()
+	if !c.alive {
+		return false
+	}
+	msgs = append(msgs, c.pending...)
+	c.pending = nil
+	c.replaying = false
+	for _, m := range msgs {
+		if !c.sendOut(m) {
+			break
+		}
+	}
+	return true
 }
 
 func (s *WebsocketServer) inputLoop(c *websocketChannel) {
@@ -216,6 +487,18 @@ func (s *WebsocketServer) inputLoop(c *websocketChannel) {
 				s.closeChannel(c)
 				return
 			}
+			if s.rateLimiter != nil {
+				if _, err := s.rateLimiter.Allow(c.apiKey, c.ip, req.Method, "websocket"); err != nil {
+					s.metrics.ErrorResponses.With(common.Labels{"interface": "websocket", "code": "throttled"}).Inc()
+					e := resultError{}
+					e.Error.Message = err.Error()
+					c.DataOut(&websocketRes{
+						ID:   req.ID,
+						Data: e,
+					})
+					continue
+				}
+			}
 			go s.onRequest(c, &req)
 		case websocket.BinaryMessage:
 			glog.Error("Binary message received from ", c.id, ", ", c.ip)
@@ -247,6 +530,13 @@ func (s *WebsocketServer) outputLoop(c *websocketChannel) {
 			s.closeChannel(c)
 			return
 		}
+		for _, m = range c.takeCoalesced() {
+			if err = c.conn.WriteJSON(m); err != nil {
+				glog.Error("Error sending message to ", c.id, ", ", err)
+				s.closeChannel(c)
+				return
+			}
+		}
 	}
 }
 
@@ -255,13 +545,25 @@ func (s *WebsocketServer) onConnect(c *websocketChannel) {
 	s.metrics.WebsocketClients.Inc()
 }
 
-func (s *WebsocketServer) onDisconnect(c *websocketChannel) {
+func (s *WebsocketServer) onDisconnect(c *websocketChannel, detached bool) {
+	if detached {
+		glog.Info("Client disconnected ", c.id, ", ", c.ip, ", session ", c.session.token, " kept for ", s.sessionTTL)
+	} else {
+		s.unsubscribeAll(c)
+		glog.Info("Client disconnected ", c.id, ", ", c.ip)
+	}
+	s.metrics.WebsocketClients.Dec()
+}
+
+// unsubscribeAll removes all subscriptions of the channel
+func (s *WebsocketServer) unsubscribeAll(c *websocketChannel) {
 	s.unsubscribeNewBlock(c)
 	s.unsubscribeNewTransaction(c)
 	s.unsubscribeAddresses(c)
+	s.unsubscribeAccounts(c)
+	s.unsubscribeContracts(c)
 	s.unsubscribeFiatRates(c)
-	glog.Info("Client disconnected ", c.id, ", ", c.ip)
-	s.metrics.WebsocketClients.Dec()
+	s.unsubscribeMempoolStats(c)
 }
 
 var requestHandlers = map[string]func(*WebsocketServer, *websocketChannel, *websocketReq) (interface{}, error){
@@ -345,6 +647,16 @@ var requestHandlers = map[string]func(*WebsocketServer, *websocketChannel, *webs
 	"estimateFee": func(s *WebsocketServer, c *websocketChannel, req *websocketReq) (rv interface{}, err error) {
 		return s.estimateFee(c, req.Params)
 	},
+	"simulateTransaction": func(s *WebsocketServer, c *websocketChannel, req *websocketReq) (rv interface{}, err error) {
+		r := struct {
+			Specific map[string]interface{} `json:"specific"`
+		}{}
+		err = json.Unmarshal(req.Params, &r)
+		if err == nil {
+			rv, err = s.api.SimulateTransaction(r.Specific)
+		}
+		return
+	},
 	"sendTransaction": func(s *WebsocketServer, c *websocketChannel, req *websocketReq) (rv interface{}, err error) {
 		r := struct {
 			Hex string `json:"hex"`
@@ -368,15 +680,42 @@ var requestHandlers = map[string]func(*WebsocketServer, *websocketChannel, *webs
 		return s.unsubscribeNewTransaction(c)
 	},
 	"subscribeAddresses": func(s *WebsocketServer, c *websocketChannel, req *websocketReq) (rv interface{}, err error) {
-		ad, err := s.unmarshalAddresses(req.Params)
+		ad, confirmations, err := s.unmarshalAddresses(req.Params)
 		if err == nil {
-			rv, err = s.subscribeAddresses(c, ad, req)
+			rv, err = s.subscribeAddresses(c, ad, confirmations, req)
 		}
 		return
 	},
 	"unsubscribeAddresses": func(s *WebsocketServer, c *websocketChannel, req *websocketReq) (rv interface{}, err error) {
 		return s.unsubscribeAddresses(c)
 	},
+	"subscribeAccounts": func(s *WebsocketServer, c *websocketChannel, req *websocketReq) (rv interface{}, err error) {
+		r := struct {
+			Descriptors []string `json:"descriptors"`
+			Gap         int      `json:"gap"`
+		}{}
+		err = json.Unmarshal(req.Params, &r)
+		if err == nil {
+			rv, err = s.subscribeAccounts(c, r.Descriptors, r.Gap, req)
+		}
+		return
+	},
+	"unsubscribeAccounts": func(s *WebsocketServer, c *websocketChannel, req *websocketReq) (rv interface{}, err error) {
+		return s.unsubscribeAccounts(c)
+	},
+	"subscribeContracts": func(s *WebsocketServer, c *websocketChannel, req *websocketReq) (rv interface{}, err error) {
+		r := struct {
+			Contracts []string `json:"contracts"`
+		}{}
+		err = json.Unmarshal(req.Params, &r)
+		if err == nil {
+			rv, err = s.subscribeContracts(c, r.Contracts, req)
+		}
+		return
+	},
+	"unsubscribeContracts": func(s *WebsocketServer, c *websocketChannel, req *websocketReq) (rv interface{}, err error) {
+		return s.unsubscribeContracts(c)
+	},
 	"subscribeFiatRates": func(s *WebsocketServer, c *websocketChannel, req *websocketReq) (rv interface{}, err error) {
 		r := struct {
 			Currency string `json:"currency"`
@@ -390,6 +729,21 @@ var requestHandlers = map[string]func(*WebsocketServer, *websocketChannel, *webs
 	"unsubscribeFiatRates": func(s *WebsocketServer, c *websocketChannel, req *websocketReq) (rv interface{}, err error) {
 		return s.unsubscribeFiatRates(c)
 	},
+	"subscribeMempoolStats": func(s *WebsocketServer, c *websocketChannel, req *websocketReq) (rv interface{}, err error) {
+		r := struct {
+			Blocks int `json:"blocks"`
+		}{}
+		if len(req.Params) > 0 {
+			err = json.Unmarshal(req.Params, &r)
+		}
+		if err == nil {
+			rv, err = s.subscribeMempoolStats(c, r.Blocks, req)
+		}
+		return
+	},
+	"unsubscribeMempoolStats": func(s *WebsocketServer, c *websocketChannel, req *websocketReq) (rv interface{}, err error) {
+		return s.unsubscribeMempoolStats(c)
+	},
 	"ping": func(s *WebsocketServer, c *websocketChannel, req *websocketReq) (rv interface{}, err error) {
 		r := struct{}{}
 		return r, nil
@@ -454,6 +808,9 @@ func (s *WebsocketServer) onRequest(c *websocketChannel, req *websocketReq) {
 	if ok {
 		data, err = f(s, c, req)
 		if err == nil {
+			if c.session != nil {
+				c.session.onRequest(req)
+			}
 			glog.V(1).Info("Client ", c.id, " onRequest ", req.Method, " success")
 			s.metrics.WebsocketRequests.With(common.Labels{"method": req.Method, "status": "success"}).Inc()
 		} else {
@@ -461,6 +818,7 @@ func (s *WebsocketServer) onRequest(c *websocketChannel, req *websocketReq) {
 				glog.Error("Client ", c.id, " onMessage ", req.Method, ": ", errors.ErrorStack(err), ", data ", string(req.Params))
 			}
 			s.metrics.WebsocketRequests.With(common.Labels{"method": req.Method, "status": "failure"}).Inc()
+			s.metrics.ErrorResponses.With(common.Labels{"interface": "websocket", "code": "error"}).Inc()
 			e := resultError{}
 			e.Error.Message = err.Error()
 			data = e
@@ -607,10 +965,16 @@ func (s *WebsocketServer) estimateFee(c *websocketChannel, params []byte) (inter
 		Blocks   []int                  `json:"blocks"`
 		Specific map[string]interface{} `json:"specific"`
 	}
+	type eip1559Fee struct {
+		MaxFeePerGas         string `json:"maxFeePerGas"`
+		MaxPriorityFeePerGas string `json:"maxPriorityFeePerGas"`
+		BaseFeePerGas        string `json:"baseFeePerGas"`
+	}
 	type estimateFeeRes struct {
-		FeePerTx   string `json:"feePerTx,omitempty"`
-		FeePerUnit string `json:"feePerUnit,omitempty"`
-		FeeLimit   string `json:"feeLimit,omitempty"`
+		FeePerTx   string      `json:"feePerTx,omitempty"`
+		FeePerUnit string      `json:"feePerUnit,omitempty"`
+		FeeLimit   string      `json:"feeLimit,omitempty"`
+		Eip1559    *eip1559Fee `json:"eip1559,omitempty"`
 	}
 	var r estimateFeeReq
 	err := json.Unmarshal(params, &r)
@@ -624,10 +988,26 @@ func (s *WebsocketServer) estimateFee(c *websocketChannel, params []byte) (inter
 			return nil, err
 		}
 		sg := strconv.FormatUint(gas, 10)
+		// chains without EIP-1559 return only the legacy gas price
+		fees, err := s.chain.EthereumTypeGetEip1559Fees()
+		if err != nil {
+			fees = nil
+		}
 		for i, b := range r.Blocks {
-			fee, err := s.chain.EstimateSmartFee(b, true)
-			if err != nil {
-				return nil, err
+			var fee big.Int
+			if fees != nil {
+				f := fees.ForBlocks(b)
+				fee.Add(&fees.BaseFeePerGas, &f.MaxPriorityFeePerGas)
+				res[i].Eip1559 = &eip1559Fee{
+					MaxFeePerGas:         f.MaxFeePerGas.String(),
+					MaxPriorityFeePerGas: f.MaxPriorityFeePerGas.String(),
+					BaseFeePerGas:        fees.BaseFeePerGas.String(),
+				}
+			} else {
+				fee, err = s.chain.EstimateSmartFee(b, true)
+				if err != nil {
+					return nil, err
+				}
 			}
 			res[i].FeePerUnit = fee.String()
 			res[i].FeeLimit = sg
@@ -707,7 +1087,11 @@ func (s *WebsocketServer) subscribeNewTransaction(c *websocketChannel, req *webs
 	if !s.newTransactionEnabled {
 		return &subscriptionResponseMessage{false, "subscribeNewTransaction not enabled, use -enablesubnewtx flag to enable."}, nil
 	}
-	s.newTransactionSubscriptions[c] = req.ID
+	filter, err := s.unmarshalNewTxFilter(req.Params)
+	if err != nil {
+		return nil, err
+	}
+	s.newTransactionSubscriptions[c] = &newTxSubscription{id: req.ID, filter: filter}
 	s.metrics.WebsocketSubscribes.With((common.Labels{"method": "subscribeNewTransaction"})).Set(float64(len(s.newTransactionSubscriptions)))
 	return &subscriptionResponse{true}, nil
 }
@@ -723,23 +1107,24 @@ func (s *WebsocketServer) unsubscribeNewTransaction(c *websocketChannel) (res in
 	return &subscriptionResponse{false}, nil
 }
 
-func (s *WebsocketServer) unmarshalAddresses(params []byte) ([]string, error) {
+func (s *WebsocketServer) unmarshalAddresses(params []byte) ([]string, uint32, error) {
 	r := struct {
-		Addresses []string `json:"addresses"`
+		Addresses     []string `json:"addresses"`
+		Confirmations uint32   `json:"confirmations"`
 	}{}
 	err := json.Unmarshal(params, &r)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 	rv := make([]string, len(r.Addresses))
 	for i, a := range r.Addresses {
 		ad, err := s.chainParser.GetAddrDescFromAddress(a)
 		if err != nil {
-			return nil, err
+			return nil, 0, err
 		}
 		rv[i] = string(ad)
 	}
-	return rv, nil
+	return rv, r.Confirmations, nil
 }
 
 // unsubscribe addresses without addressSubscriptionsLock - can be called only from subscribeAddresses and unsubscribeAddresses
@@ -758,13 +1143,18 @@ func (s *WebsocketServer) doUnsubscribeAddresses(c *websocketChannel) {
 		}
 	}
 	c.addrDescs = nil
+	c.addrConfirmations = 0
+	c.trackedTxs = nil
+	delete(s.trackingChannels, c)
 }
 
-func (s *WebsocketServer) subscribeAddresses(c *websocketChannel, addrDesc []string, req *websocketReq) (res interface{}, err error) {
+func (s *WebsocketServer) subscribeAddresses(c *websocketChannel, addrDesc []string, confirmations uint32, req *websocketReq) (res interface{}, err error) {
 	s.addressSubscriptionsLock.Lock()
 	defer s.addressSubscriptionsLock.Unlock()
+	trackedTxs := c.trackedTxs
 	// unsubscribe all previous subscriptions
 	s.doUnsubscribeAddresses(c)
+	subscribed := make(map[string]struct{}, len(addrDesc))
 	for _, ads := range addrDesc {
 		as, ok := s.addressSubscriptions[ads]
 		if !ok {
@@ -772,8 +1162,28 @@ func (s *WebsocketServer) subscribeAddresses(c *websocketChannel, addrDesc []str
 			s.addressSubscriptions[ads] = as
 		}
 		as[c] = req.ID
+		subscribed[ads] = struct{}{}
 	}
 	c.addrDescs = addrDesc
+	if confirmations > 1 {
+		c.addrConfirmations = confirmations
+		// keep tracking the txs of the addresses which stay subscribed
+		for txid, t := range trackedTxs {
+			for ads := range t.addresses {
+				if _, ok := subscribed[ads]; !ok {
+					delete(t.addresses, ads)
+				}
+			}
+			if len(t.addresses) > 0 {
+				t.id = req.ID
+				if c.trackedTxs == nil {
+					c.trackedTxs = make(map[string]*trackedTx)
+				}
+				c.trackedTxs[txid] = t
+				s.trackingChannels[c] = struct{}{}
+			}
+		}
+	}
 	s.metrics.WebsocketSubscribes.With((common.Labels{"method": "subscribeAddresses"})).Set(float64(len(s.addressSubscriptions)))
 	return &subscriptionResponse{true}, nil
 }
@@ -787,6 +1197,157 @@ func (s *WebsocketServer) unsubscribeAddresses(c *websocketChannel) (res interfa
 	return &subscriptionResponse{false}, nil
 }
 
+// unsubscribe contracts without contractSubscriptionsLock - can be called only from subscribeContracts and unsubscribeContracts
+func (s *WebsocketServer) doUnsubscribeContracts(c *websocketChannel) {
+	for _, cds := range c.contractDescs {
+		sc, e := s.contractSubscriptions[cds]
+		if e {
+			delete(sc, c)
+			if len(sc) == 0 {
+				delete(s.contractSubscriptions, cds)
+			}
+		}
+	}
+	c.contractDescs = nil
+}
+
+// subscribeContracts subscribes the token transfers of the contracts, supported only by EthereumType and TronType coins
+func (s *WebsocketServer) subscribeContracts(c *websocketChannel, contracts []string, req *websocketReq) (res interface{}, err error) {
+	if ct := s.chainParser.GetChainType(); ct != bchain.ChainEthereumType && ct != bchain.ChainTronType {
+		return nil, api.NewAPIError("Contracts are not supported", true)
+	}
+	if len(contracts) == 0 {
+		return nil, api.NewAPIError("Missing contracts", true)
+	}
+	contractDescs := make([]string, len(contracts))
+	for i, contract := range contracts {
+		cd, err := s.chainParser.GetAddrDescFromAddress(contract)
+		if err != nil || len(cd) == 0 {
+			return nil, api.NewAPIError("Invalid contract "+contract, true)
+		}
+		contractDescs[i] = string(cd)
+	}
+	s.contractSubscriptionsLock.Lock()
+	defer s.contractSubscriptionsLock.Unlock()
+	// unsubscribe all previous subscriptions
+	s.doUnsubscribeContracts(c)
+	for _, cds := range contractDescs {
+		sc, ok := s.contractSubscriptions[cds]
+		if !ok {
+			sc = make(map[*websocketChannel]string)
+			s.contractSubscriptions[cds] = sc
+		}
+		sc[c] = req.ID
+	}
+	c.contractDescs = contractDescs
+	s.metrics.WebsocketSubscribes.With((common.Labels{"method": "subscribeContracts"})).Set(float64(len(s.contractSubscriptions)))
+	return &subscriptionResponse{true}, nil
+}
+
+// unsubscribeContracts unsubscribes all contract subscriptions by this channel
+func (s *WebsocketServer) unsubscribeContracts(c *websocketChannel) (res interface{}, err error) {
+	s.contractSubscriptionsLock.Lock()
+	defer s.contractSubscriptionsLock.Unlock()
+	s.doUnsubscribeContracts(c)
+	s.metrics.WebsocketSubscribes.With((common.Labels{"method": "subscribeContracts"})).Set(float64(len(s.contractSubscriptions)))
+	return &subscriptionResponse{false}, nil
+}
+
+// unsubscribe accounts without accountSubscriptionsLock - can be called only from subscribeAccounts and unsubscribeAccounts
+func (s *WebsocketServer) doUnsubscribeAccounts(c *websocketChannel) {
+	for _, ads := range c.accountDescs {
+		sa, e := s.accountSubscriptions[ads]
+		if e {
+			delete(sa, c)
+			if len(sa) == 0 {
+				delete(s.accountSubscriptions, ads)
+			}
+		}
+	}
+	c.accountDescs = nil
+}
+
+// addAccountAddress adds derived address to the watched addresses, can be called only with accountSubscriptionsLock
+func (s *WebsocketServer) addAccountAddress(a *accountSubscription, change int, index int, addrDesc bchain.AddressDescriptor) {
+	sad := string(addrDesc)
+	as, ok := s.accountSubscriptions[sad]
+	if !ok {
+		as = make(map[*websocketChannel]*accountAddress)
+		s.accountSubscriptions[sad] = as
+	}
+	as[a.c] = &accountAddress{account: a, change: change, index: index}
+	a.c.accountDescs = append(a.c.accountDescs, sad)
+	if index >= a.derived[change] {
+		a.derived[change] = index + 1
+	}
+}
+
+// extendAccount derives new addresses of the account so that there is always gap of unused addresses after the used address
+// can be called only with accountSubscriptionsLock
+func (s *WebsocketServer) extendAccount(aa *accountAddress) error {
+	a := aa.account
+	from := a.derived[aa.change]
+	to := aa.index + a.gap
+	if from >= to {
+		return nil
+	}
+	descriptors, err := s.chainParser.DeriveAddressDescriptorsFromTo(a.descriptor, uint32(aa.change), uint32(from), uint32(to))
+	if err != nil {
+		return err
+	}
+	for i, ad := range descriptors {
+		s.addAccountAddress(a, aa.change, from+i, ad)
+	}
+	return nil
+}
+
+// subscribeAccounts subscribes the addresses derived from the descriptors, replacing the previous account subscriptions of this channel
+func (s *WebsocketServer) subscribeAccounts(c *websocketChannel, descriptors []string, gap int, req *websocketReq) (res interface{}, err error) {
+	if len(descriptors) == 0 {
+		return nil, api.NewAPIError("Missing descriptors", true)
+	}
+	// the derivation may take some time, do it outside of the lock
+	derived := make([]*api.XpubDerivedAddresses, len(descriptors))
+	for i, descriptor := range descriptors {
+		derived[i], err = s.api.GetXpubDerivedAddresses(descriptor, gap)
+		if err != nil {
+			if err == api.ErrUnsupportedXpub {
+				return nil, api.NewAPIError(err.Error(), true)
+			}
+			return nil, api.NewAPIError("Invalid descriptor "+descriptor, true)
+		}
+	}
+	s.accountSubscriptionsLock.Lock()
+	defer s.accountSubscriptionsLock.Unlock()
+	// unsubscribe all previous subscriptions
+	s.doUnsubscribeAccounts(c)
+	for i, descriptor := range descriptors {
+		a := &accountSubscription{
+			c:          c,
+			id:         req.ID,
+			descriptor: descriptor,
+			basePath:   derived[i].BasePath,
+			gap:        derived[i].Gap,
+		}
+		for change, addresses := range derived[i].Addresses {
+			for index, ad := range addresses {
+				s.addAccountAddress(a, change, index, ad)
+			}
+		}
+	}
+	s.metrics.WebsocketSubscribes.With((common.Labels{"method": "subscribeAccounts"})).Set(float64(len(s.accountSubscriptions)))
+	return &subscriptionResponse{true}, nil
+}
+
+// unsubscribeAccounts unsubscribes all account subscriptions by this channel
+func (s *WebsocketServer) unsubscribeAccounts(c *websocketChannel) (res interface{}, err error) {
+	s.accountSubscriptionsLock.Lock()
+	defer s.accountSubscriptionsLock.Unlock()
+	s.doUnsubscribeAccounts(c)
+	s.metrics.WebsocketSubscribes.With((common.Labels{"method": "subscribeAccounts"})).Set(float64(len(s.accountSubscriptions)))
+	return &subscriptionResponse{false}, nil
+}
+
 // unsubscribe fiat rates without fiatRatesSubscriptionsLock - can be called only from subscribeFiatRates and unsubscribeFiatRates
 func (s *WebsocketServer) doUnsubscribeFiatRates(c *websocketChannel) {
 	for fr, sa := range s.fiatRatesSubscriptions {
@@ -829,6 +1390,66 @@ func (s *WebsocketServer) unsubscribeFiatRates(c *websocketChannel) (res interfa
 	return &subscriptionResponse{false}, nil
 }
 
+func (s *WebsocketServer) subscribeMempoolStats(c *websocketChannel, blocks int, req *websocketReq) (res interface{}, err error) {
+	if s.chainParser.GetChainType() != bchain.ChainBitcoinType {
+		return nil, api.NewAPIError("Mempool stats are not supported", true)
+	}
+	if blocks <= 0 {
+		blocks = api.DefaultMempoolStatsBlocks
+	} else if blocks > api.MaxMempoolStatsBlocks {
+		blocks = api.MaxMempoolStatsBlocks
+	}
+	s.mempoolStatsSubscriptionsLock.Lock()
+	defer s.mempoolStatsSubscriptionsLock.Unlock()
+	s.mempoolStatsSubscriptions[c] = &mempoolStatsSubscription{id: req.ID, blocks: blocks}
+	s.metrics.WebsocketSubscribes.With((common.Labels{"method": "subscribeMempoolStats"})).Set(float64(len(s.mempoolStatsSubscriptions)))
+	return &subscriptionResponse{true}, nil
+}
+
+// unsubscribeMempoolStats unsubscribes the mempool stats subscription by this channel
+func (s *WebsocketServer) unsubscribeMempoolStats(c *websocketChannel) (res interface{}, err error) {
+	s.mempoolStatsSubscriptionsLock.Lock()
+	defer s.mempoolStatsSubscriptionsLock.Unlock()
+	delete(s.mempoolStatsSubscriptions, c)
+	s.metrics.WebsocketSubscribes.With((common.Labels{"method": "subscribeMempoolStats"})).Set(float64(len(s.mempoolStatsSubscriptions)))
+	return &subscriptionResponse{false}, nil
+}
+
+func (s *WebsocketServer) sendMempoolStats(stats *api.MempoolStats) {
+	s.mempoolStatsSubscriptionsLock.Lock()
+	defer s.mempoolStatsSubscriptionsLock.Unlock()
+	for c, sub := range s.mempoolStatsSubscriptions {
+		data := *stats
+		if len(data.Blocks) > sub.blocks {
+			data.Blocks = data.Blocks[:sub.blocks]
+		}
+		c.DataOutCoalesce(&websocketRes{
+			ID:   sub.id,
+			Data: &data,
+		})
+	}
+	glog.Info("broadcasting mempool stats to ", len(s.mempoolStatsSubscriptions), " channels")
+}
+
+func (s *WebsocketServer) onMempoolResyncAsync() {
+	stats, err := s.api.GetMempoolStats(api.MaxMempoolStatsBlocks, true)
+	if err != nil {
+		glog.Error("GetMempoolStats error ", err)
+		return
+	}
+	s.sendMempoolStats(stats)
+}
+
+// OnMempoolResync is a callback that broadcasts the mempool stats to subscribed clients after the mempool synchronization
+func (s *WebsocketServer) OnMempoolResync() {
+	s.mempoolStatsSubscriptionsLock.Lock()
+	subscribed := len(s.mempoolStatsSubscriptions) > 0
+	s.mempoolStatsSubscriptionsLock.Unlock()
+	if subscribed {
+		go s.onMempoolResyncAsync()
+	}
+}
+
 func (s *WebsocketServer) onNewBlockAsync(hash string, height uint32) {
 	s.newBlockSubscriptionsLock.Lock()
 	defer s.newBlockSubscriptionsLock.Unlock()
@@ -840,7 +1461,7 @@ func (s *WebsocketServer) onNewBlockAsync(hash string, height uint32) {
 		Hash:   hash,
 	}
 	for c, id := range s.newBlockSubscriptions {
-		c.DataOut(&websocketRes{
+		c.DataOutCoalesce(&websocketRes{
 			ID:   id,
 			Data: &data,
 		})
@@ -851,18 +1472,33 @@ func (s *WebsocketServer) onNewBlockAsync(hash string, height uint32) {
 // OnNewBlock is a callback that broadcasts info about new block to subscribed clients
 func (s *WebsocketServer) OnNewBlock(hash string, height uint32) {
 	go s.onNewBlockAsync(hash, height)
+	go s.checkTrackedTxs(height)
 }
 
-func (s *WebsocketServer) sendOnNewTx(tx *api.Tx) {
+func (s *WebsocketServer) sendOnNewTx(tx *api.Tx, mtx *bchain.MempoolTx) {
 	s.newTransactionSubscriptionsLock.Lock()
 	defer s.newTransactionSubscriptionsLock.Unlock()
-	for c, id := range s.newTransactionSubscriptions {
+	// the data for the filters are computed only if there is a filtered subscription
+	var filterData *newTxFilterData
+	sent := 0
+	for c, sub := range s.newTransactionSubscriptions {
+		if sub.filter != nil {
+			if filterData == nil {
+				filterData = s.getNewTxFilterData(tx, mtx)
+			}
+			if !sub.filter.match(filterData) {
+				continue
+			}
+		}
 		c.DataOut(&websocketRes{
-			ID:   id,
+			ID:   sub.id,
 			Data: &tx,
 		})
+		sent++
+	}
+	if sent > 0 {
+		glog.Info("broadcasting new tx ", tx.Txid, " to ", sent, " channels")
 	}
-	glog.Info("broadcasting new tx ", tx.Txid, " to ", len(s.newTransactionSubscriptions), " channels")
 }
 
 func (s *WebsocketServer) sendOnNewTxAddr(stringAddressDescriptor string, tx *api.Tx) {
@@ -889,73 +1525,398 @@ func (s *WebsocketServer) sendOnNewTxAddr(stringAddressDescriptor string, tx *ap
 					ID:   id,
 					Data: &data,
 				})
+				if c.addrConfirmations > 1 {
+					s.trackTx(c, id, stringAddressDescriptor, addr[0], tx)
+				}
 			}
 			glog.Info("broadcasting new tx ", tx.Txid, ", addr ", addr[0], " to ", len(as), " channels")
 		}
 	}
 }
 
-func (s *WebsocketServer) getNewTxSubscriptions(tx *bchain.MempoolTx) map[string]struct{} {
-	// check if there is any subscription in inputs, outputs and erc20
+// trackTx starts or updates the tracking of the confirmations of a tx of a subscribed address, must be called with addressSubscriptionsLock
+func (s *WebsocketServer) trackTx(c *websocketChannel, id string, stringAddressDescriptor string, address string, tx *api.Tx) {
+	t, ok := c.trackedTxs[tx.Txid]
+	if !ok {
+		if len(c.trackedTxs) >= maxTrackedTxs {
+			glog.V(1).Info("Client ", c.id, " tracks too many txs, tx ", tx.Txid, " is not tracked")
+			return
+		}
+		if c.trackedTxs == nil {
+			c.trackedTxs = make(map[string]*trackedTx)
+		}
+		t = &trackedTx{id: id, addresses: make(map[string]string), added: time.Now()}
+		c.trackedTxs[tx.Txid] = t
+		s.trackingChannels[c] = struct{}{}
+	}
+	t.addresses[stringAddressDescriptor] = address
+	if tx.Blockheight > 0 {
+		t.height = uint32(tx.Blockheight)
+		_, bestHeight, _ := s.is.GetSyncState()
+		s.checkTrackedTx(c, tx.Txid, t, bestHeight)
+	}
+}
+
+func (s *WebsocketServer) untrackTx(c *websocketChannel, txid string) {
+	delete(c.trackedTxs, txid)
+	if len(c.trackedTxs) == 0 {
+		c.trackedTxs = nil
+		delete(s.trackingChannels, c)
+	}
+}
+
+func (s *WebsocketServer) sendTxConfirmationsEvent(c *websocketChannel, txid string, t *trackedTx, event string, confirmations uint32) {
+	for _, address := range t.addresses {
+		c.DataOut(&websocketRes{
+			ID: t.id,
+			Data: &txConfirmationsEvent{
+				Address:       address,
+				Txid:          txid,
+				Event:         event,
+				BlockHeight:   t.height,
+				Confirmations: confirmations,
+			},
+		})
+	}
+}
+
+// checkTrackedTx sends the confirmed notification and stops the tracking if the tx reached the confirmations target
+func (s *WebsocketServer) checkTrackedTx(c *websocketChannel, txid string, t *trackedTx, bestHeight uint32) {
+	if t.height == 0 || bestHeight < t.height {
+		return
+	}
+	confirmations := bestHeight - t.height + 1
+	if confirmations >= c.addrConfirmations {
+		s.sendTxConfirmationsEvent(c, txid, t, txEventConfirmed, confirmations)
+		s.untrackTx(c, txid)
+	}
+}
+
+// getBlockTxids returns the txids of the block at given height, nil if the txs of the block are not kept in db
+func (s *WebsocketServer) getBlockTxids(height uint32) ([]string, error) {
+	btas, err := s.db.GetBlockTxAddresses(height)
+	if err != nil || btas == nil {
+		return nil, err
+	}
+	txids := make([]string, len(btas))
+	for i := range btas {
+		txids[i] = btas[i].Txid
+	}
+	return txids, nil
+}
+
+// resolveTrackedTxs sets the height of the tracked txs included in the block, must be called with addressSubscriptionsLock
+func (s *WebsocketServer) resolveTrackedTxs(height uint32) {
+	txids, err := s.blockTxids(height)
+	if err != nil {
+		glog.Error("websocket: block ", height, " txids error ", err)
+		return
+	}
+	if txids == nil {
+		// the txs of the block are not available, try to find the tracked txs in the index (Bitcoin type only)
+		if s.chainParser.GetChainType() != bchain.ChainBitcoinType {
+			return
+		}
+		for c := range s.trackingChannels {
+			for txid, t := range c.trackedTxs {
+				if t.height == 0 {
+					if ta, err := s.db.GetTxAddresses(txid); err == nil && ta != nil {
+						t.height = ta.Height
+					}
+				}
+			}
+		}
+		return
+	}
+	for _, txid := range txids {
+		for c := range s.trackingChannels {
+			if t, ok := c.trackedTxs[txid]; ok && t.height == 0 {
+				t.height = height
+			}
+		}
+	}
+}
+
+// checkTrackedTxs finds the tracked txs in the new blocks and sends the confirmed notifications
+// the txs which were not included in a block within trackedTxExpiration are no longer tracked
+func (s *WebsocketServer) checkTrackedTxs(bestHeight uint32) {
 	s.addressSubscriptionsLock.Lock()
 	defer s.addressSubscriptionsLock.Unlock()
-	subscribed := make(map[string]struct{})
+	// the checks run in goroutines, a check of an older block may come after a newer block was already processed
+	if bestHeight < s.trackedHeight {
+		return
+	}
+	if len(s.trackingChannels) > 0 {
+		from := s.trackedHeight + 1
+		if s.trackedHeight == 0 || s.trackedHeight >= bestHeight || bestHeight-s.trackedHeight > maxTrackedBlocksScan {
+			from = bestHeight
+		}
+		for h := from; h <= bestHeight; h++ {
+			s.resolveTrackedTxs(h)
+		}
+	}
+	s.trackedHeight = bestHeight
+	now := time.Now()
+	for c := range s.trackingChannels {
+		for txid, t := range c.trackedTxs {
+			if t.height == 0 && now.Sub(t.added) > trackedTxExpiration {
+				s.untrackTx(c, txid)
+				continue
+			}
+			s.checkTrackedTx(c, txid, t, bestHeight)
+		}
+	}
+}
+
+// OnDisconnectBlocks is a callback that notifies the subscribed clients about the tracked txs reverted by a reorg
+func (s *WebsocketServer) OnDisconnectBlocks(lower uint32, higher uint32) {
+	s.addressSubscriptionsLock.Lock()
+	defer s.addressSubscriptionsLock.Unlock()
+	if lower > 0 && s.trackedHeight >= lower {
+		s.trackedHeight = lower - 1
+	}
+	reverted := 0
+	for c := range s.trackingChannels {
+		for txid, t := range c.trackedTxs {
+			if t.height >= lower && t.height <= higher {
+				s.sendTxConfirmationsEvent(c, txid, t, txEventReverted, 0)
+				s.untrackTx(c, txid)
+				reverted++
+			}
+		}
+	}
+	if reverted > 0 {
+		glog.Info("broadcasting ", reverted, " txs reverted in blocks ", lower, "-", higher)
+	}
+}
+
+// OnReplacedTx is a callback that notifies the clients subscribed to the addresses of a mempool tx replaced by a conflicting tx
+// or dropped from mempool without a replacement
+func (s *WebsocketServer) OnReplacedTx(replacement *bchain.MempoolReplacement, addrDescs []bchain.AddressDescriptor) {
+	event := txEventReplaced
+	if replacement.ReplacedBy == "" {
+		event = txEventDropped
+	}
+	s.addressSubscriptionsLock.Lock()
+	defer s.addressSubscriptionsLock.Unlock()
+	for _, addrDesc := range addrDescs {
+		as, ok := s.addressSubscriptions[string(addrDesc)]
+		if !ok || len(as) == 0 {
+			continue
+		}
+		addr, _, err := s.chainParser.GetAddressesFromAddrDesc(addrDesc)
+		if err != nil || len(addr) != 1 {
+			glog.Error("GetAddressesFromAddrDesc error ", err, " for ", addrDesc)
+			continue
+		}
+		for c, id := range as {
+			c.DataOut(&websocketRes{
+				ID: id,
+				Data: &txReplacedEvent{
+					Address:    addr[0],
+					Txid:       replacement.Txid,
+					Event:      event,
+					ReplacedBy: replacement.ReplacedBy,
+				},
+			})
+			// the replaced or dropped tx will never be confirmed
+			if _, tracked := c.trackedTxs[replacement.Txid]; tracked {
+				s.untrackTx(c, replacement.Txid)
+			}
+		}
+		glog.Info("broadcasting ", event, " tx ", replacement.Txid, ", addr ", addr[0], " to ", len(as), " channels")
+	}
+}
+
+// newTxSubscribed contains the descriptors of the tx subscribed by subscribeAddresses, subscribeAccounts and subscribeContracts
+type newTxSubscribed struct {
+	addresses map[string]struct{}
+	accounts  map[string]struct{}
+	contracts map[string]struct{}
+}
+
+func (ns *newTxSubscribed) empty() bool {
+	return len(ns.addresses) == 0 && len(ns.accounts) == 0 && len(ns.contracts) == 0
+}
+
+// getNewTxSubscriptions returns the descriptors of the tx subscribed by subscribeAddresses, subscribeAccounts and subscribeContracts
+func (s *WebsocketServer) getNewTxSubscriptions(tx *bchain.MempoolTx) *newTxSubscribed {
+	// check if there is any subscription in inputs, outputs and erc20
+	addrDescs := make([]string, 0, len(tx.Vin)+len(tx.Vout)+2*len(tx.Erc20))
 	for i := range tx.Vin {
 		sad := string(tx.Vin[i].AddrDesc)
 		if len(sad) > 0 {
-			as, ok := s.addressSubscriptions[sad]
-			if ok && len(as) > 0 {
-				subscribed[sad] = struct{}{}
-			}
+			addrDescs = append(addrDescs, sad)
 		}
 	}
 	for i := range tx.Vout {
 		addrDesc, err := s.chainParser.GetAddrDescFromVout(&tx.Vout[i])
 		if err == nil && len(addrDesc) > 0 {
-			sad := string(addrDesc)
-			as, ok := s.addressSubscriptions[sad]
-			if ok && len(as) > 0 {
-				subscribed[sad] = struct{}{}
-			}
+			addrDescs = append(addrDescs, string(addrDesc))
 		}
 	}
 	for i := range tx.Erc20 {
 		addrDesc, err := s.chainParser.GetAddrDescFromAddress(tx.Erc20[i].From)
 		if err == nil && len(addrDesc) > 0 {
-			sad := string(addrDesc)
-			as, ok := s.addressSubscriptions[sad]
-			if ok && len(as) > 0 {
-				subscribed[sad] = struct{}{}
-			}
+			addrDescs = append(addrDescs, string(addrDesc))
 		}
 		addrDesc, err = s.chainParser.GetAddrDescFromAddress(tx.Erc20[i].To)
 		if err == nil && len(addrDesc) > 0 {
-			sad := string(addrDesc)
-			as, ok := s.addressSubscriptions[sad]
-			if ok && len(as) > 0 {
-				subscribed[sad] = struct{}{}
+			addrDescs = append(addrDescs, string(addrDesc))
+		}
+	}
+	subscribed := make(map[string]struct{})
+	s.addressSubscriptionsLock.Lock()
+	for _, sad := range addrDescs {
+		as, ok := s.addressSubscriptions[sad]
+		if ok && len(as) > 0 {
+			subscribed[sad] = struct{}{}
+		}
+	}
+	s.addressSubscriptionsLock.Unlock()
+	subscribedAccounts := make(map[string]struct{})
+	s.accountSubscriptionsLock.Lock()
+	for _, sad := range addrDescs {
+		as, ok := s.accountSubscriptions[sad]
+		if ok && len(as) > 0 {
+			subscribedAccounts[sad] = struct{}{}
+		}
+	}
+	s.accountSubscriptionsLock.Unlock()
+	subscribedContracts := make(map[string]struct{})
+	s.contractSubscriptionsLock.Lock()
+	if len(s.contractSubscriptions) > 0 {
+		contracts := make([]string, 0, len(tx.Erc20)+len(tx.Trc20))
+		for i := range tx.Erc20 {
+			contracts = append(contracts, tx.Erc20[i].Contract)
+		}
+		for i := range tx.Trc20 {
+			contracts = append(contracts, tx.Trc20[i].Contract)
+		}
+		for _, contract := range contracts {
+			cd, err := s.chainParser.GetAddrDescFromAddress(contract)
+			if err == nil && len(cd) > 0 {
+				if sc, ok := s.contractSubscriptions[string(cd)]; ok && len(sc) > 0 {
+					subscribedContracts[string(cd)] = struct{}{}
+				}
 			}
 		}
 	}
-	return subscribed
+	s.contractSubscriptionsLock.Unlock()
+	return &newTxSubscribed{
+		addresses: subscribed,
+		accounts:  subscribedAccounts,
+		contracts: subscribedContracts,
+	}
 }
 
-func (s *WebsocketServer) onNewTxAsync(tx *bchain.MempoolTx, subscribed map[string]struct{}) {
+// sendOnNewTxContract sends the tx with token transfers of the contract to the channels subscribed to the contract
+func (s *WebsocketServer) sendOnNewTxContract(stringContractDescriptor string, tx *api.Tx) {
+	contract, _, err := s.chainParser.GetAddressesFromAddrDesc(bchain.AddressDescriptor(stringContractDescriptor))
+	if err != nil || len(contract) != 1 {
+		glog.Error("GetAddressesFromAddrDesc error ", err, " for contract ", bchain.AddressDescriptor(stringContractDescriptor))
+		return
+	}
+	data := struct {
+		Contract string  `json:"contract"`
+		Tx       *api.Tx `json:"tx"`
+	}{
+		Contract: contract[0],
+		Tx:       tx,
+	}
+	s.contractSubscriptionsLock.Lock()
+	defer s.contractSubscriptionsLock.Unlock()
+	sc, ok := s.contractSubscriptions[stringContractDescriptor]
+	if ok {
+		for c, id := range sc {
+			c.DataOut(&websocketRes{
+				ID:   id,
+				Data: &data,
+			})
+		}
+		glog.Info("broadcasting new tx ", tx.Txid, ", contract ", contract[0], " to ", len(sc), " channels")
+	}
+}
+
+type accountAddressNotification struct {
+	Address string `json:"address"`
+	Path    string `json:"path"`
+}
+
+type accountNotification struct {
+	Descriptor string                       `json:"descriptor"`
+	Addresses  []accountAddressNotification `json:"addresses"`
+	Tx         *api.Tx                      `json:"tx"`
+}
+
+// sendOnNewTxAccounts sends one notification per affected account, listing the affected addresses of the account with their derivation paths
+// the watched addresses of the accounts are extended by the gap after the used addresses
+func (s *WebsocketServer) sendOnNewTxAccounts(subscribedAccounts map[string]struct{}, tx *api.Tx) {
+	s.accountSubscriptionsLock.Lock()
+	defer s.accountSubscriptionsLock.Unlock()
+	var accounts []*accountSubscription
+	notifications := make(map[*accountSubscription]*accountNotification)
+	for sad := range subscribedAccounts {
+		as, ok := s.accountSubscriptions[sad]
+		if !ok {
+			continue
+		}
+		var address string
+		if a, _, err := s.chainParser.GetAddressesFromAddrDesc(bchain.AddressDescriptor(sad)); err == nil && len(a) == 1 {
+			address = a[0]
+		}
+		for _, aa := range as {
+			n, ok := notifications[aa.account]
+			if !ok {
+				n = &accountNotification{Descriptor: aa.account.descriptor, Tx: tx}
+				notifications[aa.account] = n
+				accounts = append(accounts, aa.account)
+			}
+			n.Addresses = append(n.Addresses, accountAddressNotification{
+				Address: address,
+				Path:    fmt.Sprintf("%s/%d/%d", aa.account.basePath, aa.change, aa.index),
+			})
+			if err := s.extendAccount(aa); err != nil {
+				glog.Error("DeriveAddressDescriptorsFromTo error ", err, " for ", aa.account.descriptor)
+			}
+		}
+	}
+	for _, a := range accounts {
+		n := notifications[a]
+		sort.Slice(n.Addresses, func(i, j int) bool { return n.Addresses[i].Path < n.Addresses[j].Path })
+		a.c.DataOut(&websocketRes{
+			ID:   a.id,
+			Data: n,
+		})
+	}
+	if len(accounts) > 0 {
+		s.metrics.WebsocketSubscribes.With((common.Labels{"method": "subscribeAccounts"})).Set(float64(len(s.accountSubscriptions)))
+		glog.Info("broadcasting new tx ", tx.Txid, " to ", len(accounts), " accounts")
+	}
+}
+
+func (s *WebsocketServer) onNewTxAsync(tx *bchain.MempoolTx, subscribed *newTxSubscribed) {
 	atx, err := s.api.GetTransactionFromMempoolTx(tx)
 	if err != nil {
 		glog.Error("GetTransactionFromMempoolTx error ", err, " for ", tx.Txid)
 		return
 	}
-	s.sendOnNewTx(atx)
-	for stringAddressDescriptor := range subscribed {
+	s.sendOnNewTx(atx, tx)
+	for stringAddressDescriptor := range subscribed.addresses {
 		s.sendOnNewTxAddr(stringAddressDescriptor, atx)
 	}
+	if len(subscribed.accounts) > 0 {
+		s.sendOnNewTxAccounts(subscribed.accounts, atx)
+	}
+	for stringContractDescriptor := range subscribed.contracts {
+		s.sendOnNewTxContract(stringContractDescriptor, atx)
+	}
 }
 
 // OnNewTx is a callback that broadcasts info about a tx affecting subscribed address
 func (s *WebsocketServer) OnNewTx(tx *bchain.MempoolTx) {
 	subscribed := s.getNewTxSubscriptions(tx)
-	if len(s.newTransactionSubscriptions) > 0 || len(subscribed) > 0 {
+	if len(s.newTransactionSubscriptions) > 0 || !subscribed.empty() {
 		go s.onNewTxAsync(tx, subscribed)
 	}
 }
@@ -969,7 +1930,7 @@ func (s *WebsocketServer) broadcastTicker(currency string, rates map[string]floa
 			Rates: rates,
 		}
 		for c, id := range as {
-			c.DataOut(&websocketRes{
+			c.DataOutCoalesce(&websocketRes{
 				ID:   id,
 				Data: &data,
 			})
@@ -0,0 +1,304 @@
+package server
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/trezor/blockbook/api"
+	"github.com/trezor/blockbook/bchain"
+)
+
+// script types recognized by the newTxFilter
+const (
+	scriptTypeP2PKH       = "p2pkh"
+	scriptTypeP2SH        = "p2sh"
+	scriptTypeP2WPKH      = "p2wpkh"
+	scriptTypeP2WSH       = "p2wsh"
+	scriptTypeP2TR        = "p2tr"
+	scriptTypeP2PK        = "p2pk"
+	scriptTypeMultisig    = "multisig"
+	scriptTypeNullData    = "nulldata"
+	scriptTypeNonStandard = "nonstandard"
+)
+
+var scriptTypes = map[string]struct{}{
+	scriptTypeP2PKH:       {},
+	scriptTypeP2SH:        {},
+	scriptTypeP2WPKH:      {},
+	scriptTypeP2WSH:       {},
+	scriptTypeP2TR:        {},
+	scriptTypeP2PK:        {},
+	scriptTypeMultisig:    {},
+	scriptTypeNullData:    {},
+	scriptTypeNonStandard: {},
+}
+
+const (
+	opReturn        = 0x6a
+	opPushData1     = 0x4c
+	opPushData2     = 0x4d
+	opCheckSig      = 0xac
+	opCheckMultisig = 0xae
+)
+
+type newTxFilterReq struct {
+	MinValue       string   `json:"minValue"`
+	Tokens         []string `json:"tokens"`
+	ScriptTypes    []string `json:"scriptTypes"`
+	OpReturnPrefix string   `json:"opReturnPrefix"`
+	ContractTypes  []string `json:"contractTypes"`
+}
+
+// newTxFilter restricts the transactions sent to a subscribeNewTransaction subscription, all specified conditions must match
+// if tokens are specified, minValue applies to the transferred amount of the tokens instead of the value of the transaction
+type newTxFilter struct {
+	minValue       *big.Int
+	tokens         map[string]struct{} // address descriptors of the token contracts as strings
+	scriptTypes    map[string]struct{}
+	opReturnPrefix []byte
+	contractTypes  map[string]struct{} // lower case
+}
+
+// newTxFilterData are the properties of a transaction checked by the filters, computed once for all subscriptions
+type newTxFilterData struct {
+	value        *big.Int
+	tokens       map[string]*big.Int // the highest transferred amount of each token contract, zero for non fungible tokens
+	scriptTypes  map[string]struct{}
+	opReturns    [][]byte
+	contractType string
+}
+
+// unmarshalNewTxFilter parses the filter of subscribeNewTransaction, it returns nil if no filter is specified
+func (s *WebsocketServer) unmarshalNewTxFilter(params []byte) (*newTxFilter, error) {
+	if len(params) == 0 || string(params) == "null" {
+		return nil, nil
+	}
+	var r newTxFilterReq
+	if err := json.Unmarshal(params, &r); err != nil {
+		return nil, err
+	}
+	f := newTxFilter{}
+	empty := true
+	if r.MinValue != "" {
+		v, ok := new(big.Int).SetString(r.MinValue, 10)
+		if !ok || v.Sign() < 0 {
+			return nil, api.NewAPIError("Invalid minValue "+r.MinValue, true)
+		}
+		f.minValue = v
+		empty = false
+	}
+	if len(r.Tokens) > 0 {
+		f.tokens = make(map[string]struct{}, len(r.Tokens))
+		for _, t := range r.Tokens {
+			ad, err := s.chainParser.GetAddrDescFromAddress(t)
+			if err != nil || len(ad) == 0 {
+				return nil, api.NewAPIError("Invalid token "+t, true)
+			}
+			f.tokens[string(ad)] = struct{}{}
+		}
+		empty = false
+	}
+	if len(r.ScriptTypes) > 0 {
+		f.scriptTypes = make(map[string]struct{}, len(r.ScriptTypes))
+		for _, t := range r.ScriptTypes {
+			t = strings.ToLower(t)
+			if _, ok := scriptTypes[t]; !ok {
+				return nil, api.NewAPIError("Invalid script type "+t, true)
+			}
+			f.scriptTypes[t] = struct{}{}
+		}
+		empty = false
+	}
+	if r.OpReturnPrefix != "" {
+		p, err := hex.DecodeString(r.OpReturnPrefix)
+		if err != nil {
+			return nil, api.NewAPIError("Invalid opReturnPrefix, hex string expected", true)
+		}
+		f.opReturnPrefix = p
+		empty = false
+	}
+	if len(r.ContractTypes) > 0 {
+		if s.chainParser.GetChainType() != bchain.ChainTronType {
+			return nil, api.NewAPIError("Parameter contractTypes is supported only for Tron", true)
+		}
+		known := make(map[string]struct{})
+		for _, t := range s.chainParser.TronTypeGetContractTypes() {
+			known[strings.ToLower(t)] = struct{}{}
+		}
+		f.contractTypes = make(map[string]struct{}, len(r.ContractTypes))
+		for _, t := range r.ContractTypes {
+			lt := strings.ToLower(t)
+			if _, ok := known[lt]; !ok {
+				return nil, api.NewAPIError("Invalid contract type "+t, true)
+			}
+			f.contractTypes[lt] = struct{}{}
+		}
+		empty = false
+	}
+	if empty {
+		return nil, nil
+	}
+	return &f, nil
+}
+
+// getScriptType classifies the output script
+func getScriptType(script []byte) string {
+	l := len(script)
+	switch {
+	case l == 25 && script[0] == 0x76 && script[1] == 0xa9 && script[2] == 0x14 && script[23] == 0x88 && script[24] == opCheckSig:
+		return scriptTypeP2PKH
+	case l == 23 && script[0] == 0xa9 && script[1] == 0x14 && script[22] == 0x87:
+		return scriptTypeP2SH
+	case l == 22 && script[0] == 0x00 && script[1] == 0x14:
+		return scriptTypeP2WPKH
+	case l == 34 && script[0] == 0x00 && script[1] == 0x20:
+		return scriptTypeP2WSH
+	case l == 34 && script[0] == 0x51 && script[1] == 0x20:
+		return scriptTypeP2TR
+	case (l == 35 && script[0] == 33 || l == 67 && script[0] == 65) && script[l-1] == opCheckSig:
+		return scriptTypeP2PK
+	case l > 0 && script[0] == opReturn:
+		return scriptTypeNullData
+	case l > 3 && script[l-1] == opCheckMultisig:
+		return scriptTypeMultisig
+	}
+	return scriptTypeNonStandard
+}
+
+// getOpReturnData returns the data pushed by the OP_RETURN script
+func getOpReturnData(script []byte) []byte {
+	if len(script) < 2 || script[0] != opReturn {
+		return nil
+	}
+	op := script[1]
+	var start, n int
+	switch {
+	case op > 0 && op < opPushData1:
+		start, n = 2, int(op)
+	case op == opPushData1 && len(script) > 2:
+		start, n = 3, int(script[2])
+	case op == opPushData2 && len(script) > 3:
+		start, n = 4, int(script[2])|int(script[3])<<8
+	default:
+		return nil
+	}
+	if start+n > len(script) {
+		return script[start:]
+	}
+	return script[start : start+n]
+}
+
+func (s *WebsocketServer) getNewTxFilterData(tx *api.Tx, mtx *bchain.MempoolTx) *newTxFilterData {
+	d := newTxFilterData{
+		value:       (*big.Int)(tx.ValueOutSat),
+		tokens:      make(map[string]*big.Int),
+		scriptTypes: make(map[string]struct{}),
+	}
+	for i := range tx.Vout {
+		if tx.Vout[i].Hex == "" {
+			continue
+		}
+		script, err := hex.DecodeString(tx.Vout[i].Hex)
+		if err != nil {
+			continue
+		}
+		st := getScriptType(script)
+		d.scriptTypes[st] = struct{}{}
+		if st == scriptTypeNullData {
+			if data := getOpReturnData(script); data != nil {
+				d.opReturns = append(d.opReturns, data)
+			}
+		}
+	}
+	for i := range tx.TokenTransfers {
+		tt := &tx.TokenTransfers[i]
+		if ad, err := s.chainParser.GetAddrDescFromAddress(tt.Token); err == nil {
+			amount := new(big.Int)
+			switch tt.Type {
+			case api.ERC20TokenType, api.TRC20TokenType:
+				if tt.Value != nil {
+					amount = (*big.Int)(tt.Value)
+				}
+			case api.ERC1155TokenType:
+				for j := range tt.MultiTokenValues {
+					if v := (*big.Int)(tt.MultiTokenValues[j].Value); v != nil && v.Cmp(amount) > 0 {
+						amount = v
+					}
+				}
+			}
+			d.addToken(ad, amount)
+		}
+	}
+	for i := range mtx.Trc20 {
+		if ad, err := s.chainParser.GetAddrDescFromAddress(mtx.Trc20[i].Contract); err == nil && len(ad) > 0 {
+			d.addToken(ad, &mtx.Trc20[i].Amount)
+		}
+	}
+	if s.chainParser.GetChainType() == bchain.ChainTronType {
+		if ct, err := s.chainParser.TronTypeGetContractType(mtx); err == nil {
+			d.contractType = strings.ToLower(ct)
+		}
+	}
+	return &d
+}
+
+// addToken stores the transferred amount of the token contract, if it is higher than the already stored one
+func (d *newTxFilterData) addToken(contract bchain.AddressDescriptor, amount *big.Int) {
+	if a, ok := d.tokens[string(contract)]; !ok || amount.Cmp(a) > 0 {
+		d.tokens[string(contract)] = amount
+	}
+}
+
+// match returns true if the transaction satisfies all conditions of the filter
+func (f *newTxFilter) match(d *newTxFilterData) bool {
+	if f.tokens != nil {
+		found := false
+		for t := range f.tokens {
+			if amount, ok := d.tokens[t]; ok && (f.minValue == nil || amount.Cmp(f.minValue) >= 0) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	} else if f.minValue != nil && (d.value == nil || d.value.Cmp(f.minValue) < 0) {
+		return false
+	}
+	if f.scriptTypes != nil && !intersects(f.scriptTypes, d.scriptTypes) {
+		return false
+	}
+	if f.opReturnPrefix != nil {
+		found := false
+		for _, data := range d.opReturns {
+			if bytes.HasPrefix(data, f.opReturnPrefix) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if f.contractTypes != nil {
+		if _, ok := f.contractTypes[d.contractType]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func intersects(a, b map[string]struct{}) bool {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	for k := range a {
+		if _, ok := b[k]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,168 @@
+// +build unittest
+
+package server
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/trezor/blockbook/api"
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/bchain/coins/btc"
+	"github.com/trezor/blockbook/bchain/coins/eth"
+	"github.com/trezor/blockbook/bchain/coins/trx"
+)
+
+func Test_getScriptType(t *testing.T) {
+	tests := []struct {
+		script string
+		want   string
+	}{
+		{"76a914010d39800f86122416e28f485029acf77507169288ac", scriptTypeP2PKH},
+		{"a91452724c5178682f70e0ba31c6ec0633755a3b41d987", scriptTypeP2SH},
+		{"00140b6c0ab8c4bd6c8c2d7d6ee1d8b9d3cd6fa5ec4c", scriptTypeP2WPKH},
+		{"0020701a8d401c84fb13e6baf169d59684e17abd9fa216c8cc5b9fc63d622ff8c58d", scriptTypeP2WSH},
+		{"5120a60869f0dbcf1dc659c9cecbaf8050135ea9e8cdc487053f1dc6880949dc684c", scriptTypeP2TR},
+		{"2103a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90ac", scriptTypeP2PK},
+		{"6a0b68656c6c6f20776f726c64", scriptTypeNullData},
+		{"5121021111111111111111111111111111111111111111111111111111111111111111210322222222222222222222222222222222222222222222222222222222222222222252ae", scriptTypeMultisig},
+		{"51", scriptTypeNonStandard},
+	}
+	for _, tt := range tests {
+		script, _ := hex.DecodeString(tt.script)
+		if got := getScriptType(script); got != tt.want {
+			t.Errorf("getScriptType(%v) = %v, want %v", tt.script, got, tt.want)
+		}
+	}
+}
+
+func Test_getOpReturnData(t *testing.T) {
+	tests := []struct {
+		script string
+		want   string
+	}{
+		{"6a0b68656c6c6f20776f726c64", "68656c6c6f20776f726c64"},
+		{"6a4c0568656c6c6f", "68656c6c6f"},
+		{"6a4d050068656c6c6f", "68656c6c6f"},
+		{"6a", ""},
+		{"76a914010d39800f86122416e28f485029acf77507169288ac", ""},
+	}
+	for _, tt := range tests {
+		script, _ := hex.DecodeString(tt.script)
+		if got := hex.EncodeToString(getOpReturnData(script)); got != tt.want {
+			t.Errorf("getOpReturnData(%v) = %v, want %v", tt.script, got, tt.want)
+		}
+	}
+}
+
+func Test_newTxFilter_match(t *testing.T) {
+	s := &WebsocketServer{
+		chainParser: btc.NewBitcoinParser(btc.GetChainParams("test"), &btc.Configuration{}),
+	}
+	tx := &api.Tx{
+		Txid:        "tx",
+		ValueOutSat: (*api.Amount)(big.NewInt(150000000)),
+		Vout: []api.Vout{
+			{Hex: "76a914010d39800f86122416e28f485029acf77507169288ac"},
+			{Hex: "6a0b68656c6c6f20776f726c64"},
+		},
+	}
+	data := s.getNewTxFilterData(tx, &bchain.MempoolTx{})
+	tests := []struct {
+		name    string
+		params  string
+		want    bool
+		wantErr string
+	}{
+		{name: "no filter", params: `{}`, want: true},
+		{name: "minValue match", params: `{"minValue":"150000000"}`, want: true},
+		{name: "minValue no match", params: `{"minValue":"150000001"}`, want: false},
+		{name: "script type match", params: `{"scriptTypes":["P2WPKH","p2pkh"]}`, want: true},
+		{name: "script type no match", params: `{"scriptTypes":["p2tr"]}`, want: false},
+		{name: "opReturnPrefix match", params: `{"opReturnPrefix":"68656c6c6f"}`, want: true},
+		{name: "opReturnPrefix no match", params: `{"opReturnPrefix":"776f726c64"}`, want: false},
+		{name: "combined match", params: `{"minValue":"100000000","scriptTypes":["nulldata"],"opReturnPrefix":"68"}`, want: true},
+		{name: "combined no match", params: `{"minValue":"200000000","scriptTypes":["nulldata"]}`, want: false},
+		{name: "token no match", params: `{"tokens":["mtGXQvBowMkBpnhLckhxhbwYK44Gs9eEtz"]}`, want: false},
+		{name: "invalid minValue", params: `{"minValue":"1.5"}`, wantErr: "Invalid minValue 1.5"},
+		{name: "invalid script type", params: `{"scriptTypes":["p2xx"]}`, wantErr: "Invalid script type p2xx"},
+		{name: "invalid opReturnPrefix", params: `{"opReturnPrefix":"xyz"}`, wantErr: "Invalid opReturnPrefix, hex string expected"},
+		{name: "contractTypes not supported", params: `{"contractTypes":["TransferContract"]}`, wantErr: "Parameter contractTypes is supported only for Tron"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := s.unmarshalNewTxFilter([]byte(tt.params))
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := f == nil || f.match(data)
+			if got != tt.want {
+				t.Errorf("match = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newTxFilter_matchTokens(t *testing.T) {
+	s := &WebsocketServer{
+		chainParser: eth.NewEthereumParser(1),
+	}
+	usdt := "0xdAC17F958D2ee523a2206206994597C13D831ec7"
+	nft := "0x06012c8cf97BEaD5deAe237070F9587f8E7A266d"
+	tx := &api.Tx{
+		Txid:        "tx",
+		ValueOutSat: (*api.Amount)(big.NewInt(0)),
+		TokenTransfers: []api.TokenTransfer{
+			{Type: api.ERC20TokenType, Token: usdt, Value: (*api.Amount)(big.NewInt(1000))},
+			{Type: api.ERC20TokenType, Token: usdt, Value: (*api.Amount)(big.NewInt(5000))},
+			{Type: api.ERC721TokenType, Token: nft, Value: (*api.Amount)(big.NewInt(123456))},
+		},
+	}
+	data := s.getNewTxFilterData(tx, &bchain.MempoolTx{})
+	tests := []struct {
+		name   string
+		params string
+		want   bool
+	}{
+		{name: "token", params: `{"tokens":["` + usdt + `"]}`, want: true},
+		{name: "minValue without tokens applies to the value of the tx", params: `{"minValue":"1"}`, want: false},
+		{name: "token minValue match", params: `{"tokens":["` + usdt + `"],"minValue":"5000"}`, want: true},
+		{name: "token minValue no match", params: `{"tokens":["` + usdt + `"],"minValue":"5001"}`, want: false},
+		{name: "nft has no amount", params: `{"tokens":["` + nft + `"],"minValue":"1"}`, want: false},
+		{name: "any of the tokens", params: `{"tokens":["` + nft + `","` + usdt + `"],"minValue":"1"}`, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := s.unmarshalNewTxFilter([]byte(tt.params))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := f.match(data); got != tt.want {
+				t.Errorf("match = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newTxFilter_contractTypes(t *testing.T) {
+	s := &WebsocketServer{
+		chainParser: trx.NewTrxParser(1, nil),
+	}
+	f, err := s.unmarshalNewTxFilter([]byte(`{"contractTypes":["TriggerSmartContract","transfercontract"]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := f.contractTypes["transfercontract"]; !ok || len(f.contractTypes) != 2 {
+		t.Errorf("contractTypes = %v", f.contractTypes)
+	}
+	if _, err = s.unmarshalNewTxFilter([]byte(`{"contractTypes":["UnknownContract"]}`)); err == nil || err.Error() != "Invalid contract type UnknownContract" {
+		t.Errorf("error = %v, want Invalid contract type UnknownContract", err)
+	}
+}
@@ -0,0 +1,240 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+// sessionBufferSize is the maximum number of notifications kept for a disconnected session, older notifications are dropped
+// the buffered notifications and the messages postponed during the session restore must fit into the output channel
+const sessionBufferSize = outChannelSize / 2
+
+// maxPendingMessages is the maximum number of messages postponed during the session restore
+const maxPendingMessages = outChannelSize - sessionBufferSize - 2
+
+// sessionParam is the query parameter of the websocket url by which the client requests a new session or resumes an existing one
+const sessionParam = "session"
+
+// sessionMessageID is the id of the first message sent to the client of a session
+const sessionMessageID = "session"
+
+// websocketSession keeps the subscriptions of a client and the notifications it missed while it was disconnected
+type websocketSession struct {
+	token string
+	lock  sync.Mutex
+	// c is the channel of the session, if detached is true, the channel is closed and its notifications are buffered
+	c          *websocketChannel
+	detached   bool
+	detachedAt time.Time
+	// subscriptions are the last subscribe requests, indexed by the subscription name (e.g. "Addresses" for subscribeAddresses)
+	subscriptions map[string]*websocketReq
+	// buffer is a ring buffer of the notifications missed while detached
+	buffer  [sessionBufferSize]*websocketRes
+	start   int
+	count   int
+	dropped int
+}
+
+type sessionResponse struct {
+	Session string `json:"session"`
+	Resumed bool   `json:"resumed"`
+	Missed  int    `json:"missed"`
+	Dropped int    `json:"dropped"`
+}
+
+func newSessionToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// subscriptionName returns the name of the subscription changed by the method and if the method subscribes or unsubscribes
+func subscriptionName(method string) (string, bool, bool) {
+	if strings.HasPrefix(method, "unsubscribe") {
+		return strings.TrimPrefix(method, "unsubscribe"), false, true
+	}
+	if strings.HasPrefix(method, "subscribe") {
+		return strings.TrimPrefix(method, "subscribe"), true, true
+	}
+	return "", false, false
+}
+
+// onRequest records the successful subscribe and unsubscribe requests so that the subscriptions can be restored
+func (ss *websocketSession) onRequest(req *websocketReq) {
+	name, subscribe, ok := subscriptionName(req.Method)
+	if !ok {
+		return
+	}
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+	if subscribe {
+		ss.subscriptions[name] = req
+	} else {
+		delete(ss.subscriptions, name)
+	}
+}
+
+// store adds the notification to the buffer of the detached session, other messages (e.g. responses to requests) are discarded
+func (ss *websocketSession) store(data *websocketRes) {
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+	if !ss.detached {
+		return
+	}
+	subscription := false
+	for _, req := range ss.subscriptions {
+		if req.ID == data.ID {
+			subscription = true
+			break
+		}
+	}
+	if !subscription {
+		return
+	}
+	if ss.count == sessionBufferSize {
+		ss.buffer[ss.start] = nil
+		ss.start = (ss.start + 1) % sessionBufferSize
+		ss.count--
+		ss.dropped++
+	}
+	ss.buffer[(ss.start+ss.count)%sessionBufferSize] = data
+	ss.count++
+}
+
+// takeBuffer returns the buffered notifications in the order in which they were fired and empties the buffer
+func (ss *websocketSession) takeBuffer() ([]*websocketRes, int) {
+	r := make([]*websocketRes, ss.count)
+	for i := range r {
+		j := (ss.start + i) % sessionBufferSize
+		r[i] = ss.buffer[j]
+		ss.buffer[j] = nil
+	}
+	dropped := ss.dropped
+	ss.start, ss.count, ss.dropped = 0, 0, 0
+	return r, dropped
+}
+
+// getSession returns the session to be resumed by the token, or a new session if the token is not known
+func (s *WebsocketServer) getSession(token string) (*websocketSession, bool, error) {
+	s.sessionsLock.Lock()
+	defer s.sessionsLock.Unlock()
+	if token != "" {
+		if ss, ok := s.sessions[token]; ok {
+			// postpone the expiration, the session is being resumed
+			ss.lock.Lock()
+			ss.detachedAt = time.Now()
+			ss.lock.Unlock()
+			return ss, true, nil
+		}
+	}
+	token, err := newSessionToken()
+	if err != nil {
+		return nil, false, err
+	}
+	ss := &websocketSession{
+		token:         token,
+		subscriptions: make(map[string]*websocketReq),
+	}
+	s.sessions[token] = ss
+	return ss, false, nil
+}
+
+// attachSession connects the channel to the session, if the session is resumed, its subscriptions are restored
+// and the missed notifications are sent to the channel before any other message
+func (s *WebsocketServer) attachSession(c *websocketChannel, ss *websocketSession, resumed bool) {
+	var old *websocketChannel
+	var subscriptions []*websocketReq
+	ss.lock.Lock()
+	if resumed {
+		old = ss.c
+		for _, req := range ss.subscriptions {
+			subscriptions = append(subscriptions, req)
+		}
+	}
+	ss.lock.Unlock()
+	if old != nil {
+		// close the old channel if the client reconnected before the old connection was detected as closed
+		s.closeChannel(old)
+	}
+	// subscribe the new channel first and only then remove the subscriptions of the old channel
+	// so that no notification is lost in between
+	for _, req := range subscriptions {
+		if f, ok := requestHandlers[req.Method]; ok {
+			if _, err := f(s, c, req); err != nil {
+				glog.Error("Client ", c.id, " session ", ss.token, " restore ", req.Method, " error ", err)
+			}
+		}
+	}
+	if old != nil {
+		s.unsubscribeAll(old)
+	}
+	ss.lock.Lock()
+	buffered, dropped := ss.takeBuffer()
+	ss.c = c
+	ss.detached = false
+	ss.lock.Unlock()
+	msgs := make([]*websocketRes, 0, len(buffered)+1)
+	msgs = append(msgs, &websocketRes{
+		ID: sessionMessageID,
+		Data: &sessionResponse{
+			Session: ss.token,
+			Resumed: resumed,
+			Missed:  len(buffered),
+			Dropped: dropped,
+		},
+	})
+	msgs = append(msgs, buffered...)
+	if !c.flushPending(msgs) {
+		// the new channel was closed in the meantime
+		s.detachSession(c)
+		return
+	}
+	if resumed {
+		glog.Info("Client ", c.id, " resumed session ", ss.token, ", ", len(subscriptions), " subscriptions, ", len(buffered), " missed notifications, ", dropped, " dropped")
+	}
+}
+
+// detachSession keeps the subscriptions of the closed channel for sessionTTL, its notifications are buffered in the meantime
+// it returns false if the channel is not the current channel of its session
+func (s *WebsocketServer) detachSession(c *websocketChannel) bool {
+	ss := c.session
+	ss.lock.Lock()
+	defer ss.lock.Unlock()
+	if ss.c != c {
+		return false
+	}
+	ss.detached = true
+	ss.detachedAt = time.Now()
+	time.AfterFunc(s.sessionTTL, func() { s.expireSession(ss, c) })
+	return true
+}
+
+// expireSession removes the session if it was not resumed within sessionTTL
+func (s *WebsocketServer) expireSession(ss *websocketSession, c *websocketChannel) {
+	s.sessionsLock.Lock()
+	ss.lock.Lock()
+	expired := false
+	if ss.c == c && ss.detached {
+		if remaining := s.sessionTTL - time.Since(ss.detachedAt); remaining > 0 {
+			// the expiration was postponed by a resume attempt
+			time.AfterFunc(remaining, func() { s.expireSession(ss, c) })
+		} else {
+			expired = true
+			delete(s.sessions, ss.token)
+			ss.c = nil
+		}
+	}
+	ss.lock.Unlock()
+	s.sessionsLock.Unlock()
+	if expired {
+		s.unsubscribeAll(c)
+		glog.Info("Client ", c.id, " session ", ss.token, " expired")
+	}
+}
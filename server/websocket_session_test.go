@@ -0,0 +1,177 @@
+// +build unittest
+
+package server
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/trezor/blockbook/api"
+	"github.com/trezor/blockbook/tests/dbtestdata"
+)
+
+func Test_websocketSession_store(t *testing.T) {
+	ss := &websocketSession{
+		subscriptions: map[string]*websocketReq{
+			"NewBlock":  {ID: "1", Method: "subscribeNewBlock"},
+			"Addresses": {ID: "2", Method: "subscribeAddresses"},
+		},
+	}
+	// notifications are not buffered while the session is attached
+	ss.store(&websocketRes{ID: "1"})
+	if ss.count != 0 {
+		t.Fatalf("attached session buffered %d messages", ss.count)
+	}
+	ss.detached = true
+	// responses to requests are not buffered
+	ss.store(&websocketRes{ID: "3"})
+	for i := 0; i < sessionBufferSize+5; i++ {
+		ss.store(&websocketRes{ID: strconv.Itoa(i%2 + 1), Data: i})
+	}
+	buffered, dropped := ss.takeBuffer()
+	if dropped != 5 {
+		t.Errorf("dropped = %d, want 5", dropped)
+	}
+	if len(buffered) != sessionBufferSize {
+		t.Fatalf("len(buffered) = %d, want %d", len(buffered), sessionBufferSize)
+	}
+	for i, m := range buffered {
+		if m.Data != i+5 {
+			t.Fatalf("buffered[%d] = %v, want %v", i, m.Data, i+5)
+		}
+	}
+	if buffered, dropped = ss.takeBuffer(); len(buffered) != 0 || dropped != 0 {
+		t.Errorf("buffer not emptied, %d messages, %d dropped", len(buffered), dropped)
+	}
+}
+
+func Test_websocketSession_onRequest(t *testing.T) {
+	ss := &websocketSession{subscriptions: make(map[string]*websocketReq)}
+	ss.onRequest(&websocketReq{ID: "1", Method: "subscribeAddresses"})
+	ss.onRequest(&websocketReq{ID: "2", Method: "subscribeNewBlock"})
+	ss.onRequest(&websocketReq{ID: "3", Method: "getInfo"})
+	ss.onRequest(&websocketReq{ID: "4", Method: "subscribeAddresses"})
+	ss.onRequest(&websocketReq{ID: "5", Method: "unsubscribeNewBlock"})
+	want := map[string]*websocketReq{
+		"Addresses": {ID: "4", Method: "subscribeAddresses"},
+	}
+	if !reflect.DeepEqual(ss.subscriptions, want) {
+		t.Errorf("subscriptions = %+v, want %+v", ss.subscriptions, want)
+	}
+}
+
+func Test_websocketChannel_CloseOutDetachesSession(t *testing.T) {
+	ss := &websocketSession{
+		subscriptions: map[string]*websocketReq{
+			"Addresses": {ID: "1", Method: "subscribeAddresses"},
+		},
+	}
+	c := &websocketChannel{
+		alive:   true,
+		out:     make(chan *websocketRes, 10),
+		session: ss,
+	}
+	ss.c = c
+	// unsent notification and response to a request
+	c.out <- &websocketRes{ID: "1", Data: "queued"}
+	c.out <- &websocketRes{ID: "2", Data: "response"}
+	if !c.CloseOut(func() { ss.detached = true }) {
+		t.Fatal("CloseOut() = false, want true")
+	}
+	if c.CloseOut(func() { t.Error("detach called for closed channel") }) {
+		t.Error("second CloseOut() = true, want false")
+	}
+	// notification sent after the channel was closed
+	c.DataOut(&websocketRes{ID: "1", Data: "after close"})
+	buffered, _ := ss.takeBuffer()
+	want := []*websocketRes{{ID: "1", Data: "queued"}, {ID: "1", Data: "after close"}}
+	if !reflect.DeepEqual(buffered, want) {
+		t.Errorf("buffered = %+v, want %+v", buffered, want)
+	}
+}
+
+// websocketSessionTestsBitcoinType checks that a resumed session gets its subscriptions back and the notifications missed while disconnected
+func websocketSessionTestsBitcoinType(t *testing.T, s *PublicServer, ts *httptest.Server) {
+	s.websocket.sessionTTL = time.Minute
+	defer func() { s.websocket.sessionTTL = 0 }()
+	url := strings.Replace(ts.URL, "http://", "ws://", 1) + "/websocket?session="
+	readMessage := func(c *websocket.Conn) string {
+		c.SetReadDeadline(time.Now().Add(10 * time.Second))
+		_, message, err := c.ReadMessage()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return strings.TrimSpace(string(message))
+	}
+	c, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var session struct {
+		Data sessionResponse `json:"data"`
+	}
+	if err = json.Unmarshal([]byte(readMessage(c)), &session); err != nil {
+		t.Fatal(err)
+	}
+	if session.Data.Session == "" || session.Data.Resumed {
+		t.Fatalf("unexpected session %+v", session.Data)
+	}
+	err = c.WriteJSON(map[string]interface{}{
+		"id":     "addr",
+		"method": "subscribeAddresses",
+		"params": map[string]interface{}{"addresses": []string{dbtestdata.Addr1}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := readMessage(c), `{"id":"addr","data":{"subscribed":true}}`; got != want {
+		t.Fatalf("subscribeAddresses: got %v, want %v", got, want)
+	}
+	c.Close()
+	s.websocket.sessionsLock.Lock()
+	ss := s.websocket.sessions[session.Data.Session]
+	s.websocket.sessionsLock.Unlock()
+	for i := 0; ; i++ {
+		ss.lock.Lock()
+		detached := ss.detached
+		ss.lock.Unlock()
+		if detached {
+			break
+		}
+		if i == 100 {
+			t.Fatal("session not detached")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	ad, err := s.chainParser.GetAddrDescFromAddress(dbtestdata.Addr1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.websocket.sendOnNewTxAddr(string(ad), &api.Tx{Txid: "missed"})
+
+	c, _, err = websocket.DefaultDialer.Dial(url+session.Data.Session, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	want := []string{
+		`{"id":"session","data":{"session":"` + session.Data.Session + `","resumed":true,"missed":1,"dropped":0}}`,
+		`{"id":"addr","data":{"address":"` + dbtestdata.Addr1 + `","tx":{"txid":"missed","vin":null,"vout":null,"blockHeight":0,"confirmations":0,"blockTime":0,"value":null}}}`,
+	}
+	for i := range want {
+		if got := readMessage(c); got != want[i] {
+			t.Errorf("message %d: got %v, want %v", i, got, want[i])
+		}
+	}
+	// the restored subscription delivers new notifications
+	s.websocket.sendOnNewTxAddr(string(ad), &api.Tx{Txid: "live"})
+	if got, want := readMessage(c), `{"id":"addr","data":{"address":"`+dbtestdata.Addr1+`","tx":{"txid":"live","vin":null,"vout":null,"blockHeight":0,"confirmations":0,"blockTime":0,"value":null}}}`; got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
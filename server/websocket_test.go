@@ -0,0 +1,307 @@
+// +build unittest
+
+package server
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/trezor/blockbook/api"
+	"github.com/trezor/blockbook/bchain"
+	"github.com/trezor/blockbook/bchain/coins/btc"
+	"github.com/trezor/blockbook/bchain/coins/eth"
+	"github.com/trezor/blockbook/common"
+)
+
+func Test_websocketChannel_DataOutCoalesce(t *testing.T) {
+	metrics := &common.Metrics{
+		WebsocketCoalesced:     prometheus.NewCounter(prometheus.CounterOpts{Name: "test_websocket_coalesced"}),
+		WebsocketSlowConsumers: prometheus.NewCounter(prometheus.CounterOpts{Name: "test_websocket_slow_consumers"}),
+		WebsocketDropped:       prometheus.NewCounter(prometheus.CounterOpts{Name: "test_websocket_dropped"}),
+	}
+	c := &websocketChannel{
+		out:     make(chan *websocketRes, outChannelSize),
+		alive:   true,
+		metrics: metrics,
+	}
+	// the notifications are not coalesced if the client reads them fast enough
+	c.DataOutCoalesce(&websocketRes{ID: "block", Data: 1})
+	for i := 1; i < coalesceThreshold; i++ {
+		c.DataOut(&websocketRes{ID: "tx", Data: i})
+	}
+	c.DataOutCoalesce(&websocketRes{ID: "block", Data: 2})
+	c.DataOutCoalesce(&websocketRes{ID: "rates", Data: 3})
+	c.DataOutCoalesce(&websocketRes{ID: "block", Data: 4})
+	if got := len(c.out); got != coalesceThreshold {
+		t.Fatalf("len(c.out) = %d, want %d", got, coalesceThreshold)
+	}
+	if got := testutil.ToFloat64(metrics.WebsocketCoalesced); got != 1 {
+		t.Errorf("coalesced = %v, want 1", got)
+	}
+	// the client is warned once before the output channel is full
+	for len(c.out) < slowConsumerThreshold+10 {
+		c.DataOut(&websocketRes{ID: "tx"})
+	}
+	warnings := 0
+	for len(c.out) > 0 {
+		if m := <-c.out; m.ID == slowConsumerMessageID {
+			warnings++
+		}
+		if len(c.out) >= coalesceThreshold && c.takeCoalesced() != nil {
+			t.Fatal("coalesced notifications taken before the client caught up")
+		}
+	}
+	if warnings != 1 || testutil.ToFloat64(metrics.WebsocketSlowConsumers) != 1 {
+		t.Errorf("warnings = %d, want 1", warnings)
+	}
+	coalesced := c.takeCoalesced()
+	if len(coalesced) != 2 || coalesced[0].ID != "block" || coalesced[0].Data != 4 || coalesced[1].ID != "rates" {
+		t.Errorf("unexpected coalesced notifications %+v", coalesced)
+	}
+	if c.takeCoalesced() != nil || c.slowConsumer {
+		t.Error("coalesced notifications not reset")
+	}
+	// without pending coalesced notifications the next notification goes directly to the output channel
+	c.DataOutCoalesce(&websocketRes{ID: "block", Data: 5})
+	if m := <-c.out; m.Data != 5 {
+		t.Errorf("got %+v, want block 5", m)
+	}
+}
+
+// websocketCompressionTestsBitcoinType checks that the permessage-deflate extension is negotiated if the client supports it
+func websocketCompressionTestsBitcoinType(t *testing.T, ts *httptest.Server) {
+	url := strings.Replace(ts.URL, "http://", "ws://", 1) + "/websocket"
+	for _, compression := range []bool{false, true} {
+		t.Run("compression "+strconv.FormatBool(compression), func(t *testing.T) {
+			d := websocket.Dialer{EnableCompression: compression}
+			c, resp, err := d.Dial(url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer c.Close()
+			if got := strings.Contains(resp.Header.Get("Sec-Websocket-Extensions"), "permessage-deflate"); got != compression {
+				t.Errorf("permessage-deflate negotiated %v, want %v", got, compression)
+			}
+			if err = c.WriteJSON(websocketReq{ID: "1", Method: "ping"}); err != nil {
+				t.Fatal(err)
+			}
+			_, message, err := c.ReadMessage()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, want := strings.TrimSpace(string(message)), `{"id":"1","data":{}}`; got != want {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func Test_WebsocketServer_subscribeContracts(t *testing.T) {
+	s := &WebsocketServer{
+		chainParser:           eth.NewEthereumParser(1),
+		contractSubscriptions: make(map[string]map[*websocketChannel]string),
+		metrics: &common.Metrics{
+			WebsocketSubscribes: prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test_websocket_subscribes"}, []string{"method"}),
+		},
+	}
+	c := &websocketChannel{
+		out:   make(chan *websocketRes, outChannelSize),
+		alive: true,
+	}
+	const contract = "0x4af4114F73d1c1C903aC9E0361b379D1291808A2"
+	const other = "0x0d0F936Ee4c93e25944694D6C121de94D9760F11"
+	if _, err := s.subscribeContracts(c, []string{"invalid"}, &websocketReq{ID: "1"}); err == nil || err.Error() != "Invalid contract invalid" {
+		t.Fatalf("subscribeContracts invalid contract: error %v", err)
+	}
+	if _, err := s.subscribeContracts(c, []string{contract}, &websocketReq{ID: "1"}); err != nil {
+		t.Fatal(err)
+	}
+	tx := &bchain.MempoolTx{
+		Txid: "0x1",
+		Erc20: []bchain.Erc20Transfer{
+			{Contract: other, From: "0x20cD153de35D469BA46127A0C8F18626b59a256A", To: "0x555Ee11FBDDc0E49A9bAB358A8941AD95fFDB48f"},
+			{Contract: contract, From: "0x20cD153de35D469BA46127A0C8F18626b59a256A", To: "0x555Ee11FBDDc0E49A9bAB358A8941AD95fFDB48f"},
+		},
+	}
+	subscribed := s.getNewTxSubscriptions(tx)
+	if len(subscribed.contracts) != 1 || len(subscribed.addresses) != 0 || len(subscribed.accounts) != 0 {
+		t.Fatalf("unexpected subscriptions %+v", subscribed)
+	}
+	for cd := range subscribed.contracts {
+		s.sendOnNewTxContract(cd, &api.Tx{Txid: tx.Txid})
+	}
+	if len(c.out) != 1 {
+		t.Fatalf("len(c.out) = %d, want 1", len(c.out))
+	}
+	m := <-c.out
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"id":"1","data":{"contract":"` + contract + `","tx":{"txid":"0x1","vin":null,"vout":null,"blockHeight":0,"confirmations":0,"blockTime":0,"value":null}}}`
+	if string(b) != want {
+		t.Errorf("got %v, want %v", string(b), want)
+	}
+	if _, err := s.unsubscribeContracts(c); err != nil {
+		t.Fatal(err)
+	}
+	if subscribed = s.getNewTxSubscriptions(tx); !subscribed.empty() || len(s.contractSubscriptions) != 0 {
+		t.Errorf("contracts not unsubscribed, %+v", subscribed)
+	}
+}
+
+func Test_WebsocketServer_subscribeAddressesConfirmations(t *testing.T) {
+	s := &WebsocketServer{
+		chainParser:          btc.NewBitcoinParser(btc.GetChainParams("test"), &btc.Configuration{}),
+		is:                   &common.InternalState{BestHeight: 100},
+		addressSubscriptions: make(map[string]map[*websocketChannel]string),
+		trackingChannels:     make(map[*websocketChannel]struct{}),
+		metrics: &common.Metrics{
+			WebsocketSubscribes: prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test_websocket_subscribes_confirmations"}, []string{"method"}),
+		},
+	}
+	c := &websocketChannel{
+		out:   make(chan *websocketRes, outChannelSize),
+		alive: true,
+	}
+	const address = "mtGXQvBowMkBpnhLckhxhbwYK44Gs9eEtz"
+	ad, confirmations, err := s.unmarshalAddresses([]byte(`{"addresses":["` + address + `"],"confirmations":3}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.subscribeAddresses(c, ad, confirmations, &websocketReq{ID: "1"}); err != nil {
+		t.Fatal(err)
+	}
+	next := func() string {
+		if len(c.out) == 0 {
+			return ""
+		}
+		b, err := json.Marshal(<-c.out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	blocks := map[uint32][]string{
+		101: {"other", "tx1"},
+		102: {"tx2"},
+	}
+	s.blockTxids = func(height uint32) ([]string, error) {
+		if txids, ok := blocks[height]; ok {
+			return txids, nil
+		}
+		return []string{}, nil
+	}
+	// mempool arrivals are notified as before, the txs are tracked
+	s.sendOnNewTxAddr(ad[0], &api.Tx{Txid: "tx1"})
+	s.sendOnNewTxAddr(ad[0], &api.Tx{Txid: "tx2"})
+	s.sendOnNewTxAddr(ad[0], &api.Tx{Txid: "tx4"})
+	if len(c.out) != 3 {
+		t.Fatalf("len(c.out) = %d, want 3", len(c.out))
+	}
+	for len(c.out) > 0 {
+		<-c.out
+	}
+	s.checkTrackedTxs(100)
+	// the heights of the txs are found in the new blocks
+	s.checkTrackedTxs(102)
+	if got := next(); got != "" {
+		t.Fatalf("unexpected notification %v", got)
+	}
+	if c.trackedTxs["tx1"].height != 101 || c.trackedTxs["tx2"].height != 102 || c.trackedTxs["tx4"].height != 0 {
+		t.Fatalf("unexpected tracked txs heights %+v %+v %+v", c.trackedTxs["tx1"], c.trackedTxs["tx2"], c.trackedTxs["tx4"])
+	}
+	// a late check of an older block does not move the tracked height back
+	s.checkTrackedTxs(101)
+	if got := next(); got != "" || s.trackedHeight != 102 {
+		t.Fatalf("unexpected notification %v or tracked height %d after a late check", got, s.trackedHeight)
+	}
+	s.OnDisconnectBlocks(102, 102)
+	want := `{"id":"1","data":{"address":"` + address + `","txid":"tx2","event":"reverted","blockHeight":102,"confirmations":0}}`
+	if got := next(); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	// tx4 was never included in a block, it expires
+	c.trackedTxs["tx4"].added = time.Now().Add(-trackedTxExpiration - time.Minute)
+	s.checkTrackedTxs(103)
+	want = `{"id":"1","data":{"address":"` + address + `","txid":"tx1","event":"confirmed","blockHeight":101,"confirmations":3}}`
+	if got := next(); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := next(); got != "" || len(s.trackingChannels) != 0 || c.trackedTxs != nil {
+		t.Errorf("unexpected state after confirmation, notification %v, tracked %+v", got, c.trackedTxs)
+	}
+	// the number of tracked txs is limited
+	for i := 0; i < maxTrackedTxs+10; i++ {
+		s.sendOnNewTxAddr(ad[0], &api.Tx{Txid: strconv.Itoa(i)})
+		<-c.out
+	}
+	if len(c.trackedTxs) != maxTrackedTxs {
+		t.Errorf("len(trackedTxs) = %d, want %d", len(c.trackedTxs), maxTrackedTxs)
+	}
+	// the tracked txs are dropped on unsubscribe
+	s.sendOnNewTxAddr(ad[0], &api.Tx{Txid: "tx3", Blockheight: 104})
+	if _, err := s.unsubscribeAddresses(c); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.trackingChannels) != 0 || c.trackedTxs != nil || c.addrConfirmations != 0 {
+		t.Errorf("tracking not stopped on unsubscribe")
+	}
+}
+
+func Test_WebsocketServer_OnReplacedTx(t *testing.T) {
+	s := &WebsocketServer{
+		chainParser:          btc.NewBitcoinParser(btc.GetChainParams("test"), &btc.Configuration{}),
+		is:                   &common.InternalState{BestHeight: 100},
+		addressSubscriptions: make(map[string]map[*websocketChannel]string),
+		trackingChannels:     make(map[*websocketChannel]struct{}),
+		metrics: &common.Metrics{
+			WebsocketSubscribes: prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test_websocket_subscribes_replaced"}, []string{"method"}),
+		},
+	}
+	c := &websocketChannel{
+		out:   make(chan *websocketRes, outChannelSize),
+		alive: true,
+	}
+	const address = "mtGXQvBowMkBpnhLckhxhbwYK44Gs9eEtz"
+	ad, confirmations, err := s.unmarshalAddresses([]byte(`{"addresses":["` + address + `"],"confirmations":2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.subscribeAddresses(c, ad, confirmations, &websocketReq{ID: "1"}); err != nil {
+		t.Fatal(err)
+	}
+	s.sendOnNewTxAddr(ad[0], &api.Tx{Txid: "tx1"})
+	<-c.out
+	s.OnReplacedTx(&bchain.MempoolReplacement{Txid: "tx1", ReplacedBy: "tx2", Time: 1000}, []bchain.AddressDescriptor{bchain.AddressDescriptor("other"), bchain.AddressDescriptor(ad[0])})
+	if len(c.out) != 1 {
+		t.Fatalf("len(c.out) = %d, want 1", len(c.out))
+	}
+	b, err := json.Marshal(<-c.out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"id":"1","data":{"address":"` + address + `","txid":"tx1","event":"replaced","replacedBy":"tx2"}}`
+	if got := string(b); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if len(s.trackingChannels) != 0 || c.trackedTxs != nil {
+		t.Errorf("replaced tx is still tracked %+v", c.trackedTxs)
+	}
+	// dropped tx is notified without replacedBy
+	s.OnReplacedTx(&bchain.MempoolReplacement{Txid: "tx3", Time: 1010}, []bchain.AddressDescriptor{bchain.AddressDescriptor(ad[0])})
+	if b, err = json.Marshal(<-c.out); err != nil {
+		t.Fatal(err)
+	}
+	want = `{"id":"1","data":{"address":"` + address + `","txid":"tx3","event":"dropped"}}`
+	if got := string(b); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}